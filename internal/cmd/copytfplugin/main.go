@@ -0,0 +1,342 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// The copytfplugin command copies the generated tfplugin5 or tfplugin6
+// Protocol Buffers package of the terraform-plugin-go module version in
+// go.mod, which is internal to that module, into the current directory. It is
+// intended to be called by go generate in internal/tfplugin5 and
+// internal/tfplugin6, such as:
+//
+//	//go:generate go run ../cmd/copytfplugin 5
+//
+// The copy is changed so it can be linked into the same binary as the
+// original:
+//
+//   - The go_package option of the .proto file and of the file descriptor
+//     is the package of the copy.
+//   - The descriptors are registered with the protoFiles and protoTypes
+//     registries of the package, rather than the protoregistry globals.
+//
+// Each change returns an error if the original no longer has the expected
+// form, such as after a terraform-plugin-go upgrade, rather than the copy
+// silently being made without it.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// fileDescriptorOptionsNumber is the field number of
+	// FileDescriptorProto.options.
+	fileDescriptorOptionsNumber protowire.Number = 8
+
+	// fileOptionsGoPackageNumber is the field number of
+	// FileOptions.go_package.
+	fileOptionsGoPackageNumber protowire.Number = 11
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("copytfplugin: ")
+
+	if len(os.Args) != 2 || (os.Args[1] != "5" && os.Args[1] != "6") {
+		log.Fatal("usage: copytfplugin 5|6")
+	}
+
+	if err := run(os.Args[1]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(protocolVersion string) error {
+	name := "tfplugin" + protocolVersion
+
+	moduleDir, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/hashicorp/terraform-plugin-go").Output()
+
+	if err != nil {
+		return fmt.Errorf("unable to find terraform-plugin-go module: %w", err)
+	}
+
+	srcDir := filepath.Join(strings.TrimSpace(string(moduleDir)), "tfprotov"+protocolVersion, "internal", name)
+	srcGoPackage := "github.com/hashicorp/terraform-plugin-go/tfprotov" + protocolVersion + "/internal/" + name
+	dstGoPackage := "github.com/hashicorp/terraform-plugin-mux/internal/" + name
+
+	proto, err := os.ReadFile(filepath.Join(srcDir, name+".proto"))
+
+	if err != nil {
+		return err
+	}
+
+	proto, err = replaceOnce(proto, `option go_package = "`+srcGoPackage+`";`, `option go_package = "`+dstGoPackage+`";`)
+
+	if err != nil {
+		return fmt.Errorf("unable to change %s.proto go_package: %w", name, err)
+	}
+
+	pb, err := os.ReadFile(filepath.Join(srcDir, name+".pb.go"))
+
+	if err != nil {
+		return err
+	}
+
+	pb, err = changeGoPackage(pb, "file_"+name+"_proto_rawDesc", srcGoPackage, dstGoPackage)
+
+	if err != nil {
+		return fmt.Errorf("unable to change %s.pb.go go_package: %w", name, err)
+	}
+
+	pb, err = useRegistries(pb)
+
+	if err != nil {
+		return fmt.Errorf("unable to change %s.pb.go registries: %w", name, err)
+	}
+
+	grpcPB, err := os.ReadFile(filepath.Join(srcDir, name+"_grpc.pb.go"))
+
+	if err != nil {
+		return err
+	}
+
+	for fileName, content := range map[string][]byte{
+		name + ".proto":      proto,
+		name + ".pb.go":      pb,
+		name + "_grpc.pb.go": grpcPB,
+	} {
+		if err := os.WriteFile(fileName, content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// changeGoPackage returns the generated code with the go_package option of
+// the raw file descriptor constant changed, formatted the same way as
+// protoc-gen-go.
+func changeGoPackage(src []byte, constName string, oldGoPackage string, newGoPackage string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rawDescExpr ast.Expr
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		valueSpec, ok := node.(*ast.ValueSpec)
+
+		if !ok || len(valueSpec.Names) != 1 || valueSpec.Names[0].Name != constName || len(valueSpec.Values) != 1 {
+			return true
+		}
+
+		rawDescExpr = valueSpec.Values[0]
+
+		return false
+	})
+
+	if rawDescExpr == nil {
+		return nil, fmt.Errorf("constant %s not found", constName)
+	}
+
+	rawDesc, err := stringConcatenation(rawDescExpr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rawDesc, err = replaceGoPackage(rawDesc, oldGoPackage, newGoPackage)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// This is the same as the genFileDescriptor function of protoc-gen-go.
+	var newExpr bytes.Buffer
+
+	newExpr.WriteString(`""`)
+
+	for _, line := range bytes.SplitAfter(rawDesc, []byte{'\n'}) {
+		fmt.Fprintf(&newExpr, "+\n%q", line)
+	}
+
+	var result bytes.Buffer
+
+	result.Write(src[:fset.Position(rawDescExpr.Pos()).Offset])
+	result.Write(newExpr.Bytes())
+	result.Write(src[fset.Position(rawDescExpr.End()).Offset:])
+
+	return format.Source(result.Bytes())
+}
+
+// stringConcatenation returns the value of a concatenation of string
+// literals.
+func stringConcatenation(expr ast.Expr) ([]byte, error) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		value, err := strconv.Unquote(expr.Value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(value), nil
+	case *ast.BinaryExpr:
+		if expr.Op != token.ADD {
+			break
+		}
+
+		x, err := stringConcatenation(expr.X)
+
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := stringConcatenation(expr.Y)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return append(x, y...), nil
+	}
+
+	return nil, fmt.Errorf("unexpected expression %T in string concatenation", expr)
+}
+
+// replaceGoPackage returns the serialized FileDescriptorProto with its
+// go_package option changed. Other fields are kept in the same order, so the
+// result is the same as that of protoc for the changed .proto file.
+func replaceGoPackage(fileDescriptor []byte, oldGoPackage string, newGoPackage string) ([]byte, error) {
+	var replaced bool
+
+	result, err := replaceField(fileDescriptor, fileDescriptorOptionsNumber, func(options []byte) ([]byte, error) {
+		return replaceField(options, fileOptionsGoPackageNumber, func(goPackage []byte) ([]byte, error) {
+			if string(goPackage) != oldGoPackage {
+				return nil, fmt.Errorf("expected go_package %q, got %q", oldGoPackage, goPackage)
+			}
+
+			replaced = true
+
+			return []byte(newGoPackage), nil
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !replaced {
+		return nil, fmt.Errorf("go_package %q not found", oldGoPackage)
+	}
+
+	return result, nil
+}
+
+// replaceField returns the serialized message with the value of each length
+// delimited field with the number replaced by the function.
+func replaceField(message []byte, number protowire.Number, replace func([]byte) ([]byte, error)) ([]byte, error) {
+	var result []byte
+
+	for len(message) > 0 {
+		fieldNumber, fieldType, tagLength := protowire.ConsumeTag(message)
+
+		if tagLength < 0 {
+			return nil, protowire.ParseError(tagLength)
+		}
+
+		fieldLength := protowire.ConsumeFieldValue(fieldNumber, fieldType, message[tagLength:])
+
+		if fieldLength < 0 {
+			return nil, protowire.ParseError(fieldLength)
+		}
+
+		field := message[:tagLength+fieldLength]
+		message = message[tagLength+fieldLength:]
+
+		if fieldNumber != number || fieldType != protowire.BytesType {
+			result = append(result, field...)
+
+			continue
+		}
+
+		value, _ := protowire.ConsumeBytes(field[tagLength:])
+		value, err := replace(value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = protowire.AppendTag(result, fieldNumber, fieldType)
+		result = protowire.AppendBytes(result, value)
+	}
+
+	return result, nil
+}
+
+// useRegistries returns the generated code with the protoFiles and
+// protoTypes registries of the package set in its TypeBuilder.
+func useRegistries(src []byte) ([]byte, error) {
+	var err error
+
+	src, err = insertAfterLine(src, "\t\t\tNumServices:", "\t\t\tFileRegistry: protoFiles,\n")
+
+	if err != nil {
+		return nil, err
+	}
+
+	src, err = insertAfterLine(src, "\t\tMessageInfos:", "\t\tTypeRegistry: protoTypes,\n")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(src)
+}
+
+// insertAfterLine returns the source with the addition inserted after the
+// only line with the prefix.
+func insertAfterLine(src []byte, prefix string, addition string) ([]byte, error) {
+	lines := bytes.SplitAfter(src, []byte{'\n'})
+
+	var result [][]byte
+	var found int
+
+	for _, line := range lines {
+		result = append(result, line)
+
+		if bytes.HasPrefix(line, []byte(prefix)) {
+			result = append(result, []byte(addition))
+			found++
+		}
+	}
+
+	if found != 1 {
+		return nil, fmt.Errorf("expected one line with prefix %q, found %d", prefix, found)
+	}
+
+	return bytes.Join(result, nil), nil
+}
+
+// replaceOnce returns the source with the only occurrence of old replaced.
+func replaceOnce(src []byte, old string, replacement string) ([]byte, error) {
+	if count := bytes.Count(src, []byte(old)); count != 1 {
+		return nil, fmt.Errorf("expected one occurrence of %q, found %d", old, count)
+	}
+
+	return bytes.Replace(src, []byte(old), []byte(replacement), 1), nil
+}
@@ -8,4 +8,10 @@ const (
 	// EnvTfLogSdkMux is an environment variable that sets the logging level
 	// of the mux logger. Infers root SDK logging level, if unset.
 	EnvTfLogSdkMux = "TF_LOG_SDK_MUX"
+
+	// EnvTfLogSdkMuxPayloads is an environment variable that enables logging
+	// the request and response payloads of each call to an underlying server
+	// at TRACE level of the mux logger, if set to a true value such as "1".
+	// Sensitive values and private state are redacted.
+	EnvTfLogSdkMuxPayloads = "TF_LOG_SDK_MUX_PAYLOADS"
 )
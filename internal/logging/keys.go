@@ -14,3 +14,47 @@ const (
 	// The RPC being run, such as "ApplyResourceChange"
 	KeyTfRpc = "tf_rpc"
 )
+
+// Logging keys attached to logs of recovered underlying server panics.
+const (
+	// The value passed to panic by the underlying server.
+	KeyPanic = "panic"
+
+	// The stack trace of the underlying server panic.
+	KeyPanicStack = "panic_stack"
+)
+
+// Logging keys attached to logs of errors which do not affect the RPC.
+const (
+	// The error which occurred, such as writing a recording.
+	KeyError = "error"
+)
+
+// Logging keys attached to logs of request and response payloads.
+const (
+	// The JSON encoding of the request sent to the underlying server.
+	KeyTfMuxRequestPayload = "tf_mux_request_payload"
+
+	// The JSON encoding of the response, or an element of a response
+	// stream, returned by the underlying server.
+	KeyTfMuxResponsePayload = "tf_mux_response_payload"
+)
+
+// Logging keys attached to logs of slow calls to underlying servers.
+const (
+	// The duration of the call in milliseconds.
+	KeyTfMuxDurationMs = "tf_mux_duration_ms"
+
+	// The threshold of the RPC in milliseconds, which the call took longer
+	// than.
+	KeyTfMuxThresholdMs = "tf_mux_threshold_ms"
+
+	// The type name of the request, such as "examplecloud_thing", if any.
+	KeyTfTypeName = "tf_type_name"
+)
+
+// Logging keys attached to logs of calls waiting for concurrency limits.
+const (
+	// The time the call waited for the concurrency limits in milliseconds.
+	KeyTfMuxWaitMs = "tf_mux_wait_ms"
+)
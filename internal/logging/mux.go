@@ -5,6 +5,8 @@ package logging
 
 import (
 	"context"
+	"os"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
 )
@@ -18,3 +20,26 @@ const (
 func MuxTrace(ctx context.Context, msg string, additionalFields ...map[string]interface{}) {
 	tfsdklog.SubsystemTrace(ctx, SubsystemMux, msg, additionalFields...)
 }
+
+// MuxDebug emits a mux subsystem log at DEBUG level.
+func MuxDebug(ctx context.Context, msg string, additionalFields ...map[string]interface{}) {
+	tfsdklog.SubsystemDebug(ctx, SubsystemMux, msg, additionalFields...)
+}
+
+// MuxWarn emits a mux subsystem log at WARN level.
+func MuxWarn(ctx context.Context, msg string, additionalFields ...map[string]interface{}) {
+	tfsdklog.SubsystemWarn(ctx, SubsystemMux, msg, additionalFields...)
+}
+
+// MuxError emits a mux subsystem log at ERROR level.
+func MuxError(ctx context.Context, msg string, additionalFields ...map[string]interface{}) {
+	tfsdklog.SubsystemError(ctx, SubsystemMux, msg, additionalFields...)
+}
+
+// MuxPayloadsEnabled returns true if logging request and response payloads
+// is enabled with the EnvTfLogSdkMuxPayloads environment variable.
+func MuxPayloadsEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(EnvTfLogSdkMuxPayloads))
+
+	return err == nil && enabled
+}
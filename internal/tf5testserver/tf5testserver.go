@@ -5,6 +5,7 @@ package tf5testserver
 
 import (
 	"context"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"google.golang.org/grpc/codes"
@@ -13,8 +14,16 @@ import (
 
 var _ tfprotov5.ProviderServer = &TestServer{}
 
+// TestServer is a ProviderServer which records the RPCs called on it. The
+// fields ending in Func, if set, handle their RPC once it is recorded, rather
+// than the default response being returned.
 type TestServer struct {
+	// mu guards the fields which record calls, as mux servers call underlying
+	// servers concurrently.
+	mu sync.Mutex
+
 	ApplyResourceChangeCalled map[string]bool
+	ApplyResourceChangeFunc   func(context.Context, *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error)
 
 	CallFunctionCalled map[string]bool
 
@@ -27,21 +36,25 @@ type TestServer struct {
 	GetFunctionsResponse *tfprotov5.GetFunctionsResponse
 
 	GetMetadataCalled   bool
+	GetMetadataFunc     func(context.Context, *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error)
 	GetMetadataResponse *tfprotov5.GetMetadataResponse
 
 	GetProviderSchemaCalled   bool
+	GetProviderSchemaFunc     func(context.Context, *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error)
 	GetProviderSchemaResponse *tfprotov5.GetProviderSchemaResponse
 
 	GetResourceIdentitySchemasCalled   bool
 	GetResourceIdentitySchemasResponse *tfprotov5.GetResourceIdentitySchemasResponse
 
 	ImportResourceStateCalled map[string]bool
+	ImportResourceStateFunc   func(context.Context, *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error)
 
 	MoveResourceStateCalled map[string]bool
 
 	OpenEphemeralResourceCalled map[string]bool
 
 	PlanResourceChangeCalled map[string]bool
+	PlanResourceChangeFunc   func(context.Context, *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error)
 
 	PrepareProviderConfigCalled   bool
 	PrepareProviderConfigResponse *tfprotov5.PrepareProviderConfigResponse
@@ -49,6 +62,7 @@ type TestServer struct {
 	ReadDataSourceCalled map[string]bool
 
 	ReadResourceCalled map[string]bool
+	ReadResourceFunc   func(context.Context, *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error)
 
 	RenewEphemeralResourceCalled map[string]bool
 
@@ -68,6 +82,7 @@ type TestServer struct {
 	ValidateListResourceConfigCalled map[string]bool
 
 	ListResourceCalled map[string]bool
+	ListResourceFunc   func(context.Context, *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error)
 
 	ValidateActionConfigCalled map[string]bool
 
@@ -82,35 +97,45 @@ func (s *TestServer) ProviderServer() tfprotov5.ProviderServer {
 	return s
 }
 
-func (s *TestServer) ApplyResourceChange(_ context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
-	if s.ApplyResourceChangeCalled == nil {
-		s.ApplyResourceChangeCalled = make(map[string]bool)
+// recordCall records a call of an RPC in calls by key, such as the type name
+// of the request.
+func (s *TestServer) recordCall(calls *map[string]bool, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if *calls == nil {
+		*calls = make(map[string]bool)
+	}
+
+	(*calls)[key] = true
+}
+
+func (s *TestServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	s.recordCall(&s.ApplyResourceChangeCalled, req.TypeName)
+
+	if s.ApplyResourceChangeFunc != nil {
+		return s.ApplyResourceChangeFunc(ctx, req)
 	}
 
-	s.ApplyResourceChangeCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) CallFunction(_ context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
-	if s.CallFunctionCalled == nil {
-		s.CallFunctionCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.CallFunctionCalled, req.Name)
 
-	s.CallFunctionCalled[req.Name] = true
 	return nil, nil
 }
 
 func (s *TestServer) CloseEphemeralResource(ctx context.Context, req *tfprotov5.CloseEphemeralResourceRequest) (*tfprotov5.CloseEphemeralResourceResponse, error) {
-	if s.CloseEphemeralResourceCalled == nil {
-		s.CloseEphemeralResourceCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.CloseEphemeralResourceCalled, req.TypeName)
 
-	s.CloseEphemeralResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ConfigureProvider(_ context.Context, _ *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	s.mu.Lock()
 	s.ConfigureProviderCalled = true
+	s.mu.Unlock()
 
 	if s.ConfigureProviderResponse != nil {
 		return s.ConfigureProviderResponse, nil
@@ -120,7 +145,9 @@ func (s *TestServer) ConfigureProvider(_ context.Context, _ *tfprotov5.Configure
 }
 
 func (s *TestServer) GetFunctions(_ context.Context, _ *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	s.mu.Lock()
 	s.GetFunctionsCalled = true
+	s.mu.Unlock()
 
 	if s.GetFunctionsResponse != nil {
 		return s.GetFunctionsResponse, nil
@@ -129,8 +156,14 @@ func (s *TestServer) GetFunctions(_ context.Context, _ *tfprotov5.GetFunctionsRe
 	return &tfprotov5.GetFunctionsResponse{}, nil
 }
 
-func (s *TestServer) GetMetadata(_ context.Context, _ *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+func (s *TestServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	s.mu.Lock()
 	s.GetMetadataCalled = true
+	s.mu.Unlock()
+
+	if s.GetMetadataFunc != nil {
+		return s.GetMetadataFunc(ctx, req)
+	}
 
 	if s.GetMetadataResponse != nil {
 		return s.GetMetadataResponse, nil
@@ -143,8 +176,14 @@ func (s *TestServer) GetMetadata(_ context.Context, _ *tfprotov5.GetMetadataRequ
 	return &tfprotov5.GetMetadataResponse{}, nil
 }
 
-func (s *TestServer) GetProviderSchema(_ context.Context, _ *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+func (s *TestServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	s.mu.Lock()
 	s.GetProviderSchemaCalled = true
+	s.mu.Unlock()
+
+	if s.GetProviderSchemaFunc != nil {
+		return s.GetProviderSchemaFunc(ctx, req)
+	}
 
 	if s.GetProviderSchemaResponse != nil {
 		return s.GetProviderSchemaResponse, nil
@@ -154,7 +193,9 @@ func (s *TestServer) GetProviderSchema(_ context.Context, _ *tfprotov5.GetProvid
 }
 
 func (s *TestServer) GetResourceIdentitySchemas(_ context.Context, _ *tfprotov5.GetResourceIdentitySchemasRequest) (*tfprotov5.GetResourceIdentitySchemasResponse, error) {
+	s.mu.Lock()
 	s.GetResourceIdentitySchemasCalled = true
+	s.mu.Unlock()
 
 	if s.GetResourceIdentitySchemasResponse != nil {
 		return s.GetResourceIdentitySchemasResponse, nil
@@ -163,71 +204,64 @@ func (s *TestServer) GetResourceIdentitySchemas(_ context.Context, _ *tfprotov5.
 	return &tfprotov5.GetResourceIdentitySchemasResponse{}, nil
 }
 
-func (s *TestServer) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
-	if s.ImportResourceStateCalled == nil {
-		s.ImportResourceStateCalled = make(map[string]bool)
+func (s *TestServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	s.recordCall(&s.ImportResourceStateCalled, req.TypeName)
+
+	if s.ImportResourceStateFunc != nil {
+		return s.ImportResourceStateFunc(ctx, req)
 	}
 
-	s.ImportResourceStateCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) MoveResourceState(_ context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
-	if s.MoveResourceStateCalled == nil {
-		s.MoveResourceStateCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.MoveResourceStateCalled, req.TargetTypeName)
 
-	s.MoveResourceStateCalled[req.TargetTypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) OpenEphemeralResource(_ context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
-	if s.OpenEphemeralResourceCalled == nil {
-		s.OpenEphemeralResourceCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.OpenEphemeralResourceCalled, req.TypeName)
 
-	s.OpenEphemeralResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
-func (s *TestServer) PlanResourceChange(_ context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
-	if s.PlanResourceChangeCalled == nil {
-		s.PlanResourceChangeCalled = make(map[string]bool)
+func (s *TestServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	s.recordCall(&s.PlanResourceChangeCalled, req.TypeName)
+
+	if s.PlanResourceChangeFunc != nil {
+		return s.PlanResourceChangeFunc(ctx, req)
 	}
 
-	s.PlanResourceChangeCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ReadDataSource(_ context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
-	if s.ReadDataSourceCalled == nil {
-		s.ReadDataSourceCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ReadDataSourceCalled, req.TypeName)
 
-	s.ReadDataSourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
-func (s *TestServer) ReadResource(_ context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
-	if s.ReadResourceCalled == nil {
-		s.ReadResourceCalled = make(map[string]bool)
+func (s *TestServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	s.recordCall(&s.ReadResourceCalled, req.TypeName)
+
+	if s.ReadResourceFunc != nil {
+		return s.ReadResourceFunc(ctx, req)
 	}
 
-	s.ReadResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) RenewEphemeralResource(_ context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
-	if s.RenewEphemeralResourceCalled == nil {
-		s.RenewEphemeralResourceCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.RenewEphemeralResourceCalled, req.TypeName)
 
-	s.RenewEphemeralResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) StopProvider(_ context.Context, _ *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	s.mu.Lock()
 	s.StopProviderCalled = true
+	s.mu.Unlock()
 
 	if s.StopProviderResponse != nil {
 		return s.StopProviderResponse, nil
@@ -237,105 +271,78 @@ func (s *TestServer) StopProvider(_ context.Context, _ *tfprotov5.StopProviderRe
 }
 
 func (s *TestServer) UpgradeResourceIdentity(_ context.Context, req *tfprotov5.UpgradeResourceIdentityRequest) (*tfprotov5.UpgradeResourceIdentityResponse, error) {
-	if s.UpgradeResourceIdentityCalled == nil {
-		s.UpgradeResourceIdentityCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.UpgradeResourceIdentityCalled, req.TypeName)
 
-	s.UpgradeResourceIdentityCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) UpgradeResourceState(_ context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
-	if s.UpgradeResourceStateCalled == nil {
-		s.UpgradeResourceStateCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.UpgradeResourceStateCalled, req.TypeName)
 
-	s.UpgradeResourceStateCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateEphemeralResourceConfig(_ context.Context, req *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
-	if s.ValidateEphemeralResourceConfigCalled == nil {
-		s.ValidateEphemeralResourceConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateEphemeralResourceConfigCalled, req.TypeName)
 
-	s.ValidateEphemeralResourceConfigCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateDataSourceConfig(_ context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
-	if s.ValidateDataSourceConfigCalled == nil {
-		s.ValidateDataSourceConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateDataSourceConfigCalled, req.TypeName)
 
-	s.ValidateDataSourceConfigCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateResourceTypeConfig(_ context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
-	if s.ValidateResourceTypeConfigCalled == nil {
-		s.ValidateResourceTypeConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateResourceTypeConfigCalled, req.TypeName)
 
-	s.ValidateResourceTypeConfigCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateListResourceConfig(_ context.Context, req *tfprotov5.ValidateListResourceConfigRequest) (*tfprotov5.ValidateListResourceConfigResponse, error) {
-	if s.ValidateListResourceConfigCalled == nil {
-		s.ValidateListResourceConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateListResourceConfigCalled, req.TypeName)
 
-	s.ValidateListResourceConfigCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) PrepareProviderConfig(_ context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	s.mu.Lock()
 	s.PrepareProviderConfigCalled = true
+	s.mu.Unlock()
 	return s.PrepareProviderConfigResponse, nil
 }
 
-func (s *TestServer) ListResource(_ context.Context, req *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
-	if s.ListResourceCalled == nil {
-		s.ListResourceCalled = make(map[string]bool)
+func (s *TestServer) ListResource(ctx context.Context, req *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+	s.recordCall(&s.ListResourceCalled, req.TypeName)
+
+	if s.ListResourceFunc != nil {
+		return s.ListResourceFunc(ctx, req)
 	}
 
-	s.ListResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateActionConfig(_ context.Context, req *tfprotov5.ValidateActionConfigRequest) (*tfprotov5.ValidateActionConfigResponse, error) {
-	if s.ValidateActionConfigCalled == nil {
-		s.ValidateActionConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateActionConfigCalled, req.ActionType)
 
-	s.ValidateActionConfigCalled[req.ActionType] = true
 	return nil, nil
 }
 
 func (s *TestServer) PlanAction(ctx context.Context, req *tfprotov5.PlanActionRequest) (*tfprotov5.PlanActionResponse, error) {
-	if s.PlanActionCalled == nil {
-		s.PlanActionCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.PlanActionCalled, req.ActionType)
 
-	s.PlanActionCalled[req.ActionType] = true
 	return nil, nil
 }
 
 func (s *TestServer) InvokeAction(ctx context.Context, req *tfprotov5.InvokeActionRequest) (*tfprotov5.InvokeActionServerStream, error) {
-	if s.InvokeActionCalled == nil {
-		s.InvokeActionCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.InvokeActionCalled, req.ActionType)
 
-	s.InvokeActionCalled[req.ActionType] = true
 	return nil, nil
 }
 
 func (s *TestServer) GenerateResourceConfig(ctx context.Context, req *tfprotov5.GenerateResourceConfigRequest) (*tfprotov5.GenerateResourceConfigResponse, error) {
-	if s.GenerateResourceConfigCalled == nil {
-		s.GenerateResourceConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.GenerateResourceConfigCalled, req.TypeName)
 
-	s.GenerateResourceConfigCalled[req.TypeName] = true
 	return nil, nil
 }
@@ -5,6 +5,7 @@ package tf6testserver
 
 import (
 	"context"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"google.golang.org/grpc/codes"
@@ -13,8 +14,16 @@ import (
 
 var _ tfprotov6.ProviderServer = &TestServer{}
 
+// TestServer is a ProviderServer which records the RPCs called on it. The
+// fields ending in Func, if set, handle their RPC once it is recorded, rather
+// than the default response being returned.
 type TestServer struct {
+	// mu guards the fields which record calls, as mux servers call underlying
+	// servers concurrently.
+	mu sync.Mutex
+
 	ApplyResourceChangeCalled map[string]bool
+	ApplyResourceChangeFunc   func(context.Context, *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error)
 
 	CallFunctionCalled map[string]bool
 
@@ -27,25 +36,30 @@ type TestServer struct {
 	GetFunctionsResponse *tfprotov6.GetFunctionsResponse
 
 	GetMetadataCalled   bool
+	GetMetadataFunc     func(context.Context, *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error)
 	GetMetadataResponse *tfprotov6.GetMetadataResponse
 
 	GetProviderSchemaCalled   bool
+	GetProviderSchemaFunc     func(context.Context, *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error)
 	GetProviderSchemaResponse *tfprotov6.GetProviderSchemaResponse
 
 	GetResourceIdentitySchemasCalled   bool
 	GetResourceIdentitySchemasResponse *tfprotov6.GetResourceIdentitySchemasResponse
 
 	ImportResourceStateCalled map[string]bool
+	ImportResourceStateFunc   func(context.Context, *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error)
 
 	MoveResourceStateCalled map[string]bool
 
 	OpenEphemeralResourceCalled map[string]bool
 
 	PlanResourceChangeCalled map[string]bool
+	PlanResourceChangeFunc   func(context.Context, *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error)
 
 	ReadDataSourceCalled map[string]bool
 
 	ReadResourceCalled map[string]bool
+	ReadResourceFunc   func(context.Context, *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error)
 
 	RenewEphemeralResourceCalled map[string]bool
 
@@ -68,6 +82,7 @@ type TestServer struct {
 	ValidateListResourceConfigCalled map[string]bool
 
 	ListResourceCalled map[string]bool
+	ListResourceFunc   func(context.Context, *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error)
 
 	ValidateActionConfigCalled map[string]bool
 
@@ -80,8 +95,10 @@ type TestServer struct {
 	ConfigureStateStoreCalled map[string]bool
 
 	ReadStateBytesCalled map[string]bool
+	ReadStateBytesFunc   func(context.Context, *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error)
 
 	WriteStateBytesCalled map[string]bool
+	WriteStateBytesFunc   func(context.Context, *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error)
 
 	GetStatesCalled map[string]bool
 
@@ -98,35 +115,45 @@ func (s *TestServer) ProviderServer() tfprotov6.ProviderServer {
 	return s
 }
 
-func (s *TestServer) ApplyResourceChange(_ context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
-	if s.ApplyResourceChangeCalled == nil {
-		s.ApplyResourceChangeCalled = make(map[string]bool)
+// recordCall records a call of an RPC in calls by key, such as the type name
+// of the request.
+func (s *TestServer) recordCall(calls *map[string]bool, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if *calls == nil {
+		*calls = make(map[string]bool)
+	}
+
+	(*calls)[key] = true
+}
+
+func (s *TestServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	s.recordCall(&s.ApplyResourceChangeCalled, req.TypeName)
+
+	if s.ApplyResourceChangeFunc != nil {
+		return s.ApplyResourceChangeFunc(ctx, req)
 	}
 
-	s.ApplyResourceChangeCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) CallFunction(_ context.Context, req *tfprotov6.CallFunctionRequest) (*tfprotov6.CallFunctionResponse, error) {
-	if s.CallFunctionCalled == nil {
-		s.CallFunctionCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.CallFunctionCalled, req.Name)
 
-	s.CallFunctionCalled[req.Name] = true
 	return nil, nil
 }
 
 func (s *TestServer) CloseEphemeralResource(ctx context.Context, req *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
-	if s.CloseEphemeralResourceCalled == nil {
-		s.CloseEphemeralResourceCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.CloseEphemeralResourceCalled, req.TypeName)
 
-	s.CloseEphemeralResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ConfigureProvider(_ context.Context, _ *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	s.mu.Lock()
 	s.ConfigureProviderCalled = true
+	s.mu.Unlock()
 
 	if s.ConfigureProviderResponse != nil {
 		return s.ConfigureProviderResponse, nil
@@ -136,7 +163,9 @@ func (s *TestServer) ConfigureProvider(_ context.Context, _ *tfprotov6.Configure
 }
 
 func (s *TestServer) GetFunctions(_ context.Context, _ *tfprotov6.GetFunctionsRequest) (*tfprotov6.GetFunctionsResponse, error) {
+	s.mu.Lock()
 	s.GetFunctionsCalled = true
+	s.mu.Unlock()
 
 	if s.GetFunctionsResponse != nil {
 		return s.GetFunctionsResponse, nil
@@ -145,8 +174,14 @@ func (s *TestServer) GetFunctions(_ context.Context, _ *tfprotov6.GetFunctionsRe
 	return &tfprotov6.GetFunctionsResponse{}, nil
 }
 
-func (s *TestServer) GetMetadata(_ context.Context, _ *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
+func (s *TestServer) GetMetadata(ctx context.Context, req *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
+	s.mu.Lock()
 	s.GetMetadataCalled = true
+	s.mu.Unlock()
+
+	if s.GetMetadataFunc != nil {
+		return s.GetMetadataFunc(ctx, req)
+	}
 
 	if s.GetMetadataResponse != nil {
 		return s.GetMetadataResponse, nil
@@ -159,8 +194,14 @@ func (s *TestServer) GetMetadata(_ context.Context, _ *tfprotov6.GetMetadataRequ
 	return &tfprotov6.GetMetadataResponse{}, nil
 }
 
-func (s *TestServer) GetProviderSchema(_ context.Context, _ *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+func (s *TestServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	s.mu.Lock()
 	s.GetProviderSchemaCalled = true
+	s.mu.Unlock()
+
+	if s.GetProviderSchemaFunc != nil {
+		return s.GetProviderSchemaFunc(ctx, req)
+	}
 
 	if s.GetProviderSchemaResponse != nil {
 		return s.GetProviderSchemaResponse, nil
@@ -170,7 +211,9 @@ func (s *TestServer) GetProviderSchema(_ context.Context, _ *tfprotov6.GetProvid
 }
 
 func (s *TestServer) GetResourceIdentitySchemas(_ context.Context, _ *tfprotov6.GetResourceIdentitySchemasRequest) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
+	s.mu.Lock()
 	s.GetResourceIdentitySchemasCalled = true
+	s.mu.Unlock()
 
 	if s.GetResourceIdentitySchemasResponse != nil {
 		return s.GetResourceIdentitySchemasResponse, nil
@@ -179,71 +222,64 @@ func (s *TestServer) GetResourceIdentitySchemas(_ context.Context, _ *tfprotov6.
 	return &tfprotov6.GetResourceIdentitySchemasResponse{}, nil
 }
 
-func (s *TestServer) ImportResourceState(_ context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
-	if s.ImportResourceStateCalled == nil {
-		s.ImportResourceStateCalled = make(map[string]bool)
+func (s *TestServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	s.recordCall(&s.ImportResourceStateCalled, req.TypeName)
+
+	if s.ImportResourceStateFunc != nil {
+		return s.ImportResourceStateFunc(ctx, req)
 	}
 
-	s.ImportResourceStateCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) MoveResourceState(_ context.Context, req *tfprotov6.MoveResourceStateRequest) (*tfprotov6.MoveResourceStateResponse, error) {
-	if s.MoveResourceStateCalled == nil {
-		s.MoveResourceStateCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.MoveResourceStateCalled, req.TargetTypeName)
 
-	s.MoveResourceStateCalled[req.TargetTypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) OpenEphemeralResource(_ context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
-	if s.OpenEphemeralResourceCalled == nil {
-		s.OpenEphemeralResourceCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.OpenEphemeralResourceCalled, req.TypeName)
 
-	s.OpenEphemeralResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
-func (s *TestServer) PlanResourceChange(_ context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
-	if s.PlanResourceChangeCalled == nil {
-		s.PlanResourceChangeCalled = make(map[string]bool)
+func (s *TestServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	s.recordCall(&s.PlanResourceChangeCalled, req.TypeName)
+
+	if s.PlanResourceChangeFunc != nil {
+		return s.PlanResourceChangeFunc(ctx, req)
 	}
 
-	s.PlanResourceChangeCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ReadDataSource(_ context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
-	if s.ReadDataSourceCalled == nil {
-		s.ReadDataSourceCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ReadDataSourceCalled, req.TypeName)
 
-	s.ReadDataSourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
-func (s *TestServer) ReadResource(_ context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
-	if s.ReadResourceCalled == nil {
-		s.ReadResourceCalled = make(map[string]bool)
+func (s *TestServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	s.recordCall(&s.ReadResourceCalled, req.TypeName)
+
+	if s.ReadResourceFunc != nil {
+		return s.ReadResourceFunc(ctx, req)
 	}
 
-	s.ReadResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) RenewEphemeralResource(_ context.Context, req *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
-	if s.RenewEphemeralResourceCalled == nil {
-		s.RenewEphemeralResourceCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.RenewEphemeralResourceCalled, req.TypeName)
 
-	s.RenewEphemeralResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) StopProvider(_ context.Context, _ *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error) {
+	s.mu.Lock()
 	s.StopProviderCalled = true
+	s.mu.Unlock()
 
 	if s.StopProviderResponse != nil {
 		return s.StopProviderResponse, nil
@@ -253,130 +289,120 @@ func (s *TestServer) StopProvider(_ context.Context, _ *tfprotov6.StopProviderRe
 }
 
 func (s *TestServer) UpgradeResourceIdentity(_ context.Context, req *tfprotov6.UpgradeResourceIdentityRequest) (*tfprotov6.UpgradeResourceIdentityResponse, error) {
-	if s.UpgradeResourceIdentityCalled == nil {
-		s.UpgradeResourceIdentityCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.UpgradeResourceIdentityCalled, req.TypeName)
 
-	s.UpgradeResourceIdentityCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) UpgradeResourceState(_ context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
-	if s.UpgradeResourceStateCalled == nil {
-		s.UpgradeResourceStateCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.UpgradeResourceStateCalled, req.TypeName)
 
-	s.UpgradeResourceStateCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateEphemeralResourceConfig(_ context.Context, req *tfprotov6.ValidateEphemeralResourceConfigRequest) (*tfprotov6.ValidateEphemeralResourceConfigResponse, error) {
-	if s.ValidateEphemeralResourceConfigCalled == nil {
-		s.ValidateEphemeralResourceConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateEphemeralResourceConfigCalled, req.TypeName)
 
-	s.ValidateEphemeralResourceConfigCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateDataResourceConfig(_ context.Context, req *tfprotov6.ValidateDataResourceConfigRequest) (*tfprotov6.ValidateDataResourceConfigResponse, error) {
-	if s.ValidateDataResourceConfigCalled == nil {
-		s.ValidateDataResourceConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateDataResourceConfigCalled, req.TypeName)
 
-	s.ValidateDataResourceConfigCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateResourceConfig(_ context.Context, req *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
-	if s.ValidateResourceConfigCalled == nil {
-		s.ValidateResourceConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateResourceConfigCalled, req.TypeName)
 
-	s.ValidateResourceConfigCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateProviderConfig(_ context.Context, req *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	s.mu.Lock()
 	s.ValidateProviderConfigCalled = true
+	s.mu.Unlock()
 	return s.ValidateProviderConfigResponse, nil
 }
 
 func (s *TestServer) ValidateListResourceConfig(_ context.Context, req *tfprotov6.ValidateListResourceConfigRequest) (*tfprotov6.ValidateListResourceConfigResponse, error) {
-	if s.ValidateListResourceConfigCalled == nil {
-		s.ValidateListResourceConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateListResourceConfigCalled, req.TypeName)
 
-	s.ValidateListResourceConfigCalled[req.TypeName] = true
 	return nil, nil
 }
 
-func (s *TestServer) ListResource(_ context.Context, req *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
-	if s.ListResourceCalled == nil {
-		s.ListResourceCalled = make(map[string]bool)
+func (s *TestServer) ListResource(ctx context.Context, req *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
+	s.recordCall(&s.ListResourceCalled, req.TypeName)
+
+	if s.ListResourceFunc != nil {
+		return s.ListResourceFunc(ctx, req)
 	}
 
-	s.ListResourceCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateActionConfig(_ context.Context, req *tfprotov6.ValidateActionConfigRequest) (*tfprotov6.ValidateActionConfigResponse, error) {
-	if s.ValidateActionConfigCalled == nil {
-		s.ValidateActionConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateActionConfigCalled, req.ActionType)
 
-	s.ValidateActionConfigCalled[req.ActionType] = true
 	return nil, nil
 }
 
 func (s *TestServer) PlanAction(ctx context.Context, req *tfprotov6.PlanActionRequest) (*tfprotov6.PlanActionResponse, error) {
-	if s.PlanActionCalled == nil {
-		s.PlanActionCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.PlanActionCalled, req.ActionType)
 
-	s.PlanActionCalled[req.ActionType] = true
 	return nil, nil
 }
 
 func (s *TestServer) InvokeAction(ctx context.Context, req *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
-	if s.InvokeActionCalled == nil {
-		s.InvokeActionCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.InvokeActionCalled, req.ActionType)
 
-	s.InvokeActionCalled[req.ActionType] = true
 	return nil, nil
 }
 
 func (s *TestServer) ValidateStateStoreConfig(_ context.Context, req *tfprotov6.ValidateStateStoreConfigRequest) (*tfprotov6.ValidateStateStoreConfigResponse, error) {
-	if s.ValidateStateStoreConfigCalled == nil {
-		s.ValidateStateStoreConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ValidateStateStoreConfigCalled, req.TypeName)
 
-	s.ValidateStateStoreConfigCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) ConfigureStateStore(_ context.Context, req *tfprotov6.ConfigureStateStoreRequest) (*tfprotov6.ConfigureStateStoreResponse, error) {
-	if s.ConfigureStateStoreCalled == nil {
-		s.ConfigureStateStoreCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.ConfigureStateStoreCalled, req.TypeName)
 
-	s.ConfigureStateStoreCalled[req.TypeName] = true
 	return nil, nil
 }
 
-func (s *TestServer) ReadStateBytes(_ context.Context, req *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
-	if s.ReadStateBytesCalled == nil {
-		s.ReadStateBytesCalled = make(map[string]bool)
+func (s *TestServer) ReadStateBytes(ctx context.Context, req *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+	s.recordCall(&s.ReadStateBytesCalled, req.TypeName)
+
+	if s.ReadStateBytesFunc != nil {
+		return s.ReadStateBytesFunc(ctx, req)
 	}
 
-	s.ReadStateBytesCalled[req.TypeName] = true
 	return nil, nil
 }
 
-func (s *TestServer) WriteStateBytes(_ context.Context, req *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
-	if s.WriteStateBytesCalled == nil {
-		s.WriteStateBytesCalled = make(map[string]bool)
+func (s *TestServer) WriteStateBytes(ctx context.Context, req *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
+	if s.WriteStateBytesFunc != nil {
+		// The call is recorded from the first chunk with metadata, which
+		// the function still receives.
+		chunks := req.Chunks
+
+		return s.WriteStateBytesFunc(ctx, &tfprotov6.WriteStateBytesStream{
+			Chunks: func(yield func(*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic) bool) {
+				var recorded bool
+
+				for chunk, diags := range chunks {
+					if !recorded && chunk != nil && chunk.Meta != nil {
+						s.recordCall(&s.WriteStateBytesCalled, chunk.Meta.TypeName)
+						recorded = true
+					}
+
+					if !yield(chunk, diags) {
+						return
+					}
+				}
+			},
+		})
 	}
 
 	if req != nil && req.Chunks != nil {
@@ -384,7 +410,8 @@ func (s *TestServer) WriteStateBytes(_ context.Context, req *tfprotov6.WriteStat
 			if chunk == nil || chunk.Meta == nil {
 				continue
 			}
-			s.WriteStateBytesCalled[chunk.Meta.TypeName] = true
+			s.recordCall(&s.WriteStateBytesCalled, chunk.Meta.TypeName)
+
 			break
 		}
 	}
@@ -393,46 +420,31 @@ func (s *TestServer) WriteStateBytes(_ context.Context, req *tfprotov6.WriteStat
 }
 
 func (s *TestServer) GetStates(_ context.Context, req *tfprotov6.GetStatesRequest) (*tfprotov6.GetStatesResponse, error) {
-	if s.GetStatesCalled == nil {
-		s.GetStatesCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.GetStatesCalled, req.TypeName)
 
-	s.GetStatesCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) DeleteState(_ context.Context, req *tfprotov6.DeleteStateRequest) (*tfprotov6.DeleteStateResponse, error) {
-	if s.DeleteStateCalled == nil {
-		s.DeleteStateCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.DeleteStateCalled, req.TypeName)
 
-	s.DeleteStateCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) LockState(_ context.Context, req *tfprotov6.LockStateRequest) (*tfprotov6.LockStateResponse, error) {
-	if s.LockStateCalled == nil {
-		s.LockStateCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.LockStateCalled, req.TypeName)
 
-	s.LockStateCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) UnlockState(_ context.Context, req *tfprotov6.UnlockStateRequest) (*tfprotov6.UnlockStateResponse, error) {
-	if s.UnlockStateCalled == nil {
-		s.UnlockStateCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.UnlockStateCalled, req.TypeName)
 
-	s.UnlockStateCalled[req.TypeName] = true
 	return nil, nil
 }
 
 func (s *TestServer) GenerateResourceConfig(ctx context.Context, req *tfprotov6.GenerateResourceConfigRequest) (*tfprotov6.GenerateResourceConfigResponse, error) {
-	if s.GenerateResourceConfigCalled == nil {
-		s.GenerateResourceConfigCalled = make(map[string]bool)
-	}
+	s.recordCall(&s.GenerateResourceConfigCalled, req.TypeName)
 
-	s.GenerateResourceConfigCalled[req.TypeName] = true
 	return nil, nil
 }
@@ -0,0 +1,36 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfplugin6
+
+//go:generate go run ../cmd/copytfplugin 6
+
+import (
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The generated code in this package is a copy of the terraform-plugin-go
+// tfplugin6 package, which is internal to that module, made by the
+// copytfplugin command with go generate. The descriptors are registered with
+// these package-specific registries, rather than the protoregistry globals,
+// so they do not conflict with the original registrations when both packages
+// are linked into the same binary, such as a provider which uses tf6server
+// and tf6pluginclient. The only other change to the generated code is its
+// go_package.
+var (
+	protoFiles = newProtoFiles()
+	protoTypes = new(protoregistry.Types)
+)
+
+// newProtoFiles returns a protoregistry.Files with the well-known types
+// imported by tfplugin6.proto.
+func newProtoFiles() *protoregistry.Files {
+	files := new(protoregistry.Files)
+
+	// Registering a single well-known file into an empty registry cannot
+	// conflict.
+	_ = files.RegisterFile(timestamppb.File_google_protobuf_timestamp_proto)
+
+	return files
+}
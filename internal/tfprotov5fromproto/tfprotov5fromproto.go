@@ -0,0 +1,911 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfprotov5fromproto converts Protocol Buffers generated tfplugin5
+// response types into terraform-plugin-go tfprotov5 types, for responses
+// received from a provider server over gRPC.
+package tfprotov5fromproto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin5"
+)
+
+func ActionSchema(in *tfplugin5.ActionSchema) (*tfprotov5.ActionSchema, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	schema, err := Schema(in.Schema)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.ActionSchema{
+		Schema: schema,
+	}, nil
+}
+
+func ApplyResourceChangeResponse(in *tfplugin5.ApplyResourceChange_Response) *tfprotov5.ApplyResourceChangeResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ApplyResourceChangeResponse{
+		Diagnostics:                 Diagnostics(in.Diagnostics),
+		NewIdentity:                 ResourceIdentityData(in.NewIdentity),
+		NewState:                    DynamicValue(in.NewState),
+		Private:                     in.Private,
+		UnsafeToUseLegacyTypeSystem: in.LegacyTypeSystem, //nolint:staticcheck
+	}
+}
+
+func AttributePath(in *tfplugin5.AttributePath) *tftypes.AttributePath {
+	if in == nil {
+		return nil
+	}
+
+	steps := make([]tftypes.AttributePathStep, 0, len(in.Steps))
+
+	for _, step := range in.Steps {
+		switch selector := step.GetSelector().(type) {
+		case *tfplugin5.AttributePath_Step_AttributeName:
+			steps = append(steps, tftypes.AttributeName(selector.AttributeName))
+		case *tfplugin5.AttributePath_Step_ElementKeyInt:
+			steps = append(steps, tftypes.ElementKeyInt(selector.ElementKeyInt))
+		case *tfplugin5.AttributePath_Step_ElementKeyString:
+			steps = append(steps, tftypes.ElementKeyString(selector.ElementKeyString))
+		}
+	}
+
+	return tftypes.NewAttributePathWithSteps(steps)
+}
+
+func AttributePaths(in []*tfplugin5.AttributePath) []*tftypes.AttributePath {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*tftypes.AttributePath, 0, len(in))
+
+	for _, attributePath := range in {
+		out = append(out, AttributePath(attributePath))
+	}
+
+	return out
+}
+
+func CallFunctionResponse(in *tfplugin5.CallFunction_Response) *tfprotov5.CallFunctionResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.CallFunctionResponse{
+		Error:  FunctionError(in.Error),
+		Result: DynamicValue(in.Result),
+	}
+}
+
+func CloseEphemeralResourceResponse(in *tfplugin5.CloseEphemeralResource_Response) *tfprotov5.CloseEphemeralResourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.CloseEphemeralResourceResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ConfigureProviderResponse(in *tfplugin5.Configure_Response) *tfprotov5.ConfigureProviderResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ConfigureProviderResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func Deferred(in *tfplugin5.Deferred) *tfprotov5.Deferred {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.Deferred{
+		Reason: tfprotov5.DeferredReason(in.Reason),
+	}
+}
+
+func Diagnostic(in *tfplugin5.Diagnostic) *tfprotov5.Diagnostic {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.Diagnostic{
+		Attribute: AttributePath(in.Attribute),
+		Detail:    in.Detail,
+		Severity:  tfprotov5.DiagnosticSeverity(in.Severity),
+		Summary:   in.Summary,
+	}
+}
+
+func Diagnostics(in []*tfplugin5.Diagnostic) []*tfprotov5.Diagnostic {
+	if in == nil {
+		return nil
+	}
+
+	diags := make([]*tfprotov5.Diagnostic, 0, len(in))
+
+	for _, diag := range in {
+		diags = append(diags, Diagnostic(diag))
+	}
+
+	return diags
+}
+
+func DynamicValue(in *tfplugin5.DynamicValue) *tfprotov5.DynamicValue {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.DynamicValue{
+		JSON:    in.Json,
+		MsgPack: in.Msgpack,
+	}
+}
+
+func Function(in *tfplugin5.Function) (*tfprotov5.Function, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &tfprotov5.Function{
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Parameters:         make([]*tfprotov5.FunctionParameter, 0, len(in.Parameters)),
+		Summary:            in.Summary,
+	}
+
+	for _, parameter := range in.Parameters {
+		v5Parameter, err := FunctionParameter(parameter)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert parameter %q: %w", parameter.GetName(), err)
+		}
+
+		out.Parameters = append(out.Parameters, v5Parameter)
+	}
+
+	variadicParameter, err := FunctionParameter(in.VariadicParameter)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert variadic parameter: %w", err)
+	}
+
+	out.VariadicParameter = variadicParameter
+
+	functionReturn, err := FunctionReturn(in.Return)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert return: %w", err)
+	}
+
+	out.Return = functionReturn
+
+	return out, nil
+}
+
+func FunctionError(in *tfplugin5.FunctionError) *tfprotov5.FunctionError {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.FunctionError{
+		FunctionArgument: in.FunctionArgument,
+		Text:             in.Text,
+	}
+}
+
+func FunctionParameter(in *tfplugin5.Function_Parameter) (*tfprotov5.FunctionParameter, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	parameterType, err := Type(in.Type)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.FunctionParameter{
+		AllowNullValue:     in.AllowNullValue,
+		AllowUnknownValues: in.AllowUnknownValues,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Name:               in.Name,
+		Type:               parameterType,
+	}, nil
+}
+
+func FunctionReturn(in *tfplugin5.Function_Return) (*tfprotov5.FunctionReturn, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	returnType, err := Type(in.Type)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.FunctionReturn{
+		Type: returnType,
+	}, nil
+}
+
+func Functions(in map[string]*tfplugin5.Function) (map[string]*tfprotov5.Function, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := make(map[string]*tfprotov5.Function, len(in))
+
+	for name, function := range in {
+		v5Function, err := Function(function)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert function %q: %w", name, err)
+		}
+
+		out[name] = v5Function
+	}
+
+	return out, nil
+}
+
+func GenerateResourceConfigResponse(in *tfplugin5.GenerateResourceConfig_Response) *tfprotov5.GenerateResourceConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.GenerateResourceConfigResponse{
+		Config:      DynamicValue(in.Config),
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func GetFunctionsResponse(in *tfplugin5.GetFunctions_Response) (*tfprotov5.GetFunctionsResponse, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	functions, err := Functions(in.Functions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.GetFunctionsResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Functions:   functions,
+	}, nil
+}
+
+func GetMetadataResponse(in *tfplugin5.GetMetadata_Response) *tfprotov5.GetMetadataResponse {
+	if in == nil {
+		return nil
+	}
+
+	out := &tfprotov5.GetMetadataResponse{
+		Actions:            make([]tfprotov5.ActionMetadata, 0, len(in.Actions)),
+		DataSources:        make([]tfprotov5.DataSourceMetadata, 0, len(in.DataSources)),
+		Diagnostics:        Diagnostics(in.Diagnostics),
+		EphemeralResources: make([]tfprotov5.EphemeralResourceMetadata, 0, len(in.EphemeralResources)),
+		Functions:          make([]tfprotov5.FunctionMetadata, 0, len(in.Functions)),
+		ListResources:      make([]tfprotov5.ListResourceMetadata, 0, len(in.ListResources)),
+		Resources:          make([]tfprotov5.ResourceMetadata, 0, len(in.Resources)),
+		ServerCapabilities: ServerCapabilities(in.ServerCapabilities),
+	}
+
+	for _, action := range in.Actions {
+		out.Actions = append(out.Actions, tfprotov5.ActionMetadata{
+			TypeName: action.GetTypeName(),
+		})
+	}
+
+	for _, datasource := range in.DataSources {
+		out.DataSources = append(out.DataSources, tfprotov5.DataSourceMetadata{
+			TypeName: datasource.GetTypeName(),
+		})
+	}
+
+	for _, ephemeralResource := range in.EphemeralResources {
+		out.EphemeralResources = append(out.EphemeralResources, tfprotov5.EphemeralResourceMetadata{
+			TypeName: ephemeralResource.GetTypeName(),
+		})
+	}
+
+	for _, function := range in.Functions {
+		out.Functions = append(out.Functions, tfprotov5.FunctionMetadata{
+			Name: function.GetName(),
+		})
+	}
+
+	for _, listResource := range in.ListResources {
+		out.ListResources = append(out.ListResources, tfprotov5.ListResourceMetadata{
+			TypeName: listResource.GetTypeName(),
+		})
+	}
+
+	for _, resource := range in.Resources {
+		out.Resources = append(out.Resources, tfprotov5.ResourceMetadata{
+			TypeName: resource.GetTypeName(),
+		})
+	}
+
+	return out
+}
+
+func GetProviderSchemaResponse(in *tfplugin5.GetProviderSchema_Response) (*tfprotov5.GetProviderSchemaResponse, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	actionSchemas := make(map[string]*tfprotov5.ActionSchema, len(in.ActionSchemas))
+
+	for k, v := range in.ActionSchemas {
+		v5Schema, err := ActionSchema(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert action %q schema: %w", k, err)
+		}
+
+		actionSchemas[k] = v5Schema
+	}
+
+	dataSourceSchemas, err := Schemas(in.DataSourceSchemas)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert data source %w", err)
+	}
+
+	ephemeralResourceSchemas, err := Schemas(in.EphemeralResourceSchemas)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert ephemeral resource %w", err)
+	}
+
+	functions, err := Functions(in.Functions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	listResourceSchemas, err := Schemas(in.ListResourceSchemas)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert list resource %w", err)
+	}
+
+	provider, err := Schema(in.Provider)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert provider schema: %w", err)
+	}
+
+	providerMeta, err := Schema(in.ProviderMeta)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert provider meta schema: %w", err)
+	}
+
+	resourceSchemas, err := Schemas(in.ResourceSchemas)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert resource %w", err)
+	}
+
+	return &tfprotov5.GetProviderSchemaResponse{
+		ActionSchemas:            actionSchemas,
+		DataSourceSchemas:        dataSourceSchemas,
+		Diagnostics:              Diagnostics(in.Diagnostics),
+		EphemeralResourceSchemas: ephemeralResourceSchemas,
+		Functions:                functions,
+		ListResourceSchemas:      listResourceSchemas,
+		Provider:                 provider,
+		ProviderMeta:             providerMeta,
+		ResourceSchemas:          resourceSchemas,
+		ServerCapabilities:       ServerCapabilities(in.ServerCapabilities),
+	}, nil
+}
+
+func GetResourceIdentitySchemasResponse(in *tfplugin5.GetResourceIdentitySchemas_Response) (*tfprotov5.GetResourceIdentitySchemasResponse, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	identitySchemas := make(map[string]*tfprotov5.ResourceIdentitySchema, len(in.IdentitySchemas))
+
+	for k, v := range in.IdentitySchemas {
+		v5Schema, err := ResourceIdentitySchema(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert resource %q identity schema: %w", k, err)
+		}
+
+		identitySchemas[k] = v5Schema
+	}
+
+	return &tfprotov5.GetResourceIdentitySchemasResponse{
+		Diagnostics:     Diagnostics(in.Diagnostics),
+		IdentitySchemas: identitySchemas,
+	}, nil
+}
+
+func ImportResourceStateResponse(in *tfplugin5.ImportResourceState_Response) *tfprotov5.ImportResourceStateResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ImportResourceStateResponse{
+		Deferred:          Deferred(in.Deferred),
+		Diagnostics:       Diagnostics(in.Diagnostics),
+		ImportedResources: ImportedResources(in.ImportedResources),
+	}
+}
+
+func ImportedResources(in []*tfplugin5.ImportResourceState_ImportedResource) []*tfprotov5.ImportedResource {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*tfprotov5.ImportedResource, 0, len(in))
+
+	for _, resource := range in {
+		if resource == nil {
+			out = append(out, nil)
+
+			continue
+		}
+
+		out = append(out, &tfprotov5.ImportedResource{
+			Identity: ResourceIdentityData(resource.Identity),
+			Private:  resource.Private,
+			State:    DynamicValue(resource.State),
+			TypeName: resource.TypeName,
+		})
+	}
+
+	return out
+}
+
+func InvokeActionEvent(in *tfplugin5.InvokeAction_Event) tfprotov5.InvokeActionEvent {
+	switch event := in.GetType().(type) {
+	case *tfplugin5.InvokeAction_Event_Progress_:
+		return tfprotov5.InvokeActionEvent{
+			Type: tfprotov5.ProgressInvokeActionEventType{
+				Message: event.Progress.GetMessage(),
+			},
+		}
+	case *tfplugin5.InvokeAction_Event_Completed_:
+		return tfprotov5.InvokeActionEvent{
+			Type: tfprotov5.CompletedInvokeActionEventType{
+				Diagnostics: Diagnostics(event.Completed.GetDiagnostics()),
+			},
+		}
+	}
+
+	// An event without a type is treated as completed without diagnostics,
+	// which is the only terminal event.
+	return tfprotov5.InvokeActionEvent{
+		Type: tfprotov5.CompletedInvokeActionEventType{},
+	}
+}
+
+func ListResourceResult(in *tfplugin5.ListResource_Event) tfprotov5.ListResourceResult {
+	return tfprotov5.ListResourceResult{
+		Diagnostics: Diagnostics(in.GetDiagnostic()),
+		DisplayName: in.GetDisplayName(),
+		Identity:    ResourceIdentityData(in.GetIdentity()),
+		Resource:    DynamicValue(in.GetResourceObject()),
+	}
+}
+
+func MoveResourceStateResponse(in *tfplugin5.MoveResourceState_Response) *tfprotov5.MoveResourceStateResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.MoveResourceStateResponse{
+		Diagnostics:    Diagnostics(in.Diagnostics),
+		TargetIdentity: ResourceIdentityData(in.TargetIdentity),
+		TargetPrivate:  in.TargetPrivate,
+		TargetState:    DynamicValue(in.TargetState),
+	}
+}
+
+func OpenEphemeralResourceResponse(in *tfplugin5.OpenEphemeralResource_Response) *tfprotov5.OpenEphemeralResourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.OpenEphemeralResourceResponse{
+		Deferred:    Deferred(in.Deferred),
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Private:     in.Private,
+		RenewAt:     Timestamp(in.RenewAt),
+		Result:      DynamicValue(in.Result),
+	}
+}
+
+func PlanActionResponse(in *tfplugin5.PlanAction_Response) *tfprotov5.PlanActionResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.PlanActionResponse{
+		Deferred:    Deferred(in.Deferred),
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func PlanResourceChangeResponse(in *tfplugin5.PlanResourceChange_Response) *tfprotov5.PlanResourceChangeResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.PlanResourceChangeResponse{
+		Deferred:                    Deferred(in.Deferred),
+		Diagnostics:                 Diagnostics(in.Diagnostics),
+		PlannedIdentity:             ResourceIdentityData(in.PlannedIdentity),
+		PlannedPrivate:              in.PlannedPrivate,
+		PlannedState:                DynamicValue(in.PlannedState),
+		RequiresReplace:             AttributePaths(in.RequiresReplace),
+		UnsafeToUseLegacyTypeSystem: in.LegacyTypeSystem, //nolint:staticcheck
+	}
+}
+
+func PrepareProviderConfigResponse(in *tfplugin5.PrepareProviderConfig_Response) *tfprotov5.PrepareProviderConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.PrepareProviderConfigResponse{
+		Diagnostics:    Diagnostics(in.Diagnostics),
+		PreparedConfig: DynamicValue(in.PreparedConfig),
+	}
+}
+
+func ReadDataSourceResponse(in *tfplugin5.ReadDataSource_Response) *tfprotov5.ReadDataSourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ReadDataSourceResponse{
+		Deferred:    Deferred(in.Deferred),
+		Diagnostics: Diagnostics(in.Diagnostics),
+		State:       DynamicValue(in.State),
+	}
+}
+
+func ReadResourceResponse(in *tfplugin5.ReadResource_Response) *tfprotov5.ReadResourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ReadResourceResponse{
+		Deferred:    Deferred(in.Deferred),
+		Diagnostics: Diagnostics(in.Diagnostics),
+		NewIdentity: ResourceIdentityData(in.NewIdentity),
+		NewState:    DynamicValue(in.NewState),
+		Private:     in.Private,
+	}
+}
+
+func RenewEphemeralResourceResponse(in *tfplugin5.RenewEphemeralResource_Response) *tfprotov5.RenewEphemeralResourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.RenewEphemeralResourceResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Private:     in.Private,
+		RenewAt:     Timestamp(in.RenewAt),
+	}
+}
+
+func ResourceIdentityData(in *tfplugin5.ResourceIdentityData) *tfprotov5.ResourceIdentityData {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ResourceIdentityData{
+		IdentityData: DynamicValue(in.IdentityData),
+	}
+}
+
+func ResourceIdentitySchema(in *tfplugin5.ResourceIdentitySchema) (*tfprotov5.ResourceIdentitySchema, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &tfprotov5.ResourceIdentitySchema{
+		Version: in.Version,
+	}
+
+	if in.IdentityAttributes != nil {
+		out.IdentityAttributes = make([]*tfprotov5.ResourceIdentitySchemaAttribute, 0, len(in.IdentityAttributes))
+	}
+
+	for _, attribute := range in.IdentityAttributes {
+		if attribute == nil {
+			out.IdentityAttributes = append(out.IdentityAttributes, nil)
+
+			continue
+		}
+
+		attributeType, err := Type(attribute.Type)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert attribute %q type: %w", attribute.Name, err)
+		}
+
+		out.IdentityAttributes = append(out.IdentityAttributes, &tfprotov5.ResourceIdentitySchemaAttribute{
+			Description:       attribute.Description,
+			Name:              attribute.Name,
+			OptionalForImport: attribute.OptionalForImport,
+			RequiredForImport: attribute.RequiredForImport,
+			Type:              attributeType,
+		})
+	}
+
+	return out, nil
+}
+
+func Schema(in *tfplugin5.Schema) (*tfprotov5.Schema, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	block, err := SchemaBlock(in.Block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.Schema{
+		Block:   block,
+		Version: in.Version,
+	}, nil
+}
+
+func SchemaAttribute(in *tfplugin5.Schema_Attribute) (*tfprotov5.SchemaAttribute, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	attributeType, err := Type(in.Type)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert attribute %q type: %w", in.Name, err)
+	}
+
+	return &tfprotov5.SchemaAttribute{
+		Computed:           in.Computed,
+		Deprecated:         in.Deprecated,
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Name:               in.Name,
+		Optional:           in.Optional,
+		Required:           in.Required,
+		Sensitive:          in.Sensitive,
+		Type:               attributeType,
+		WriteOnly:          in.WriteOnly,
+	}, nil
+}
+
+func SchemaBlock(in *tfplugin5.Schema_Block) (*tfprotov5.SchemaBlock, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &tfprotov5.SchemaBlock{
+		Deprecated:         in.Deprecated,
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Version:            in.Version,
+	}
+
+	if in.Attributes != nil {
+		out.Attributes = make([]*tfprotov5.SchemaAttribute, 0, len(in.Attributes))
+	}
+
+	for _, attribute := range in.Attributes {
+		v5Attribute, err := SchemaAttribute(attribute)
+
+		if err != nil {
+			return nil, err
+		}
+
+		out.Attributes = append(out.Attributes, v5Attribute)
+	}
+
+	if in.BlockTypes != nil {
+		out.BlockTypes = make([]*tfprotov5.SchemaNestedBlock, 0, len(in.BlockTypes))
+	}
+
+	for _, block := range in.BlockTypes {
+		v5Block, err := SchemaNestedBlock(block)
+
+		if err != nil {
+			return nil, err
+		}
+
+		out.BlockTypes = append(out.BlockTypes, v5Block)
+	}
+
+	return out, nil
+}
+
+func SchemaNestedBlock(in *tfplugin5.Schema_NestedBlock) (*tfprotov5.SchemaNestedBlock, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	block, err := SchemaBlock(in.Block)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert block %q: %w", in.TypeName, err)
+	}
+
+	return &tfprotov5.SchemaNestedBlock{
+		Block:    block,
+		MaxItems: in.MaxItems,
+		MinItems: in.MinItems,
+		Nesting:  tfprotov5.SchemaNestedBlockNestingMode(in.Nesting),
+		TypeName: in.TypeName,
+	}, nil
+}
+
+func Schemas(in map[string]*tfplugin5.Schema) (map[string]*tfprotov5.Schema, error) {
+	out := make(map[string]*tfprotov5.Schema, len(in))
+
+	for k, v := range in {
+		v5Schema, err := Schema(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q schema: %w", k, err)
+		}
+
+		out[k] = v5Schema
+	}
+
+	return out, nil
+}
+
+func ServerCapabilities(in *tfplugin5.ServerCapabilities) *tfprotov5.ServerCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ServerCapabilities{
+		GenerateResourceConfig:    in.GenerateResourceConfig,
+		GetProviderSchemaOptional: in.GetProviderSchemaOptional,
+		MoveResourceState:         in.MoveResourceState,
+		PlanDestroy:               in.PlanDestroy,
+	}
+}
+
+func StopProviderResponse(in *tfplugin5.Stop_Response) *tfprotov5.StopProviderResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.StopProviderResponse{
+		Error: in.Error,
+	}
+}
+
+func StringKind(in tfplugin5.StringKind) tfprotov5.StringKind {
+	return tfprotov5.StringKind(in)
+}
+
+func Timestamp(in *timestamppb.Timestamp) time.Time {
+	if in == nil {
+		return time.Time{}
+	}
+
+	return in.AsTime()
+}
+
+func Type(in []byte) (tftypes.Type, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	// ParseJSONType is the only exported way to convert type constraints
+	// in the protocol into tftypes.Type.
+	return tftypes.ParseJSONType(in) //nolint:staticcheck
+}
+
+func UpgradeResourceIdentityResponse(in *tfplugin5.UpgradeResourceIdentity_Response) *tfprotov5.UpgradeResourceIdentityResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.UpgradeResourceIdentityResponse{
+		Diagnostics:      Diagnostics(in.Diagnostics),
+		UpgradedIdentity: ResourceIdentityData(in.UpgradedIdentity),
+	}
+}
+
+func UpgradeResourceStateResponse(in *tfplugin5.UpgradeResourceState_Response) *tfprotov5.UpgradeResourceStateResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.UpgradeResourceStateResponse{
+		Diagnostics:   Diagnostics(in.Diagnostics),
+		UpgradedState: DynamicValue(in.UpgradedState),
+	}
+}
+
+func ValidateActionConfigResponse(in *tfplugin5.ValidateActionConfig_Response) *tfprotov5.ValidateActionConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ValidateActionConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateDataSourceConfigResponse(in *tfplugin5.ValidateDataSourceConfig_Response) *tfprotov5.ValidateDataSourceConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ValidateDataSourceConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateEphemeralResourceConfigResponse(in *tfplugin5.ValidateEphemeralResourceConfig_Response) *tfprotov5.ValidateEphemeralResourceConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ValidateEphemeralResourceConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateListResourceConfigResponse(in *tfplugin5.ValidateListResourceConfig_Response) *tfprotov5.ValidateListResourceConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ValidateListResourceConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateResourceTypeConfigResponse(in *tfplugin5.ValidateResourceTypeConfig_Response) *tfprotov5.ValidateResourceTypeConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov5.ValidateResourceTypeConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
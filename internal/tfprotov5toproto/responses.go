@@ -0,0 +1,963 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov5toproto
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin5"
+)
+
+func ActionMetadata(in *tfprotov5.ActionMetadata) *tfplugin5.GetMetadata_ActionMetadata {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.GetMetadata_ActionMetadata{
+		TypeName: in.TypeName,
+	}
+}
+
+func ActionSchema(in *tfprotov5.ActionSchema) *tfplugin5.ActionSchema {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ActionSchema{
+		Schema: Schema(in.Schema),
+	}
+
+	return resp
+}
+
+func ApplyResourceChangeResponse(in *tfprotov5.ApplyResourceChangeResponse) *tfplugin5.ApplyResourceChange_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ApplyResourceChange_Response{
+		Diagnostics:      Diagnostics(in.Diagnostics),
+		LegacyTypeSystem: in.UnsafeToUseLegacyTypeSystem, //nolint:staticcheck
+		NewState:         DynamicValue(in.NewState),
+		Private:          in.Private,
+		NewIdentity:      ResourceIdentityData(in.NewIdentity),
+	}
+
+	return resp
+}
+
+func AttributePath(in *tftypes.AttributePath) *tfplugin5.AttributePath {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.AttributePath{
+		Steps: AttributePathSteps(in.Steps()),
+	}
+
+	return resp
+}
+
+func AttributePathStep(step tftypes.AttributePathStep) *tfplugin5.AttributePath_Step {
+	if step == nil {
+		return nil
+	}
+
+	switch step := step.(type) {
+	case tftypes.AttributeName:
+		return &tfplugin5.AttributePath_Step{
+			Selector: &tfplugin5.AttributePath_Step_AttributeName{
+				AttributeName: string(step),
+			},
+		}
+	case tftypes.ElementKeyInt:
+		return &tfplugin5.AttributePath_Step{
+			Selector: &tfplugin5.AttributePath_Step_ElementKeyInt{
+				ElementKeyInt: int64(step),
+			},
+		}
+	case tftypes.ElementKeyString:
+		return &tfplugin5.AttributePath_Step{
+			Selector: &tfplugin5.AttributePath_Step_ElementKeyString{
+				ElementKeyString: string(step),
+			},
+		}
+	case tftypes.ElementKeyValue:
+		// The protocol has no equivalent of an ElementKeyValue, so this
+		// returns nil for the step to signal a step we cannot convey back
+		// to Terraform.
+		return nil
+	}
+
+	// It is not currently possible to create tftypes.AttributePathStep
+	// implementations outside the tftypes package and these implementations
+	// should rarely change, if ever, since they are critical to how
+	// Terraform understands attribute paths. If this panic was reached, it
+	// implies that a new step type was introduced and needs to be
+	// implemented as a new case above or that this logic needs to be
+	// otherwise changed to handle some new attribute path system.
+	panic(fmt.Sprintf("unimplemented tftypes.AttributePathStep type: %T", step))
+}
+
+func AttributePathSteps(in []tftypes.AttributePathStep) []*tfplugin5.AttributePath_Step {
+	resp := make([]*tfplugin5.AttributePath_Step, 0, len(in))
+
+	for _, step := range in {
+		s := AttributePathStep(step)
+
+		// In the face of a ElementKeyValue or missing step, Terraform has no
+		// way to represent the attribute path, so only return the prefix.
+		if s == nil {
+			return resp
+		}
+
+		resp = append(resp, s)
+	}
+
+	return resp
+}
+
+func AttributePaths(in []*tftypes.AttributePath) []*tfplugin5.AttributePath {
+	resp := make([]*tfplugin5.AttributePath, 0, len(in))
+
+	for _, a := range in {
+		resp = append(resp, AttributePath(a))
+	}
+
+	return resp
+}
+
+func CallFunctionResponse(in *tfprotov5.CallFunctionResponse) *tfplugin5.CallFunction_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.CallFunction_Response{
+		Error:  FunctionError(in.Error),
+		Result: DynamicValue(in.Result),
+	}
+
+	return resp
+}
+
+func CloseEphemeralResourceResponse(in *tfprotov5.CloseEphemeralResourceResponse) *tfplugin5.CloseEphemeralResource_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.CloseEphemeralResource_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ConfigureProviderResponse(in *tfprotov5.ConfigureProviderResponse) *tfplugin5.Configure_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Configure_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+
+	return resp
+}
+
+func CtyType(in tftypes.Type) []byte {
+	if in == nil {
+		return nil
+	}
+
+	// MarshalJSON is always error safe.
+	// nolint:staticcheck // Intended first-party usage
+	resp, _ := in.MarshalJSON()
+
+	return resp
+}
+
+func DataSourceMetadata(in *tfprotov5.DataSourceMetadata) *tfplugin5.GetMetadata_DataSourceMetadata {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.GetMetadata_DataSourceMetadata{
+		TypeName: in.TypeName,
+	}
+
+	return resp
+}
+
+func Deferred(in *tfprotov5.Deferred) *tfplugin5.Deferred {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Deferred{
+		Reason: tfplugin5.Deferred_Reason(in.Reason),
+	}
+
+	return resp
+}
+
+func Diagnostic(in *tfprotov5.Diagnostic) *tfplugin5.Diagnostic {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Diagnostic{
+		Attribute: AttributePath(in.Attribute),
+		Detail:    ForceValidUTF8(in.Detail),
+		Severity:  DiagnosticSeverity(in.Severity),
+		Summary:   ForceValidUTF8(in.Summary),
+	}
+
+	return resp
+}
+
+func DiagnosticSeverity(in tfprotov5.DiagnosticSeverity) tfplugin5.Diagnostic_Severity {
+	return tfplugin5.Diagnostic_Severity(in)
+}
+
+func Diagnostics(in []*tfprotov5.Diagnostic) []*tfplugin5.Diagnostic {
+	resp := make([]*tfplugin5.Diagnostic, 0, len(in))
+
+	for _, diag := range in {
+		resp = append(resp, Diagnostic(diag))
+	}
+
+	return resp
+}
+
+func EphemeralResourceMetadata(in *tfprotov5.EphemeralResourceMetadata) *tfplugin5.GetMetadata_EphemeralResourceMetadata {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.GetMetadata_EphemeralResourceMetadata{
+		TypeName: in.TypeName,
+	}
+}
+
+// ForceValidUTF8 returns a string guaranteed to be valid UTF-8 even if the
+// input isn't, by replacing any invalid bytes with a valid UTF-8 encoding of
+// the Unicode Replacement Character (\uFFFD). This is intended for
+// user-facing messages such as diagnostic summary and detail messages.
+func ForceValidUTF8(s string) string {
+	// Most strings that pass through here will already be valid UTF-8 and
+	// utf8.ValidString has a fast path which will beat our rune-by-rune
+	// analysis below, so it's worth the cost of walking the string twice
+	// in the rarer invalid case.
+	if utf8.ValidString(s) {
+		return s
+	}
+
+	// If we get down here then we know there's at least one invalid UTF-8
+	// sequence in the string, so in this slow path we'll reconstruct the
+	// string one rune at a time, guaranteeing that we'll only write valid
+	// UTF-8 sequences into the resulting buffer.
+	//
+	// Any invalid string will grow at least a little larger as a result of
+	// this operation because we'll be replacing each invalid byte with
+	// the three-byte sequence \xEF\xBF\xBD, which is the UTF-8 encoding of
+	// the replacement character \uFFFD. 9 is a magic number giving room for
+	// three such expansions without any further allocation.
+	ret := make([]byte, 0, len(s)+9)
+	for {
+		// If the first byte in s is not the start of a valid UTF-8 sequence
+		// then the following will return utf8.RuneError, 1, where
+		// utf8.RuneError is the unicode replacement character.
+		r, advance := utf8.DecodeRuneInString(s)
+		if advance == 0 {
+			break
+		}
+		s = s[advance:]
+		ret = utf8.AppendRune(ret, r)
+	}
+	return string(ret)
+}
+
+func Function(in *tfprotov5.Function) *tfplugin5.Function {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Function{
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		DeprecationMessage: in.DeprecationMessage,
+		Parameters:         make([]*tfplugin5.Function_Parameter, 0, len(in.Parameters)),
+		Return:             FunctionReturn(in.Return),
+		Summary:            in.Summary,
+		VariadicParameter:  FunctionParameter(in.VariadicParameter),
+	}
+
+	for _, parameter := range in.Parameters {
+		resp.Parameters = append(resp.Parameters, FunctionParameter(parameter))
+	}
+
+	return resp
+}
+
+func FunctionError(in *tfprotov5.FunctionError) *tfplugin5.FunctionError {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.FunctionError{
+		FunctionArgument: in.FunctionArgument,
+		Text:             ForceValidUTF8(in.Text),
+	}
+
+	return resp
+}
+
+func FunctionMetadata(in *tfprotov5.FunctionMetadata) *tfplugin5.GetMetadata_FunctionMetadata {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.GetMetadata_FunctionMetadata{
+		Name: in.Name,
+	}
+
+	return resp
+}
+
+func FunctionParameter(in *tfprotov5.FunctionParameter) *tfplugin5.Function_Parameter {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Function_Parameter{
+		AllowNullValue:     in.AllowNullValue,
+		AllowUnknownValues: in.AllowUnknownValues,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Name:               in.Name,
+		Type:               CtyType(in.Type),
+	}
+
+	return resp
+}
+
+func FunctionReturn(in *tfprotov5.FunctionReturn) *tfplugin5.Function_Return {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Function_Return{
+		Type: CtyType(in.Type),
+	}
+
+	return resp
+}
+
+func GenerateResourceConfigResponse(in *tfprotov5.GenerateResourceConfigResponse) *tfplugin5.GenerateResourceConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.GenerateResourceConfig_Response{
+		Config:      DynamicValue(in.Config),
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func GetFunctionsResponse(in *tfprotov5.GetFunctionsResponse) *tfplugin5.GetFunctions_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.GetFunctions_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Functions:   make(map[string]*tfplugin5.Function, len(in.Functions)),
+	}
+
+	for name, function := range in.Functions {
+		resp.Functions[name] = Function(function)
+	}
+
+	return resp
+}
+
+func GetMetadataResponse(in *tfprotov5.GetMetadataResponse) *tfplugin5.GetMetadata_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.GetMetadata_Response{
+		Actions:            make([]*tfplugin5.GetMetadata_ActionMetadata, 0, len(in.Actions)),
+		DataSources:        make([]*tfplugin5.GetMetadata_DataSourceMetadata, 0, len(in.DataSources)),
+		Diagnostics:        Diagnostics(in.Diagnostics),
+		EphemeralResources: make([]*tfplugin5.GetMetadata_EphemeralResourceMetadata, 0, len(in.EphemeralResources)),
+		ListResources:      make([]*tfplugin5.GetMetadata_ListResourceMetadata, 0, len(in.ListResources)),
+		Functions:          make([]*tfplugin5.GetMetadata_FunctionMetadata, 0, len(in.Functions)),
+		Resources:          make([]*tfplugin5.GetMetadata_ResourceMetadata, 0, len(in.Resources)),
+		ServerCapabilities: ServerCapabilities(in.ServerCapabilities),
+	}
+
+	for _, datasource := range in.DataSources {
+		resp.DataSources = append(resp.DataSources, DataSourceMetadata(&datasource))
+	}
+
+	for _, ephemeralResource := range in.EphemeralResources {
+		resp.EphemeralResources = append(resp.EphemeralResources, EphemeralResourceMetadata(&ephemeralResource))
+	}
+
+	for _, listResource := range in.ListResources {
+		resp.ListResources = append(resp.ListResources, ListResourceMetadata(&listResource))
+	}
+
+	for _, function := range in.Functions {
+		resp.Functions = append(resp.Functions, FunctionMetadata(&function))
+	}
+
+	for _, resource := range in.Resources {
+		resp.Resources = append(resp.Resources, ResourceMetadata(&resource))
+	}
+
+	for _, action := range in.Actions {
+		resp.Actions = append(resp.Actions, ActionMetadata(&action))
+	}
+
+	return resp
+}
+
+func GetProviderSchemaResponse(in *tfprotov5.GetProviderSchemaResponse) *tfplugin5.GetProviderSchema_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.GetProviderSchema_Response{
+		ActionSchemas:            make(map[string]*tfplugin5.ActionSchema, len(in.ActionSchemas)),
+		DataSourceSchemas:        make(map[string]*tfplugin5.Schema, len(in.DataSourceSchemas)),
+		Diagnostics:              Diagnostics(in.Diagnostics),
+		EphemeralResourceSchemas: make(map[string]*tfplugin5.Schema, len(in.EphemeralResourceSchemas)),
+		ListResourceSchemas:      make(map[string]*tfplugin5.Schema, len(in.ListResourceSchemas)),
+		Functions:                make(map[string]*tfplugin5.Function, len(in.Functions)),
+		Provider:                 Schema(in.Provider),
+		ProviderMeta:             Schema(in.ProviderMeta),
+		ResourceSchemas:          make(map[string]*tfplugin5.Schema, len(in.ResourceSchemas)),
+		ServerCapabilities:       ServerCapabilities(in.ServerCapabilities),
+	}
+
+	for name, schema := range in.EphemeralResourceSchemas {
+		resp.EphemeralResourceSchemas[name] = Schema(schema)
+	}
+
+	for name, schema := range in.ListResourceSchemas {
+		resp.ListResourceSchemas[name] = Schema(schema)
+	}
+
+	for name, schema := range in.ResourceSchemas {
+		resp.ResourceSchemas[name] = Schema(schema)
+	}
+
+	for name, schema := range in.DataSourceSchemas {
+		resp.DataSourceSchemas[name] = Schema(schema)
+	}
+
+	for name, function := range in.Functions {
+		resp.Functions[name] = Function(function)
+	}
+
+	for name, actionSchema := range in.ActionSchemas {
+		resp.ActionSchemas[name] = ActionSchema(actionSchema)
+	}
+
+	return resp
+}
+
+func GetResourceIdentitySchemasResponse(in *tfprotov5.GetResourceIdentitySchemasResponse) *tfplugin5.GetResourceIdentitySchemas_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.GetResourceIdentitySchemas_Response{
+		Diagnostics:     Diagnostics(in.Diagnostics),
+		IdentitySchemas: make(map[string]*tfplugin5.ResourceIdentitySchema, len(in.IdentitySchemas)),
+	}
+
+	for name, schema := range in.IdentitySchemas {
+		resp.IdentitySchemas[name] = ResourceIdentitySchema(schema)
+	}
+
+	return resp
+}
+
+func ImportResourceStateResponse(in *tfprotov5.ImportResourceStateResponse) *tfplugin5.ImportResourceState_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ImportResourceState_Response{
+		Diagnostics:       Diagnostics(in.Diagnostics),
+		ImportedResources: ImportedResources(in.ImportedResources),
+		Deferred:          Deferred(in.Deferred),
+	}
+
+	return resp
+}
+
+func ImportedResource(in *tfprotov5.ImportedResource) *tfplugin5.ImportResourceState_ImportedResource {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ImportResourceState_ImportedResource{
+		Private:  in.Private,
+		State:    DynamicValue(in.State),
+		TypeName: in.TypeName,
+		Identity: ResourceIdentityData(in.Identity),
+	}
+
+	return resp
+}
+
+func ImportedResources(in []*tfprotov5.ImportedResource) []*tfplugin5.ImportResourceState_ImportedResource {
+	resp := make([]*tfplugin5.ImportResourceState_ImportedResource, 0, len(in))
+
+	for _, i := range in {
+		resp = append(resp, ImportedResource(i))
+	}
+
+	return resp
+}
+
+func InvokeActionEvent(in *tfprotov5.InvokeActionEvent) *tfplugin5.InvokeAction_Event {
+	if in == nil {
+		return nil
+	}
+
+	switch event := (in.Type).(type) {
+	case tfprotov5.ProgressInvokeActionEventType:
+		return &tfplugin5.InvokeAction_Event{
+			Type: &tfplugin5.InvokeAction_Event_Progress_{
+				Progress: &tfplugin5.InvokeAction_Event_Progress{
+					Message: event.Message,
+				},
+			},
+		}
+	case tfprotov5.CompletedInvokeActionEventType:
+		return &tfplugin5.InvokeAction_Event{
+			Type: &tfplugin5.InvokeAction_Event_Completed_{
+				Completed: &tfplugin5.InvokeAction_Event_Completed{
+					Diagnostics: Diagnostics(event.Diagnostics),
+				},
+			},
+		}
+	}
+
+	// It is not currently possible to create tfprotov5.InvokeActionEventType
+	// implementations outside the tfprotov5 package. If this panic was reached,
+	// it implies that a new event type was introduced and needs to be implemented
+	// as a new case above.
+	panic(fmt.Sprintf("unimplemented tfprotov5.InvokeActionEventType type: %T", in.Type))
+}
+
+func ListResourceMetadata(in *tfprotov5.ListResourceMetadata) *tfplugin5.GetMetadata_ListResourceMetadata {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.GetMetadata_ListResourceMetadata{
+		TypeName: in.TypeName,
+	}
+}
+
+func ListResourceResult(in *tfprotov5.ListResourceResult) *tfplugin5.ListResource_Event {
+	return &tfplugin5.ListResource_Event{
+		DisplayName:    in.DisplayName,
+		ResourceObject: DynamicValue(in.Resource),
+		Identity:       ResourceIdentityData(in.Identity),
+		Diagnostic:     Diagnostics(in.Diagnostics),
+	}
+}
+
+func MoveResourceStateResponse(in *tfprotov5.MoveResourceStateResponse) *tfplugin5.MoveResourceState_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.MoveResourceState_Response{
+		Diagnostics:    Diagnostics(in.Diagnostics),
+		TargetPrivate:  in.TargetPrivate,
+		TargetState:    DynamicValue(in.TargetState),
+		TargetIdentity: ResourceIdentityData(in.TargetIdentity),
+	}
+
+	return resp
+}
+
+func OpenEphemeralResourceResponse(in *tfprotov5.OpenEphemeralResourceResponse) *tfplugin5.OpenEphemeralResource_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.OpenEphemeralResource_Response{
+		Result:      DynamicValue(in.Result),
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Private:     in.Private,
+		RenewAt:     Timestamp(in.RenewAt),
+		Deferred:    Deferred(in.Deferred),
+	}
+}
+
+func PlanActionResponse(in *tfprotov5.PlanActionResponse) *tfplugin5.PlanAction_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.PlanAction_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Deferred:    Deferred(in.Deferred),
+	}
+
+	return resp
+}
+
+func PlanResourceChangeResponse(in *tfprotov5.PlanResourceChangeResponse) *tfplugin5.PlanResourceChange_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.PlanResourceChange_Response{
+		Diagnostics:      Diagnostics(in.Diagnostics),
+		LegacyTypeSystem: in.UnsafeToUseLegacyTypeSystem, //nolint:staticcheck
+		PlannedPrivate:   in.PlannedPrivate,
+		PlannedState:     DynamicValue(in.PlannedState),
+		RequiresReplace:  AttributePaths(in.RequiresReplace),
+		Deferred:         Deferred(in.Deferred),
+		PlannedIdentity:  ResourceIdentityData(in.PlannedIdentity),
+	}
+
+	return resp
+}
+
+func PrepareProviderConfigResponse(in *tfprotov5.PrepareProviderConfigResponse) *tfplugin5.PrepareProviderConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.PrepareProviderConfig_Response{
+		Diagnostics:    Diagnostics(in.Diagnostics),
+		PreparedConfig: DynamicValue(in.PreparedConfig),
+	}
+
+	return resp
+}
+
+func ReadDataSourceResponse(in *tfprotov5.ReadDataSourceResponse) *tfplugin5.ReadDataSource_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ReadDataSource_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		State:       DynamicValue(in.State),
+		Deferred:    Deferred(in.Deferred),
+	}
+
+	return resp
+}
+
+func ReadResourceResponse(in *tfprotov5.ReadResourceResponse) *tfplugin5.ReadResource_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ReadResource_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		NewState:    DynamicValue(in.NewState),
+		Private:     in.Private,
+		Deferred:    Deferred(in.Deferred),
+		NewIdentity: ResourceIdentityData(in.NewIdentity),
+	}
+
+	return resp
+}
+
+func RenewEphemeralResourceResponse(in *tfprotov5.RenewEphemeralResourceResponse) *tfplugin5.RenewEphemeralResource_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.RenewEphemeralResource_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Private:     in.Private,
+		RenewAt:     Timestamp(in.RenewAt),
+	}
+}
+
+func ResourceIdentityAttribute(in *tfprotov5.ResourceIdentitySchemaAttribute) *tfplugin5.ResourceIdentitySchema_IdentityAttribute {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ResourceIdentitySchema_IdentityAttribute{
+		Name:              in.Name,
+		Type:              CtyType(in.Type),
+		RequiredForImport: in.RequiredForImport,
+		OptionalForImport: in.OptionalForImport,
+		Description:       in.Description,
+	}
+
+	return resp
+}
+
+func ResourceIdentityAttributes(in []*tfprotov5.ResourceIdentitySchemaAttribute) []*tfplugin5.ResourceIdentitySchema_IdentityAttribute {
+	if in == nil {
+		return nil
+	}
+
+	resp := make([]*tfplugin5.ResourceIdentitySchema_IdentityAttribute, 0, len(in))
+
+	for _, a := range in {
+		resp = append(resp, ResourceIdentityAttribute(a))
+	}
+
+	return resp
+}
+
+func ResourceIdentitySchema(in *tfprotov5.ResourceIdentitySchema) *tfplugin5.ResourceIdentitySchema {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ResourceIdentitySchema{
+		Version:            in.Version,
+		IdentityAttributes: ResourceIdentityAttributes(in.IdentityAttributes),
+	}
+
+	return resp
+}
+
+func ResourceMetadata(in *tfprotov5.ResourceMetadata) *tfplugin5.GetMetadata_ResourceMetadata {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.GetMetadata_ResourceMetadata{
+		TypeName: in.TypeName,
+	}
+
+	return resp
+}
+
+func Schema(in *tfprotov5.Schema) *tfplugin5.Schema {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Schema{
+		Block:   SchemaBlock(in.Block),
+		Version: in.Version,
+	}
+
+	return resp
+}
+
+func SchemaAttribute(in *tfprotov5.SchemaAttribute) *tfplugin5.Schema_Attribute {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Schema_Attribute{
+		Computed:           in.Computed,
+		Deprecated:         in.Deprecated,
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Name:               in.Name,
+		Optional:           in.Optional,
+		Required:           in.Required,
+		Sensitive:          in.Sensitive,
+		Type:               CtyType(in.Type),
+		WriteOnly:          in.WriteOnly,
+	}
+
+	return resp
+}
+
+func SchemaAttributes(in []*tfprotov5.SchemaAttribute) []*tfplugin5.Schema_Attribute {
+	resp := make([]*tfplugin5.Schema_Attribute, 0, len(in))
+
+	for _, a := range in {
+		resp = append(resp, SchemaAttribute(a))
+	}
+
+	return resp
+}
+
+func SchemaBlock(in *tfprotov5.SchemaBlock) *tfplugin5.Schema_Block {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Schema_Block{
+		Attributes:         SchemaAttributes(in.Attributes),
+		BlockTypes:         SchemaNestedBlocks(in.BlockTypes),
+		Deprecated:         in.Deprecated,
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Version:            in.Version,
+	}
+
+	return resp
+}
+
+func SchemaNestedBlock(in *tfprotov5.SchemaNestedBlock) *tfplugin5.Schema_NestedBlock {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Schema_NestedBlock{
+		Block:    SchemaBlock(in.Block),
+		MaxItems: in.MaxItems,
+		MinItems: in.MinItems,
+		Nesting:  SchemaNestedBlockNestingMode(in.Nesting),
+		TypeName: in.TypeName,
+	}
+
+	return resp
+}
+
+func SchemaNestedBlockNestingMode(in tfprotov5.SchemaNestedBlockNestingMode) tfplugin5.Schema_NestedBlock_NestingMode {
+	return tfplugin5.Schema_NestedBlock_NestingMode(in)
+}
+
+func SchemaNestedBlocks(in []*tfprotov5.SchemaNestedBlock) []*tfplugin5.Schema_NestedBlock {
+	resp := make([]*tfplugin5.Schema_NestedBlock, 0, len(in))
+
+	for _, b := range in {
+		resp = append(resp, SchemaNestedBlock(b))
+	}
+
+	return resp
+}
+
+func ServerCapabilities(in *tfprotov5.ServerCapabilities) *tfplugin5.ServerCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ServerCapabilities{
+		GetProviderSchemaOptional: in.GetProviderSchemaOptional,
+		MoveResourceState:         in.MoveResourceState,
+		PlanDestroy:               in.PlanDestroy,
+		GenerateResourceConfig:    in.GenerateResourceConfig,
+	}
+
+	return resp
+}
+
+func StopProviderResponse(in *tfprotov5.StopProviderResponse) *tfplugin5.Stop_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.Stop_Response{
+		Error: in.Error,
+	}
+
+	return resp
+}
+
+func StringKind(in tfprotov5.StringKind) tfplugin5.StringKind {
+	return tfplugin5.StringKind(in)
+}
+
+func Timestamp(in time.Time) *timestamppb.Timestamp {
+	if in.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(in)
+}
+
+func UpgradeResourceIdentityResponse(in *tfprotov5.UpgradeResourceIdentityResponse) *tfplugin5.UpgradeResourceIdentity_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.UpgradeResourceIdentity_Response{
+		Diagnostics:      Diagnostics(in.Diagnostics),
+		UpgradedIdentity: ResourceIdentityData(in.UpgradedIdentity),
+	}
+
+	return resp
+}
+
+func UpgradeResourceStateResponse(in *tfprotov5.UpgradeResourceStateResponse) *tfplugin5.UpgradeResourceState_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.UpgradeResourceState_Response{
+		Diagnostics:   Diagnostics(in.Diagnostics),
+		UpgradedState: DynamicValue(in.UpgradedState),
+	}
+
+	return resp
+}
+
+func ValidateActionConfigResponse(in *tfprotov5.ValidateActionConfigResponse) *tfplugin5.ValidateActionConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ValidateActionConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateDataSourceConfigResponse(in *tfprotov5.ValidateDataSourceConfigResponse) *tfplugin5.ValidateDataSourceConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ValidateDataSourceConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+
+	return resp
+}
+
+func ValidateEphemeralResourceConfigResponse(in *tfprotov5.ValidateEphemeralResourceConfigResponse) *tfplugin5.ValidateEphemeralResourceConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ValidateEphemeralResourceConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateListResourceConfigResponse(in *tfprotov5.ValidateListResourceConfigResponse) *tfplugin5.ValidateListResourceConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ValidateListResourceConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateResourceTypeConfigResponse(in *tfprotov5.ValidateResourceTypeConfigResponse) *tfplugin5.ValidateResourceTypeConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin5.ValidateResourceTypeConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+
+	return resp
+}
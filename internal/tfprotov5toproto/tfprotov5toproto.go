@@ -0,0 +1,468 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfprotov5toproto converts terraform-plugin-go tfprotov5 request
+// and response types into Protocol Buffers generated tfplugin5 types, for
+// sending requests to a provider server over gRPC or encoding requests and
+// responses for recordings.
+package tfprotov5toproto
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin5"
+)
+
+func ApplyResourceChangeRequest(in *tfprotov5.ApplyResourceChangeRequest) *tfplugin5.ApplyResourceChange_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ApplyResourceChange_Request{
+		Config:          DynamicValue(in.Config),
+		PlannedPrivate:  in.PlannedPrivate,
+		PlannedState:    DynamicValue(in.PlannedState),
+		PriorState:      DynamicValue(in.PriorState),
+		ProviderMeta:    DynamicValue(in.ProviderMeta),
+		TypeName:        in.TypeName,
+		PlannedIdentity: ResourceIdentityData(in.PlannedIdentity),
+	}
+}
+
+func CallFunctionRequest(in *tfprotov5.CallFunctionRequest) *tfplugin5.CallFunction_Request {
+	if in == nil {
+		return nil
+	}
+
+	out := &tfplugin5.CallFunction_Request{
+		Arguments: make([]*tfplugin5.DynamicValue, 0, len(in.Arguments)),
+		Name:      in.Name,
+	}
+
+	for _, argument := range in.Arguments {
+		out.Arguments = append(out.Arguments, DynamicValue(argument))
+	}
+
+	return out
+}
+
+func CloseEphemeralResourceRequest(in *tfprotov5.CloseEphemeralResourceRequest) *tfplugin5.CloseEphemeralResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.CloseEphemeralResource_Request{
+		TypeName: in.TypeName,
+		Private:  in.Private,
+	}
+}
+
+func ConfigureProviderClientCapabilities(in *tfprotov5.ConfigureProviderClientCapabilities) *tfplugin5.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func ConfigureProviderRequest(in *tfprotov5.ConfigureProviderRequest) *tfplugin5.Configure_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.Configure_Request{
+		ClientCapabilities: ConfigureProviderClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		TerraformVersion:   in.TerraformVersion,
+	}
+}
+
+func DynamicValue(in *tfprotov5.DynamicValue) *tfplugin5.DynamicValue {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.DynamicValue{
+		Json:    in.JSON,
+		Msgpack: in.MsgPack,
+	}
+}
+
+func GenerateResourceConfigRequest(in *tfprotov5.GenerateResourceConfigRequest) *tfplugin5.GenerateResourceConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.GenerateResourceConfig_Request{
+		State:    DynamicValue(in.State),
+		TypeName: in.TypeName,
+	}
+}
+
+func GetFunctionsRequest(in *tfprotov5.GetFunctionsRequest) *tfplugin5.GetFunctions_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.GetFunctions_Request{}
+}
+
+func GetMetadataRequest(in *tfprotov5.GetMetadataRequest) *tfplugin5.GetMetadata_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.GetMetadata_Request{}
+}
+
+func GetProviderSchemaRequest(in *tfprotov5.GetProviderSchemaRequest) *tfplugin5.GetProviderSchema_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.GetProviderSchema_Request{}
+}
+
+func GetResourceIdentitySchemasRequest(in *tfprotov5.GetResourceIdentitySchemasRequest) *tfplugin5.GetResourceIdentitySchemas_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.GetResourceIdentitySchemas_Request{}
+}
+
+func ImportResourceStateClientCapabilities(in *tfprotov5.ImportResourceStateClientCapabilities) *tfplugin5.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func ImportResourceStateRequest(in *tfprotov5.ImportResourceStateRequest) *tfplugin5.ImportResourceState_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ImportResourceState_Request{
+		ClientCapabilities: ImportResourceStateClientCapabilities(in.ClientCapabilities),
+		Id:                 in.ID,
+		Identity:           ResourceIdentityData(in.Identity),
+		TypeName:           in.TypeName,
+	}
+}
+
+func InvokeActionClientCapabilities(in *tfprotov5.InvokeActionClientCapabilities) *tfplugin5.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ClientCapabilities{}
+}
+
+func InvokeActionRequest(in *tfprotov5.InvokeActionRequest) *tfplugin5.InvokeAction_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.InvokeAction_Request{
+		ActionType:         in.ActionType,
+		ClientCapabilities: InvokeActionClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+	}
+}
+
+func ListResourceRequest(in *tfprotov5.ListResourceRequest) *tfplugin5.ListResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ListResource_Request{
+		Config:                DynamicValue(in.Config),
+		IncludeResourceObject: in.IncludeResource,
+		Limit:                 in.Limit,
+		TypeName:              in.TypeName,
+	}
+}
+
+func MoveResourceStateRequest(in *tfprotov5.MoveResourceStateRequest) *tfplugin5.MoveResourceState_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.MoveResourceState_Request{
+		SourceIdentity:              RawState(in.SourceIdentity),
+		SourceIdentitySchemaVersion: in.SourceIdentitySchemaVersion,
+		SourcePrivate:               in.SourcePrivate,
+		SourceProviderAddress:       in.SourceProviderAddress,
+		SourceSchemaVersion:         in.SourceSchemaVersion,
+		SourceState:                 RawState(in.SourceState),
+		SourceTypeName:              in.SourceTypeName,
+		TargetTypeName:              in.TargetTypeName,
+	}
+}
+
+func OpenEphemeralResourceClientCapabilities(in *tfprotov5.OpenEphemeralResourceClientCapabilities) *tfplugin5.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func OpenEphemeralResourceRequest(in *tfprotov5.OpenEphemeralResourceRequest) *tfplugin5.OpenEphemeralResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.OpenEphemeralResource_Request{
+		ClientCapabilities: OpenEphemeralResourceClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		TypeName:           in.TypeName,
+	}
+}
+
+func PlanActionClientCapabilities(in *tfprotov5.PlanActionClientCapabilities) *tfplugin5.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func PlanActionRequest(in *tfprotov5.PlanActionRequest) *tfplugin5.PlanAction_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.PlanAction_Request{
+		ActionType:         in.ActionType,
+		ClientCapabilities: PlanActionClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+	}
+}
+
+func PlanResourceChangeClientCapabilities(in *tfprotov5.PlanResourceChangeClientCapabilities) *tfplugin5.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func PlanResourceChangeRequest(in *tfprotov5.PlanResourceChangeRequest) *tfplugin5.PlanResourceChange_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.PlanResourceChange_Request{
+		ClientCapabilities: PlanResourceChangeClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		PriorIdentity:      ResourceIdentityData(in.PriorIdentity),
+		PriorPrivate:       in.PriorPrivate,
+		PriorState:         DynamicValue(in.PriorState),
+		ProposedNewState:   DynamicValue(in.ProposedNewState),
+		ProviderMeta:       DynamicValue(in.ProviderMeta),
+		TypeName:           in.TypeName,
+	}
+}
+
+func PrepareProviderConfigRequest(in *tfprotov5.PrepareProviderConfigRequest) *tfplugin5.PrepareProviderConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.PrepareProviderConfig_Request{
+		Config: DynamicValue(in.Config),
+	}
+}
+
+func RawState(in *tfprotov5.RawState) *tfplugin5.RawState {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.RawState{
+		Flatmap: in.Flatmap,
+		Json:    in.JSON,
+	}
+}
+
+func ReadDataSourceClientCapabilities(in *tfprotov5.ReadDataSourceClientCapabilities) *tfplugin5.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func ReadDataSourceRequest(in *tfprotov5.ReadDataSourceRequest) *tfplugin5.ReadDataSource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ReadDataSource_Request{
+		ClientCapabilities: ReadDataSourceClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		ProviderMeta:       DynamicValue(in.ProviderMeta),
+		TypeName:           in.TypeName,
+	}
+}
+
+func ReadResourceClientCapabilities(in *tfprotov5.ReadResourceClientCapabilities) *tfplugin5.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func ReadResourceRequest(in *tfprotov5.ReadResourceRequest) *tfplugin5.ReadResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ReadResource_Request{
+		ClientCapabilities: ReadResourceClientCapabilities(in.ClientCapabilities),
+		CurrentIdentity:    ResourceIdentityData(in.CurrentIdentity),
+		CurrentState:       DynamicValue(in.CurrentState),
+		Private:            in.Private,
+		ProviderMeta:       DynamicValue(in.ProviderMeta),
+		TypeName:           in.TypeName,
+	}
+}
+
+func RenewEphemeralResourceRequest(in *tfprotov5.RenewEphemeralResourceRequest) *tfplugin5.RenewEphemeralResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.RenewEphemeralResource_Request{
+		Private:  in.Private,
+		TypeName: in.TypeName,
+	}
+}
+
+func ResourceIdentityData(in *tfprotov5.ResourceIdentityData) *tfplugin5.ResourceIdentityData {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ResourceIdentityData{
+		IdentityData: DynamicValue(in.IdentityData),
+	}
+}
+
+func StopProviderRequest(in *tfprotov5.StopProviderRequest) *tfplugin5.Stop_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.Stop_Request{}
+}
+
+func UpgradeResourceIdentityRequest(in *tfprotov5.UpgradeResourceIdentityRequest) *tfplugin5.UpgradeResourceIdentity_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.UpgradeResourceIdentity_Request{
+		RawIdentity: RawState(in.RawIdentity),
+		TypeName:    in.TypeName,
+		Version:     in.Version,
+	}
+}
+
+func UpgradeResourceStateRequest(in *tfprotov5.UpgradeResourceStateRequest) *tfplugin5.UpgradeResourceState_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.UpgradeResourceState_Request{
+		RawState: RawState(in.RawState),
+		TypeName: in.TypeName,
+		Version:  in.Version,
+	}
+}
+
+func ValidateActionConfigRequest(in *tfprotov5.ValidateActionConfigRequest) *tfplugin5.ValidateActionConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ValidateActionConfig_Request{
+		ActionType: in.ActionType,
+		Config:     DynamicValue(in.Config),
+	}
+}
+
+func ValidateDataSourceConfigRequest(in *tfprotov5.ValidateDataSourceConfigRequest) *tfplugin5.ValidateDataSourceConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ValidateDataSourceConfig_Request{
+		Config:   DynamicValue(in.Config),
+		TypeName: in.TypeName,
+	}
+}
+
+func ValidateEphemeralResourceConfigRequest(in *tfprotov5.ValidateEphemeralResourceConfigRequest) *tfplugin5.ValidateEphemeralResourceConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ValidateEphemeralResourceConfig_Request{
+		Config:   DynamicValue(in.Config),
+		TypeName: in.TypeName,
+	}
+}
+
+func ValidateListResourceConfigRequest(in *tfprotov5.ValidateListResourceConfigRequest) *tfplugin5.ValidateListResourceConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ValidateListResourceConfig_Request{
+		Config:                DynamicValue(in.Config),
+		IncludeResourceObject: DynamicValue(in.IncludeResourceObject),
+		Limit:                 DynamicValue(in.Limit),
+		TypeName:              in.TypeName,
+	}
+}
+
+func ValidateResourceTypeConfigClientCapabilities(in *tfprotov5.ValidateResourceTypeConfigClientCapabilities) *tfplugin5.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ClientCapabilities{
+		WriteOnlyAttributesAllowed: in.WriteOnlyAttributesAllowed,
+	}
+}
+
+func ValidateResourceTypeConfigRequest(in *tfprotov5.ValidateResourceTypeConfigRequest) *tfplugin5.ValidateResourceTypeConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin5.ValidateResourceTypeConfig_Request{
+		ClientCapabilities: ValidateResourceTypeConfigClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		TypeName:           in.TypeName,
+	}
+}
@@ -146,20 +146,22 @@ func Diagnostics(in []*tfprotov5.Diagnostic) []*tfprotov6.Diagnostic {
 		return nil
 	}
 
-	diags := make([]*tfprotov6.Diagnostic, 0, len(in))
+	// The diagnostics are allocated together, rather than individually.
+	values := make([]tfprotov6.Diagnostic, len(in))
+	diags := make([]*tfprotov6.Diagnostic, len(in))
 
-	for _, diag := range in {
+	for i, diag := range in {
 		if diag == nil {
-			diags = append(diags, nil)
 			continue
 		}
 
-		diags = append(diags, &tfprotov6.Diagnostic{
+		values[i] = tfprotov6.Diagnostic{
 			Severity:  tfprotov6.DiagnosticSeverity(diag.Severity),
 			Summary:   diag.Summary,
 			Detail:    diag.Detail,
 			Attribute: diag.Attribute,
-		})
+		}
+		diags[i] = &values[i]
 	}
 
 	return diags
@@ -844,6 +846,7 @@ func ServerCapabilities(in *tfprotov5.ServerCapabilities) *tfprotov6.ServerCapab
 		GetProviderSchemaOptional: in.GetProviderSchemaOptional,
 		MoveResourceState:         in.MoveResourceState,
 		PlanDestroy:               in.PlanDestroy,
+		GenerateResourceConfig:    in.GenerateResourceConfig,
 	}
 }
 
@@ -1018,8 +1021,10 @@ func ValidateListResourceConfigRequest(in *tfprotov5.ValidateListResourceConfigR
 	}
 
 	return &tfprotov6.ValidateListResourceConfigRequest{
-		Config:   DynamicValue(in.Config),
-		TypeName: in.TypeName,
+		Config:                DynamicValue(in.Config),
+		IncludeResourceObject: DynamicValue(in.IncludeResourceObject),
+		Limit:                 DynamicValue(in.Limit),
+		TypeName:              in.TypeName,
 	}
 }
 
@@ -1039,8 +1044,10 @@ func ListResourceRequest(in *tfprotov5.ListResourceRequest) *tfprotov6.ListResou
 	}
 
 	return &tfprotov6.ListResourceRequest{
-		Config:   DynamicValue(in.Config),
-		TypeName: in.TypeName,
+		Config:          DynamicValue(in.Config),
+		IncludeResource: in.IncludeResource,
+		Limit:           in.Limit,
+		TypeName:        in.TypeName,
 	}
 }
 
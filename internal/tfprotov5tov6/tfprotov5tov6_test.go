@@ -4,6 +4,7 @@
 package tfprotov5tov6_test
 
 import (
+	"fmt"
 	"slices"
 	"testing"
 	"time"
@@ -1078,6 +1079,9 @@ func TestGetProviderSchemaResponse(t *testing.T) {
 				ResourceSchemas: map[string]*tfprotov5.Schema{
 					"test_resource": testTfprotov5Schema,
 				},
+				ServerCapabilities: &tfprotov5.ServerCapabilities{
+					GetProviderSchemaOptional: true,
+				},
 			},
 			expected: &tfprotov6.GetProviderSchemaResponse{
 				ActionSchemas: map[string]*tfprotov6.ActionSchema{
@@ -1103,6 +1107,9 @@ func TestGetProviderSchemaResponse(t *testing.T) {
 				ResourceSchemas: map[string]*tfprotov6.Schema{
 					"test_resource": testTfprotov6Schema,
 				},
+				ServerCapabilities: &tfprotov6.ServerCapabilities{
+					GetProviderSchemaOptional: true,
+				},
 			},
 		},
 	}
@@ -2982,12 +2989,16 @@ func TestValidateListResourceConfigRequest(t *testing.T) {
 		},
 		"all-valid-fields": {
 			in: &tfprotov5.ValidateListResourceConfigRequest{
-				Config:   &testTfprotov5DynamicValue,
-				TypeName: "test_list_resource",
+				Config:                &testTfprotov5DynamicValue,
+				IncludeResourceObject: &testTfprotov5DynamicValue,
+				Limit:                 &testTfprotov5DynamicValue,
+				TypeName:              "test_list_resource",
 			},
 			expected: &tfprotov6.ValidateListResourceConfigRequest{
-				Config:   &testTfprotov6DynamicValue,
-				TypeName: "test_list_resource",
+				Config:                &testTfprotov6DynamicValue,
+				IncludeResourceObject: &testTfprotov6DynamicValue,
+				Limit:                 &testTfprotov6DynamicValue,
+				TypeName:              "test_list_resource",
 			},
 		},
 	}
@@ -3054,12 +3065,16 @@ func TestListResourceRequest(t *testing.T) {
 		},
 		"all-valid-fields": {
 			in: &tfprotov5.ListResourceRequest{
-				Config:   &testTfprotov5DynamicValue,
-				TypeName: "test_list_resource",
+				Config:          &testTfprotov5DynamicValue,
+				IncludeResource: true,
+				Limit:           10,
+				TypeName:        "test_list_resource",
 			},
 			expected: &tfprotov6.ListResourceRequest{
-				Config:   &testTfprotov6DynamicValue,
-				TypeName: "test_list_resource",
+				Config:          &testTfprotov6DynamicValue,
+				IncludeResource: true,
+				Limit:           10,
+				TypeName:        "test_list_resource",
 			},
 		},
 	}
@@ -3553,6 +3568,76 @@ func TestGenerateResourceConfigResponse(t *testing.T) {
 	}
 }
 
+func BenchmarkDiagnostics(b *testing.B) {
+	in := make([]*tfprotov5.Diagnostic, 0, 100)
+
+	for range 100 {
+		in = append(in, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityWarning,
+			Summary:   "test summary",
+			Detail:    "test detail",
+			Attribute: tftypes.NewAttributePath().WithAttributeName("test"),
+		})
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		tfprotov5tov6.Diagnostics(in)
+	}
+}
+
+func BenchmarkPlanResourceChangeRequest(b *testing.B) {
+	value := &tfprotov5.DynamicValue{
+		MsgPack: make([]byte, 4096),
+	}
+	in := &tfprotov5.PlanResourceChangeRequest{
+		ClientCapabilities: &tfprotov5.PlanResourceChangeClientCapabilities{
+			DeferralAllowed: true,
+		},
+		Config:           value,
+		PriorPrivate:     testBytes,
+		PriorState:       value,
+		ProposedNewState: value,
+		ProviderMeta:     value,
+		TypeName:         "test_resource",
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		tfprotov5tov6.PlanResourceChangeRequest(in)
+	}
+}
+
+func BenchmarkGetProviderSchemaResponse(b *testing.B) {
+	in := &tfprotov5.GetProviderSchemaResponse{
+		ResourceSchemas: make(map[string]*tfprotov5.Schema, 500),
+	}
+
+	for i := range 500 {
+		block := &tfprotov5.SchemaBlock{}
+
+		for j := range 20 {
+			block.Attributes = append(block.Attributes, &tfprotov5.SchemaAttribute{
+				Name:     fmt.Sprintf("attribute_%d", j),
+				Type:     tftypes.String,
+				Optional: true,
+			})
+		}
+
+		in.ResourceSchemas[fmt.Sprintf("test_resource_%d", i)] = &tfprotov5.Schema{
+			Block: block,
+		}
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		tfprotov5tov6.GetProviderSchemaResponse(in)
+	}
+}
+
 func pointer[T any](value T) *T {
 	return &value
 }
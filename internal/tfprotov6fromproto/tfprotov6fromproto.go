@@ -0,0 +1,1069 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfprotov6fromproto converts Protocol Buffers generated tfplugin6
+// response types into terraform-plugin-go tfprotov6 types, for responses
+// received from a provider server over gRPC.
+package tfprotov6fromproto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin6"
+)
+
+func ActionSchema(in *tfplugin6.ActionSchema) (*tfprotov6.ActionSchema, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	schema, err := Schema(in.Schema)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.ActionSchema{
+		Schema: schema,
+	}, nil
+}
+
+func ApplyResourceChangeResponse(in *tfplugin6.ApplyResourceChange_Response) *tfprotov6.ApplyResourceChangeResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ApplyResourceChangeResponse{
+		Diagnostics:                 Diagnostics(in.Diagnostics),
+		NewIdentity:                 ResourceIdentityData(in.NewIdentity),
+		NewState:                    DynamicValue(in.NewState),
+		Private:                     in.Private,
+		UnsafeToUseLegacyTypeSystem: in.LegacyTypeSystem, //nolint:staticcheck
+	}
+}
+
+func AttributePath(in *tfplugin6.AttributePath) *tftypes.AttributePath {
+	if in == nil {
+		return nil
+	}
+
+	steps := make([]tftypes.AttributePathStep, 0, len(in.Steps))
+
+	for _, step := range in.Steps {
+		switch selector := step.GetSelector().(type) {
+		case *tfplugin6.AttributePath_Step_AttributeName:
+			steps = append(steps, tftypes.AttributeName(selector.AttributeName))
+		case *tfplugin6.AttributePath_Step_ElementKeyInt:
+			steps = append(steps, tftypes.ElementKeyInt(selector.ElementKeyInt))
+		case *tfplugin6.AttributePath_Step_ElementKeyString:
+			steps = append(steps, tftypes.ElementKeyString(selector.ElementKeyString))
+		}
+	}
+
+	return tftypes.NewAttributePathWithSteps(steps)
+}
+
+func AttributePaths(in []*tfplugin6.AttributePath) []*tftypes.AttributePath {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*tftypes.AttributePath, 0, len(in))
+
+	for _, attributePath := range in {
+		out = append(out, AttributePath(attributePath))
+	}
+
+	return out
+}
+
+func CallFunctionResponse(in *tfplugin6.CallFunction_Response) *tfprotov6.CallFunctionResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.CallFunctionResponse{
+		Error:  FunctionError(in.Error),
+		Result: DynamicValue(in.Result),
+	}
+}
+
+func CloseEphemeralResourceResponse(in *tfplugin6.CloseEphemeralResource_Response) *tfprotov6.CloseEphemeralResourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.CloseEphemeralResourceResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ConfigureProviderResponse(in *tfplugin6.ConfigureProvider_Response) *tfprotov6.ConfigureProviderResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ConfigureProviderResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ConfigureStateStoreResponse(in *tfplugin6.ConfigureStateStore_Response) *tfprotov6.ConfigureStateStoreResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ConfigureStateStoreResponse{
+		Capabilities: StateStoreServerCapabilities(in.Capabilities),
+		Diagnostics:  Diagnostics(in.Diagnostics),
+	}
+}
+
+func Deferred(in *tfplugin6.Deferred) *tfprotov6.Deferred {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.Deferred{
+		Reason: tfprotov6.DeferredReason(in.Reason),
+	}
+}
+
+func DeleteStateResponse(in *tfplugin6.DeleteState_Response) *tfprotov6.DeleteStateResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.DeleteStateResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func Diagnostic(in *tfplugin6.Diagnostic) *tfprotov6.Diagnostic {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.Diagnostic{
+		Attribute: AttributePath(in.Attribute),
+		Detail:    in.Detail,
+		Severity:  tfprotov6.DiagnosticSeverity(in.Severity),
+		Summary:   in.Summary,
+	}
+}
+
+func Diagnostics(in []*tfplugin6.Diagnostic) []*tfprotov6.Diagnostic {
+	if in == nil {
+		return nil
+	}
+
+	diags := make([]*tfprotov6.Diagnostic, 0, len(in))
+
+	for _, diag := range in {
+		diags = append(diags, Diagnostic(diag))
+	}
+
+	return diags
+}
+
+func DynamicValue(in *tfplugin6.DynamicValue) *tfprotov6.DynamicValue {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.DynamicValue{
+		JSON:    in.Json,
+		MsgPack: in.Msgpack,
+	}
+}
+
+func Function(in *tfplugin6.Function) (*tfprotov6.Function, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &tfprotov6.Function{
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Parameters:         make([]*tfprotov6.FunctionParameter, 0, len(in.Parameters)),
+		Summary:            in.Summary,
+	}
+
+	for _, parameter := range in.Parameters {
+		v5Parameter, err := FunctionParameter(parameter)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert parameter %q: %w", parameter.GetName(), err)
+		}
+
+		out.Parameters = append(out.Parameters, v5Parameter)
+	}
+
+	variadicParameter, err := FunctionParameter(in.VariadicParameter)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert variadic parameter: %w", err)
+	}
+
+	out.VariadicParameter = variadicParameter
+
+	functionReturn, err := FunctionReturn(in.Return)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert return: %w", err)
+	}
+
+	out.Return = functionReturn
+
+	return out, nil
+}
+
+func FunctionError(in *tfplugin6.FunctionError) *tfprotov6.FunctionError {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.FunctionError{
+		FunctionArgument: in.FunctionArgument,
+		Text:             in.Text,
+	}
+}
+
+func FunctionParameter(in *tfplugin6.Function_Parameter) (*tfprotov6.FunctionParameter, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	parameterType, err := Type(in.Type)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.FunctionParameter{
+		AllowNullValue:     in.AllowNullValue,
+		AllowUnknownValues: in.AllowUnknownValues,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Name:               in.Name,
+		Type:               parameterType,
+	}, nil
+}
+
+func FunctionReturn(in *tfplugin6.Function_Return) (*tfprotov6.FunctionReturn, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	returnType, err := Type(in.Type)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.FunctionReturn{
+		Type: returnType,
+	}, nil
+}
+
+func Functions(in map[string]*tfplugin6.Function) (map[string]*tfprotov6.Function, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := make(map[string]*tfprotov6.Function, len(in))
+
+	for name, function := range in {
+		v5Function, err := Function(function)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert function %q: %w", name, err)
+		}
+
+		out[name] = v5Function
+	}
+
+	return out, nil
+}
+
+func GenerateResourceConfigResponse(in *tfplugin6.GenerateResourceConfig_Response) *tfprotov6.GenerateResourceConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.GenerateResourceConfigResponse{
+		Config:      DynamicValue(in.Config),
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func GetFunctionsResponse(in *tfplugin6.GetFunctions_Response) (*tfprotov6.GetFunctionsResponse, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	functions, err := Functions(in.Functions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.GetFunctionsResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Functions:   functions,
+	}, nil
+}
+
+func GetMetadataResponse(in *tfplugin6.GetMetadata_Response) *tfprotov6.GetMetadataResponse {
+	if in == nil {
+		return nil
+	}
+
+	out := &tfprotov6.GetMetadataResponse{
+		Actions:            make([]tfprotov6.ActionMetadata, 0, len(in.Actions)),
+		DataSources:        make([]tfprotov6.DataSourceMetadata, 0, len(in.DataSources)),
+		Diagnostics:        Diagnostics(in.Diagnostics),
+		EphemeralResources: make([]tfprotov6.EphemeralResourceMetadata, 0, len(in.EphemeralResources)),
+		Functions:          make([]tfprotov6.FunctionMetadata, 0, len(in.Functions)),
+		ListResources:      make([]tfprotov6.ListResourceMetadata, 0, len(in.ListResources)),
+		Resources:          make([]tfprotov6.ResourceMetadata, 0, len(in.Resources)),
+		ServerCapabilities: ServerCapabilities(in.ServerCapabilities),
+		StateStores:        make([]tfprotov6.StateStoreMetadata, 0, len(in.StateStores)),
+	}
+
+	for _, action := range in.Actions {
+		out.Actions = append(out.Actions, tfprotov6.ActionMetadata{
+			TypeName: action.GetTypeName(),
+		})
+	}
+
+	for _, datasource := range in.DataSources {
+		out.DataSources = append(out.DataSources, tfprotov6.DataSourceMetadata{
+			TypeName: datasource.GetTypeName(),
+		})
+	}
+
+	for _, ephemeralResource := range in.EphemeralResources {
+		out.EphemeralResources = append(out.EphemeralResources, tfprotov6.EphemeralResourceMetadata{
+			TypeName: ephemeralResource.GetTypeName(),
+		})
+	}
+
+	for _, function := range in.Functions {
+		out.Functions = append(out.Functions, tfprotov6.FunctionMetadata{
+			Name: function.GetName(),
+		})
+	}
+
+	for _, listResource := range in.ListResources {
+		out.ListResources = append(out.ListResources, tfprotov6.ListResourceMetadata{
+			TypeName: listResource.GetTypeName(),
+		})
+	}
+
+	for _, resource := range in.Resources {
+		out.Resources = append(out.Resources, tfprotov6.ResourceMetadata{
+			TypeName: resource.GetTypeName(),
+		})
+	}
+
+	for _, stateStore := range in.StateStores {
+		out.StateStores = append(out.StateStores, tfprotov6.StateStoreMetadata{
+			TypeName: stateStore.GetTypeName(),
+		})
+	}
+
+	return out
+}
+
+func GetProviderSchemaResponse(in *tfplugin6.GetProviderSchema_Response) (*tfprotov6.GetProviderSchemaResponse, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	actionSchemas := make(map[string]*tfprotov6.ActionSchema, len(in.ActionSchemas))
+
+	for k, v := range in.ActionSchemas {
+		v6Schema, err := ActionSchema(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert action %q schema: %w", k, err)
+		}
+
+		actionSchemas[k] = v6Schema
+	}
+
+	dataSourceSchemas, err := Schemas(in.DataSourceSchemas)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert data source %w", err)
+	}
+
+	ephemeralResourceSchemas, err := Schemas(in.EphemeralResourceSchemas)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert ephemeral resource %w", err)
+	}
+
+	functions, err := Functions(in.Functions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	listResourceSchemas, err := Schemas(in.ListResourceSchemas)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert list resource %w", err)
+	}
+
+	provider, err := Schema(in.Provider)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert provider schema: %w", err)
+	}
+
+	providerMeta, err := Schema(in.ProviderMeta)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert provider meta schema: %w", err)
+	}
+
+	resourceSchemas, err := Schemas(in.ResourceSchemas)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert resource %w", err)
+	}
+
+	stateStoreSchemas, err := Schemas(in.StateStoreSchemas)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert state store %w", err)
+	}
+
+	return &tfprotov6.GetProviderSchemaResponse{
+		ActionSchemas:            actionSchemas,
+		DataSourceSchemas:        dataSourceSchemas,
+		Diagnostics:              Diagnostics(in.Diagnostics),
+		EphemeralResourceSchemas: ephemeralResourceSchemas,
+		Functions:                functions,
+		ListResourceSchemas:      listResourceSchemas,
+		Provider:                 provider,
+		ProviderMeta:             providerMeta,
+		ResourceSchemas:          resourceSchemas,
+		ServerCapabilities:       ServerCapabilities(in.ServerCapabilities),
+		StateStoreSchemas:        stateStoreSchemas,
+	}, nil
+}
+
+func GetResourceIdentitySchemasResponse(in *tfplugin6.GetResourceIdentitySchemas_Response) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	identitySchemas := make(map[string]*tfprotov6.ResourceIdentitySchema, len(in.IdentitySchemas))
+
+	for k, v := range in.IdentitySchemas {
+		v6Schema, err := ResourceIdentitySchema(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert resource %q identity schema: %w", k, err)
+		}
+
+		identitySchemas[k] = v6Schema
+	}
+
+	return &tfprotov6.GetResourceIdentitySchemasResponse{
+		Diagnostics:     Diagnostics(in.Diagnostics),
+		IdentitySchemas: identitySchemas,
+	}, nil
+}
+
+func GetStatesResponse(in *tfplugin6.GetStates_Response) *tfprotov6.GetStatesResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.GetStatesResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		StateIDs:    in.StateIds,
+	}
+}
+
+func ImportResourceStateResponse(in *tfplugin6.ImportResourceState_Response) *tfprotov6.ImportResourceStateResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ImportResourceStateResponse{
+		Deferred:          Deferred(in.Deferred),
+		Diagnostics:       Diagnostics(in.Diagnostics),
+		ImportedResources: ImportedResources(in.ImportedResources),
+	}
+}
+
+func ImportedResources(in []*tfplugin6.ImportResourceState_ImportedResource) []*tfprotov6.ImportedResource {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*tfprotov6.ImportedResource, 0, len(in))
+
+	for _, resource := range in {
+		if resource == nil {
+			out = append(out, nil)
+
+			continue
+		}
+
+		out = append(out, &tfprotov6.ImportedResource{
+			Identity: ResourceIdentityData(resource.Identity),
+			Private:  resource.Private,
+			State:    DynamicValue(resource.State),
+			TypeName: resource.TypeName,
+		})
+	}
+
+	return out
+}
+
+func InvokeActionEvent(in *tfplugin6.InvokeAction_Event) tfprotov6.InvokeActionEvent {
+	switch event := in.GetType().(type) {
+	case *tfplugin6.InvokeAction_Event_Progress_:
+		return tfprotov6.InvokeActionEvent{
+			Type: tfprotov6.ProgressInvokeActionEventType{
+				Message: event.Progress.GetMessage(),
+			},
+		}
+	case *tfplugin6.InvokeAction_Event_Completed_:
+		return tfprotov6.InvokeActionEvent{
+			Type: tfprotov6.CompletedInvokeActionEventType{
+				Diagnostics: Diagnostics(event.Completed.GetDiagnostics()),
+			},
+		}
+	}
+
+	// An event without a type is treated as completed without diagnostics,
+	// which is the only terminal event.
+	return tfprotov6.InvokeActionEvent{
+		Type: tfprotov6.CompletedInvokeActionEventType{},
+	}
+}
+
+func ListResourceResult(in *tfplugin6.ListResource_Event) tfprotov6.ListResourceResult {
+	return tfprotov6.ListResourceResult{
+		Diagnostics: Diagnostics(in.GetDiagnostic()),
+		DisplayName: in.GetDisplayName(),
+		Identity:    ResourceIdentityData(in.GetIdentity()),
+		Resource:    DynamicValue(in.GetResourceObject()),
+	}
+}
+
+func LockStateResponse(in *tfplugin6.LockState_Response) *tfprotov6.LockStateResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.LockStateResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		LockID:      in.LockId,
+	}
+}
+
+func MoveResourceStateResponse(in *tfplugin6.MoveResourceState_Response) *tfprotov6.MoveResourceStateResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.MoveResourceStateResponse{
+		Diagnostics:    Diagnostics(in.Diagnostics),
+		TargetIdentity: ResourceIdentityData(in.TargetIdentity),
+		TargetPrivate:  in.TargetPrivate,
+		TargetState:    DynamicValue(in.TargetState),
+	}
+}
+
+func OpenEphemeralResourceResponse(in *tfplugin6.OpenEphemeralResource_Response) *tfprotov6.OpenEphemeralResourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.OpenEphemeralResourceResponse{
+		Deferred:    Deferred(in.Deferred),
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Private:     in.Private,
+		RenewAt:     Timestamp(in.RenewAt),
+		Result:      DynamicValue(in.Result),
+	}
+}
+
+func PlanActionResponse(in *tfplugin6.PlanAction_Response) *tfprotov6.PlanActionResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.PlanActionResponse{
+		Deferred:    Deferred(in.Deferred),
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func PlanResourceChangeResponse(in *tfplugin6.PlanResourceChange_Response) *tfprotov6.PlanResourceChangeResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.PlanResourceChangeResponse{
+		Deferred:                    Deferred(in.Deferred),
+		Diagnostics:                 Diagnostics(in.Diagnostics),
+		PlannedIdentity:             ResourceIdentityData(in.PlannedIdentity),
+		PlannedPrivate:              in.PlannedPrivate,
+		PlannedState:                DynamicValue(in.PlannedState),
+		RequiresReplace:             AttributePaths(in.RequiresReplace),
+		UnsafeToUseLegacyTypeSystem: in.LegacyTypeSystem, //nolint:staticcheck
+	}
+}
+
+func ReadDataSourceResponse(in *tfplugin6.ReadDataSource_Response) *tfprotov6.ReadDataSourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ReadDataSourceResponse{
+		Deferred:    Deferred(in.Deferred),
+		Diagnostics: Diagnostics(in.Diagnostics),
+		State:       DynamicValue(in.State),
+	}
+}
+
+func ReadResourceResponse(in *tfplugin6.ReadResource_Response) *tfprotov6.ReadResourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ReadResourceResponse{
+		Deferred:    Deferred(in.Deferred),
+		Diagnostics: Diagnostics(in.Diagnostics),
+		NewIdentity: ResourceIdentityData(in.NewIdentity),
+		NewState:    DynamicValue(in.NewState),
+		Private:     in.Private,
+	}
+}
+
+func ReadStateBytesChunk(in *tfplugin6.ReadStateBytes_ResponseChunk) tfprotov6.ReadStateByteChunk {
+	if in == nil {
+		return tfprotov6.ReadStateByteChunk{}
+	}
+
+	return tfprotov6.ReadStateByteChunk{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		StateByteChunk: tfprotov6.StateByteChunk{
+			Bytes:       in.Bytes,
+			Range:       StateByteRange(in.Range),
+			TotalLength: in.TotalLength,
+		},
+	}
+}
+
+func RenewEphemeralResourceResponse(in *tfplugin6.RenewEphemeralResource_Response) *tfprotov6.RenewEphemeralResourceResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.RenewEphemeralResourceResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Private:     in.Private,
+		RenewAt:     Timestamp(in.RenewAt),
+	}
+}
+
+func ResourceIdentityData(in *tfplugin6.ResourceIdentityData) *tfprotov6.ResourceIdentityData {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ResourceIdentityData{
+		IdentityData: DynamicValue(in.IdentityData),
+	}
+}
+
+func ResourceIdentitySchema(in *tfplugin6.ResourceIdentitySchema) (*tfprotov6.ResourceIdentitySchema, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &tfprotov6.ResourceIdentitySchema{
+		Version: in.Version,
+	}
+
+	if in.IdentityAttributes != nil {
+		out.IdentityAttributes = make([]*tfprotov6.ResourceIdentitySchemaAttribute, 0, len(in.IdentityAttributes))
+	}
+
+	for _, attribute := range in.IdentityAttributes {
+		if attribute == nil {
+			out.IdentityAttributes = append(out.IdentityAttributes, nil)
+
+			continue
+		}
+
+		attributeType, err := Type(attribute.Type)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert attribute %q type: %w", attribute.Name, err)
+		}
+
+		out.IdentityAttributes = append(out.IdentityAttributes, &tfprotov6.ResourceIdentitySchemaAttribute{
+			Description:       attribute.Description,
+			Name:              attribute.Name,
+			OptionalForImport: attribute.OptionalForImport,
+			RequiredForImport: attribute.RequiredForImport,
+			Type:              attributeType,
+		})
+	}
+
+	return out, nil
+}
+
+func Schema(in *tfplugin6.Schema) (*tfprotov6.Schema, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	block, err := SchemaBlock(in.Block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.Schema{
+		Block:   block,
+		Version: in.Version,
+	}, nil
+}
+
+func SchemaAttribute(in *tfplugin6.Schema_Attribute) (*tfprotov6.SchemaAttribute, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &tfprotov6.SchemaAttribute{
+		Computed:           in.Computed,
+		Deprecated:         in.Deprecated,
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Name:               in.Name,
+		Optional:           in.Optional,
+		Required:           in.Required,
+		Sensitive:          in.Sensitive,
+		WriteOnly:          in.WriteOnly,
+	}
+
+	if in.NestedType != nil {
+		nestedType, err := SchemaObject(in.NestedType)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert attribute %q nested type: %w", in.Name, err)
+		}
+
+		out.NestedType = nestedType
+	}
+
+	attributeType, err := Type(in.Type)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert attribute %q type: %w", in.Name, err)
+	}
+
+	out.Type = attributeType
+
+	return out, nil
+}
+
+func SchemaBlock(in *tfplugin6.Schema_Block) (*tfprotov6.SchemaBlock, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &tfprotov6.SchemaBlock{
+		Deprecated:         in.Deprecated,
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Version:            in.Version,
+	}
+
+	if in.Attributes != nil {
+		out.Attributes = make([]*tfprotov6.SchemaAttribute, 0, len(in.Attributes))
+	}
+
+	for _, attribute := range in.Attributes {
+		v5Attribute, err := SchemaAttribute(attribute)
+
+		if err != nil {
+			return nil, err
+		}
+
+		out.Attributes = append(out.Attributes, v5Attribute)
+	}
+
+	if in.BlockTypes != nil {
+		out.BlockTypes = make([]*tfprotov6.SchemaNestedBlock, 0, len(in.BlockTypes))
+	}
+
+	for _, block := range in.BlockTypes {
+		v5Block, err := SchemaNestedBlock(block)
+
+		if err != nil {
+			return nil, err
+		}
+
+		out.BlockTypes = append(out.BlockTypes, v5Block)
+	}
+
+	return out, nil
+}
+
+func SchemaNestedBlock(in *tfplugin6.Schema_NestedBlock) (*tfprotov6.SchemaNestedBlock, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	block, err := SchemaBlock(in.Block)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert block %q: %w", in.TypeName, err)
+	}
+
+	return &tfprotov6.SchemaNestedBlock{
+		Block:    block,
+		MaxItems: in.MaxItems,
+		MinItems: in.MinItems,
+		Nesting:  tfprotov6.SchemaNestedBlockNestingMode(in.Nesting),
+		TypeName: in.TypeName,
+	}, nil
+}
+
+func SchemaObject(in *tfplugin6.Schema_Object) (*tfprotov6.SchemaObject, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &tfprotov6.SchemaObject{
+		Attributes: make([]*tfprotov6.SchemaAttribute, 0, len(in.Attributes)),
+		Nesting:    tfprotov6.SchemaObjectNestingMode(in.Nesting),
+	}
+
+	for _, attribute := range in.Attributes {
+		v6Attribute, err := SchemaAttribute(attribute)
+
+		if err != nil {
+			return nil, err
+		}
+
+		out.Attributes = append(out.Attributes, v6Attribute)
+	}
+
+	return out, nil
+}
+
+func Schemas(in map[string]*tfplugin6.Schema) (map[string]*tfprotov6.Schema, error) {
+	out := make(map[string]*tfprotov6.Schema, len(in))
+
+	for k, v := range in {
+		v6Schema, err := Schema(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q schema: %w", k, err)
+		}
+
+		out[k] = v6Schema
+	}
+
+	return out, nil
+}
+
+func ServerCapabilities(in *tfplugin6.ServerCapabilities) *tfprotov6.ServerCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ServerCapabilities{
+		GenerateResourceConfig:    in.GenerateResourceConfig,
+		GetProviderSchemaOptional: in.GetProviderSchemaOptional,
+		MoveResourceState:         in.MoveResourceState,
+		PlanDestroy:               in.PlanDestroy,
+	}
+}
+
+func StateByteRange(in *tfplugin6.StateByteRange) tfprotov6.StateByteRange {
+	if in == nil {
+		return tfprotov6.StateByteRange{}
+	}
+
+	return tfprotov6.StateByteRange{
+		End:   in.End,
+		Start: in.Start,
+	}
+}
+
+func StateStoreServerCapabilities(in *tfplugin6.StateStoreServerCapabilities) *tfprotov6.StateStoreServerCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.StateStoreServerCapabilities{
+		ChunkSize: in.ChunkSize,
+	}
+}
+
+func StopProviderResponse(in *tfplugin6.StopProvider_Response) *tfprotov6.StopProviderResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.StopProviderResponse{
+		Error: in.Error,
+	}
+}
+
+func StringKind(in tfplugin6.StringKind) tfprotov6.StringKind {
+	return tfprotov6.StringKind(in)
+}
+
+func Timestamp(in *timestamppb.Timestamp) time.Time {
+	if in == nil {
+		return time.Time{}
+	}
+
+	return in.AsTime()
+}
+
+func Type(in []byte) (tftypes.Type, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	// ParseJSONType is the only exported way to convert type constraints
+	// in the protocol into tftypes.Type.
+	return tftypes.ParseJSONType(in) //nolint:staticcheck
+}
+
+func UnlockStateResponse(in *tfplugin6.UnlockState_Response) *tfprotov6.UnlockStateResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.UnlockStateResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func UpgradeResourceIdentityResponse(in *tfplugin6.UpgradeResourceIdentity_Response) *tfprotov6.UpgradeResourceIdentityResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.UpgradeResourceIdentityResponse{
+		Diagnostics:      Diagnostics(in.Diagnostics),
+		UpgradedIdentity: ResourceIdentityData(in.UpgradedIdentity),
+	}
+}
+
+func UpgradeResourceStateResponse(in *tfplugin6.UpgradeResourceState_Response) *tfprotov6.UpgradeResourceStateResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.UpgradeResourceStateResponse{
+		Diagnostics:   Diagnostics(in.Diagnostics),
+		UpgradedState: DynamicValue(in.UpgradedState),
+	}
+}
+
+func ValidateActionConfigResponse(in *tfplugin6.ValidateActionConfig_Response) *tfprotov6.ValidateActionConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ValidateActionConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateDataResourceConfigResponse(in *tfplugin6.ValidateDataResourceConfig_Response) *tfprotov6.ValidateDataResourceConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ValidateDataResourceConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateEphemeralResourceConfigResponse(in *tfplugin6.ValidateEphemeralResourceConfig_Response) *tfprotov6.ValidateEphemeralResourceConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ValidateEphemeralResourceConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateListResourceConfigResponse(in *tfplugin6.ValidateListResourceConfig_Response) *tfprotov6.ValidateListResourceConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ValidateListResourceConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateProviderConfigResponse(in *tfplugin6.ValidateProviderConfig_Response) *tfprotov6.ValidateProviderConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ValidateProviderConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateResourceConfigResponse(in *tfplugin6.ValidateResourceConfig_Response) *tfprotov6.ValidateResourceConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ValidateResourceConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateStateStoreConfigResponse(in *tfplugin6.ValidateStateStoreConfig_Response) *tfprotov6.ValidateStateStoreConfigResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.ValidateStateStoreConfigResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func WriteStateBytesResponse(in *tfplugin6.WriteStateBytes_Response) *tfprotov6.WriteStateBytesResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &tfprotov6.WriteStateBytesResponse{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
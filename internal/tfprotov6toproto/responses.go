@@ -0,0 +1,1093 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6toproto
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin6"
+)
+
+func ActionMetadata(in *tfprotov6.ActionMetadata) *tfplugin6.GetMetadata_ActionMetadata {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetMetadata_ActionMetadata{
+		TypeName: in.TypeName,
+	}
+}
+
+func ActionSchema(in *tfprotov6.ActionSchema) *tfplugin6.ActionSchema {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ActionSchema{
+		Schema: Schema(in.Schema),
+	}
+	return resp
+}
+
+func ApplyResourceChangeResponse(in *tfprotov6.ApplyResourceChangeResponse) *tfplugin6.ApplyResourceChange_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ApplyResourceChange_Response{
+		Diagnostics:      Diagnostics(in.Diagnostics),
+		LegacyTypeSystem: in.UnsafeToUseLegacyTypeSystem, //nolint:staticcheck
+		NewState:         DynamicValue(in.NewState),
+		Private:          in.Private,
+		NewIdentity:      ResourceIdentityData(in.NewIdentity),
+	}
+
+	return resp
+}
+
+func AttributePath(in *tftypes.AttributePath) *tfplugin6.AttributePath {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.AttributePath{
+		Steps: AttributePathSteps(in.Steps()),
+	}
+
+	return resp
+}
+
+func AttributePathStep(step tftypes.AttributePathStep) *tfplugin6.AttributePath_Step {
+	if step == nil {
+		return nil
+	}
+
+	switch step := step.(type) {
+	case tftypes.AttributeName:
+		return &tfplugin6.AttributePath_Step{
+			Selector: &tfplugin6.AttributePath_Step_AttributeName{
+				AttributeName: string(step),
+			},
+		}
+	case tftypes.ElementKeyInt:
+		return &tfplugin6.AttributePath_Step{
+			Selector: &tfplugin6.AttributePath_Step_ElementKeyInt{
+				ElementKeyInt: int64(step),
+			},
+		}
+	case tftypes.ElementKeyString:
+		return &tfplugin6.AttributePath_Step{
+			Selector: &tfplugin6.AttributePath_Step_ElementKeyString{
+				ElementKeyString: string(step),
+			},
+		}
+	case tftypes.ElementKeyValue:
+		// The protocol has no equivalent of an ElementKeyValue, so this
+		// returns nil for the step to signal a step we cannot convey back
+		// to Terraform.
+		return nil
+	}
+
+	// It is not currently possible to create tftypes.AttributePathStep
+	// implementations outside the tftypes package and these implementations
+	// should rarely change, if ever, since they are critical to how
+	// Terraform understands attribute paths. If this panic was reached, it
+	// implies that a new step type was introduced and needs to be
+	// implemented as a new case above or that this logic needs to be
+	// otherwise changed to handle some new attribute path system.
+	panic(fmt.Sprintf("unimplemented tftypes.AttributePathStep type: %T", step))
+}
+
+func AttributePathSteps(in []tftypes.AttributePathStep) []*tfplugin6.AttributePath_Step {
+	resp := make([]*tfplugin6.AttributePath_Step, 0, len(in))
+
+	for _, step := range in {
+		s := AttributePathStep(step)
+
+		// In the face of a ElementKeyValue or missing step, Terraform has no
+		// way to represent the attribute path, so only return the prefix.
+		if s == nil {
+			return resp
+		}
+
+		resp = append(resp, s)
+	}
+
+	return resp
+}
+
+func AttributePaths(in []*tftypes.AttributePath) []*tfplugin6.AttributePath {
+	resp := make([]*tfplugin6.AttributePath, 0, len(in))
+
+	for _, a := range in {
+		resp = append(resp, AttributePath(a))
+	}
+
+	return resp
+}
+
+func CallFunctionResponse(in *tfprotov6.CallFunctionResponse) *tfplugin6.CallFunction_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.CallFunction_Response{
+		Error:  FunctionError(in.Error),
+		Result: DynamicValue(in.Result),
+	}
+
+	return resp
+}
+
+func CloseEphemeralResourceResponse(in *tfprotov6.CloseEphemeralResourceResponse) *tfplugin6.CloseEphemeralResource_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.CloseEphemeralResource_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ConfigureProviderResponse(in *tfprotov6.ConfigureProviderResponse) *tfplugin6.ConfigureProvider_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ConfigureProvider_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+
+	return resp
+}
+
+func ConfigureStateStoreResponse(in *tfprotov6.ConfigureStateStoreResponse) *tfplugin6.ConfigureStateStore_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ConfigureStateStore_Response{
+		Diagnostics:  Diagnostics(in.Diagnostics),
+		Capabilities: StateStoreServerCapabilities(in.Capabilities),
+	}
+}
+
+func CtyType(in tftypes.Type) []byte {
+	if in == nil {
+		return nil
+	}
+
+	// MarshalJSON is always error safe.
+	// nolint:staticcheck // Intended first-party usage
+	resp, _ := in.MarshalJSON()
+
+	return resp
+}
+
+func DataSourceMetadata(in *tfprotov6.DataSourceMetadata) *tfplugin6.GetMetadata_DataSourceMetadata {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetMetadata_DataSourceMetadata{
+		TypeName: in.TypeName,
+	}
+}
+
+func Deferred(in *tfprotov6.Deferred) *tfplugin6.Deferred {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Deferred{
+		Reason: tfplugin6.Deferred_Reason(in.Reason),
+	}
+
+	return resp
+}
+
+func DeleteStateResponse(in *tfprotov6.DeleteStateResponse) *tfplugin6.DeleteState_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.DeleteState_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func Diagnostic(in *tfprotov6.Diagnostic) *tfplugin6.Diagnostic {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Diagnostic{
+		Attribute: AttributePath(in.Attribute),
+		Detail:    ForceValidUTF8(in.Detail),
+		Severity:  DiagnosticSeverity(in.Severity),
+		Summary:   ForceValidUTF8(in.Summary),
+	}
+
+	return resp
+}
+
+func DiagnosticSeverity(in tfprotov6.DiagnosticSeverity) tfplugin6.Diagnostic_Severity {
+	return tfplugin6.Diagnostic_Severity(in)
+}
+
+func Diagnostics(in []*tfprotov6.Diagnostic) []*tfplugin6.Diagnostic {
+	resp := make([]*tfplugin6.Diagnostic, 0, len(in))
+
+	for _, diag := range in {
+		resp = append(resp, Diagnostic(diag))
+	}
+
+	return resp
+}
+
+func EphemeralResourceMetadata(in *tfprotov6.EphemeralResourceMetadata) *tfplugin6.GetMetadata_EphemeralResourceMetadata {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetMetadata_EphemeralResourceMetadata{
+		TypeName: in.TypeName,
+	}
+}
+
+// ForceValidUTF8 returns a string guaranteed to be valid UTF-8 even if the
+// input isn't, by replacing any invalid bytes with a valid UTF-8 encoding of
+// the Unicode Replacement Character (\uFFFD). This is intended for
+// user-facing messages such as diagnostic summary and detail messages.
+func ForceValidUTF8(s string) string {
+	// Most strings that pass through here will already be valid UTF-8 and
+	// utf8.ValidString has a fast path which will beat our rune-by-rune
+	// analysis below, so it's worth the cost of walking the string twice
+	// in the rarer invalid case.
+	if utf8.ValidString(s) {
+		return s
+	}
+
+	// If we get down here then we know there's at least one invalid UTF-8
+	// sequence in the string, so in this slow path we'll reconstruct the
+	// string one rune at a time, guaranteeing that we'll only write valid
+	// UTF-8 sequences into the resulting buffer.
+	//
+	// Any invalid string will grow at least a little larger as a result of
+	// this operation because we'll be replacing each invalid byte with
+	// the three-byte sequence \xEF\xBF\xBD, which is the UTF-8 encoding of
+	// the replacement character \uFFFD. 9 is a magic number giving room for
+	// three such expansions without any further allocation.
+	ret := make([]byte, 0, len(s)+9)
+	for {
+		// If the first byte in s is not the start of a valid UTF-8 sequence
+		// then the following will return utf8.RuneError, 1, where
+		// utf8.RuneError is the unicode replacement character.
+		r, advance := utf8.DecodeRuneInString(s)
+		if advance == 0 {
+			break
+		}
+		s = s[advance:]
+		ret = utf8.AppendRune(ret, r)
+	}
+	return string(ret)
+}
+
+func Function(in *tfprotov6.Function) *tfplugin6.Function {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Function{
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		DeprecationMessage: in.DeprecationMessage,
+		Parameters:         make([]*tfplugin6.Function_Parameter, 0, len(in.Parameters)),
+		Return:             FunctionReturn(in.Return),
+		Summary:            in.Summary,
+		VariadicParameter:  FunctionParameter(in.VariadicParameter),
+	}
+
+	for _, parameter := range in.Parameters {
+		resp.Parameters = append(resp.Parameters, FunctionParameter(parameter))
+	}
+
+	return resp
+}
+
+func FunctionError(in *tfprotov6.FunctionError) *tfplugin6.FunctionError {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.FunctionError{
+		FunctionArgument: in.FunctionArgument,
+		Text:             ForceValidUTF8(in.Text),
+	}
+
+	return resp
+}
+
+func FunctionMetadata(in *tfprotov6.FunctionMetadata) *tfplugin6.GetMetadata_FunctionMetadata {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetMetadata_FunctionMetadata{
+		Name: in.Name,
+	}
+}
+
+func FunctionParameter(in *tfprotov6.FunctionParameter) *tfplugin6.Function_Parameter {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Function_Parameter{
+		AllowNullValue:     in.AllowNullValue,
+		AllowUnknownValues: in.AllowUnknownValues,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Name:               in.Name,
+		Type:               CtyType(in.Type),
+	}
+
+	return resp
+}
+
+func FunctionReturn(in *tfprotov6.FunctionReturn) *tfplugin6.Function_Return {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Function_Return{
+		Type: CtyType(in.Type),
+	}
+
+	return resp
+}
+
+func GenerateResourceConfigResponse(in *tfprotov6.GenerateResourceConfigResponse) *tfplugin6.GenerateResourceConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GenerateResourceConfig_Response{
+		Config:      DynamicValue(in.Config),
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func GetFunctionsResponse(in *tfprotov6.GetFunctionsResponse) *tfplugin6.GetFunctions_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.GetFunctions_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Functions:   make(map[string]*tfplugin6.Function, len(in.Functions)),
+	}
+
+	for name, function := range in.Functions {
+		resp.Functions[name] = Function(function)
+	}
+
+	return resp
+}
+
+func GetMetadataResponse(in *tfprotov6.GetMetadataResponse) *tfplugin6.GetMetadata_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.GetMetadata_Response{
+		Actions:            make([]*tfplugin6.GetMetadata_ActionMetadata, 0, len(in.Actions)),
+		DataSources:        make([]*tfplugin6.GetMetadata_DataSourceMetadata, 0, len(in.DataSources)),
+		Diagnostics:        Diagnostics(in.Diagnostics),
+		EphemeralResources: make([]*tfplugin6.GetMetadata_EphemeralResourceMetadata, 0, len(in.EphemeralResources)),
+		ListResources:      make([]*tfplugin6.GetMetadata_ListResourceMetadata, 0, len(in.ListResources)),
+		Functions:          make([]*tfplugin6.GetMetadata_FunctionMetadata, 0, len(in.Functions)),
+		Resources:          make([]*tfplugin6.GetMetadata_ResourceMetadata, 0, len(in.Resources)),
+		StateStores:        make([]*tfplugin6.GetMetadata_StateStoreMetadata, 0, len(in.StateStores)),
+		ServerCapabilities: ServerCapabilities(in.ServerCapabilities),
+	}
+
+	for _, datasource := range in.DataSources {
+		resp.DataSources = append(resp.DataSources, DataSourceMetadata(&datasource))
+	}
+
+	for _, ephemeralResource := range in.EphemeralResources {
+		resp.EphemeralResources = append(resp.EphemeralResources, EphemeralResourceMetadata(&ephemeralResource))
+	}
+
+	for _, listResource := range in.ListResources {
+		resp.ListResources = append(resp.ListResources, ListResourceMetadata(&listResource))
+	}
+
+	for _, function := range in.Functions {
+		resp.Functions = append(resp.Functions, FunctionMetadata(&function))
+	}
+
+	for _, resource := range in.Resources {
+		resp.Resources = append(resp.Resources, ResourceMetadata(&resource))
+	}
+
+	for _, action := range in.Actions {
+		resp.Actions = append(resp.Actions, ActionMetadata(&action))
+	}
+
+	for _, stateStore := range in.StateStores {
+		resp.StateStores = append(resp.StateStores, StateStoreMetadata(&stateStore))
+	}
+
+	return resp
+}
+
+func GetProviderSchemaResponse(in *tfprotov6.GetProviderSchemaResponse) *tfplugin6.GetProviderSchema_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.GetProviderSchema_Response{
+		ActionSchemas:            make(map[string]*tfplugin6.ActionSchema, len(in.ActionSchemas)),
+		DataSourceSchemas:        make(map[string]*tfplugin6.Schema, len(in.DataSourceSchemas)),
+		Diagnostics:              Diagnostics(in.Diagnostics),
+		EphemeralResourceSchemas: make(map[string]*tfplugin6.Schema, len(in.EphemeralResourceSchemas)),
+		ListResourceSchemas:      make(map[string]*tfplugin6.Schema, len(in.ListResourceSchemas)),
+		StateStoreSchemas:        make(map[string]*tfplugin6.Schema, len(in.StateStoreSchemas)),
+		Functions:                make(map[string]*tfplugin6.Function, len(in.Functions)),
+		Provider:                 Schema(in.Provider),
+		ProviderMeta:             Schema(in.ProviderMeta),
+		ResourceSchemas:          make(map[string]*tfplugin6.Schema, len(in.ResourceSchemas)),
+		ServerCapabilities:       ServerCapabilities(in.ServerCapabilities),
+	}
+
+	for name, schema := range in.EphemeralResourceSchemas {
+		resp.EphemeralResourceSchemas[name] = Schema(schema)
+	}
+
+	for name, schema := range in.ListResourceSchemas {
+		resp.ListResourceSchemas[name] = Schema(schema)
+	}
+
+	for name, schema := range in.StateStoreSchemas {
+		resp.StateStoreSchemas[name] = Schema(schema)
+	}
+
+	for name, schema := range in.ResourceSchemas {
+		resp.ResourceSchemas[name] = Schema(schema)
+	}
+
+	for name, schema := range in.DataSourceSchemas {
+		resp.DataSourceSchemas[name] = Schema(schema)
+	}
+
+	for name, function := range in.Functions {
+		resp.Functions[name] = Function(function)
+	}
+
+	for name, actionSchema := range in.ActionSchemas {
+		resp.ActionSchemas[name] = ActionSchema(actionSchema)
+	}
+
+	return resp
+}
+
+func GetResourceIdentitySchemasResponse(in *tfprotov6.GetResourceIdentitySchemasResponse) *tfplugin6.GetResourceIdentitySchemas_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.GetResourceIdentitySchemas_Response{
+		Diagnostics:     Diagnostics(in.Diagnostics),
+		IdentitySchemas: make(map[string]*tfplugin6.ResourceIdentitySchema, len(in.IdentitySchemas)),
+	}
+
+	for name, schema := range in.IdentitySchemas {
+		resp.IdentitySchemas[name] = ResourceIdentitySchema(schema)
+	}
+
+	return resp
+}
+
+func GetStatesResponse(in *tfprotov6.GetStatesResponse) *tfplugin6.GetStates_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetStates_Response{
+		StateIds:    in.StateIDs,
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ImportResourceStateResponse(in *tfprotov6.ImportResourceStateResponse) *tfplugin6.ImportResourceState_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ImportResourceState_Response{
+		Diagnostics:       Diagnostics(in.Diagnostics),
+		ImportedResources: ImportedResources(in.ImportedResources),
+		Deferred:          Deferred(in.Deferred),
+	}
+
+	return resp
+}
+
+func ImportedResource(in *tfprotov6.ImportedResource) *tfplugin6.ImportResourceState_ImportedResource {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ImportResourceState_ImportedResource{
+		Private:  in.Private,
+		State:    DynamicValue(in.State),
+		TypeName: in.TypeName,
+		Identity: ResourceIdentityData(in.Identity),
+	}
+
+	return resp
+}
+
+func ImportedResources(in []*tfprotov6.ImportedResource) []*tfplugin6.ImportResourceState_ImportedResource {
+	resp := make([]*tfplugin6.ImportResourceState_ImportedResource, 0, len(in))
+
+	for _, i := range in {
+		resp = append(resp, ImportedResource(i))
+	}
+
+	return resp
+}
+
+func InvokeActionEvent(in *tfprotov6.InvokeActionEvent) *tfplugin6.InvokeAction_Event {
+	if in == nil {
+		return nil
+	}
+
+	switch event := (in.Type).(type) {
+	case tfprotov6.ProgressInvokeActionEventType:
+		return &tfplugin6.InvokeAction_Event{
+			Type: &tfplugin6.InvokeAction_Event_Progress_{
+				Progress: &tfplugin6.InvokeAction_Event_Progress{
+					Message: event.Message,
+				},
+			},
+		}
+	case tfprotov6.CompletedInvokeActionEventType:
+		return &tfplugin6.InvokeAction_Event{
+			Type: &tfplugin6.InvokeAction_Event_Completed_{
+				Completed: &tfplugin6.InvokeAction_Event_Completed{
+					Diagnostics: Diagnostics(event.Diagnostics),
+				},
+			},
+		}
+	}
+
+	// It is not currently possible to create tfprotov6.InvokeActionEventType
+	// implementations outside the tfprotov6 package. If this panic was reached,
+	// it implies that a new event type was introduced and needs to be implemented
+	// as a new case above.
+	panic(fmt.Sprintf("unimplemented tfprotov6.InvokeActionEventType type: %T", in.Type))
+}
+
+func ListResourceMetadata(in *tfprotov6.ListResourceMetadata) *tfplugin6.GetMetadata_ListResourceMetadata {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetMetadata_ListResourceMetadata{
+		TypeName: in.TypeName,
+	}
+}
+
+func ListResourceResult(in *tfprotov6.ListResourceResult) *tfplugin6.ListResource_Event {
+	return &tfplugin6.ListResource_Event{
+		DisplayName:    in.DisplayName,
+		ResourceObject: DynamicValue(in.Resource),
+		Identity:       ResourceIdentityData(in.Identity),
+		Diagnostic:     Diagnostics(in.Diagnostics),
+	}
+}
+
+func LockStateResponse(in *tfprotov6.LockStateResponse) *tfplugin6.LockState_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.LockState_Response{
+		LockId:      in.LockID,
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func MoveResourceStateResponse(in *tfprotov6.MoveResourceStateResponse) *tfplugin6.MoveResourceState_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.MoveResourceState_Response{
+		Diagnostics:    Diagnostics(in.Diagnostics),
+		TargetPrivate:  in.TargetPrivate,
+		TargetState:    DynamicValue(in.TargetState),
+		TargetIdentity: ResourceIdentityData(in.TargetIdentity),
+	}
+
+	return resp
+}
+
+func OpenEphemeralResourceResponse(in *tfprotov6.OpenEphemeralResourceResponse) *tfplugin6.OpenEphemeralResource_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.OpenEphemeralResource_Response{
+		Result:      DynamicValue(in.Result),
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Private:     in.Private,
+		RenewAt:     Timestamp(in.RenewAt),
+		Deferred:    Deferred(in.Deferred),
+	}
+}
+
+func PlanActionResponse(in *tfprotov6.PlanActionResponse) *tfplugin6.PlanAction_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.PlanAction_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Deferred:    Deferred(in.Deferred),
+	}
+
+	return resp
+}
+
+func PlanResourceChangeResponse(in *tfprotov6.PlanResourceChangeResponse) *tfplugin6.PlanResourceChange_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.PlanResourceChange_Response{
+		Diagnostics:      Diagnostics(in.Diagnostics),
+		LegacyTypeSystem: in.UnsafeToUseLegacyTypeSystem, //nolint:staticcheck
+		PlannedPrivate:   in.PlannedPrivate,
+		PlannedState:     DynamicValue(in.PlannedState),
+		RequiresReplace:  AttributePaths(in.RequiresReplace),
+		Deferred:         Deferred(in.Deferred),
+		PlannedIdentity:  ResourceIdentityData(in.PlannedIdentity),
+	}
+
+	return resp
+}
+
+func ReadDataSourceResponse(in *tfprotov6.ReadDataSourceResponse) *tfplugin6.ReadDataSource_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ReadDataSource_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		State:       DynamicValue(in.State),
+		Deferred:    Deferred(in.Deferred),
+	}
+
+	return resp
+}
+
+func ReadResourceResponse(in *tfprotov6.ReadResourceResponse) *tfplugin6.ReadResource_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ReadResource_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		NewState:    DynamicValue(in.NewState),
+		Private:     in.Private,
+		Deferred:    Deferred(in.Deferred),
+		NewIdentity: ResourceIdentityData(in.NewIdentity),
+	}
+
+	return resp
+}
+
+func ReadStateBytesChunk(in *tfprotov6.ReadStateByteChunk) *tfplugin6.ReadStateBytes_ResponseChunk {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ReadStateBytes_ResponseChunk{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Bytes:       in.Bytes,
+		TotalLength: in.TotalLength,
+		Range:       StateByteRange(in.Range),
+	}
+}
+
+func RenewEphemeralResourceResponse(in *tfprotov6.RenewEphemeralResourceResponse) *tfplugin6.RenewEphemeralResource_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.RenewEphemeralResource_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+		Private:     in.Private,
+		RenewAt:     Timestamp(in.RenewAt),
+	}
+}
+
+func ResourceIdentityAttribute(in *tfprotov6.ResourceIdentitySchemaAttribute) *tfplugin6.ResourceIdentitySchema_IdentityAttribute {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ResourceIdentitySchema_IdentityAttribute{
+		Name:              in.Name,
+		Type:              CtyType(in.Type),
+		RequiredForImport: in.RequiredForImport,
+		OptionalForImport: in.OptionalForImport,
+		Description:       in.Description,
+	}
+
+	return resp
+}
+
+func ResourceIdentityAttributes(in []*tfprotov6.ResourceIdentitySchemaAttribute) []*tfplugin6.ResourceIdentitySchema_IdentityAttribute {
+	if in == nil {
+		return nil
+	}
+
+	resp := make([]*tfplugin6.ResourceIdentitySchema_IdentityAttribute, 0, len(in))
+
+	for _, a := range in {
+		resp = append(resp, ResourceIdentityAttribute(a))
+	}
+
+	return resp
+}
+
+func ResourceIdentitySchema(in *tfprotov6.ResourceIdentitySchema) *tfplugin6.ResourceIdentitySchema {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ResourceIdentitySchema{
+		Version:            in.Version,
+		IdentityAttributes: ResourceIdentityAttributes(in.IdentityAttributes),
+	}
+
+	return resp
+}
+
+func ResourceMetadata(in *tfprotov6.ResourceMetadata) *tfplugin6.GetMetadata_ResourceMetadata {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.GetMetadata_ResourceMetadata{
+		TypeName: in.TypeName,
+	}
+
+	return resp
+}
+
+func Schema(in *tfprotov6.Schema) *tfplugin6.Schema {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Schema{
+		Block:   SchemaBlock(in.Block),
+		Version: in.Version,
+	}
+
+	return resp
+}
+
+func SchemaAttribute(in *tfprotov6.SchemaAttribute) *tfplugin6.Schema_Attribute {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Schema_Attribute{
+		Computed:           in.Computed,
+		Deprecated:         in.Deprecated,
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Name:               in.Name,
+		NestedType:         SchemaObject(in.NestedType),
+		Optional:           in.Optional,
+		Required:           in.Required,
+		Sensitive:          in.Sensitive,
+		Type:               CtyType(in.Type),
+		WriteOnly:          in.WriteOnly,
+	}
+
+	return resp
+}
+
+func SchemaAttributes(in []*tfprotov6.SchemaAttribute) []*tfplugin6.Schema_Attribute {
+	resp := make([]*tfplugin6.Schema_Attribute, 0, len(in))
+
+	for _, a := range in {
+		resp = append(resp, SchemaAttribute(a))
+	}
+
+	return resp
+}
+
+func SchemaBlock(in *tfprotov6.SchemaBlock) *tfplugin6.Schema_Block {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Schema_Block{
+		Attributes:         SchemaAttributes(in.Attributes),
+		BlockTypes:         SchemaNestedBlocks(in.BlockTypes),
+		Deprecated:         in.Deprecated,
+		DeprecationMessage: in.DeprecationMessage,
+		Description:        in.Description,
+		DescriptionKind:    StringKind(in.DescriptionKind),
+		Version:            in.Version,
+	}
+
+	return resp
+}
+
+func SchemaNestedBlock(in *tfprotov6.SchemaNestedBlock) *tfplugin6.Schema_NestedBlock {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Schema_NestedBlock{
+		Block:    SchemaBlock(in.Block),
+		MaxItems: in.MaxItems,
+		MinItems: in.MinItems,
+		Nesting:  SchemaNestedBlockNestingMode(in.Nesting),
+		TypeName: in.TypeName,
+	}
+
+	return resp
+}
+
+func SchemaNestedBlockNestingMode(in tfprotov6.SchemaNestedBlockNestingMode) tfplugin6.Schema_NestedBlock_NestingMode {
+	return tfplugin6.Schema_NestedBlock_NestingMode(in)
+}
+
+func SchemaNestedBlocks(in []*tfprotov6.SchemaNestedBlock) []*tfplugin6.Schema_NestedBlock {
+	resp := make([]*tfplugin6.Schema_NestedBlock, 0, len(in))
+
+	for _, b := range in {
+		resp = append(resp, SchemaNestedBlock(b))
+	}
+
+	return resp
+}
+
+func SchemaObject(in *tfprotov6.SchemaObject) *tfplugin6.Schema_Object {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.Schema_Object{
+		Attributes: SchemaAttributes(in.Attributes),
+		Nesting:    SchemaObjectNestingMode(in.Nesting),
+	}
+
+	return resp
+}
+
+func SchemaObjectNestingMode(in tfprotov6.SchemaObjectNestingMode) tfplugin6.Schema_Object_NestingMode {
+	return tfplugin6.Schema_Object_NestingMode(in)
+}
+
+func ServerCapabilities(in *tfprotov6.ServerCapabilities) *tfplugin6.ServerCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ServerCapabilities{
+		GetProviderSchemaOptional: in.GetProviderSchemaOptional,
+		MoveResourceState:         in.MoveResourceState,
+		PlanDestroy:               in.PlanDestroy,
+		GenerateResourceConfig:    in.GenerateResourceConfig,
+	}
+
+	return resp
+}
+
+func StateStoreMetadata(in *tfprotov6.StateStoreMetadata) *tfplugin6.GetMetadata_StateStoreMetadata {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetMetadata_StateStoreMetadata{
+		TypeName: in.TypeName,
+	}
+}
+
+func StateStoreServerCapabilities(in *tfprotov6.StateStoreServerCapabilities) *tfplugin6.StateStoreServerCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.StateStoreServerCapabilities{
+		ChunkSize: in.ChunkSize,
+	}
+
+	return resp
+}
+
+func StopProviderResponse(in *tfprotov6.StopProviderResponse) *tfplugin6.StopProvider_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.StopProvider_Response{
+		Error: in.Error,
+	}
+
+	return resp
+}
+
+func StringKind(in tfprotov6.StringKind) tfplugin6.StringKind {
+	return tfplugin6.StringKind(in)
+}
+
+func Timestamp(in time.Time) *timestamppb.Timestamp {
+	if in.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(in)
+}
+
+func UnlockStateResponse(in *tfprotov6.UnlockStateResponse) *tfplugin6.UnlockState_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.UnlockState_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func UpgradeResourceIdentityResponse(in *tfprotov6.UpgradeResourceIdentityResponse) *tfplugin6.UpgradeResourceIdentity_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.UpgradeResourceIdentity_Response{
+		Diagnostics:      Diagnostics(in.Diagnostics),
+		UpgradedIdentity: ResourceIdentityData(in.UpgradedIdentity),
+	}
+
+	return resp
+}
+
+func UpgradeResourceStateResponse(in *tfprotov6.UpgradeResourceStateResponse) *tfplugin6.UpgradeResourceState_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.UpgradeResourceState_Response{
+		Diagnostics:   Diagnostics(in.Diagnostics),
+		UpgradedState: DynamicValue(in.UpgradedState),
+	}
+
+	return resp
+}
+
+func ValidateActionConfigResponse(in *tfprotov6.ValidateActionConfigResponse) *tfplugin6.ValidateActionConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateActionConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateDataResourceConfigResponse(in *tfprotov6.ValidateDataResourceConfigResponse) *tfplugin6.ValidateDataResourceConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ValidateDataResourceConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+
+	return resp
+}
+
+func ValidateEphemeralResourceConfigResponse(in *tfprotov6.ValidateEphemeralResourceConfigResponse) *tfplugin6.ValidateEphemeralResourceConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateEphemeralResourceConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateListResourceConfigResponse(in *tfprotov6.ValidateListResourceConfigResponse) *tfplugin6.ValidateListResourceConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateListResourceConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func ValidateProviderConfigResponse(in *tfprotov6.ValidateProviderConfigResponse) *tfplugin6.ValidateProviderConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ValidateProviderConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+
+	return resp
+}
+
+func ValidateResourceConfigResponse(in *tfprotov6.ValidateResourceConfigResponse) *tfplugin6.ValidateResourceConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	resp := &tfplugin6.ValidateResourceConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+
+	return resp
+}
+
+func ValidateStateStoreConfigResponse(in *tfprotov6.ValidateStateStoreConfigResponse) *tfplugin6.ValidateStateStoreConfig_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateStateStoreConfig_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
+
+func WriteStateBytesResponse(in *tfprotov6.WriteStateBytesResponse) *tfplugin6.WriteStateBytes_Response {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.WriteStateBytes_Response{
+		Diagnostics: Diagnostics(in.Diagnostics),
+	}
+}
@@ -0,0 +1,588 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfprotov6toproto converts terraform-plugin-go tfprotov6 request
+// and response types into Protocol Buffers generated tfplugin6 types, for
+// sending requests to a provider server over gRPC or encoding requests and
+// responses for recordings.
+package tfprotov6toproto
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin6"
+)
+
+func ApplyResourceChangeRequest(in *tfprotov6.ApplyResourceChangeRequest) *tfplugin6.ApplyResourceChange_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ApplyResourceChange_Request{
+		Config:          DynamicValue(in.Config),
+		PlannedPrivate:  in.PlannedPrivate,
+		PlannedState:    DynamicValue(in.PlannedState),
+		PriorState:      DynamicValue(in.PriorState),
+		ProviderMeta:    DynamicValue(in.ProviderMeta),
+		TypeName:        in.TypeName,
+		PlannedIdentity: ResourceIdentityData(in.PlannedIdentity),
+	}
+}
+
+func CallFunctionRequest(in *tfprotov6.CallFunctionRequest) *tfplugin6.CallFunction_Request {
+	if in == nil {
+		return nil
+	}
+
+	out := &tfplugin6.CallFunction_Request{
+		Arguments: make([]*tfplugin6.DynamicValue, 0, len(in.Arguments)),
+		Name:      in.Name,
+	}
+
+	for _, argument := range in.Arguments {
+		out.Arguments = append(out.Arguments, DynamicValue(argument))
+	}
+
+	return out
+}
+
+func CloseEphemeralResourceRequest(in *tfprotov6.CloseEphemeralResourceRequest) *tfplugin6.CloseEphemeralResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.CloseEphemeralResource_Request{
+		TypeName: in.TypeName,
+		Private:  in.Private,
+	}
+}
+
+func ConfigureProviderClientCapabilities(in *tfprotov6.ConfigureProviderClientCapabilities) *tfplugin6.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func ConfigureProviderRequest(in *tfprotov6.ConfigureProviderRequest) *tfplugin6.ConfigureProvider_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ConfigureProvider_Request{
+		ClientCapabilities: ConfigureProviderClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		TerraformVersion:   in.TerraformVersion,
+	}
+}
+
+func ConfigureStateStoreClientCapabilities(in *tfprotov6.ConfigureStateStoreClientCapabilities) *tfplugin6.StateStoreClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.StateStoreClientCapabilities{
+		ChunkSize: in.ChunkSize,
+	}
+}
+
+func ConfigureStateStoreRequest(in *tfprotov6.ConfigureStateStoreRequest) *tfplugin6.ConfigureStateStore_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ConfigureStateStore_Request{
+		Capabilities: ConfigureStateStoreClientCapabilities(in.Capabilities),
+		Config:       DynamicValue(in.Config),
+		TypeName:     in.TypeName,
+	}
+}
+
+func DeleteStateRequest(in *tfprotov6.DeleteStateRequest) *tfplugin6.DeleteState_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.DeleteState_Request{
+		StateId:  in.StateID,
+		TypeName: in.TypeName,
+	}
+}
+
+func DynamicValue(in *tfprotov6.DynamicValue) *tfplugin6.DynamicValue {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.DynamicValue{
+		Json:    in.JSON,
+		Msgpack: in.MsgPack,
+	}
+}
+
+func GenerateResourceConfigRequest(in *tfprotov6.GenerateResourceConfigRequest) *tfplugin6.GenerateResourceConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GenerateResourceConfig_Request{
+		State:    DynamicValue(in.State),
+		TypeName: in.TypeName,
+	}
+}
+
+func GetFunctionsRequest(in *tfprotov6.GetFunctionsRequest) *tfplugin6.GetFunctions_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetFunctions_Request{}
+}
+
+func GetMetadataRequest(in *tfprotov6.GetMetadataRequest) *tfplugin6.GetMetadata_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetMetadata_Request{}
+}
+
+func GetProviderSchemaRequest(in *tfprotov6.GetProviderSchemaRequest) *tfplugin6.GetProviderSchema_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetProviderSchema_Request{}
+}
+
+func GetResourceIdentitySchemasRequest(in *tfprotov6.GetResourceIdentitySchemasRequest) *tfplugin6.GetResourceIdentitySchemas_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetResourceIdentitySchemas_Request{}
+}
+
+func GetStatesRequest(in *tfprotov6.GetStatesRequest) *tfplugin6.GetStates_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.GetStates_Request{
+		TypeName: in.TypeName,
+	}
+}
+
+func ImportResourceStateClientCapabilities(in *tfprotov6.ImportResourceStateClientCapabilities) *tfplugin6.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func ImportResourceStateRequest(in *tfprotov6.ImportResourceStateRequest) *tfplugin6.ImportResourceState_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ImportResourceState_Request{
+		ClientCapabilities: ImportResourceStateClientCapabilities(in.ClientCapabilities),
+		Id:                 in.ID,
+		Identity:           ResourceIdentityData(in.Identity),
+		TypeName:           in.TypeName,
+	}
+}
+
+func InvokeActionClientCapabilities(in *tfprotov6.InvokeActionClientCapabilities) *tfplugin6.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ClientCapabilities{}
+}
+
+func InvokeActionRequest(in *tfprotov6.InvokeActionRequest) *tfplugin6.InvokeAction_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.InvokeAction_Request{
+		ActionType:         in.ActionType,
+		ClientCapabilities: InvokeActionClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+	}
+}
+
+func ListResourceRequest(in *tfprotov6.ListResourceRequest) *tfplugin6.ListResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ListResource_Request{
+		Config:                DynamicValue(in.Config),
+		IncludeResourceObject: in.IncludeResource,
+		Limit:                 in.Limit,
+		TypeName:              in.TypeName,
+	}
+}
+
+func LockStateRequest(in *tfprotov6.LockStateRequest) *tfplugin6.LockState_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.LockState_Request{
+		Operation: in.Operation,
+		StateId:   in.StateID,
+		TypeName:  in.TypeName,
+	}
+}
+
+func MoveResourceStateRequest(in *tfprotov6.MoveResourceStateRequest) *tfplugin6.MoveResourceState_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.MoveResourceState_Request{
+		SourceIdentity:              RawState(in.SourceIdentity),
+		SourceIdentitySchemaVersion: in.SourceIdentitySchemaVersion,
+		SourcePrivate:               in.SourcePrivate,
+		SourceProviderAddress:       in.SourceProviderAddress,
+		SourceSchemaVersion:         in.SourceSchemaVersion,
+		SourceState:                 RawState(in.SourceState),
+		SourceTypeName:              in.SourceTypeName,
+		TargetTypeName:              in.TargetTypeName,
+	}
+}
+
+func OpenEphemeralResourceClientCapabilities(in *tfprotov6.OpenEphemeralResourceClientCapabilities) *tfplugin6.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func OpenEphemeralResourceRequest(in *tfprotov6.OpenEphemeralResourceRequest) *tfplugin6.OpenEphemeralResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.OpenEphemeralResource_Request{
+		ClientCapabilities: OpenEphemeralResourceClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		TypeName:           in.TypeName,
+	}
+}
+
+func PlanActionClientCapabilities(in *tfprotov6.PlanActionClientCapabilities) *tfplugin6.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func PlanActionRequest(in *tfprotov6.PlanActionRequest) *tfplugin6.PlanAction_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.PlanAction_Request{
+		ActionType:         in.ActionType,
+		ClientCapabilities: PlanActionClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+	}
+}
+
+func PlanResourceChangeClientCapabilities(in *tfprotov6.PlanResourceChangeClientCapabilities) *tfplugin6.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func PlanResourceChangeRequest(in *tfprotov6.PlanResourceChangeRequest) *tfplugin6.PlanResourceChange_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.PlanResourceChange_Request{
+		ClientCapabilities: PlanResourceChangeClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		PriorIdentity:      ResourceIdentityData(in.PriorIdentity),
+		PriorPrivate:       in.PriorPrivate,
+		PriorState:         DynamicValue(in.PriorState),
+		ProposedNewState:   DynamicValue(in.ProposedNewState),
+		ProviderMeta:       DynamicValue(in.ProviderMeta),
+		TypeName:           in.TypeName,
+	}
+}
+
+func RawState(in *tfprotov6.RawState) *tfplugin6.RawState {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.RawState{
+		Flatmap: in.Flatmap,
+		Json:    in.JSON,
+	}
+}
+
+func ReadDataSourceClientCapabilities(in *tfprotov6.ReadDataSourceClientCapabilities) *tfplugin6.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func ReadDataSourceRequest(in *tfprotov6.ReadDataSourceRequest) *tfplugin6.ReadDataSource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ReadDataSource_Request{
+		ClientCapabilities: ReadDataSourceClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		ProviderMeta:       DynamicValue(in.ProviderMeta),
+		TypeName:           in.TypeName,
+	}
+}
+
+func ReadResourceClientCapabilities(in *tfprotov6.ReadResourceClientCapabilities) *tfplugin6.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ClientCapabilities{
+		DeferralAllowed: in.DeferralAllowed,
+	}
+}
+
+func ReadResourceRequest(in *tfprotov6.ReadResourceRequest) *tfplugin6.ReadResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ReadResource_Request{
+		ClientCapabilities: ReadResourceClientCapabilities(in.ClientCapabilities),
+		CurrentIdentity:    ResourceIdentityData(in.CurrentIdentity),
+		CurrentState:       DynamicValue(in.CurrentState),
+		Private:            in.Private,
+		ProviderMeta:       DynamicValue(in.ProviderMeta),
+		TypeName:           in.TypeName,
+	}
+}
+
+func ReadStateBytesRequest(in *tfprotov6.ReadStateBytesRequest) *tfplugin6.ReadStateBytes_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ReadStateBytes_Request{
+		StateId:  in.StateID,
+		TypeName: in.TypeName,
+	}
+}
+
+func RenewEphemeralResourceRequest(in *tfprotov6.RenewEphemeralResourceRequest) *tfplugin6.RenewEphemeralResource_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.RenewEphemeralResource_Request{
+		Private:  in.Private,
+		TypeName: in.TypeName,
+	}
+}
+
+func ResourceIdentityData(in *tfprotov6.ResourceIdentityData) *tfplugin6.ResourceIdentityData {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ResourceIdentityData{
+		IdentityData: DynamicValue(in.IdentityData),
+	}
+}
+
+func StateByteRange(in tfprotov6.StateByteRange) *tfplugin6.StateByteRange {
+	return &tfplugin6.StateByteRange{
+		End:   in.End,
+		Start: in.Start,
+	}
+}
+
+func StopProviderRequest(in *tfprotov6.StopProviderRequest) *tfplugin6.StopProvider_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.StopProvider_Request{}
+}
+
+func UnlockStateRequest(in *tfprotov6.UnlockStateRequest) *tfplugin6.UnlockState_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.UnlockState_Request{
+		LockId:   in.LockID,
+		StateId:  in.StateID,
+		TypeName: in.TypeName,
+	}
+}
+
+func UpgradeResourceIdentityRequest(in *tfprotov6.UpgradeResourceIdentityRequest) *tfplugin6.UpgradeResourceIdentity_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.UpgradeResourceIdentity_Request{
+		RawIdentity: RawState(in.RawIdentity),
+		TypeName:    in.TypeName,
+		Version:     in.Version,
+	}
+}
+
+func UpgradeResourceStateRequest(in *tfprotov6.UpgradeResourceStateRequest) *tfplugin6.UpgradeResourceState_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.UpgradeResourceState_Request{
+		RawState: RawState(in.RawState),
+		TypeName: in.TypeName,
+		Version:  in.Version,
+	}
+}
+
+func ValidateActionConfigRequest(in *tfprotov6.ValidateActionConfigRequest) *tfplugin6.ValidateActionConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateActionConfig_Request{
+		ActionType: in.ActionType,
+		Config:     DynamicValue(in.Config),
+	}
+}
+
+func ValidateDataResourceConfigRequest(in *tfprotov6.ValidateDataResourceConfigRequest) *tfplugin6.ValidateDataResourceConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateDataResourceConfig_Request{
+		Config:   DynamicValue(in.Config),
+		TypeName: in.TypeName,
+	}
+}
+
+func ValidateEphemeralResourceConfigRequest(in *tfprotov6.ValidateEphemeralResourceConfigRequest) *tfplugin6.ValidateEphemeralResourceConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateEphemeralResourceConfig_Request{
+		Config:   DynamicValue(in.Config),
+		TypeName: in.TypeName,
+	}
+}
+
+func ValidateListResourceConfigRequest(in *tfprotov6.ValidateListResourceConfigRequest) *tfplugin6.ValidateListResourceConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateListResourceConfig_Request{
+		Config:                DynamicValue(in.Config),
+		IncludeResourceObject: DynamicValue(in.IncludeResourceObject),
+		Limit:                 DynamicValue(in.Limit),
+		TypeName:              in.TypeName,
+	}
+}
+
+func ValidateProviderConfigRequest(in *tfprotov6.ValidateProviderConfigRequest) *tfplugin6.ValidateProviderConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateProviderConfig_Request{
+		Config: DynamicValue(in.Config),
+	}
+}
+
+func ValidateResourceConfigClientCapabilities(in *tfprotov6.ValidateResourceConfigClientCapabilities) *tfplugin6.ClientCapabilities {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ClientCapabilities{
+		WriteOnlyAttributesAllowed: in.WriteOnlyAttributesAllowed,
+	}
+}
+
+func ValidateResourceConfigRequest(in *tfprotov6.ValidateResourceConfigRequest) *tfplugin6.ValidateResourceConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateResourceConfig_Request{
+		ClientCapabilities: ValidateResourceConfigClientCapabilities(in.ClientCapabilities),
+		Config:             DynamicValue(in.Config),
+		TypeName:           in.TypeName,
+	}
+}
+
+func ValidateStateStoreConfigRequest(in *tfprotov6.ValidateStateStoreConfigRequest) *tfplugin6.ValidateStateStoreConfig_Request {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.ValidateStateStoreConfig_Request{
+		Config:   DynamicValue(in.Config),
+		TypeName: in.TypeName,
+	}
+}
+
+func WriteStateBytesChunk(in *tfprotov6.WriteStateBytesChunk) *tfplugin6.WriteStateBytes_RequestChunk {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.WriteStateBytes_RequestChunk{
+		Bytes:       in.Bytes,
+		Meta:        WriteStateChunkMeta(in.Meta),
+		Range:       StateByteRange(in.Range),
+		TotalLength: in.TotalLength,
+	}
+}
+
+func WriteStateChunkMeta(in *tfprotov6.WriteStateChunkMeta) *tfplugin6.RequestChunkMeta {
+	if in == nil {
+		return nil
+	}
+
+	return &tfplugin6.RequestChunkMeta{
+		StateId:  in.StateID,
+		TypeName: in.TypeName,
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 var ErrSchemaAttributeNestedTypeNotImplemented error = errors.New("SchemaAttribute NestedType is not implemented in protocol version 5")
 
+var ErrStateStoreNotImplemented error = errors.New("state stores are not implemented in protocol version 5")
+
 func ApplyResourceChangeRequest(in *tfprotov6.ApplyResourceChangeRequest) *tfprotov5.ApplyResourceChangeRequest {
 	if in == nil {
 		return nil
@@ -149,20 +151,22 @@ func Diagnostics(in []*tfprotov6.Diagnostic) []*tfprotov5.Diagnostic {
 		return nil
 	}
 
-	diags := make([]*tfprotov5.Diagnostic, 0, len(in))
+	// The diagnostics are allocated together, rather than individually.
+	values := make([]tfprotov5.Diagnostic, len(in))
+	diags := make([]*tfprotov5.Diagnostic, len(in))
 
-	for _, diag := range in {
+	for i, diag := range in {
 		if diag == nil {
-			diags = append(diags, nil)
 			continue
 		}
 
-		diags = append(diags, &tfprotov5.Diagnostic{
+		values[i] = tfprotov5.Diagnostic{
 			Attribute: diag.Attribute,
 			Detail:    diag.Detail,
 			Severity:  tfprotov5.DiagnosticSeverity(diag.Severity),
 			Summary:   diag.Summary,
-		})
+		}
+		diags[i] = &values[i]
 	}
 
 	return diags
@@ -444,6 +448,7 @@ func GetProviderSchemaResponse(in *tfprotov6.GetProviderSchemaResponse) (*tfprot
 		Provider:                 provider,
 		ProviderMeta:             providerMeta,
 		ResourceSchemas:          resourceSchemas,
+		ServerCapabilities:       ServerCapabilities(in.ServerCapabilities),
 	}, nil
 }
 
@@ -936,6 +941,7 @@ func ServerCapabilities(in *tfprotov6.ServerCapabilities) *tfprotov5.ServerCapab
 		GetProviderSchemaOptional: in.GetProviderSchemaOptional,
 		MoveResourceState:         in.MoveResourceState,
 		PlanDestroy:               in.PlanDestroy,
+		GenerateResourceConfig:    in.GenerateResourceConfig,
 	}
 }
 
@@ -1089,8 +1095,10 @@ func ValidateListResourceConfigRequest(in *tfprotov6.ValidateListResourceConfigR
 	}
 
 	return &tfprotov5.ValidateListResourceConfigRequest{
-		Config:   DynamicValue(in.Config),
-		TypeName: in.TypeName,
+		Config:                DynamicValue(in.Config),
+		IncludeResourceObject: DynamicValue(in.IncludeResourceObject),
+		Limit:                 DynamicValue(in.Limit),
+		TypeName:              in.TypeName,
 	}
 }
 
@@ -1110,8 +1118,10 @@ func ListResourceRequest(in *tfprotov6.ListResourceRequest) *tfprotov5.ListResou
 	}
 
 	return &tfprotov5.ListResourceRequest{
-		Config:   DynamicValue(in.Config),
-		TypeName: in.TypeName,
+		Config:          DynamicValue(in.Config),
+		IncludeResource: in.IncludeResource,
+		Limit:           in.Limit,
+		TypeName:        in.TypeName,
 	}
 }
 
@@ -1081,6 +1081,9 @@ func TestGetProviderSchemaResponse(t *testing.T) {
 				ResourceSchemas: map[string]*tfprotov6.Schema{
 					"test_resource": testTfprotov6Schema,
 				},
+				ServerCapabilities: &tfprotov6.ServerCapabilities{
+					GetProviderSchemaOptional: true,
+				},
 			},
 			expected: &tfprotov5.GetProviderSchemaResponse{
 				ActionSchemas: map[string]*tfprotov5.ActionSchema{
@@ -1106,6 +1109,9 @@ func TestGetProviderSchemaResponse(t *testing.T) {
 				ResourceSchemas: map[string]*tfprotov5.Schema{
 					"test_resource": testTfprotov5Schema,
 				},
+				ServerCapabilities: &tfprotov5.ServerCapabilities{
+					GetProviderSchemaOptional: true,
+				},
 			},
 		},
 		"data-source-nested-attribute-error": {
@@ -3285,12 +3291,16 @@ func TestValidateListResourceConfigRequest(t *testing.T) {
 		},
 		"all-valid-fields": {
 			in: &tfprotov6.ValidateListResourceConfigRequest{
-				Config:   &testTfprotov6DynamicValue,
-				TypeName: "test_list_resource",
+				Config:                &testTfprotov6DynamicValue,
+				IncludeResourceObject: &testTfprotov6DynamicValue,
+				Limit:                 &testTfprotov6DynamicValue,
+				TypeName:              "test_list_resource",
 			},
 			expected: &tfprotov5.ValidateListResourceConfigRequest{
-				Config:   &testTfprotov5DynamicValue,
-				TypeName: "test_list_resource",
+				Config:                &testTfprotov5DynamicValue,
+				IncludeResourceObject: &testTfprotov5DynamicValue,
+				Limit:                 &testTfprotov5DynamicValue,
+				TypeName:              "test_list_resource",
 			},
 		},
 	}
@@ -3357,12 +3367,16 @@ func TestListResourceRequest(t *testing.T) {
 		},
 		"all-valid-fields": {
 			in: &tfprotov6.ListResourceRequest{
-				Config:   &testTfprotov6DynamicValue,
-				TypeName: "test_list_resource",
+				Config:          &testTfprotov6DynamicValue,
+				IncludeResource: true,
+				Limit:           10,
+				TypeName:        "test_list_resource",
 			},
 			expected: &tfprotov5.ListResourceRequest{
-				Config:   &testTfprotov5DynamicValue,
-				TypeName: "test_list_resource",
+				Config:          &testTfprotov5DynamicValue,
+				IncludeResource: true,
+				Limit:           10,
+				TypeName:        "test_list_resource",
 			},
 		},
 	}
@@ -3921,6 +3935,76 @@ func TestGenerateResourceConfigResponse(t *testing.T) {
 	}
 }
 
+func BenchmarkDiagnostics(b *testing.B) {
+	in := make([]*tfprotov6.Diagnostic, 0, 100)
+
+	for range 100 {
+		in = append(in, &tfprotov6.Diagnostic{
+			Severity:  tfprotov6.DiagnosticSeverityWarning,
+			Summary:   "test summary",
+			Detail:    "test detail",
+			Attribute: tftypes.NewAttributePath().WithAttributeName("test"),
+		})
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		tfprotov6tov5.Diagnostics(in)
+	}
+}
+
+func BenchmarkPlanResourceChangeRequest(b *testing.B) {
+	value := &tfprotov6.DynamicValue{
+		MsgPack: make([]byte, 4096),
+	}
+	in := &tfprotov6.PlanResourceChangeRequest{
+		ClientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{
+			DeferralAllowed: true,
+		},
+		Config:           value,
+		PriorPrivate:     testBytes,
+		PriorState:       value,
+		ProposedNewState: value,
+		ProviderMeta:     value,
+		TypeName:         "test_resource",
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		tfprotov6tov5.PlanResourceChangeRequest(in)
+	}
+}
+
+func BenchmarkGetProviderSchemaResponse(b *testing.B) {
+	in := &tfprotov6.GetProviderSchemaResponse{
+		ResourceSchemas: make(map[string]*tfprotov6.Schema, 500),
+	}
+
+	for i := range 500 {
+		block := &tfprotov6.SchemaBlock{}
+
+		for j := range 20 {
+			block.Attributes = append(block.Attributes, &tfprotov6.SchemaAttribute{
+				Name:     fmt.Sprintf("attribute_%d", j),
+				Type:     tftypes.String,
+				Optional: true,
+			})
+		}
+
+		in.ResourceSchemas[fmt.Sprintf("test_resource_%d", i)] = &tfprotov6.Schema{
+			Block: block,
+		}
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, _ = tfprotov6tov5.GetProviderSchemaResponse(in)
+	}
+}
+
 func pointer[T any](value T) *T {
 	return &value
 }
@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tftypesjson converts terraform-plugin-go tftypes values into values
+// which can be encoded with encoding/json, for recordings and logs of
+// requests and responses.
+package tftypesjson
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Unknown is the value of unknown values, which have no JSON encoding.
+const Unknown = "<unknown>"
+
+// Redacted is the value of values which must not be exposed, such as the
+// values of sensitive attributes in logs.
+const Redacted = "<redacted>"
+
+// Value returns the value as nil, bool, json.Number, string, []any, or
+// map[string]any. Unknown values, including those nested in collections and
+// objects, are returned as Unknown.
+func Value(in tftypes.Value) any {
+	if !in.IsKnown() {
+		return Unknown
+	}
+
+	if in.IsNull() {
+		return nil
+	}
+
+	typ := in.Type()
+
+	switch {
+	case typ.Is(tftypes.Bool):
+		var out bool
+
+		_ = in.As(&out)
+
+		return out
+	case typ.Is(tftypes.Number):
+		out := new(big.Float)
+
+		_ = in.As(&out)
+
+		return json.Number(out.Text('g', -1))
+	case typ.Is(tftypes.String):
+		var out string
+
+		_ = in.As(&out)
+
+		return out
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+
+		_ = in.As(&elements)
+
+		out := make([]any, 0, len(elements))
+
+		for _, element := range elements {
+			out = append(out, Value(element))
+		}
+
+		return out
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attributes map[string]tftypes.Value
+
+		_ = in.As(&attributes)
+
+		out := make(map[string]any, len(attributes))
+
+		for name, attribute := range attributes {
+			out[name] = Value(attribute)
+		}
+
+		return out
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypesjson_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tftypesjson"
+)
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		in       tftypes.Value
+		expected any
+	}{
+		"null": {
+			in:       tftypes.NewValue(tftypes.String, nil),
+			expected: nil,
+		},
+		"unknown": {
+			in:       tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			expected: tftypesjson.Unknown,
+		},
+		"bool": {
+			in:       tftypes.NewValue(tftypes.Bool, true),
+			expected: true,
+		},
+		"number": {
+			in:       tftypes.NewValue(tftypes.Number, big.NewFloat(1.5)),
+			expected: json.Number("1.5"),
+		},
+		"string": {
+			in:       tftypes.NewValue(tftypes.String, "test"),
+			expected: "test",
+		},
+		"list": {
+			in: tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "one"),
+				tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+			expected: []any{"one", tftypesjson.Unknown},
+		},
+		"object": {
+			in: tftypes.NewValue(
+				tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"id":   tftypes.String,
+						"tags": tftypes.Map{ElementType: tftypes.String},
+					},
+				},
+				map[string]tftypes.Value{
+					"id": tftypes.NewValue(tftypes.String, "test-id"),
+					"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+						"key": tftypes.NewValue(tftypes.String, "value"),
+					}),
+				},
+			),
+			expected: map[string]any{
+				"id": "test-id",
+				"tags": map[string]any{
+					"key": "value",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tftypesjson.Value(testCase.in)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
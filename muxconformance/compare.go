@@ -0,0 +1,205 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxconformance
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// compare returns the differences between the expected and the actual
+// value, which may be of the counterpart type in the other protocol version.
+// Fields are compared by name, ignoring fields of the expected value without
+// a counterpart field, and integers, such as enumerations, are compared by
+// value. Nil and empty slices and maps are equal, as they cannot be
+// distinguished in the plugin protocol. Iterators are compared by the
+// elements they yield, consuming the actual iterator.
+func compare(path string, expected reflect.Value, actual reflect.Value) []string {
+	switch expected.Type() {
+	case attributePathType:
+		expectedPath := expected.Interface().(*tftypes.AttributePath) //nolint:forcetypeassert
+		actualPath, ok := actual.Interface().(*tftypes.AttributePath)
+
+		if !ok || !expectedPath.Equal(actualPath) {
+			return []string{difference(path, expectedPath, actual.Interface())}
+		}
+
+		return nil
+	case timeType:
+		expectedTime := expected.Interface().(time.Time) //nolint:forcetypeassert
+		actualTime, ok := actual.Interface().(time.Time)
+
+		if !ok || !expectedTime.Equal(actualTime) {
+			return []string{difference(path, expectedTime, actual.Interface())}
+		}
+
+		return nil
+	case tftypesType:
+		if expected.IsNil() || actual.IsNil() {
+			if expected.IsNil() != actual.IsNil() {
+				return []string{difference(path, expected.Interface(), actual.Interface())}
+			}
+
+			return nil
+		}
+
+		expectedType := expected.Interface().(tftypes.Type) //nolint:forcetypeassert
+		actualType, ok := actual.Interface().(tftypes.Type)
+
+		if !ok || !expectedType.Equal(actualType) {
+			return []string{difference(path, expected.Interface(), actual.Interface())}
+		}
+
+		return nil
+	}
+
+	if expected.Kind() != actual.Kind() {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, expected.Type(), actual.Type())}
+	}
+
+	switch expected.Kind() {
+	case reflect.Bool:
+		if expected.Bool() != actual.Bool() {
+			return []string{difference(path, expected.Bool(), actual.Bool())}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if expected.Int() != actual.Int() {
+			return []string{difference(path, expected.Int(), actual.Int())}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if expected.Uint() != actual.Uint() {
+			return []string{difference(path, expected.Uint(), actual.Uint())}
+		}
+	case reflect.String:
+		if expected.String() != actual.String() {
+			return []string{difference(path, expected.String(), actual.String())}
+		}
+	case reflect.Pointer, reflect.Interface:
+		if expected.IsNil() || actual.IsNil() {
+			if expected.IsNil() != actual.IsNil() {
+				return []string{difference(path, nilness(expected), nilness(actual))}
+			}
+
+			return nil
+		}
+
+		if expected.Kind() == reflect.Interface && expected.Elem().Type().Name() != actual.Elem().Type().Name() {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", path, expected.Elem().Type(), actual.Elem().Type())}
+		}
+
+		return compare(path, expected.Elem(), actual.Elem())
+	case reflect.Slice:
+		if expected.IsNil() != actual.IsNil() && (expected.Len() > 0 || actual.Len() > 0) {
+			return []string{difference(path, nilness(expected), nilness(actual))}
+		}
+
+		if expected.Type().Elem().Kind() == reflect.Uint8 {
+			if !bytes.Equal(expected.Bytes(), actual.Bytes()) {
+				return []string{difference(path, expected.Bytes(), actual.Bytes())}
+			}
+
+			return nil
+		}
+
+		if expected.Len() != actual.Len() {
+			return []string{fmt.Sprintf("%s: expected %d elements, got %d", path, expected.Len(), actual.Len())}
+		}
+
+		var differences []string
+
+		for i := range expected.Len() {
+			differences = append(differences, compare(fmt.Sprintf("%s[%d]", path, i), expected.Index(i), actual.Index(i))...)
+		}
+
+		return differences
+	case reflect.Map:
+		if expected.IsNil() != actual.IsNil() && (expected.Len() > 0 || actual.Len() > 0) {
+			return []string{difference(path, nilness(expected), nilness(actual))}
+		}
+
+		if expected.Len() != actual.Len() {
+			return []string{fmt.Sprintf("%s: expected %d elements, got %d", path, expected.Len(), actual.Len())}
+		}
+
+		var differences []string
+
+		for _, key := range expected.MapKeys() {
+			elemPath := fmt.Sprintf("%s[%v]", path, key)
+
+			if key.Type() != actual.Type().Key() {
+				return []string{fmt.Sprintf("%s: expected %s keys, got %s", path, key.Type(), actual.Type().Key())}
+			}
+
+			actualElem := actual.MapIndex(key)
+
+			if !actualElem.IsValid() {
+				differences = append(differences, fmt.Sprintf("%s: missing", elemPath))
+
+				continue
+			}
+
+			differences = append(differences, compare(elemPath, expected.MapIndex(key), actualElem)...)
+		}
+
+		return differences
+	case reflect.Struct:
+		var differences []string
+
+		for i := range expected.NumField() {
+			field := expected.Type().Field(i)
+
+			if _, ok := actual.Type().FieldByName(field.Name); !ok {
+				continue
+			}
+
+			differences = append(differences, compare(path+"."+field.Name, expected.Field(i), actual.FieldByName(field.Name))...)
+		}
+
+		return differences
+	case reflect.Func:
+		if expected.IsNil() != actual.IsNil() {
+			return []string{difference(path, nilness(expected), nilness(actual))}
+		}
+
+		if expected.IsNil() {
+			return nil
+		}
+
+		expectedElems, actualElems := collect(expected), collect(actual)
+
+		if len(expectedElems) != len(actualElems) {
+			return []string{fmt.Sprintf("%s: expected %d elements, got %d", path, len(expectedElems), len(actualElems))}
+		}
+
+		var differences []string
+
+		for i := range expectedElems {
+			for j := range expectedElems[i] {
+				differences = append(differences, compare(fmt.Sprintf("%s[%d][%d]", path, i, j), expectedElems[i][j], actualElems[i][j])...)
+			}
+		}
+
+		return differences
+	default:
+		return []string{fmt.Sprintf("%s: unable to compare %s", path, expected.Type())}
+	}
+
+	return nil
+}
+
+func difference(path string, expected any, actual any) string {
+	return fmt.Sprintf("%s: expected %v, got %v", path, expected, actual)
+}
+
+func nilness(value reflect.Value) string {
+	if value.IsNil() {
+		return "nil"
+	}
+
+	return "non-nil"
+}
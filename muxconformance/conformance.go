@@ -0,0 +1,224 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxconformance
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// providerServer5 contains every RPC of protocol version 5, including those
+// which are not yet part of tfprotov5.ProviderServer.
+type providerServer5 interface {
+	tfprotov5.ProviderServer
+	tfprotov5.ActionServer
+	tfprotov5.ListResourceServer
+}
+
+// providerServer6 contains every RPC of protocol version 6, including those
+// which are not yet part of tfprotov6.ProviderServer.
+type providerServer6 interface {
+	tfprotov6.ProviderServer
+	tfprotov6.ActionServer
+	tfprotov6.ListResourceServer
+	tfprotov6.StateStoreServer
+}
+
+// rpcNames5 are the names of the protocol version 5 RPCs whose protocol
+// version 6 counterparts are named differently, by protocol version 6 name.
+var rpcNames5 = map[string]string{
+	"ValidateDataResourceConfig": "ValidateDataSourceConfig",
+	"ValidateProviderConfig":     "PrepareProviderConfig",
+	"ValidateResourceConfig":     "ValidateResourceTypeConfig",
+}
+
+// RunV5 runs the conformance tests of a protocol version 5 ProviderServer
+// wrapper, such as a server created by tf5muxserver.NewMuxServer. The wrap
+// function is called with a mock underlying server, and must return the
+// wrapper of it.
+//
+// Each RPC is a subtest, which calls the RPC of the wrapper with a generated
+// request, which has every field set, and fails unless the request received
+// by the mock server and the response returned by the wrapper equal the
+// generated request and the response generated by the mock server, field by
+// field. The elements of streams, such as ListResource results, are compared
+// in the same way. RPCs which are not implemented by the wrapper fail.
+//
+// Requests and responses are generated with reflection, so fields added to
+// terraform-plugin-go are tested without changes to this package. Every
+// string is "test", including type names and the keys of maps, such as those
+// of the schemas returned by the mock server, so the types of requests are
+// implemented by the mock server. Every integer is 2, which is a valid value
+// of every enumeration, such as DiagnosticSeverityWarning.
+//
+// The underlying server may be called by the wrapper any number of times, so
+// RPCs whose responses are cached by the wrapper, such as
+// GetProviderSchema, are tested with the last request received by the mock
+// server. RPCs and fields which the wrapper cannot preserve can be excluded
+// with the WithSkippedRPCs and WithIgnoredFields options.
+func RunV5(t *testing.T, wrap func(func() tfprotov5.ProviderServer) (tfprotov5.ProviderServer, error), opts ...RunOption) {
+	t.Helper()
+
+	run(t, reflect.TypeFor[providerServer5](), reflect.TypeFor[providerServer5](), nil, opts, func(counterparts map[string]reflect.Type) (reflect.Value, *recorder, error) {
+		mock := &mockServer5{recorder: newRecorder(counterparts)}
+		wrapper, err := wrap(func() tfprotov5.ProviderServer { return mock })
+
+		return reflect.ValueOf(wrapper), mock.recorder, err
+	})
+}
+
+// RunV6 runs the conformance tests of a protocol version 6 ProviderServer
+// wrapper, such as a server created by tf6muxserver.NewMuxServer, in the
+// same way as RunV5.
+func RunV6(t *testing.T, wrap func(func() tfprotov6.ProviderServer) (tfprotov6.ProviderServer, error), opts ...RunOption) {
+	t.Helper()
+
+	run(t, reflect.TypeFor[providerServer6](), reflect.TypeFor[providerServer6](), nil, opts, func(counterparts map[string]reflect.Type) (reflect.Value, *recorder, error) {
+		mock := &mockServer6{recorder: newRecorder(counterparts)}
+		wrapper, err := wrap(func() tfprotov6.ProviderServer { return mock })
+
+		return reflect.ValueOf(wrapper), mock.recorder, err
+	})
+}
+
+// RunV5ToV6 runs the conformance tests of a protocol version 6 wrapper of a
+// protocol version 5 server, such as a server created by
+// tf5to6server.UpgradeServer, in the same way as RunV5. Fields are compared
+// by name across protocol versions, and fields without a counterpart in the
+// other protocol version are not set in the generated requests and
+// responses. RPCs without a counterpart in protocol version 5, such as those
+// of state stores, are skipped.
+func RunV5ToV6(t *testing.T, wrap func(func() tfprotov5.ProviderServer) (tfprotov6.ProviderServer, error), opts ...RunOption) {
+	t.Helper()
+
+	run(t, reflect.TypeFor[providerServer6](), reflect.TypeFor[providerServer5](), rpcNames5, opts, func(counterparts map[string]reflect.Type) (reflect.Value, *recorder, error) {
+		mock := &mockServer5{recorder: newRecorder(counterparts)}
+		wrapper, err := wrap(func() tfprotov5.ProviderServer { return mock })
+
+		return reflect.ValueOf(wrapper), mock.recorder, err
+	})
+}
+
+// RunV6ToV5 runs the conformance tests of a protocol version 5 wrapper of a
+// protocol version 6 server, such as a server created by
+// tf6to5server.DowngradeServer, in the same way as RunV5ToV6.
+func RunV6ToV5(t *testing.T, wrap func(func() tfprotov6.ProviderServer) (tfprotov5.ProviderServer, error), opts ...RunOption) {
+	t.Helper()
+
+	rpcNames6 := make(map[string]string, len(rpcNames5))
+
+	for name6, name5 := range rpcNames5 {
+		rpcNames6[name5] = name6
+	}
+
+	run(t, reflect.TypeFor[providerServer5](), reflect.TypeFor[providerServer6](), rpcNames6, opts, func(counterparts map[string]reflect.Type) (reflect.Value, *recorder, error) {
+		mock := &mockServer6{recorder: newRecorder(counterparts)}
+		wrapper, err := wrap(func() tfprotov6.ProviderServer { return mock })
+
+		return reflect.ValueOf(wrapper), mock.recorder, err
+	})
+}
+
+// run runs a subtest for each RPC of the wrapper interface. The rpcNames are
+// the names of the RPCs of the underlying interface, by the names of the RPCs
+// of the wrapper interface, if they differ. The newWrapper function returns
+// the wrapper of a new mock server, whose responses are generated with the
+// counterparts, which are the response types of the wrapper by underlying
+// RPC name.
+func run(t *testing.T, wrapperInterface reflect.Type, underlyingInterface reflect.Type, rpcNames map[string]string, opts []RunOption, newWrapper func(counterparts map[string]reflect.Type) (reflect.Value, *recorder, error)) {
+	t.Helper()
+
+	var options runOptions
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	counterparts := make(map[string]reflect.Type, wrapperInterface.NumMethod())
+
+	for i := range wrapperInterface.NumMethod() {
+		method := wrapperInterface.Method(i)
+		counterparts[underlyingRPCName(rpcNames, method.Name)] = method.Type.Out(0)
+	}
+
+	wrapper, recorder, err := newWrapper(counterparts)
+
+	if err != nil {
+		t.Fatalf("unexpected error creating wrapper: %s", err)
+	}
+
+	if !wrapper.IsValid() || wrapper.IsZero() {
+		t.Fatalf("expected wrapper, got nil")
+	}
+
+	for i := range wrapperInterface.NumMethod() {
+		method := wrapperInterface.Method(i)
+
+		t.Run(method.Name, func(t *testing.T) {
+			if options.skippedRPCs[method.Name] {
+				t.Skipf("%s is skipped with WithSkippedRPCs", method.Name)
+			}
+
+			underlyingName := underlyingRPCName(rpcNames, method.Name)
+			underlyingMethod, ok := underlyingInterface.MethodByName(underlyingName)
+
+			if !ok {
+				t.Skipf("%s has no counterpart in the protocol version of the underlying server", method.Name)
+			}
+
+			wrapperMethod := wrapper.MethodByName(method.Name)
+
+			if !wrapperMethod.IsValid() {
+				t.Fatalf("wrapper does not implement %s", method.Name)
+			}
+
+			req, err := generate(method.Type.In(1), underlyingMethod.Type.In(1))
+
+			if err != nil {
+				t.Fatalf("unexpected error generating request: %s", err)
+			}
+
+			results := wrapperMethod.Call([]reflect.Value{reflect.ValueOf(context.Background()), req})
+
+			if err, _ := results[1].Interface().(error); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// Streams of the response are consumed before the underlying
+			// server is checked, as wrappers such as gRPC clients may call
+			// the underlying server asynchronously.
+			resp := snapshot(results[0])
+
+			underlyingReq, underlyingResp, ok := recorder.call(underlyingName)
+
+			if !ok {
+				t.Fatalf("wrapper did not call %s of the underlying server", underlyingName)
+			}
+
+			for _, difference := range compare("request", req, underlyingReq) {
+				if !options.ignored(method.Name, difference) {
+					t.Errorf("request received by the underlying server differs: %s", difference)
+				}
+			}
+
+			for _, difference := range compare("response", underlyingResp, resp) {
+				if !options.ignored(method.Name, difference) {
+					t.Errorf("response returned by the wrapper differs: %s", difference)
+				}
+			}
+		})
+	}
+}
+
+func underlyingRPCName(rpcNames map[string]string, name string) string {
+	if underlyingName, ok := rpcNames[name]; ok {
+		return underlyingName
+	}
+
+	return name
+}
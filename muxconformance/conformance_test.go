@@ -0,0 +1,62 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxconformance_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/muxconformance"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
+)
+
+func TestRunV5_MuxServer(t *testing.T) {
+	t.Parallel()
+
+	muxconformance.RunV5(t, func(server func() tfprotov5.ProviderServer) (tfprotov5.ProviderServer, error) {
+		muxServer, err := tf5muxserver.NewMuxServer(context.Background(), server)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return muxServer.ProviderServer(), nil
+	})
+}
+
+func TestRunV6_MuxServer(t *testing.T) {
+	t.Parallel()
+
+	muxconformance.RunV6(t, func(server func() tfprotov6.ProviderServer) (tfprotov6.ProviderServer, error) {
+		muxServer, err := tf6muxserver.NewMuxServer(context.Background(), server)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return muxServer.ProviderServer(), nil
+	})
+}
+
+func TestRunV5ToV6_UpgradeServer(t *testing.T) {
+	t.Parallel()
+
+	muxconformance.RunV5ToV6(t, func(server func() tfprotov5.ProviderServer) (tfprotov6.ProviderServer, error) {
+		return tf5to6server.UpgradeServer(context.Background(), server)
+	})
+}
+
+func TestRunV6ToV5_DowngradeServer(t *testing.T) {
+	t.Parallel()
+
+	muxconformance.RunV6ToV5(t, func(server func() tfprotov6.ProviderServer) (tfprotov5.ProviderServer, error) {
+		return tf6to5server.DowngradeServer(context.Background(), server)
+	})
+}
@@ -0,0 +1,24 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package muxconformance contains conformance tests of provider servers which
+// wrap another provider server, such as the servers created by the
+// tf5muxserver, tf6muxserver, tf5to6server, and tf6to5server packages.
+//
+// The tests call every RPC of the wrapper with a request which has every
+// field set, and check that the underlying mock server received the same
+// request and that the wrapper returned the same response as the mock server,
+// field by field. Requests and responses are generated with reflection, so
+// fields added to terraform-plugin-go are tested automatically.
+//
+// Refer to the RunV5(), RunV6(), RunV5ToV6(), and RunV6ToV5() functions for
+// running the tests of a wrapper, such as in the tests of its package:
+//
+//	func TestUpgradeServer_Conformance(t *testing.T) {
+//		t.Parallel()
+//
+//		muxconformance.RunV5ToV6(t, func(server func() tfprotov5.ProviderServer) (tfprotov6.ProviderServer, error) {
+//			return tf5to6server.UpgradeServer(context.Background(), server)
+//		})
+//	}
+package muxconformance
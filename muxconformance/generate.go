@@ -0,0 +1,280 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxconformance
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Generated values, which are the same for every field, so that type names
+// in requests match the type names of the generated schemas.
+const (
+	// generatedInt is the value of every integer, which is a valid value of
+	// every enumeration, such as DiagnosticSeverityWarning, rather than an
+	// error or unknown value which servers may handle differently.
+	generatedInt = 2
+
+	// generatedString is the value of every string, including type names and
+	// map keys.
+	generatedString = "test"
+)
+
+var (
+	// generatedTime is the value of every time.Time.
+	generatedTime = time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	attributePathType = reflect.TypeFor[*tftypes.AttributePath]()
+	timeType          = reflect.TypeFor[time.Time]()
+	tftypesType       = reflect.TypeFor[tftypes.Type]()
+
+	// interfaceImplementations are the values generated for interface types,
+	// other than tftypes.Type. Generating a value of an interface type which
+	// is not listed is an error.
+	interfaceImplementations = map[reflect.Type]reflect.Type{
+		reflect.TypeFor[tfprotov5.InvokeActionEventType](): reflect.TypeFor[tfprotov5.CompletedInvokeActionEventType](),
+		reflect.TypeFor[tfprotov6.InvokeActionEventType](): reflect.TypeFor[tfprotov6.CompletedInvokeActionEventType](),
+	}
+)
+
+// generate returns a value of the type with every field set to a non-zero
+// value, except fields which have no counterpart field of the same name in
+// the counterpart type. The counterpart type is the type of the same value in
+// the other protocol version, or the type itself if the wrapper and the
+// underlying server implement the same protocol version, so values can be
+// compared field by field after translation. Slices, maps, and iterators
+// contain a single element. Pointers to recursive types, such as the nested
+// blocks of schema blocks, are nil within a value of the same type, and
+// slices and maps of them are empty, as nil elements cannot be sent over
+// gRPC.
+func generate(typ reflect.Type, counterpart reflect.Type) (reflect.Value, error) {
+	g := &generator{
+		visiting: make(map[reflect.Type]bool),
+	}
+
+	return g.generate(typ, counterpart)
+}
+
+// generator generates values, tracking the types being generated to end the
+// recursion of recursive types.
+type generator struct {
+	visiting map[reflect.Type]bool
+}
+
+func (g *generator) generate(typ reflect.Type, counterpart reflect.Type) (reflect.Value, error) {
+	value := reflect.New(typ).Elem()
+
+	switch typ {
+	case attributePathType:
+		value.Set(reflect.ValueOf(tftypes.NewAttributePath().WithAttributeName(generatedString)))
+
+		return value, nil
+	case timeType:
+		value.Set(reflect.ValueOf(generatedTime))
+
+		return value, nil
+	case tftypesType:
+		value.Set(reflect.ValueOf(tftypes.String))
+
+		return value, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(generatedInt)
+	case reflect.Uint8:
+		value.SetUint(generatedInt)
+	case reflect.String:
+		value.SetString(generatedString)
+	case reflect.Pointer:
+		if g.visiting[typ] {
+			return value, nil
+		}
+
+		g.visiting[typ] = true
+		elem, err := g.generate(typ.Elem(), counterpart.Elem())
+		delete(g.visiting, typ)
+
+		if err != nil {
+			return value, err
+		}
+
+		value.Set(elem.Addr())
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			value.SetBytes([]byte(generatedString))
+
+			return value, nil
+		}
+
+		if g.visiting[typ.Elem()] {
+			return value, nil
+		}
+
+		elem, err := g.generate(typ.Elem(), counterpart.Elem())
+
+		if err != nil {
+			return value, err
+		}
+
+		value.Set(reflect.Append(value, elem))
+	case reflect.Map:
+		if g.visiting[typ.Elem()] {
+			return value, nil
+		}
+
+		key, err := g.generate(typ.Key(), counterpart.Key())
+
+		if err != nil {
+			return value, err
+		}
+
+		elem, err := g.generate(typ.Elem(), counterpart.Elem())
+
+		if err != nil {
+			return value, err
+		}
+
+		value.Set(reflect.MakeMap(typ))
+		value.SetMapIndex(key, elem)
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+
+			if !field.IsExported() {
+				return value, fmt.Errorf("unable to generate %s: unexported field %s", typ, field.Name)
+			}
+
+			counterpartField, ok := counterpart.FieldByName(field.Name)
+
+			if !ok {
+				continue
+			}
+
+			fieldValue, err := g.generate(field.Type, counterpartField.Type)
+
+			if err != nil {
+				return value, err
+			}
+
+			value.Field(i).Set(fieldValue)
+		}
+	case reflect.Interface:
+		implementation, ok := interfaceImplementations[typ]
+
+		if !ok {
+			return value, fmt.Errorf("unable to generate %s: unsupported interface type", typ)
+		}
+
+		counterpartImplementation, ok := interfaceImplementations[counterpart]
+
+		if !ok {
+			return value, fmt.Errorf("unable to generate %s: unsupported interface type", counterpart)
+		}
+
+		elem, err := g.generate(implementation, counterpartImplementation)
+
+		if err != nil {
+			return value, err
+		}
+
+		value.Set(elem)
+	case reflect.Func:
+		elems, err := g.generateSeq(typ, counterpart)
+
+		if err != nil {
+			return value, err
+		}
+
+		value.Set(seq(typ, elems))
+	default:
+		return value, fmt.Errorf("unable to generate %s: unsupported kind %s", typ, typ.Kind())
+	}
+
+	return value, nil
+}
+
+// generateSeq returns the elements of an iterator of the type, which is an
+// iter.Seq or iter.Seq2. Each element is the slice of values yielded at
+// once.
+func (g *generator) generateSeq(typ reflect.Type, counterpart reflect.Type) ([][]reflect.Value, error) {
+	if !isSeq(typ) || !isSeq(counterpart) {
+		return nil, fmt.Errorf("unable to generate %s: unsupported function type", typ)
+	}
+
+	yield, counterpartYield := typ.In(0), counterpart.In(0)
+	elem := make([]reflect.Value, 0, yield.NumIn())
+
+	for i := range yield.NumIn() {
+		value, err := g.generate(yield.In(i), counterpartYield.In(i))
+
+		if err != nil {
+			return nil, err
+		}
+
+		elem = append(elem, value)
+	}
+
+	return [][]reflect.Value{elem}, nil
+}
+
+// isSeq returns true if the type is an iter.Seq or iter.Seq2.
+func isSeq(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Func || typ.NumIn() != 1 || typ.NumOut() != 0 {
+		return false
+	}
+
+	yield := typ.In(0)
+
+	return yield.Kind() == reflect.Func && yield.NumIn() >= 1 && yield.NumIn() <= 2 &&
+		yield.NumOut() == 1 && yield.Out(0).Kind() == reflect.Bool
+}
+
+// seq returns an iterator of the type, which yields the elements.
+func seq(typ reflect.Type, elems [][]reflect.Value) reflect.Value {
+	return reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		for _, elem := range elems {
+			if !args[0].Call(elem)[0].Bool() {
+				break
+			}
+		}
+
+		return nil
+	})
+}
+
+// collect returns the elements yielded by an iterator, which is an iter.Seq
+// or iter.Seq2, or nil if it is nil.
+func collect(value reflect.Value) [][]reflect.Value {
+	if value.IsNil() {
+		return nil
+	}
+
+	elems := [][]reflect.Value{}
+	yield := reflect.MakeFunc(value.Type().In(0), func(args []reflect.Value) []reflect.Value {
+		elem := make([]reflect.Value, len(args))
+
+		for i, arg := range args {
+			// The arguments are copied, as reflect.MakeFunc may reuse
+			// them.
+			elem[i] = reflect.New(arg.Type()).Elem()
+			elem[i].Set(arg)
+		}
+
+		elems = append(elems, elem)
+
+		return []reflect.Value{reflect.ValueOf(true)}
+	})
+
+	value.Call([]reflect.Value{yield})
+
+	return elems
+}
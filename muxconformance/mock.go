@@ -0,0 +1,283 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxconformance
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+var _ providerServer5 = &mockServer5{}
+
+// mockServer5 is a protocol version 5 server which records the requests it
+// receives and returns generated responses.
+type mockServer5 struct {
+	recorder *recorder
+}
+
+func (s *mockServer5) ApplyResourceChange(_ context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	return respond[tfprotov5.ApplyResourceChangeResponse](s.recorder, "ApplyResourceChange", req)
+}
+
+func (s *mockServer5) CallFunction(_ context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	return respond[tfprotov5.CallFunctionResponse](s.recorder, "CallFunction", req)
+}
+
+func (s *mockServer5) CloseEphemeralResource(_ context.Context, req *tfprotov5.CloseEphemeralResourceRequest) (*tfprotov5.CloseEphemeralResourceResponse, error) {
+	return respond[tfprotov5.CloseEphemeralResourceResponse](s.recorder, "CloseEphemeralResource", req)
+}
+
+func (s *mockServer5) ConfigureProvider(_ context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	return respond[tfprotov5.ConfigureProviderResponse](s.recorder, "ConfigureProvider", req)
+}
+
+func (s *mockServer5) GenerateResourceConfig(_ context.Context, req *tfprotov5.GenerateResourceConfigRequest) (*tfprotov5.GenerateResourceConfigResponse, error) {
+	return respond[tfprotov5.GenerateResourceConfigResponse](s.recorder, "GenerateResourceConfig", req)
+}
+
+func (s *mockServer5) GetFunctions(_ context.Context, req *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	return respond[tfprotov5.GetFunctionsResponse](s.recorder, "GetFunctions", req)
+}
+
+func (s *mockServer5) GetMetadata(_ context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	return respond[tfprotov5.GetMetadataResponse](s.recorder, "GetMetadata", req)
+}
+
+func (s *mockServer5) GetProviderSchema(_ context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	return respond[tfprotov5.GetProviderSchemaResponse](s.recorder, "GetProviderSchema", req)
+}
+
+func (s *mockServer5) GetResourceIdentitySchemas(_ context.Context, req *tfprotov5.GetResourceIdentitySchemasRequest) (*tfprotov5.GetResourceIdentitySchemasResponse, error) {
+	return respond[tfprotov5.GetResourceIdentitySchemasResponse](s.recorder, "GetResourceIdentitySchemas", req)
+}
+
+func (s *mockServer5) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	return respond[tfprotov5.ImportResourceStateResponse](s.recorder, "ImportResourceState", req)
+}
+
+func (s *mockServer5) InvokeAction(_ context.Context, req *tfprotov5.InvokeActionRequest) (*tfprotov5.InvokeActionServerStream, error) {
+	return respond[tfprotov5.InvokeActionServerStream](s.recorder, "InvokeAction", req)
+}
+
+func (s *mockServer5) ListResource(_ context.Context, req *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+	return respond[tfprotov5.ListResourceServerStream](s.recorder, "ListResource", req)
+}
+
+func (s *mockServer5) MoveResourceState(_ context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	return respond[tfprotov5.MoveResourceStateResponse](s.recorder, "MoveResourceState", req)
+}
+
+func (s *mockServer5) OpenEphemeralResource(_ context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
+	return respond[tfprotov5.OpenEphemeralResourceResponse](s.recorder, "OpenEphemeralResource", req)
+}
+
+func (s *mockServer5) PlanAction(_ context.Context, req *tfprotov5.PlanActionRequest) (*tfprotov5.PlanActionResponse, error) {
+	return respond[tfprotov5.PlanActionResponse](s.recorder, "PlanAction", req)
+}
+
+func (s *mockServer5) PlanResourceChange(_ context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	return respond[tfprotov5.PlanResourceChangeResponse](s.recorder, "PlanResourceChange", req)
+}
+
+func (s *mockServer5) PrepareProviderConfig(_ context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	return respond[tfprotov5.PrepareProviderConfigResponse](s.recorder, "PrepareProviderConfig", req)
+}
+
+func (s *mockServer5) ReadDataSource(_ context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	return respond[tfprotov5.ReadDataSourceResponse](s.recorder, "ReadDataSource", req)
+}
+
+func (s *mockServer5) ReadResource(_ context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	return respond[tfprotov5.ReadResourceResponse](s.recorder, "ReadResource", req)
+}
+
+func (s *mockServer5) RenewEphemeralResource(_ context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
+	return respond[tfprotov5.RenewEphemeralResourceResponse](s.recorder, "RenewEphemeralResource", req)
+}
+
+func (s *mockServer5) StopProvider(_ context.Context, req *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	return respond[tfprotov5.StopProviderResponse](s.recorder, "StopProvider", req)
+}
+
+func (s *mockServer5) UpgradeResourceIdentity(_ context.Context, req *tfprotov5.UpgradeResourceIdentityRequest) (*tfprotov5.UpgradeResourceIdentityResponse, error) {
+	return respond[tfprotov5.UpgradeResourceIdentityResponse](s.recorder, "UpgradeResourceIdentity", req)
+}
+
+func (s *mockServer5) UpgradeResourceState(_ context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	return respond[tfprotov5.UpgradeResourceStateResponse](s.recorder, "UpgradeResourceState", req)
+}
+
+func (s *mockServer5) ValidateActionConfig(_ context.Context, req *tfprotov5.ValidateActionConfigRequest) (*tfprotov5.ValidateActionConfigResponse, error) {
+	return respond[tfprotov5.ValidateActionConfigResponse](s.recorder, "ValidateActionConfig", req)
+}
+
+func (s *mockServer5) ValidateDataSourceConfig(_ context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
+	return respond[tfprotov5.ValidateDataSourceConfigResponse](s.recorder, "ValidateDataSourceConfig", req)
+}
+
+func (s *mockServer5) ValidateEphemeralResourceConfig(_ context.Context, req *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
+	return respond[tfprotov5.ValidateEphemeralResourceConfigResponse](s.recorder, "ValidateEphemeralResourceConfig", req)
+}
+
+func (s *mockServer5) ValidateListResourceConfig(_ context.Context, req *tfprotov5.ValidateListResourceConfigRequest) (*tfprotov5.ValidateListResourceConfigResponse, error) {
+	return respond[tfprotov5.ValidateListResourceConfigResponse](s.recorder, "ValidateListResourceConfig", req)
+}
+
+func (s *mockServer5) ValidateResourceTypeConfig(_ context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	return respond[tfprotov5.ValidateResourceTypeConfigResponse](s.recorder, "ValidateResourceTypeConfig", req)
+}
+
+var _ providerServer6 = &mockServer6{}
+
+// mockServer6 is a protocol version 6 server which records the requests it
+// receives and returns generated responses.
+type mockServer6 struct {
+	recorder *recorder
+}
+
+func (s *mockServer6) ApplyResourceChange(_ context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	return respond[tfprotov6.ApplyResourceChangeResponse](s.recorder, "ApplyResourceChange", req)
+}
+
+func (s *mockServer6) CallFunction(_ context.Context, req *tfprotov6.CallFunctionRequest) (*tfprotov6.CallFunctionResponse, error) {
+	return respond[tfprotov6.CallFunctionResponse](s.recorder, "CallFunction", req)
+}
+
+func (s *mockServer6) CloseEphemeralResource(_ context.Context, req *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
+	return respond[tfprotov6.CloseEphemeralResourceResponse](s.recorder, "CloseEphemeralResource", req)
+}
+
+func (s *mockServer6) ConfigureProvider(_ context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	return respond[tfprotov6.ConfigureProviderResponse](s.recorder, "ConfigureProvider", req)
+}
+
+func (s *mockServer6) ConfigureStateStore(_ context.Context, req *tfprotov6.ConfigureStateStoreRequest) (*tfprotov6.ConfigureStateStoreResponse, error) {
+	return respond[tfprotov6.ConfigureStateStoreResponse](s.recorder, "ConfigureStateStore", req)
+}
+
+func (s *mockServer6) DeleteState(_ context.Context, req *tfprotov6.DeleteStateRequest) (*tfprotov6.DeleteStateResponse, error) {
+	return respond[tfprotov6.DeleteStateResponse](s.recorder, "DeleteState", req)
+}
+
+func (s *mockServer6) GenerateResourceConfig(_ context.Context, req *tfprotov6.GenerateResourceConfigRequest) (*tfprotov6.GenerateResourceConfigResponse, error) {
+	return respond[tfprotov6.GenerateResourceConfigResponse](s.recorder, "GenerateResourceConfig", req)
+}
+
+func (s *mockServer6) GetFunctions(_ context.Context, req *tfprotov6.GetFunctionsRequest) (*tfprotov6.GetFunctionsResponse, error) {
+	return respond[tfprotov6.GetFunctionsResponse](s.recorder, "GetFunctions", req)
+}
+
+func (s *mockServer6) GetMetadata(_ context.Context, req *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
+	return respond[tfprotov6.GetMetadataResponse](s.recorder, "GetMetadata", req)
+}
+
+func (s *mockServer6) GetProviderSchema(_ context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return respond[tfprotov6.GetProviderSchemaResponse](s.recorder, "GetProviderSchema", req)
+}
+
+func (s *mockServer6) GetResourceIdentitySchemas(_ context.Context, req *tfprotov6.GetResourceIdentitySchemasRequest) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
+	return respond[tfprotov6.GetResourceIdentitySchemasResponse](s.recorder, "GetResourceIdentitySchemas", req)
+}
+
+func (s *mockServer6) GetStates(_ context.Context, req *tfprotov6.GetStatesRequest) (*tfprotov6.GetStatesResponse, error) {
+	return respond[tfprotov6.GetStatesResponse](s.recorder, "GetStates", req)
+}
+
+func (s *mockServer6) ImportResourceState(_ context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	return respond[tfprotov6.ImportResourceStateResponse](s.recorder, "ImportResourceState", req)
+}
+
+func (s *mockServer6) InvokeAction(_ context.Context, req *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
+	return respond[tfprotov6.InvokeActionServerStream](s.recorder, "InvokeAction", req)
+}
+
+func (s *mockServer6) ListResource(_ context.Context, req *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
+	return respond[tfprotov6.ListResourceServerStream](s.recorder, "ListResource", req)
+}
+
+func (s *mockServer6) LockState(_ context.Context, req *tfprotov6.LockStateRequest) (*tfprotov6.LockStateResponse, error) {
+	return respond[tfprotov6.LockStateResponse](s.recorder, "LockState", req)
+}
+
+func (s *mockServer6) MoveResourceState(_ context.Context, req *tfprotov6.MoveResourceStateRequest) (*tfprotov6.MoveResourceStateResponse, error) {
+	return respond[tfprotov6.MoveResourceStateResponse](s.recorder, "MoveResourceState", req)
+}
+
+func (s *mockServer6) OpenEphemeralResource(_ context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	return respond[tfprotov6.OpenEphemeralResourceResponse](s.recorder, "OpenEphemeralResource", req)
+}
+
+func (s *mockServer6) PlanAction(_ context.Context, req *tfprotov6.PlanActionRequest) (*tfprotov6.PlanActionResponse, error) {
+	return respond[tfprotov6.PlanActionResponse](s.recorder, "PlanAction", req)
+}
+
+func (s *mockServer6) PlanResourceChange(_ context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	return respond[tfprotov6.PlanResourceChangeResponse](s.recorder, "PlanResourceChange", req)
+}
+
+func (s *mockServer6) ReadDataSource(_ context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	return respond[tfprotov6.ReadDataSourceResponse](s.recorder, "ReadDataSource", req)
+}
+
+func (s *mockServer6) ReadResource(_ context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	return respond[tfprotov6.ReadResourceResponse](s.recorder, "ReadResource", req)
+}
+
+func (s *mockServer6) ReadStateBytes(_ context.Context, req *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+	return respond[tfprotov6.ReadStateBytesStream](s.recorder, "ReadStateBytes", req)
+}
+
+func (s *mockServer6) RenewEphemeralResource(_ context.Context, req *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+	return respond[tfprotov6.RenewEphemeralResourceResponse](s.recorder, "RenewEphemeralResource", req)
+}
+
+func (s *mockServer6) StopProvider(_ context.Context, req *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error) {
+	return respond[tfprotov6.StopProviderResponse](s.recorder, "StopProvider", req)
+}
+
+func (s *mockServer6) UnlockState(_ context.Context, req *tfprotov6.UnlockStateRequest) (*tfprotov6.UnlockStateResponse, error) {
+	return respond[tfprotov6.UnlockStateResponse](s.recorder, "UnlockState", req)
+}
+
+func (s *mockServer6) UpgradeResourceIdentity(_ context.Context, req *tfprotov6.UpgradeResourceIdentityRequest) (*tfprotov6.UpgradeResourceIdentityResponse, error) {
+	return respond[tfprotov6.UpgradeResourceIdentityResponse](s.recorder, "UpgradeResourceIdentity", req)
+}
+
+func (s *mockServer6) UpgradeResourceState(_ context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
+	return respond[tfprotov6.UpgradeResourceStateResponse](s.recorder, "UpgradeResourceState", req)
+}
+
+func (s *mockServer6) ValidateActionConfig(_ context.Context, req *tfprotov6.ValidateActionConfigRequest) (*tfprotov6.ValidateActionConfigResponse, error) {
+	return respond[tfprotov6.ValidateActionConfigResponse](s.recorder, "ValidateActionConfig", req)
+}
+
+func (s *mockServer6) ValidateDataResourceConfig(_ context.Context, req *tfprotov6.ValidateDataResourceConfigRequest) (*tfprotov6.ValidateDataResourceConfigResponse, error) {
+	return respond[tfprotov6.ValidateDataResourceConfigResponse](s.recorder, "ValidateDataResourceConfig", req)
+}
+
+func (s *mockServer6) ValidateEphemeralResourceConfig(_ context.Context, req *tfprotov6.ValidateEphemeralResourceConfigRequest) (*tfprotov6.ValidateEphemeralResourceConfigResponse, error) {
+	return respond[tfprotov6.ValidateEphemeralResourceConfigResponse](s.recorder, "ValidateEphemeralResourceConfig", req)
+}
+
+func (s *mockServer6) ValidateListResourceConfig(_ context.Context, req *tfprotov6.ValidateListResourceConfigRequest) (*tfprotov6.ValidateListResourceConfigResponse, error) {
+	return respond[tfprotov6.ValidateListResourceConfigResponse](s.recorder, "ValidateListResourceConfig", req)
+}
+
+func (s *mockServer6) ValidateProviderConfig(_ context.Context, req *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	return respond[tfprotov6.ValidateProviderConfigResponse](s.recorder, "ValidateProviderConfig", req)
+}
+
+func (s *mockServer6) ValidateResourceConfig(_ context.Context, req *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
+	return respond[tfprotov6.ValidateResourceConfigResponse](s.recorder, "ValidateResourceConfig", req)
+}
+
+func (s *mockServer6) ValidateStateStoreConfig(_ context.Context, req *tfprotov6.ValidateStateStoreConfigRequest) (*tfprotov6.ValidateStateStoreConfigResponse, error) {
+	return respond[tfprotov6.ValidateStateStoreConfigResponse](s.recorder, "ValidateStateStoreConfig", req)
+}
+
+func (s *mockServer6) WriteStateBytes(_ context.Context, req *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
+	return respond[tfprotov6.WriteStateBytesResponse](s.recorder, "WriteStateBytes", req)
+}
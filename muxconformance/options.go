@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxconformance
+
+import (
+	"strings"
+)
+
+// RunOption is an option for the RunV5, RunV6, RunV5ToV6, and RunV6ToV5
+// functions.
+type RunOption func(*runOptions)
+
+// runOptions contains the configuration of the Run functions.
+type runOptions struct {
+	// ignoredFields are the paths of ignored fields, by wrapper RPC name.
+	ignoredFields map[string][]string
+
+	// skippedRPCs are the names of the skipped wrapper RPCs.
+	skippedRPCs map[string]bool
+}
+
+// WithIgnoredFields is a RunOption which ignores the differences of fields
+// which cannot be preserved by the wrapper, such as fields which are not part
+// of the plugin protocol. Each field is the name of a wrapper RPC and the
+// path of the field, as reported by the tests, such as:
+//
+//	"ValidateProviderConfig response.PreparedConfig"
+//
+// The differences of the elements and fields within ignored fields are also
+// ignored.
+func WithIgnoredFields(fields ...string) RunOption {
+	return func(o *runOptions) {
+		if o.ignoredFields == nil {
+			o.ignoredFields = make(map[string][]string)
+		}
+
+		for _, field := range fields {
+			rpc, path, _ := strings.Cut(field, " ")
+			o.ignoredFields[rpc] = append(o.ignoredFields[rpc], path)
+		}
+	}
+}
+
+// WithSkippedRPCs is a RunOption which skips the tests of wrapper RPCs, such
+// as RPCs whose generated requests cannot be sent by the wrapper.
+func WithSkippedRPCs(rpcs ...string) RunOption {
+	return func(o *runOptions) {
+		if o.skippedRPCs == nil {
+			o.skippedRPCs = make(map[string]bool)
+		}
+
+		for _, rpc := range rpcs {
+			o.skippedRPCs[rpc] = true
+		}
+	}
+}
+
+// ignored returns true if the difference, as returned by compare, is of an
+// ignored field of the RPC or of a value within it.
+func (o runOptions) ignored(rpc string, difference string) bool {
+	path, _, _ := strings.Cut(difference, ": ")
+
+	for _, ignoredPath := range o.ignoredFields[rpc] {
+		if path == ignoredPath || strings.HasPrefix(path, ignoredPath+".") || strings.HasPrefix(path, ignoredPath+"[") {
+			return true
+		}
+	}
+
+	return false
+}
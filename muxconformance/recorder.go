@@ -0,0 +1,94 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxconformance
+
+import (
+	"reflect"
+	"sync"
+)
+
+// recorder records the last request received and the last response returned
+// by a mock server for each RPC.
+type recorder struct {
+	mu sync.Mutex
+
+	// counterparts are the types of the responses of the wrapper, by RPC
+	// name, with which responses are generated.
+	counterparts map[string]reflect.Type
+
+	requests  map[string]reflect.Value
+	responses map[string]reflect.Value
+}
+
+func newRecorder(counterparts map[string]reflect.Type) *recorder {
+	return &recorder{
+		counterparts: counterparts,
+		requests:     make(map[string]reflect.Value),
+		responses:    make(map[string]reflect.Value),
+	}
+}
+
+// call returns the last request received and the last response returned for
+// the RPC, or false if the RPC was not called.
+func (r *recorder) call(rpc string) (reflect.Value, reflect.Value, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	req, ok := r.requests[rpc]
+
+	if !ok {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+
+	return req, r.responses[rpc], true
+}
+
+// respond records the request and returns a generated response of the RPC.
+func respond[Resp any](r *recorder, rpc string, req any) (*Resp, error) {
+	typ := reflect.TypeFor[*Resp]()
+	counterpart, ok := r.counterparts[rpc]
+
+	if !ok {
+		counterpart = typ
+	}
+
+	resp, err := generate(typ, counterpart)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests[rpc] = snapshot(reflect.ValueOf(req))
+	r.responses[rpc] = resp
+
+	return resp.Interface().(*Resp), nil //nolint:forcetypeassert
+}
+
+// snapshot returns a copy of a request or response pointer whose iterators,
+// such as the chunks of WriteStateBytes, are consumed and replaced with
+// iterators which replay their elements, as requests may only be iterated
+// during the call and streams may only be iterated once.
+func snapshot(ptr reflect.Value) reflect.Value {
+	if ptr.IsNil() {
+		return ptr
+	}
+
+	value := reflect.New(ptr.Type().Elem())
+	value.Elem().Set(ptr.Elem())
+
+	for i := range value.Elem().NumField() {
+		field := value.Elem().Field(i)
+
+		if field.Kind() != reflect.Func || field.IsNil() {
+			continue
+		}
+
+		field.Set(seq(field.Type(), collect(field)))
+	}
+
+	return value
+}
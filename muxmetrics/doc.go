@@ -0,0 +1,14 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package muxmetrics contains the protocol version agnostic metrics of calls
+// from a combined server to its underlying servers.
+//
+// The tf5muxserver and tf6muxserver packages record each call to an
+// underlying server through the Metrics interface, when enabled with their
+// WithMetrics option. Each call is labeled by the RPC name, the Go type of
+// the underlying server, and the type name which the request was routed by.
+//
+// Refer to the NewInMemory() function for an implementation which keeps the
+// metrics in memory, such as for acceptance testing or debugging.
+package muxmetrics
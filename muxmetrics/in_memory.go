@@ -0,0 +1,156 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxmetrics
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+var _ Metrics = &InMemory{}
+
+// InMemory is a Metrics implementation which aggregates the calls with the
+// same labels in memory. It should always be instantiated by calling
+// NewInMemory().
+type InMemory struct {
+	mu     sync.Mutex
+	series map[Labels]*Series
+}
+
+// Series is the aggregate of the calls with the same labels.
+type Series struct {
+	// Labels identify the calls in the series.
+	Labels Labels
+
+	// Calls is the number of calls.
+	Calls int
+
+	// ErrorDiagnostics is the total number of error diagnostics returned by
+	// the calls.
+	ErrorDiagnostics int
+
+	// Errors is the number of calls which returned a gRPC error.
+	Errors int
+
+	// TotalDuration is the sum of the call durations.
+	TotalDuration time.Duration
+
+	// MinDuration is the shortest call duration.
+	MinDuration time.Duration
+
+	// MaxDuration is the longest call duration.
+	MaxDuration time.Duration
+}
+
+// MeanDuration returns the mean call duration.
+func (s Series) MeanDuration() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+
+	return s.TotalDuration / time.Duration(s.Calls)
+}
+
+// NewInMemory returns an empty InMemory.
+func NewInMemory() *InMemory {
+	return &InMemory{
+		series: make(map[Labels]*Series),
+	}
+}
+
+// RecordCall adds the call to the series with the same labels.
+func (m *InMemory) RecordCall(_ context.Context, labels Labels, result CallResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	series, ok := m.series[labels]
+
+	if !ok {
+		series = &Series{
+			Labels:      labels,
+			MinDuration: result.Duration,
+		}
+		m.series[labels] = series
+	}
+
+	series.Calls++
+	series.ErrorDiagnostics += result.ErrorDiagnostics
+	series.TotalDuration += result.Duration
+	series.MinDuration = min(series.MinDuration, result.Duration)
+	series.MaxDuration = max(series.MaxDuration, result.Duration)
+
+	if result.Err != nil {
+		series.Errors++
+	}
+}
+
+// Reset removes all series.
+func (m *InMemory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clear(m.series)
+}
+
+// Snapshot returns a copy of all series, sorted by RPC, server, and type
+// name.
+func (m *InMemory) Snapshot() []Series {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]Series, 0, len(m.series))
+
+	for _, series := range m.series {
+		result = append(result, *series)
+	}
+
+	slices.SortFunc(result, func(a, b Series) int {
+		return cmp.Or(
+			cmp.Compare(a.Labels.RPC, b.Labels.RPC),
+			cmp.Compare(a.Labels.Server, b.Labels.Server),
+			cmp.Compare(a.Labels.TypeName, b.Labels.TypeName),
+		)
+	})
+
+	return result
+}
+
+// Dump writes a table of the Snapshot series to w.
+func (m *InMemory) Dump(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, err := fmt.Fprintln(tw, "RPC\tSERVER\tTYPE\tCALLS\tERRORS\tERROR DIAGNOSTICS\tMIN\tMEAN\tMAX")
+
+	if err != nil {
+		return err
+	}
+
+	for _, series := range m.Snapshot() {
+		_, err := fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\n",
+			series.Labels.RPC,
+			series.Labels.Server,
+			series.Labels.TypeName,
+			series.Calls,
+			series.Errors,
+			series.ErrorDiagnostics,
+			series.MinDuration,
+			series.MeanDuration(),
+			series.MaxDuration,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,118 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxmetrics_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-mux/muxmetrics"
+)
+
+func TestInMemorySnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	metrics := muxmetrics.NewInMemory()
+
+	readResourceLabels := muxmetrics.Labels{
+		RPC:      "ReadResource",
+		Server:   "*test.Server",
+		TypeName: "test_resource",
+	}
+	configureProviderLabels := muxmetrics.Labels{
+		RPC:    "ConfigureProvider",
+		Server: "*test.Server",
+	}
+
+	metrics.RecordCall(ctx, readResourceLabels, muxmetrics.CallResult{
+		Duration: 3 * time.Millisecond,
+	})
+	metrics.RecordCall(ctx, readResourceLabels, muxmetrics.CallResult{
+		Duration:         1 * time.Millisecond,
+		ErrorDiagnostics: 2,
+	})
+	metrics.RecordCall(ctx, readResourceLabels, muxmetrics.CallResult{
+		Duration: 5 * time.Millisecond,
+		Err:      errors.New("test error"),
+	})
+	metrics.RecordCall(ctx, configureProviderLabels, muxmetrics.CallResult{
+		Duration: 2 * time.Millisecond,
+	})
+
+	expected := []muxmetrics.Series{
+		{
+			Labels:        configureProviderLabels,
+			Calls:         1,
+			TotalDuration: 2 * time.Millisecond,
+			MinDuration:   2 * time.Millisecond,
+			MaxDuration:   2 * time.Millisecond,
+		},
+		{
+			Labels:           readResourceLabels,
+			Calls:            3,
+			ErrorDiagnostics: 2,
+			Errors:           1,
+			TotalDuration:    9 * time.Millisecond,
+			MinDuration:      1 * time.Millisecond,
+			MaxDuration:      5 * time.Millisecond,
+		},
+	}
+
+	got := metrics.Snapshot()
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected snapshot difference: %s", diff)
+	}
+
+	if got[1].MeanDuration() != 3*time.Millisecond {
+		t.Errorf("expected mean duration of 3ms, got: %s", got[1].MeanDuration())
+	}
+
+	metrics.Reset()
+
+	if got := metrics.Snapshot(); len(got) != 0 {
+		t.Errorf("expected no series after reset, got: %v", got)
+	}
+}
+
+func TestInMemoryDump(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	metrics := muxmetrics.NewInMemory()
+
+	metrics.RecordCall(
+		ctx,
+		muxmetrics.Labels{
+			RPC:      "ReadResource",
+			Server:   "*test.Server",
+			TypeName: "test_resource",
+		},
+		muxmetrics.CallResult{
+			Duration:         2 * time.Millisecond,
+			ErrorDiagnostics: 1,
+		},
+	)
+
+	var got strings.Builder
+
+	err := metrics.Dump(&got)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "RPC           SERVER        TYPE           CALLS  ERRORS  ERROR DIAGNOSTICS  MIN  MEAN  MAX\n" +
+		"ReadResource  *test.Server  test_resource  1      0       1                  2ms  2ms   2ms\n"
+
+	if diff := cmp.Diff(got.String(), expected); diff != "" {
+		t.Errorf("unexpected dump difference: %s", diff)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxmetrics
+
+import (
+	"context"
+	"time"
+)
+
+// Metrics records calls from a combined server to its underlying servers.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// RecordCall is called once for each completed call to an underlying
+	// server. For streaming RPCs, such as ListResource, the call is complete
+	// once the stream has ended.
+	RecordCall(ctx context.Context, labels Labels, result CallResult)
+}
+
+// Labels identify the calls which are recorded together.
+type Labels struct {
+	// RPC is the name of the RPC, such as "ReadResource".
+	RPC string
+
+	// Server is the Go type of the underlying server, such as
+	// "*schema.GRPCProviderServer", which matches the tf_mux_provider
+	// logging field.
+	Server string
+
+	// TypeName is the action, data source, ephemeral resource, function,
+	// list resource, resource, or state store type name which the request
+	// was routed by. It is empty for RPCs which are sent to every underlying
+	// server, such as ConfigureProvider, and for server discovery.
+	TypeName string
+}
+
+// CallResult is the result of a single call to an underlying server.
+type CallResult struct {
+	// Duration is the time taken by the call, including the time taken to
+	// stream all elements for streaming RPCs.
+	Duration time.Duration
+
+	// ErrorDiagnostics is the number of error severity diagnostics returned
+	// by the call, including those in streamed elements. Function errors are
+	// counted as error diagnostics.
+	ErrorDiagnostics int
+
+	// Err is the gRPC error returned by the call, if any.
+	Err error
+}
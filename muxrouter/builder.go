@@ -0,0 +1,52 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxrouter
+
+// Builder collects new routing, such as while merging the responses of all
+// underlying servers in the GetProviderSchema RPC, before it is published
+// with Router.Publish. It should always be instantiated by calling
+// Router.NewBuilder(). A Builder is not safe for concurrent use.
+type Builder[S, C, D any] struct {
+	// router is the Router which created the Builder.
+	router *Router[S, C, D]
+
+	// routes contains the route for each type name, by kind.
+	routes map[Kind]map[string]Route[S, C]
+
+	// discoveryComplete is whether publishing should mark server discovery
+	// as complete.
+	discoveryComplete bool
+}
+
+// Add routes the given kind and name to the underlying server. If another
+// underlying server was already added for the kind and name, the existing
+// route is kept and false is returned with a DuplicateError diagnostic.
+func (b *Builder[S, C, D]) Add(kind Kind, name string, server S, capabilities C) (D, bool) {
+	routes, ok := b.routes[kind]
+
+	if !ok {
+		routes = make(map[string]Route[S, C])
+		b.routes[kind] = routes
+	}
+
+	if _, ok := routes[name]; ok {
+		return b.router.DuplicateError(kind, name), false
+	}
+
+	routes[name] = Route[S, C]{
+		Server:       server,
+		Capabilities: capabilities,
+	}
+
+	var diagnostic D
+
+	return diagnostic, true
+}
+
+// SetDiscoveryComplete marks that the Builder contains the routes of all
+// underlying servers for all kinds, so publishing it will complete server
+// discovery and lookups will no longer need to call the underlying servers.
+func (b *Builder[S, C, D]) SetDiscoveryComplete() {
+	b.discoveryComplete = true
+}
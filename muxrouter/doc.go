@@ -0,0 +1,20 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package muxrouter contains the protocol version agnostic routing used to
+// combine multiple provider servers into a single server.
+//
+// A Router maps each action, data source, ephemeral resource, list resource,
+// function, state store, and managed resource type name to the underlying
+// server which implements it. It is parameterized over the server handle,
+// server capabilities, and diagnostic types. The server handle is whatever the
+// caller uses to identify an underlying server, such as the index of the
+// server in the tf5muxserver and tf6muxserver packages, along with:
+//
+//   - *tfprotov5.ServerCapabilities and *tfprotov5.Diagnostic
+//   - *tfprotov6.ServerCapabilities and *tfprotov6.Diagnostic
+//
+// Most providers should use the tf5muxserver or tf6muxserver packages, which
+// are built on this package, rather than using it directly. Refer to the
+// New() function for creating a Router for a custom routing implementation.
+package muxrouter
@@ -0,0 +1,164 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxrouter
+
+// Kind is a kind of type which is routed to a single underlying server.
+type Kind int
+
+const (
+	// KindAction is an action type, routed by action type name.
+	KindAction Kind = iota
+
+	// KindDataSource is a data source type, routed by type name.
+	KindDataSource
+
+	// KindEphemeralResource is an ephemeral resource type, routed by type
+	// name.
+	KindEphemeralResource
+
+	// KindListResource is a list resource type, routed by type name.
+	KindListResource
+
+	// KindFunction is a function, routed by function name.
+	KindFunction
+
+	// KindStateStore is a state store type, routed by type name.
+	KindStateStore
+
+	// KindResource is a managed resource type, routed by type name.
+	KindResource
+)
+
+// Kinds returns all kinds in the order they are processed during server
+// discovery.
+func Kinds() []Kind {
+	return []Kind{
+		KindAction,
+		KindDataSource,
+		KindEphemeralResource,
+		KindListResource,
+		KindFunction,
+		KindStateStore,
+		KindResource,
+	}
+}
+
+// kindText contains the human readable descriptions of a kind which are used
+// in diagnostics.
+type kindText struct {
+	// duplicate is used to describe multiple implementations of the kind,
+	// such as "same resource type".
+	duplicate string
+
+	// implementors is used to describe the kind when stating that only one
+	// underlying server may implement it, such as "Resource types".
+	implementors string
+
+	// name is used to prefix the type name in diagnostic details, such as
+	// "resource type".
+	name string
+
+	// requested is used to describe the kind when it is not implemented,
+	// such as "requested resource type".
+	requested string
+
+	// title is used in the diagnostic summary when the kind is not
+	// implemented, such as "Resource".
+	title string
+}
+
+var kindTexts = map[Kind]kindText{
+	KindAction: {
+		duplicate:    "same action type",
+		implementors: "Actions",
+		name:         "action",
+		requested:    "requested action",
+		title:        "Action",
+	},
+	KindDataSource: {
+		duplicate:    "same data source type",
+		implementors: "Data source types",
+		name:         "data source type",
+		requested:    "requested data source type",
+		title:        "Data Source",
+	},
+	KindEphemeralResource: {
+		duplicate:    "same ephemeral resource type",
+		implementors: "Ephemeral resource types",
+		name:         "ephemeral resource type",
+		requested:    "requested ephemeral resource type",
+		title:        "Ephemeral Resource",
+	},
+	KindListResource: {
+		duplicate:    "same list resource type",
+		implementors: "List resource types",
+		name:         "list resource type",
+		requested:    "requested list resource type",
+		title:        "List Resource",
+	},
+	KindFunction: {
+		duplicate:    "same function name",
+		implementors: "Functions",
+		name:         "function",
+		requested:    "requested function",
+		title:        "Function",
+	},
+	KindStateStore: {
+		duplicate:    "same state store",
+		implementors: "State stores",
+		name:         "state store",
+		requested:    "requested state store",
+		title:        "State Store",
+	},
+	KindResource: {
+		duplicate:    "same resource type",
+		implementors: "Resource types",
+		name:         "resource type",
+		requested:    "requested resource type",
+		title:        "Resource",
+	},
+}
+
+// String returns a human readable description of the kind, such as
+// "resource type".
+func (k Kind) String() string {
+	text, ok := kindTexts[k]
+
+	if !ok {
+		return "unknown"
+	}
+
+	return text.name
+}
+
+// DuplicateSummary is the summary of diagnostics for types implemented by
+// more than one underlying server.
+const DuplicateSummary = "Invalid Provider Server Combination"
+
+// DuplicateDetail returns the detail of the diagnostic for a type of this
+// kind which is implemented by more than one underlying server.
+func (k Kind) DuplicateDetail(name string) string {
+	text := kindTexts[k]
+
+	return "The combined provider has multiple implementations of the " + text.duplicate + " across underlying providers. " +
+		text.implementors + " must be implemented by only one underlying provider. " +
+		"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+		"Duplicate " + text.name + ": " + name
+}
+
+// MissingSummary returns the summary of the diagnostic for a type of this
+// kind which is not implemented by any underlying server.
+func (k Kind) MissingSummary() string {
+	return kindTexts[k].title + " Not Implemented"
+}
+
+// MissingDetail returns the detail of the diagnostic for a type of this kind
+// which is not implemented by any underlying server.
+func (k Kind) MissingDetail(name string) string {
+	text := kindTexts[k]
+
+	return "The combined provider does not implement the " + text.requested + ". " +
+		"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+		"Missing " + text.name + ": " + name
+}
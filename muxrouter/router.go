@@ -0,0 +1,281 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxrouter
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+)
+
+// Protocol contains the protocol version specific functionality of a Router,
+// where S is the caller's handle for an underlying server, such as its index,
+// C is the server capabilities type, and D is the diagnostic type.
+type Protocol[S, C, D any] struct {
+	// Discover returns the types implemented by the given underlying server,
+	// such as by calling its GetMetadata RPC. It is called for each
+	// underlying server, in order, during server discovery. Any error
+	// return aborts the server discovery.
+	Discover func(ctx context.Context, server S) (*ServerTypes[C, D], error)
+
+	// NewErrorDiagnostic returns an error severity diagnostic with the given
+	// summary and detail.
+	NewErrorDiagnostic func(summary string, detail string) D
+
+	// IsErrorDiagnostic returns true if the given diagnostic has an error
+	// severity. The diagnostic may be nil.
+	IsErrorDiagnostic func(diagnostic D) bool
+}
+
+// ServerTypes contains the types implemented by a single underlying server.
+type ServerTypes[C, D any] struct {
+	// Capabilities are the server capabilities of the underlying server,
+	// which are associated with each of its routes.
+	Capabilities C
+
+	// Diagnostics are returned by the underlying server during discovery.
+	Diagnostics []D
+
+	// TypeNames contains the type names implemented by the underlying
+	// server for each kind.
+	TypeNames map[Kind][]string
+}
+
+// Route is the underlying server which implements a type.
+type Route[S, C any] struct {
+	// Server is the underlying server.
+	Server S
+
+	// Capabilities are the server capabilities of the underlying server.
+	Capabilities C
+}
+
+// Router maps type names to the underlying server which implements them,
+// where S is the caller's handle for an underlying server, such as its index,
+// C is the server capabilities type, and D is the diagnostic type. It should always be instantiated by calling
+// New().
+//
+// The routing is published as immutable snapshots, so lookups after server
+// discovery has completed never block, even while new routing is being built
+// concurrently, such as during the GetProviderSchema RPC.
+type Router[S, C, D any] struct {
+	// protocol is the protocol version specific functionality.
+	protocol Protocol[S, C, D]
+
+	// routing is the current routing table snapshot. It is always non-nil
+	// and the routingTable it points to must never be modified.
+	routing atomic.Pointer[routingTable[S, C, D]]
+
+	// serverDiscoveryMutex is a mutex to prevent concurrent server discovery
+	// from calling all underlying servers more than once. It is never held
+	// during routing lookups.
+	serverDiscoveryMutex sync.Mutex
+
+	// servers are the underlying servers, in discovery order.
+	servers []S
+}
+
+// routingTable is an immutable snapshot of the routing for each kind.
+type routingTable[S, C, D any] struct {
+	// routes contains the route for each type name, by kind.
+	routes map[Kind]map[string]Route[S, C]
+
+	// discoveryComplete is whether the underlying server discovery of types
+	// has been completed against all servers. If false during a lookup, the
+	// router needs to pre-emptively perform server discovery so it knows
+	// which underlying server should receive the RPC.
+	discoveryComplete bool
+
+	// discoveryDiagnostics caches diagnostics found during server discovery
+	// so they can be returned for later lookups if necessary.
+	discoveryDiagnostics []D
+}
+
+// New returns a Router for the given protocol and underlying servers. No
+// underlying server is called until the first lookup or until routing is
+// published.
+func New[S, C, D any](protocol Protocol[S, C, D], servers ...S) *Router[S, C, D] {
+	r := &Router[S, C, D]{
+		protocol: protocol,
+		servers:  servers,
+	}
+
+	r.routing.Store(&routingTable[S, C, D]{
+		routes: make(map[Kind]map[string]Route[S, C]),
+	})
+
+	return r
+}
+
+// DuplicateError returns an error diagnostic for a type implemented by more
+// than one underlying server.
+func (r *Router[S, C, D]) DuplicateError(kind Kind, name string) D {
+	return r.protocol.NewErrorDiagnostic(DuplicateSummary, kind.DuplicateDetail(name))
+}
+
+// MissingError returns an error diagnostic for a type which is not
+// implemented by any underlying server.
+func (r *Router[S, C, D]) MissingError(kind Kind, name string) D {
+	return r.protocol.NewErrorDiagnostic(kind.MissingSummary(), kind.MissingDetail(name))
+}
+
+// Lookup returns the route for the given kind and name, performing server
+// discovery first if it has not been completed. The diagnostics contain any
+// server discovery diagnostics or a MissingError diagnostic if no underlying
+// server implements the type. If the diagnostics contain an error, the route
+// must not be used.
+//
+// The error return represents errors returned by Protocol.Discover.
+func (r *Router[S, C, D]) Lookup(ctx context.Context, kind Kind, name string) (Route[S, C], []D, error) {
+	table := r.routing.Load()
+
+	if !table.discoveryComplete {
+		var err error
+
+		table, err = r.serverDiscovery(ctx)
+
+		if err != nil || r.hasError(table.discoveryDiagnostics) {
+			return Route[S, C]{}, table.discoveryDiagnostics, err
+		}
+	}
+
+	route, ok := table.routes[kind][name]
+
+	if !ok {
+		return Route[S, C]{}, []D{r.MissingError(kind, name)}, nil
+	}
+
+	return route, table.discoveryDiagnostics, nil
+}
+
+// NewBuilder returns a Builder for new routing, which can be published with
+// the Publish method.
+func (r *Router[S, C, D]) NewBuilder() *Builder[S, C, D] {
+	return &Builder[S, C, D]{
+		router: r,
+		routes: make(map[Kind]map[string]Route[S, C]),
+	}
+}
+
+// Publish replaces the routing for the given kinds, or all kinds if none are
+// given, with the routes added to the Builder. Existing server discovery
+// diagnostics are preserved. The Builder must not be used after it is
+// published.
+func (r *Router[S, C, D]) Publish(builder *Builder[S, C, D], kinds ...Kind) {
+	if len(kinds) == 0 {
+		kinds = Kinds()
+	}
+
+	r.updateRouting(func(current *routingTable[S, C, D]) *routingTable[S, C, D] {
+		next := &routingTable[S, C, D]{
+			routes:               maps.Clone(current.routes),
+			discoveryComplete:    current.discoveryComplete || builder.discoveryComplete,
+			discoveryDiagnostics: current.discoveryDiagnostics,
+		}
+
+		for _, kind := range kinds {
+			next.routes[kind] = builder.routes[kind]
+		}
+
+		return next
+	})
+}
+
+// Routes returns a copy of the current routes for the given kind. It does not
+// perform server discovery.
+func (r *Router[S, C, D]) Routes(kind Kind) map[string]Route[S, C] {
+	return maps.Clone(r.routing.Load().routes[kind])
+}
+
+// hasError returns true if any of the diagnostics have an error severity.
+func (r *Router[S, C, D]) hasError(diagnostics []D) bool {
+	for _, diagnostic := range diagnostics {
+		if r.protocol.IsErrorDiagnostic(diagnostic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// serverDiscovery will populate the routing for all kinds by calling
+// Protocol.Discover for every underlying server. It is intended to only be
+// called through Lookup. The returned routing table is the one to use for
+// lookups and is always non-nil.
+func (r *Router[S, C, D]) serverDiscovery(ctx context.Context) (*routingTable[S, C, D], error) {
+	r.serverDiscoveryMutex.Lock()
+	defer r.serverDiscoveryMutex.Unlock()
+
+	// Return early if subsequent concurrent operations reached this logic or
+	// routing was published with discovery completed in the meantime.
+	if current := r.routing.Load(); current.discoveryComplete {
+		return current, nil
+	}
+
+	logging.MuxTrace(ctx, "starting underlying server discovery")
+
+	builder := r.NewBuilder()
+	var diagnostics []D
+
+	for _, server := range r.servers {
+		serverTypes, err := r.protocol.Discover(ctx, server)
+
+		if err != nil {
+			return &routingTable[S, C, D]{
+				routes:               builder.routes,
+				discoveryDiagnostics: diagnostics,
+			}, err
+		}
+
+		if serverTypes == nil {
+			continue
+		}
+
+		// Collect all underlying server diagnostics, but skip early return.
+		diagnostics = append(diagnostics, serverTypes.Diagnostics...)
+
+		for _, kind := range Kinds() {
+			for _, name := range serverTypes.TypeNames[kind] {
+				if diagnostic, ok := builder.Add(kind, name, server, serverTypes.Capabilities); !ok {
+					diagnostics = append(diagnostics, diagnostic)
+				}
+			}
+		}
+	}
+
+	discovered := &routingTable[S, C, D]{
+		routes:               builder.routes,
+		discoveryComplete:    true,
+		discoveryDiagnostics: diagnostics,
+	}
+
+	r.updateRouting(func(current *routingTable[S, C, D]) *routingTable[S, C, D] {
+		// Routing may have been published with discovery completed
+		// concurrently, in which case it is preferred.
+		if current.discoveryComplete {
+			return current
+		}
+
+		return discovered
+	})
+
+	return r.routing.Load(), nil
+}
+
+// updateRouting atomically publishes the routing table returned by update,
+// which receives the current routing table. The update function may be called
+// multiple times if other updates are published concurrently, so it must not
+// modify the given routing table or have other side effects.
+func (r *Router[S, C, D]) updateRouting(update func(current *routingTable[S, C, D]) *routingTable[S, C, D]) {
+	for {
+		current := r.routing.Load()
+
+		if r.routing.CompareAndSwap(current, update(current)) {
+			return
+		}
+	}
+}
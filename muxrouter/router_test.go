@@ -0,0 +1,373 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxrouter_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-mux/muxrouter"
+)
+
+// testDiagnostic is a protocol agnostic diagnostic for testing.
+type testDiagnostic struct {
+	Error   bool
+	Summary string
+	Detail  string
+}
+
+// testProtocol returns a muxrouter.Protocol where servers are identified by
+// name and discovery returns the given types for each server name. The number
+// of discovery calls for each server name is recorded in discoverCalls.
+func testProtocol(types map[string]*muxrouter.ServerTypes[bool, *testDiagnostic], discoverCalls map[string]int) muxrouter.Protocol[string, bool, *testDiagnostic] {
+	var mutex sync.Mutex
+
+	return muxrouter.Protocol[string, bool, *testDiagnostic]{
+		Discover: func(_ context.Context, server string) (*muxrouter.ServerTypes[bool, *testDiagnostic], error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			discoverCalls[server]++
+
+			if server == "error" {
+				return nil, errors.New("test discovery error")
+			}
+
+			return types[server], nil
+		},
+		NewErrorDiagnostic: func(summary string, detail string) *testDiagnostic {
+			return &testDiagnostic{
+				Error:   true,
+				Summary: summary,
+				Detail:  detail,
+			}
+		},
+		IsErrorDiagnostic: func(diagnostic *testDiagnostic) bool {
+			return diagnostic != nil && diagnostic.Error
+		},
+	}
+}
+
+func TestRouterLookup(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		servers               []string
+		types                 map[string]*muxrouter.ServerTypes[bool, *testDiagnostic]
+		kind                  muxrouter.Kind
+		name                  string
+		expectedRoute         muxrouter.Route[string, bool]
+		expectedDiagnostics   []*testDiagnostic
+		expectedError         bool
+		expectedDiscoverCalls map[string]int
+	}{
+		"found": {
+			servers: []string{"server1", "server2"},
+			types: map[string]*muxrouter.ServerTypes[bool, *testDiagnostic]{
+				"server1": {
+					TypeNames: map[muxrouter.Kind][]string{
+						muxrouter.KindResource: {"test_resource1"},
+					},
+				},
+				"server2": {
+					Capabilities: true,
+					TypeNames: map[muxrouter.Kind][]string{
+						muxrouter.KindResource: {"test_resource2"},
+					},
+				},
+			},
+			kind: muxrouter.KindResource,
+			name: "test_resource2",
+			expectedRoute: muxrouter.Route[string, bool]{
+				Server:       "server2",
+				Capabilities: true,
+			},
+			expectedDiscoverCalls: map[string]int{
+				"server1": 1,
+				"server2": 1,
+			},
+		},
+		"found-warning-diagnostics": {
+			servers: []string{"server1"},
+			types: map[string]*muxrouter.ServerTypes[bool, *testDiagnostic]{
+				"server1": {
+					Diagnostics: []*testDiagnostic{
+						{
+							Summary: "test warning",
+						},
+					},
+					TypeNames: map[muxrouter.Kind][]string{
+						muxrouter.KindFunction: {"test_function"},
+					},
+				},
+			},
+			kind: muxrouter.KindFunction,
+			name: "test_function",
+			expectedRoute: muxrouter.Route[string, bool]{
+				Server: "server1",
+			},
+			expectedDiagnostics: []*testDiagnostic{
+				{
+					Summary: "test warning",
+				},
+			},
+			expectedDiscoverCalls: map[string]int{
+				"server1": 1,
+			},
+		},
+		"different-kind": {
+			servers: []string{"server1"},
+			types: map[string]*muxrouter.ServerTypes[bool, *testDiagnostic]{
+				"server1": {
+					TypeNames: map[muxrouter.Kind][]string{
+						muxrouter.KindResource: {"test_foo"},
+					},
+				},
+			},
+			kind: muxrouter.KindDataSource,
+			name: "test_foo",
+			expectedDiagnostics: []*testDiagnostic{
+				{
+					Error:   true,
+					Summary: "Data Source Not Implemented",
+					Detail: "The combined provider does not implement the requested data source type. " +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Missing data source type: test_foo",
+				},
+			},
+			expectedDiscoverCalls: map[string]int{
+				"server1": 1,
+			},
+		},
+		"duplicate": {
+			servers: []string{"server1", "server2"},
+			types: map[string]*muxrouter.ServerTypes[bool, *testDiagnostic]{
+				"server1": {
+					TypeNames: map[muxrouter.Kind][]string{
+						muxrouter.KindAction: {"test_action"},
+					},
+				},
+				"server2": {
+					TypeNames: map[muxrouter.Kind][]string{
+						muxrouter.KindAction: {"test_action"},
+					},
+				},
+			},
+			kind: muxrouter.KindAction,
+			name: "test_action",
+			expectedDiagnostics: []*testDiagnostic{
+				{
+					Error:   true,
+					Summary: "Invalid Provider Server Combination",
+					Detail: "The combined provider has multiple implementations of the same action type across underlying providers. " +
+						"Actions must be implemented by only one underlying provider. " +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Duplicate action: test_action",
+				},
+			},
+			expectedDiscoverCalls: map[string]int{
+				"server1": 1,
+				"server2": 1,
+			},
+		},
+		"discover-error": {
+			servers: []string{"server1", "error", "server2"},
+			types: map[string]*muxrouter.ServerTypes[bool, *testDiagnostic]{
+				"server1": {
+					TypeNames: map[muxrouter.Kind][]string{
+						muxrouter.KindResource: {"test_resource"},
+					},
+				},
+			},
+			kind:          muxrouter.KindResource,
+			name:          "test_resource",
+			expectedError: true,
+			expectedDiscoverCalls: map[string]int{
+				"server1": 1,
+				"error":   1,
+			},
+		},
+		"missing": {
+			servers: []string{"server1"},
+			types: map[string]*muxrouter.ServerTypes[bool, *testDiagnostic]{
+				"server1": {
+					TypeNames: map[muxrouter.Kind][]string{
+						muxrouter.KindStateStore: {"test_store"},
+					},
+				},
+			},
+			kind: muxrouter.KindStateStore,
+			name: "test_nonexistent",
+			expectedDiagnostics: []*testDiagnostic{
+				{
+					Error:   true,
+					Summary: "State Store Not Implemented",
+					Detail: "The combined provider does not implement the requested state store. " +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Missing state store: test_nonexistent",
+				},
+			},
+			expectedDiscoverCalls: map[string]int{
+				"server1": 1,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			discoverCalls := make(map[string]int)
+			router := muxrouter.New(testProtocol(testCase.types, discoverCalls), testCase.servers...)
+
+			route, diagnostics, err := router.Lookup(context.Background(), testCase.kind, testCase.name)
+
+			if err != nil {
+				if !testCase.expectedError {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			if err == nil && testCase.expectedError {
+				t.Fatal("expected error, got none")
+			}
+
+			if diff := cmp.Diff(route, testCase.expectedRoute); diff != "" {
+				t.Errorf("unexpected route difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(diagnostics, testCase.expectedDiagnostics); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(discoverCalls, testCase.expectedDiscoverCalls); diff != "" {
+				t.Errorf("unexpected discover calls difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRouterLookup_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	discoverCalls := make(map[string]int)
+	types := map[string]*muxrouter.ServerTypes[bool, *testDiagnostic]{
+		"server1": {
+			TypeNames: map[muxrouter.Kind][]string{
+				muxrouter.KindResource: {"test_resource"},
+			},
+		},
+	}
+	router := muxrouter.New(testProtocol(types, discoverCalls), "server1")
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			route, _, _ := router.Lookup(context.Background(), muxrouter.KindResource, "test_resource")
+
+			if route.Server != "server1" {
+				t.Errorf("expected server1, got: %s", route.Server)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if discoverCalls["server1"] != 1 {
+		t.Errorf("expected server discovery once, got: %d", discoverCalls["server1"])
+	}
+}
+
+func TestRouterPublish(t *testing.T) {
+	t.Parallel()
+
+	discoverCalls := make(map[string]int)
+	router := muxrouter.New(testProtocol(nil, discoverCalls), "server1", "server2")
+
+	builder := router.NewBuilder()
+
+	if _, ok := builder.Add(muxrouter.KindResource, "test_resource", "server1", false); !ok {
+		t.Fatal("unexpected duplicate adding test_resource")
+	}
+
+	if _, ok := builder.Add(muxrouter.KindFunction, "test_function", "server1", false); !ok {
+		t.Fatal("unexpected duplicate adding test_function")
+	}
+
+	expectedDuplicate := &testDiagnostic{
+		Error:   true,
+		Summary: "Invalid Provider Server Combination",
+		Detail: "The combined provider has multiple implementations of the same resource type across underlying providers. " +
+			"Resource types must be implemented by only one underlying provider. " +
+			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+			"Duplicate resource type: test_resource",
+	}
+
+	diagnostic, ok := builder.Add(muxrouter.KindResource, "test_resource", "server2", true)
+
+	if ok {
+		t.Fatal("expected duplicate adding test_resource")
+	}
+
+	if diff := cmp.Diff(diagnostic, expectedDuplicate); diff != "" {
+		t.Errorf("unexpected duplicate diagnostic difference: %s", diff)
+	}
+
+	builder.SetDiscoveryComplete()
+	router.Publish(builder)
+
+	// Only replace the function routing.
+	functionBuilder := router.NewBuilder()
+	functionBuilder.Add(muxrouter.KindFunction, "test_function", "server2", true)
+	router.Publish(functionBuilder, muxrouter.KindFunction)
+
+	expectedRoutes := map[muxrouter.Kind]map[string]muxrouter.Route[string, bool]{
+		muxrouter.KindFunction: {
+			"test_function": {
+				Server:       "server2",
+				Capabilities: true,
+			},
+		},
+		muxrouter.KindResource: {
+			"test_resource": {
+				Server: "server1",
+			},
+		},
+	}
+
+	for kind, expected := range expectedRoutes {
+		if diff := cmp.Diff(router.Routes(kind), expected); diff != "" {
+			t.Errorf("unexpected %s routes difference: %s", kind, diff)
+		}
+
+		for name, expectedRoute := range expected {
+			route, diagnostics, err := router.Lookup(context.Background(), kind, name)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(diagnostics) > 0 {
+				t.Errorf("unexpected diagnostics: %v", diagnostics)
+			}
+
+			if diff := cmp.Diff(route, expectedRoute); diff != "" {
+				t.Errorf("unexpected %s route difference: %s", name, diff)
+			}
+		}
+	}
+
+	if len(discoverCalls) > 0 {
+		t.Errorf("unexpected server discovery after publishing completed discovery: %v", discoverCalls)
+	}
+}
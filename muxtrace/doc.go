@@ -0,0 +1,33 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package muxtrace contains the protocol version agnostic tracing of combined
+// servers and the calls to their underlying servers.
+//
+// The tf5muxserver and tf6muxserver packages create spans through the Tracer
+// interface, when enabled with their WithTracer option:
+//
+//   - A "mux.<RPC>" span, such as "mux.ReadResource", for each RPC of the
+//     combined server.
+//   - A "mux.Discover" span for the discovery of the types implemented by
+//     each underlying server.
+//   - A "downstream.<RPC>" span, such as "downstream.ReadResource", for
+//     each call to an underlying server, including each call of RPCs which
+//     are sent to every underlying server. For streaming RPCs, the span ends
+//     once the stream has ended.
+//   - A "downstream.<RPC>.chunk" span for each element of a stream, such as
+//     each ListResource result or WriteStateBytes request chunk, which covers
+//     passing the element from the producer to the consumer of the stream.
+//
+// The span attributes use the same keys as the mux logging fields, such as
+// tf_rpc and tf_mux_provider, and the keys in this package.
+//
+// Spans are the children of the span in the context. When the context has no
+// span, the W3C Trace Context traceparent header of the incoming gRPC metadata
+// is used as the remote parent, if present, so spans can be part of a trace
+// started by Terraform.
+//
+// The Tracer interface is designed so it can be implemented with an
+// OpenTelemetry tracer. Refer to the NewInMemoryTracer() function for an
+// implementation which keeps the ended spans in memory, such as for testing.
+package muxtrace
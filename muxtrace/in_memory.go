@@ -0,0 +1,166 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxtrace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+var _ Tracer = &InMemoryTracer{}
+
+// InMemoryTracer is a Tracer which keeps ended spans in memory. It should
+// always be instantiated by calling NewInMemoryTracer().
+type InMemoryTracer struct {
+	mu         sync.Mutex
+	lastSpanID uint64
+	spans      []RecordedSpan
+}
+
+// RecordedSpan is an ended span of an InMemoryTracer.
+type RecordedSpan struct {
+	// Name is the span name, such as "mux.ReadResource".
+	Name string
+
+	// SpanContext identifies the span.
+	SpanContext SpanContext
+
+	// Parent identifies the parent span. It is the zero value for spans
+	// without a parent.
+	Parent SpanContext
+
+	// Attributes are the span attributes by key.
+	Attributes map[string]any
+
+	// StartTime is when the span was started.
+	StartTime time.Time
+
+	// EndTime is when the span was ended.
+	EndTime time.Time
+}
+
+// NewInMemoryTracer returns an InMemoryTracer without spans.
+func NewInMemoryTracer() *InMemoryTracer {
+	return &InMemoryTracer{}
+}
+
+// Start creates a span, which is recorded when it is ended. Spans without a
+// parent start a new trace.
+func (t *InMemoryTracer) Start(ctx context.Context, name string, attributes []Attribute) (context.Context, Span) {
+	parent, _ := SpanContextFromContext(ctx)
+	spanContext := SpanContext{
+		TraceID:    parent.TraceID,
+		TraceFlags: parent.TraceFlags,
+	}
+
+	if !parent.IsValid() {
+		_, _ = rand.Read(spanContext.TraceID[:])
+		spanContext.TraceFlags = 0x01
+	}
+
+	t.mu.Lock()
+	t.lastSpanID++
+	binary.BigEndian.PutUint64(spanContext.SpanID[:], t.lastSpanID)
+	t.mu.Unlock()
+
+	span := &inMemorySpan{
+		tracer: t,
+		recorded: RecordedSpan{
+			Name:        name,
+			SpanContext: spanContext,
+			Parent:      parent,
+			Attributes:  make(map[string]any, len(attributes)),
+			StartTime:   time.Now(),
+		},
+	}
+
+	span.SetAttributes(attributes...)
+
+	return ContextWithSpanContext(ctx, spanContext), span
+}
+
+// Spans returns a copy of the ended spans, in the order they were ended.
+func (t *InMemoryTracer) Spans() []RecordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]RecordedSpan, 0, len(t.spans))
+
+	for _, span := range t.spans {
+		span.Attributes = maps.Clone(span.Attributes)
+		result = append(result, span)
+	}
+
+	return result
+}
+
+// SpanNames returns the names of the ended spans, in the order they were
+// ended.
+func (t *InMemoryTracer) SpanNames() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]string, 0, len(t.spans))
+
+	for _, span := range t.spans {
+		result = append(result, span.Name)
+	}
+
+	return result
+}
+
+// Reset removes all ended spans.
+func (t *InMemoryTracer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.spans = slices.Delete(t.spans, 0, len(t.spans))
+}
+
+// inMemorySpan is the Span of an InMemoryTracer.
+type inMemorySpan struct {
+	tracer *InMemoryTracer
+
+	mu       sync.Mutex
+	ended    bool
+	recorded RecordedSpan
+}
+
+func (s *inMemorySpan) SetAttributes(attributes ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ended {
+		return
+	}
+
+	for _, attribute := range attributes {
+		s.recorded.Attributes[attribute.Key] = attribute.Value
+	}
+}
+
+func (s *inMemorySpan) End() {
+	s.mu.Lock()
+
+	if s.ended {
+		s.mu.Unlock()
+
+		return
+	}
+
+	s.ended = true
+	s.recorded.EndTime = time.Now()
+	recorded := s.recorded
+	s.mu.Unlock()
+
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	s.tracer.spans = append(s.tracer.spans, recorded)
+}
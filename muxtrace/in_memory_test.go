@@ -0,0 +1,113 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxtrace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hashicorp/terraform-plugin-mux/muxtrace"
+)
+
+func TestInMemoryTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer := muxtrace.NewInMemoryTracer()
+
+	ctx, parentSpan := muxtrace.Start(context.Background(), tracer, "parent", muxtrace.String("key", "value"))
+	_, childSpan := muxtrace.Start(ctx, tracer, "child")
+
+	childSpan.SetAttributes(muxtrace.Int("count", 2))
+	childSpan.End()
+	childSpan.End()
+	parentSpan.End()
+
+	spans := tracer.Spans()
+
+	if diff := cmp.Diff(tracer.SpanNames(), []string{"child", "parent"}); diff != "" {
+		t.Fatalf("unexpected span names difference: %s", diff)
+	}
+
+	child, parent := spans[0], spans[1]
+
+	if parent.Parent.IsValid() {
+		t.Errorf("expected parent span to not have a parent, got: %v", parent.Parent)
+	}
+
+	if child.Parent != parent.SpanContext {
+		t.Errorf("expected child span parent %v, got: %v", parent.SpanContext, child.Parent)
+	}
+
+	if child.SpanContext.TraceID != parent.SpanContext.TraceID {
+		t.Errorf("expected child span in the parent trace")
+	}
+
+	if diff := cmp.Diff(parent.Attributes, map[string]any{"key": "value"}); diff != "" {
+		t.Errorf("unexpected parent attributes difference: %s", diff)
+	}
+
+	if diff := cmp.Diff(child.Attributes, map[string]any{"count": 2}); diff != "" {
+		t.Errorf("unexpected child attributes difference: %s", diff)
+	}
+
+	if child.EndTime.Before(child.StartTime) {
+		t.Errorf("expected end time after start time")
+	}
+
+	tracer.Reset()
+
+	if got := tracer.Spans(); len(got) != 0 {
+		t.Errorf("expected no spans after reset, got: %v", got)
+	}
+}
+
+func TestStart_IncomingMetadata(t *testing.T) {
+	t.Parallel()
+
+	tracer := muxtrace.NewInMemoryTracer()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+
+	_, span := muxtrace.Start(ctx, tracer, "test")
+	span.End()
+
+	spans := tracer.Spans()
+
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got: %d", len(spans))
+	}
+
+	expectedParent := muxtrace.SpanContext{
+		TraceID:    muxtrace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     muxtrace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: 0x01,
+		Remote:     true,
+	}
+
+	if diff := cmp.Diff(spans[0].Parent, expectedParent); diff != "" {
+		t.Errorf("unexpected parent difference: %s", diff)
+	}
+
+	if spans[0].SpanContext.TraceID != expectedParent.TraceID {
+		t.Errorf("expected span in the remote trace")
+	}
+}
+
+func TestStart_NilTracer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	gotCtx, span := muxtrace.Start(ctx, nil, "test")
+
+	// The span must be usable without a tracer.
+	span.SetAttributes(muxtrace.String("key", "value"))
+	span.End()
+
+	if gotCtx != ctx {
+		t.Errorf("expected context to be unchanged")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxtrace
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// TraceparentHeader is the W3C Trace Context header, which Terraform and
+// OpenTelemetry gRPC instrumentation use to propagate the span context in gRPC
+// metadata.
+const TraceparentHeader = "traceparent"
+
+// TraceID is the W3C Trace Context trace identifier.
+type TraceID [16]byte
+
+// String returns the lowercase hexadecimal encoding of the trace identifier.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanID is the W3C Trace Context span identifier.
+type SpanID [8]byte
+
+// String returns the lowercase hexadecimal encoding of the span identifier.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext identifies a span within a trace.
+type SpanContext struct {
+	// TraceID is the identifier of the trace.
+	TraceID TraceID
+
+	// SpanID is the identifier of the span.
+	SpanID SpanID
+
+	// TraceFlags are the W3C Trace Context trace flags, such as 0x01 for a
+	// sampled trace.
+	TraceFlags byte
+
+	// Remote is true if the span was created in another process, such as
+	// Terraform.
+	Remote bool
+}
+
+// IsValid returns true if the trace and span identifiers are not all zeros.
+func (s SpanContext) IsValid() bool {
+	return s.TraceID != TraceID{} && s.SpanID != SpanID{}
+}
+
+// Traceparent returns the W3C Trace Context traceparent header value.
+func (s SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", s.TraceID, s.SpanID, s.TraceFlags)
+}
+
+// spanContextKey is the context key of the SpanContext.
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a copy of ctx containing the span context.
+func ContextWithSpanContext(ctx context.Context, spanContext SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, spanContext)
+}
+
+// SpanContextFromContext returns the span context in ctx, if any.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	spanContext, ok := ctx.Value(spanContextKey{}).(SpanContext)
+
+	return spanContext, ok
+}
+
+// SpanContextFromIncomingMetadata returns the remote span context of the
+// traceparent header in the incoming gRPC metadata of ctx, if present and
+// valid.
+func SpanContextFromIncomingMetadata(ctx context.Context) (SpanContext, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if !ok {
+		return SpanContext{}, false
+	}
+
+	values := md.Get(TraceparentHeader)
+
+	if len(values) == 0 {
+		return SpanContext{}, false
+	}
+
+	spanContext, err := ParseTraceparent(values[0])
+
+	if err != nil {
+		return SpanContext{}, false
+	}
+
+	spanContext.Remote = true
+
+	return spanContext, true
+}
+
+// ParseTraceparent parses a W3C Trace Context traceparent header value, such
+// as "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func ParseTraceparent(traceparent string) (SpanContext, error) {
+	var spanContext SpanContext
+
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+
+	// Future versions can add fields, so only the version 00 format must
+	// have exactly four fields.
+	if len(parts) < 4 || (parts[0] == "00" && len(parts) != 4) {
+		return spanContext, fmt.Errorf("invalid traceparent %q: expected version-traceid-spanid-flags", traceparent)
+	}
+
+	version, err := hex.DecodeString(parts[0])
+
+	if err != nil || len(version) != 1 || version[0] == 0xff {
+		return spanContext, fmt.Errorf("invalid traceparent %q: invalid version", traceparent)
+	}
+
+	traceID, err := hex.DecodeString(parts[1])
+
+	if err != nil || len(traceID) != len(spanContext.TraceID) {
+		return spanContext, fmt.Errorf("invalid traceparent %q: invalid trace ID", traceparent)
+	}
+
+	spanID, err := hex.DecodeString(parts[2])
+
+	if err != nil || len(spanID) != len(spanContext.SpanID) {
+		return spanContext, fmt.Errorf("invalid traceparent %q: invalid span ID", traceparent)
+	}
+
+	traceFlags, err := hex.DecodeString(parts[3])
+
+	if err != nil || len(traceFlags) != 1 {
+		return spanContext, fmt.Errorf("invalid traceparent %q: invalid trace flags", traceparent)
+	}
+
+	copy(spanContext.TraceID[:], traceID)
+	copy(spanContext.SpanID[:], spanID)
+	spanContext.TraceFlags = traceFlags[0]
+
+	if !spanContext.IsValid() {
+		return SpanContext{}, fmt.Errorf("invalid traceparent %q: all zero trace or span ID", traceparent)
+	}
+
+	return spanContext, nil
+}
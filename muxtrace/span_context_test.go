@@ -0,0 +1,154 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxtrace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hashicorp/terraform-plugin-mux/muxtrace"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		traceparent   string
+		expected      muxtrace.SpanContext
+		expectedError bool
+	}{
+		"valid": {
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expected: muxtrace.SpanContext{
+				TraceID:    muxtrace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:     muxtrace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+				TraceFlags: 0x01,
+			},
+		},
+		"future-version": {
+			traceparent: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra",
+			expected: muxtrace.SpanContext{
+				TraceID: muxtrace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:  muxtrace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			},
+		},
+		"invalid-version": {
+			traceparent:   "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedError: true,
+		},
+		"invalid-version-00-extra": {
+			traceparent:   "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			expectedError: true,
+		},
+		"invalid-trace-id": {
+			traceparent:   "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+			expectedError: true,
+		},
+		"invalid-span-id": {
+			traceparent:   "00-4bf92f3577b34da6a3ce929d0e0e4736-zzf067aa0ba902b7-01",
+			expectedError: true,
+		},
+		"invalid-trace-flags": {
+			traceparent:   "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1",
+			expectedError: true,
+		},
+		"zero-trace-id": {
+			traceparent:   "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			expectedError: true,
+		},
+		"missing-fields": {
+			traceparent:   "00-4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := muxtrace.ParseTraceparent(testCase.traceparent)
+
+			if err != nil {
+				if !testCase.expectedError {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if testCase.expectedError {
+				t.Fatal("expected error, got none")
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected span context difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSpanContextTraceparent(t *testing.T) {
+	t.Parallel()
+
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	spanContext, err := muxtrace.ParseTraceparent(traceparent)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := spanContext.Traceparent(); got != traceparent {
+		t.Errorf("expected %q, got: %q", traceparent, got)
+	}
+}
+
+func TestSpanContextFromIncomingMetadata(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		ctx      context.Context
+		expected muxtrace.SpanContext
+		ok       bool
+	}{
+		"no-metadata": {
+			ctx: context.Background(),
+		},
+		"no-traceparent": {
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("other", "value")),
+		},
+		"invalid-traceparent": {
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", "invalid")),
+		},
+		"traceparent": {
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")),
+			expected: muxtrace.SpanContext{
+				TraceID:    muxtrace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:     muxtrace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+				TraceFlags: 0x01,
+				Remote:     true,
+			},
+			ok: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := muxtrace.SpanContextFromIncomingMetadata(testCase.ctx)
+
+			if ok != testCase.ok {
+				t.Fatalf("expected ok %t, got: %t", testCase.ok, ok)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected span context difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package muxtrace
+
+import (
+	"context"
+)
+
+// Span attribute keys, in addition to the mux logging field keys.
+const (
+	// AttributeKeyTypeName is the action, data source, ephemeral resource,
+	// function, list resource, resource, or state store type name which the
+	// request was routed by.
+	AttributeKeyTypeName = "tf_type_name"
+
+	// AttributeKeyDiagnosticErrorCount is the number of error severity
+	// diagnostics in the response or stream element.
+	AttributeKeyDiagnosticErrorCount = "diagnostic_error_count"
+
+	// AttributeKeyDiagnosticWarningCount is the number of warning severity
+	// diagnostics in the response or stream element.
+	AttributeKeyDiagnosticWarningCount = "diagnostic_warning_count"
+
+	// AttributeKeyStreamChunk is the zero-based index of the element in the
+	// stream.
+	AttributeKeyStreamChunk = "tf_stream_chunk"
+
+	// AttributeKeyError is the gRPC error returned by the call.
+	AttributeKeyError = "error"
+)
+
+// Tracer creates spans. Implementations must be safe for concurrent use.
+type Tracer interface {
+	// Start creates a span, which is a child of the span context in ctx, if
+	// any. The returned context must contain the span context of the new
+	// span, such as by calling ContextWithSpanContext.
+	Start(ctx context.Context, name string, attributes []Attribute) (context.Context, Span)
+}
+
+// Span is a single operation within a trace.
+type Span interface {
+	// SetAttributes adds or replaces attributes of the span.
+	SetAttributes(attributes ...Attribute)
+
+	// End completes the span. Any calls after the first are ignored.
+	End()
+}
+
+// Attribute is a key and value pair of a span.
+type Attribute struct {
+	// Key is the attribute key, such as "tf_rpc".
+	Key string
+
+	// Value is the attribute value, which is either a string or an int.
+	Value any
+}
+
+// String returns a string Attribute.
+func String(key string, value string) Attribute {
+	return Attribute{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// Int returns an int Attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// Start calls tracer.Start, after adding the span context of the incoming
+// gRPC metadata to ctx when ctx has no span context. If tracer is nil, ctx and
+// a span which does nothing are returned.
+func Start(ctx context.Context, tracer Tracer, name string, attributes ...Attribute) (context.Context, Span) {
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	if _, ok := SpanContextFromContext(ctx); !ok {
+		if remote, ok := SpanContextFromIncomingMetadata(ctx); ok {
+			ctx = ContextWithSpanContext(ctx, remote)
+		}
+	}
+
+	return tracer.Start(ctx, name, attributes)
+}
+
+// noopSpan is the Span returned by Start without a Tracer.
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+
+func (noopSpan) End() {}
@@ -0,0 +1,782 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+)
+
+// WithConsistencyChecks is a NewMuxServerWithOptions option which checks the
+// PlanResourceChange and ApplyResourceChange responses of underlying servers
+// with the rules Terraform uses to reject them, so errors such as "Provider
+// produced invalid plan" and "Provider produced inconsistent result after
+// apply" are found in unit tests rather than acceptance tests. Violations are
+// returned as an error diagnostic for each invalid value, with the attribute
+// path of the value.
+//
+// The planned state of PlanResourceChange responses is checked so that:
+//
+//   - Values of attributes which are not computed match the configuration,
+//     or the prior state. Values of optional and computed attributes only
+//     need to match if they are configured.
+//   - Nested blocks match the configuration. Elements of set nested blocks
+//     are not compared, as they cannot be correlated with the configuration.
+//   - Values of write-only attributes are null.
+//   - Each RequiresReplace path exists in the resource schema.
+//
+// The new state of ApplyResourceChange responses is checked so that known
+// values of the planned state are kept and values of write-only attributes
+// are null.
+//
+// When a resource is planned again before it is applied, as Terraform does
+// before applying a saved plan, the planned state is also checked to keep the
+// known values of the earlier plan if the known values of the configuration
+// were kept. The earlier plan is that of the last PlanResourceChange call on
+// the mux server with the same type name and prior state. Plans of resources
+// which are created are not compared, as instances of count and for_each with
+// the same configuration have the same null prior state and cannot be told
+// apart. Only the plans of the most recently planned resources are kept.
+//
+// Responses are only checked if they have no error diagnostics and the
+// resource schema is known. Schemas are fetched as they are for
+// WithResponseValidation.
+func WithConsistencyChecks() MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.consistencyChecks = true
+	}
+}
+
+// maxPlannedResources is the number of plans kept by consistencyChecker,
+// which bounds its memory when plans are not applied, such as in plan only
+// runs.
+const maxPlannedResources = 10000
+
+// consistencyChecker is the Interceptor added by WithConsistencyChecks, which
+// tracks the plans of each resource until they are applied.
+type consistencyChecker struct {
+	schemas *schemaCache
+
+	// mu guards plans and planOrder, which has the key of each plan from
+	// the least to the most recently planned.
+	mu        sync.Mutex
+	plans     map[plannedResourceKey]plannedResource
+	planOrder *list.List
+}
+
+// plannedResourceKey identifies a planned resource of an underlying server.
+type plannedResourceKey struct {
+	serverIndex int
+	typeName    string
+	priorState  string
+}
+
+// plannedResource is the configuration and planned state of a
+// PlanResourceChange call.
+type plannedResource struct {
+	config       tftypes.Value
+	plannedState tftypes.Value
+
+	// order is the element of the key in planOrder.
+	order *list.Element
+}
+
+func newConsistencyChecker(schemas *schemaCache) *consistencyChecker {
+	return &consistencyChecker{
+		planOrder: list.New(),
+		plans:     make(map[plannedResourceKey]plannedResource),
+		schemas:   schemas,
+	}
+}
+
+func (c *consistencyChecker) intercept(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+	resp, err := handler(ctx, req)
+
+	if err != nil {
+		return resp, err
+	}
+
+	switch typedResp := resp.(type) {
+	case *tfprotov5.ApplyResourceChangeResponse:
+		typedReq, ok := req.(*tfprotov5.ApplyResourceChangeRequest)
+
+		if ok && typedResp != nil && !diagnosticsHasError(typedResp.Diagnostics) {
+			typedResp.Diagnostics = append(typedResp.Diagnostics, c.checkApply(ctx, info, typedReq, typedResp)...)
+		}
+	case *tfprotov5.PlanResourceChangeResponse:
+		typedReq, ok := req.(*tfprotov5.PlanResourceChangeRequest)
+
+		if ok && typedResp != nil && !diagnosticsHasError(typedResp.Diagnostics) {
+			typedResp.Diagnostics = append(typedResp.Diagnostics, c.checkPlan(ctx, info, typedReq, typedResp)...)
+		}
+	}
+
+	return resp, err
+}
+
+// checkApply returns an error diagnostic for each value of the new state which
+// Terraform would reject, and forgets the plan of the resource.
+func (c *consistencyChecker) checkApply(ctx context.Context, info *CallInfo, req *tfprotov5.ApplyResourceChangeRequest, resp *tfprotov5.ApplyResourceChangeResponse) []*tfprotov5.Diagnostic {
+	schema := c.resourceSchema(ctx, info)
+
+	if schema == nil {
+		return nil
+	}
+
+	typ := schema.ValueType()
+	priorState, priorErr := decodeValue(req.PriorState, typ)
+	plannedState, plannedErr := decodeValue(req.PlannedState, typ)
+	newState, newErr := decodeValue(resp.NewState, typ)
+
+	if priorErr == nil && !priorState.IsNull() {
+		c.mu.Lock()
+		c.forgetPlan(c.planKey(info, priorState))
+		c.mu.Unlock()
+	}
+
+	// Invalid values are returned by WithResponseValidation.
+	if plannedErr != nil || newErr != nil {
+		return nil
+	}
+
+	var errs []consistencyError
+
+	errs = append(errs, compatibleBlockErrors(tftypes.NewAttributePath(), schema.Block, plannedState, newState)...)
+	errs = append(errs, writeOnlyErrors(tftypes.NewAttributePath(), schema.Block, newState)...)
+
+	return inconsistentResponseDiagnostics(info, "Provider Produced Inconsistent Result After Apply", errs)
+}
+
+// checkPlan returns an error diagnostic for each value of the planned state
+// which Terraform would reject, and tracks the plan of the resource.
+func (c *consistencyChecker) checkPlan(ctx context.Context, info *CallInfo, req *tfprotov5.PlanResourceChangeRequest, resp *tfprotov5.PlanResourceChangeResponse) []*tfprotov5.Diagnostic {
+	schema := c.resourceSchema(ctx, info)
+
+	if schema == nil {
+		return nil
+	}
+
+	typ := schema.ValueType()
+	priorState, priorErr := decodeValue(req.PriorState, typ)
+	config, configErr := decodeValue(req.Config, typ)
+	plannedState, plannedErr := decodeValue(resp.PlannedState, typ)
+
+	// Invalid values are returned by WithResponseValidation.
+	if priorErr != nil || configErr != nil || plannedErr != nil {
+		return nil
+	}
+
+	var errs []consistencyError
+
+	errs = append(errs, plannedObjectErrors(tftypes.NewAttributePath(), schema.Block, priorState, config, plannedState)...)
+	errs = append(errs, writeOnlyErrors(tftypes.NewAttributePath(), schema.Block, plannedState)...)
+
+	for _, path := range resp.RequiresReplace {
+		if _, _, err := tftypes.WalkAttributePath(typ, path); err != nil {
+			errs = append(errs, consistencyError{
+				message: fmt.Sprintf("RequiresReplace path %s does not exist in the resource schema", path),
+			})
+		}
+	}
+
+	diagnostics := inconsistentResponseDiagnostics(info, "Provider Produced Invalid Plan", errs)
+
+	// Plans of resources which are created are not tracked, as their key
+	// is not unique to the resource.
+	if priorState.IsNull() {
+		return diagnostics
+	}
+
+	key := c.planKey(info, priorState)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	earlier, ok := c.plans[key]
+
+	c.forgetPlan(key)
+
+	// Destroy plans are not tracked, as they are not planned again.
+	if plannedState.IsNull() {
+		return diagnostics
+	}
+
+	c.plans[key] = plannedResource{
+		config:       config,
+		plannedState: plannedState,
+		order:        c.planOrder.PushBack(key),
+	}
+
+	if c.planOrder.Len() > maxPlannedResources {
+		oldest, _ := c.planOrder.Front().Value.(plannedResourceKey)
+
+		c.forgetPlan(oldest)
+	}
+
+	// The earlier plan is only compared if this plan is of the same
+	// configuration, with values which may have become known since.
+	if !ok || len(compatibleValueErrors(tftypes.NewAttributePath(), earlier.config, config, false)) > 0 {
+		return diagnostics
+	}
+
+	finalErrs := compatibleBlockErrors(tftypes.NewAttributePath(), schema.Block, earlier.plannedState, plannedState)
+
+	return append(diagnostics, inconsistentResponseDiagnostics(info, "Provider Produced Inconsistent Final Plan", finalErrs)...)
+}
+
+// forgetPlan removes the plan with the key, if any. The caller must hold mu.
+func (c *consistencyChecker) forgetPlan(key plannedResourceKey) {
+	plan, ok := c.plans[key]
+
+	if !ok {
+		return
+	}
+
+	c.planOrder.Remove(plan.order)
+	delete(c.plans, key)
+}
+
+// planKey returns the key of the plan of the resource with the prior state.
+func (c *consistencyChecker) planKey(info *CallInfo, priorState tftypes.Value) plannedResourceKey {
+	return plannedResourceKey{
+		serverIndex: info.serverIndex,
+		typeName:    info.TypeName,
+		priorState:  priorState.String(),
+	}
+}
+
+// resourceSchema returns the schema of the resource of the call, or nil if it
+// is unknown. Errors fetching the schemas are logged, as they should not
+// affect the call.
+func (c *consistencyChecker) resourceSchema(ctx context.Context, info *CallInfo) *tfprotov5.Schema {
+	schemas, err := c.schemas.get(ctx, info)
+
+	if err != nil {
+		logging.MuxError(ctx, "error fetching schemas for consistency checks", map[string]interface{}{logging.KeyError: err.Error()})
+	}
+
+	return schemas.resourceSchema(info.TypeName)
+}
+
+// consistencyError is a value which Terraform would reject.
+type consistencyError struct {
+	path    *tftypes.AttributePath
+	message string
+}
+
+func (e consistencyError) Error() string {
+	if e.path == nil || len(e.path.Steps()) == 0 {
+		return e.message
+	}
+
+	return e.path.String() + ": " + e.message
+}
+
+// newConsistencyError returns a consistencyError with the message formatted
+// with the arguments.
+func newConsistencyError(path *tftypes.AttributePath, format string, args ...any) consistencyError {
+	return consistencyError{
+		path:    path,
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// plannedObjectErrors returns the errors of a planned object of the block,
+// following the rules of Terraform for planned values. The prior and config
+// values are objects of the block, which may be null.
+func plannedObjectErrors(path *tftypes.AttributePath, block *tfprotov5.SchemaBlock, prior, config, planned tftypes.Value) []consistencyError {
+	if planned.IsNull() && !config.IsNull() {
+		return []consistencyError{newConsistencyError(path, "planned for absence but config wants existence")}
+	}
+
+	if config.IsNull() && !planned.IsNull() {
+		return []consistencyError{newConsistencyError(path, "planned for existence but config wants absence")}
+	}
+
+	if planned.IsNull() || block == nil {
+		return nil
+	}
+
+	var errs []consistencyError
+
+	for _, attribute := range block.Attributes {
+		if attribute == nil {
+			continue
+		}
+
+		attributePath := path.WithAttributeName(attribute.Name)
+		typ := attribute.ValueType()
+
+		errs = append(errs, plannedAttributeErrors(
+			attributePath,
+			attribute,
+			objectAttribute(prior, attribute.Name, typ),
+			objectAttribute(config, attribute.Name, typ),
+			objectAttribute(planned, attribute.Name, typ),
+		)...)
+	}
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock == nil {
+			continue
+		}
+
+		nestedBlockPath := path.WithAttributeName(nestedBlock.TypeName)
+		typ := nestedBlock.ValueType()
+
+		errs = append(errs, plannedNestedBlockErrors(
+			nestedBlockPath,
+			nestedBlock,
+			objectAttribute(prior, nestedBlock.TypeName, typ),
+			objectAttribute(config, nestedBlock.TypeName, typ),
+			objectAttribute(planned, nestedBlock.TypeName, typ),
+		)...)
+	}
+
+	return errs
+}
+
+// plannedAttributeErrors returns the errors of a planned attribute value.
+func plannedAttributeErrors(path *tftypes.AttributePath, attribute *tfprotov5.SchemaAttribute, prior, config, planned tftypes.Value) []consistencyError {
+	// Values of write-only attributes are checked by writeOnlyErrors.
+	if attribute.WriteOnly {
+		return nil
+	}
+
+	if planned.Equal(config) {
+		return nil
+	}
+
+	// The provider may keep the prior value, such as when it is
+	// semantically equal to the configuration.
+	if planned.Equal(prior) && !prior.IsNull() && !config.IsNull() {
+		return nil
+	}
+
+	switch {
+	case attribute.Computed && !attribute.Optional:
+		return nil
+	case attribute.Computed && config.IsNull():
+		return nil
+	case config.IsNull():
+		if attribute.Sensitive {
+			return []consistencyError{newConsistencyError(path, "planned value for a non-computed attribute")}
+		}
+
+		return []consistencyError{newConsistencyError(path, "planned value %s for a non-computed attribute", planned)}
+	}
+
+	if prior.IsNull() {
+		if attribute.Sensitive {
+			return []consistencyError{newConsistencyError(path, "sensitive planned value does not match config value")}
+		}
+
+		return []consistencyError{newConsistencyError(path, "planned value %s does not match config value %s", planned, config)}
+	}
+
+	if attribute.Sensitive {
+		return []consistencyError{newConsistencyError(path, "sensitive planned value does not match config value nor prior value")}
+	}
+
+	return []consistencyError{newConsistencyError(path, "planned value %s does not match config value %s nor prior value %s", planned, config, prior)}
+}
+
+// plannedNestedBlockErrors returns the errors of the planned value of a
+// nested block.
+func plannedNestedBlockErrors(path *tftypes.AttributePath, nestedBlock *tfprotov5.SchemaNestedBlock, prior, config, planned tftypes.Value) []consistencyError {
+	if planned.Equal(config) {
+		return nil
+	}
+
+	// Unknown configuration is a dynamic block with an unknown for_each
+	// value, which the provider cannot change.
+	if !config.IsKnown() {
+		return []consistencyError{newConsistencyError(path, "planned value %s for unknown dynamic block", planned)}
+	}
+
+	if !planned.IsKnown() {
+		return []consistencyError{newConsistencyError(path, "attribute representing nested block must not be unknown itself; set nested attribute values to unknown instead")}
+	}
+
+	switch nestedBlock.Nesting {
+	case tfprotov5.SchemaNestedBlockNestingModeSingle, tfprotov5.SchemaNestedBlockNestingModeGroup:
+		return plannedObjectErrors(path, nestedBlock.Block, prior, config, planned)
+	case tfprotov5.SchemaNestedBlockNestingModeList:
+		if planned.IsNull() {
+			return []consistencyError{newConsistencyError(path, "attribute representing a list of nested blocks must be empty to indicate no blocks, not null")}
+		}
+
+		plannedElements := listElements(planned)
+		configElements := listElements(config)
+		priorElements := listElements(prior)
+
+		if len(plannedElements) != len(configElements) {
+			return []consistencyError{newConsistencyError(path, "block count in plan (%d) disagrees with count in config (%d)", len(plannedElements), len(configElements))}
+		}
+
+		var errs []consistencyError
+
+		for index, plannedElement := range plannedElements {
+			elementPath := path.WithElementKeyInt(index)
+
+			if !plannedElement.IsKnown() {
+				errs = append(errs, newConsistencyError(elementPath, "element representing nested block must not be unknown itself; set nested attribute values to unknown instead"))
+
+				continue
+			}
+
+			priorElement := tftypes.NewValue(nestedBlock.Block.ValueType(), nil)
+
+			if index < len(priorElements) {
+				priorElement = priorElements[index]
+			}
+
+			errs = append(errs, plannedObjectErrors(elementPath, nestedBlock.Block, priorElement, configElements[index], plannedElement)...)
+		}
+
+		return errs
+	case tfprotov5.SchemaNestedBlockNestingModeMap:
+		if planned.IsNull() {
+			return []consistencyError{newConsistencyError(path, "attribute representing a map of nested blocks must be empty to indicate no blocks, not null")}
+		}
+
+		plannedElements := mapElements(planned)
+		configElements := mapElements(config)
+		priorElements := mapElements(prior)
+
+		var errs []consistencyError
+
+		for _, key := range slices.Sorted(maps.Keys(plannedElements)) {
+			elementPath := path.WithElementKeyString(key)
+			plannedElement := plannedElements[key]
+
+			if !plannedElement.IsKnown() {
+				errs = append(errs, newConsistencyError(elementPath, "element representing nested block must not be unknown itself; set nested attribute values to unknown instead"))
+
+				continue
+			}
+
+			configElement, ok := configElements[key]
+
+			if !ok {
+				errs = append(errs, newConsistencyError(elementPath, "block key %q from plan is not present in config", key))
+
+				continue
+			}
+
+			priorElement, ok := priorElements[key]
+
+			if !ok {
+				priorElement = tftypes.NewValue(nestedBlock.Block.ValueType(), nil)
+			}
+
+			errs = append(errs, plannedObjectErrors(elementPath, nestedBlock.Block, priorElement, configElement, plannedElement)...)
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(configElements)) {
+			if _, ok := plannedElements[key]; !ok {
+				errs = append(errs, newConsistencyError(path.WithElementKeyString(key), "block key %q from config is not present in plan", key))
+			}
+		}
+
+		return errs
+	case tfprotov5.SchemaNestedBlockNestingModeSet:
+		// Elements of sets cannot be correlated with the configuration,
+		// so only unknown elements are rejected.
+		var errs []consistencyError
+
+		for _, plannedElement := range listElements(planned) {
+			if !plannedElement.IsKnown() {
+				errs = append(errs, newConsistencyError(path.WithElementKeyValue(plannedElement), "element representing nested block must not be unknown itself; set nested attribute values to unknown instead"))
+			}
+		}
+
+		return errs
+	}
+
+	return nil
+}
+
+// compatibleBlockErrors returns the errors of an object of the block which
+// does not keep the known values of the planned object, following the rules
+// of Terraform for new states after apply and final plans.
+func compatibleBlockErrors(path *tftypes.AttributePath, block *tfprotov5.SchemaBlock, planned, actual tftypes.Value) []consistencyError {
+	if !planned.IsKnown() || planned.IsNull() || actual.IsNull() || !actual.IsKnown() || block == nil {
+		return compatibleValueErrors(path, planned, actual, false)
+	}
+
+	var errs []consistencyError
+
+	for _, attribute := range block.Attributes {
+		// Values of write-only attributes are checked by writeOnlyErrors.
+		if attribute == nil || attribute.WriteOnly {
+			continue
+		}
+
+		typ := attribute.ValueType()
+
+		errs = append(errs, compatibleValueErrors(
+			path.WithAttributeName(attribute.Name),
+			objectAttribute(planned, attribute.Name, typ),
+			objectAttribute(actual, attribute.Name, typ),
+			attribute.Sensitive,
+		)...)
+	}
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock == nil {
+			continue
+		}
+
+		nestedBlockPath := path.WithAttributeName(nestedBlock.TypeName)
+		typ := nestedBlock.ValueType()
+		plannedValue := objectAttribute(planned, nestedBlock.TypeName, typ)
+		actualValue := objectAttribute(actual, nestedBlock.TypeName, typ)
+
+		switch nestedBlock.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeSingle, tfprotov5.SchemaNestedBlockNestingModeGroup:
+			errs = append(errs, compatibleBlockErrors(nestedBlockPath, nestedBlock.Block, plannedValue, actualValue)...)
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			if !plannedValue.IsKnown() || !actualValue.IsKnown() {
+				errs = append(errs, compatibleValueErrors(nestedBlockPath, plannedValue, actualValue, false)...)
+
+				continue
+			}
+
+			plannedElements := listElements(plannedValue)
+			actualElements := listElements(actualValue)
+
+			if len(plannedElements) != len(actualElements) {
+				errs = append(errs, newConsistencyError(nestedBlockPath, "block count changed from %d to %d", len(plannedElements), len(actualElements)))
+
+				continue
+			}
+
+			for index, plannedElement := range plannedElements {
+				errs = append(errs, compatibleBlockErrors(nestedBlockPath.WithElementKeyInt(index), nestedBlock.Block, plannedElement, actualElements[index])...)
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeMap:
+			if !plannedValue.IsKnown() || !actualValue.IsKnown() {
+				errs = append(errs, compatibleValueErrors(nestedBlockPath, plannedValue, actualValue, false)...)
+
+				continue
+			}
+
+			plannedElements := mapElements(plannedValue)
+			actualElements := mapElements(actualValue)
+
+			for _, key := range slices.Sorted(maps.Keys(plannedElements)) {
+				actualElement, ok := actualElements[key]
+
+				if !ok {
+					errs = append(errs, newConsistencyError(nestedBlockPath, "block key %q has vanished", key))
+
+					continue
+				}
+
+				errs = append(errs, compatibleBlockErrors(nestedBlockPath.WithElementKeyString(key), nestedBlock.Block, plannedElements[key], actualElement)...)
+			}
+
+			for _, key := range slices.Sorted(maps.Keys(actualElements)) {
+				if _, ok := plannedElements[key]; !ok {
+					errs = append(errs, newConsistencyError(nestedBlockPath, "new block key %q has appeared", key))
+				}
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			errs = append(errs, compatibleValueErrors(nestedBlockPath, plannedValue, actualValue, false)...)
+		}
+	}
+
+	return errs
+}
+
+// compatibleValueErrors returns the errors of a value which does not keep the
+// known values of the planned value. Values of sensitive attributes are not
+// included in the errors.
+func compatibleValueErrors(path *tftypes.AttributePath, planned, actual tftypes.Value, sensitive bool) []consistencyError {
+	// Unknown values may become any value.
+	if !planned.IsKnown() {
+		return nil
+	}
+
+	inconsistent := func() []consistencyError {
+		if sensitive {
+			return []consistencyError{newConsistencyError(path, "inconsistent values for sensitive attribute")}
+		}
+
+		return []consistencyError{newConsistencyError(path, "was %s, but now %s", planned, actual)}
+	}
+
+	if planned.IsFullyKnown() || planned.IsNull() || actual.IsNull() || !actual.IsKnown() {
+		if planned.Equal(actual) {
+			return nil
+		}
+
+		// Elements of sets with unknown values cannot be correlated with
+		// the elements of the actual set.
+		if _, ok := planned.Type().(tftypes.Set); ok && !planned.IsFullyKnown() && !actual.IsNull() && actual.IsKnown() {
+			return nil
+		}
+
+		return inconsistent()
+	}
+
+	var errs []consistencyError
+
+	switch planned.Type().(type) {
+	case tftypes.List, tftypes.Tuple:
+		plannedElements := listElements(planned)
+		actualElements := listElements(actual)
+
+		if len(plannedElements) != len(actualElements) {
+			return []consistencyError{newConsistencyError(path, "length changed from %d to %d", len(plannedElements), len(actualElements))}
+		}
+
+		for index, plannedElement := range plannedElements {
+			errs = append(errs, compatibleValueErrors(path.WithElementKeyInt(index), plannedElement, actualElements[index], sensitive)...)
+		}
+	case tftypes.Map:
+		plannedElements := mapElements(planned)
+		actualElements := mapElements(actual)
+
+		for _, key := range slices.Sorted(maps.Keys(plannedElements)) {
+			actualElement, ok := actualElements[key]
+
+			if !ok {
+				errs = append(errs, newConsistencyError(path, "element %q has vanished", key))
+
+				continue
+			}
+
+			errs = append(errs, compatibleValueErrors(path.WithElementKeyString(key), plannedElements[key], actualElement, sensitive)...)
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(actualElements)) {
+			if _, ok := plannedElements[key]; !ok {
+				errs = append(errs, newConsistencyError(path, "new element %q has appeared", key))
+			}
+		}
+	case tftypes.Object:
+		plannedAttributes := mapElements(planned)
+		actualAttributes := mapElements(actual)
+
+		for _, name := range slices.Sorted(maps.Keys(plannedAttributes)) {
+			errs = append(errs, compatibleValueErrors(path.WithAttributeName(name), plannedAttributes[name], actualAttributes[name], sensitive)...)
+		}
+	}
+
+	return errs
+}
+
+// writeOnlyErrors returns the errors of values of write-only attributes which
+// are not null in an object of the block.
+func writeOnlyErrors(path *tftypes.AttributePath, block *tfprotov5.SchemaBlock, value tftypes.Value) []consistencyError {
+	if !value.IsKnown() || value.IsNull() || block == nil {
+		return nil
+	}
+
+	var errs []consistencyError
+
+	for _, attribute := range block.Attributes {
+		if attribute == nil || !attribute.WriteOnly {
+			continue
+		}
+
+		if !objectAttribute(value, attribute.Name, attribute.ValueType()).IsNull() {
+			errs = append(errs, newConsistencyError(path.WithAttributeName(attribute.Name), "value for write-only attribute must be null"))
+		}
+	}
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock == nil {
+			continue
+		}
+
+		nestedBlockPath := path.WithAttributeName(nestedBlock.TypeName)
+		nestedBlockValue := objectAttribute(value, nestedBlock.TypeName, nestedBlock.ValueType())
+
+		if !nestedBlockValue.IsKnown() {
+			continue
+		}
+
+		switch nestedBlock.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeSingle, tfprotov5.SchemaNestedBlockNestingModeGroup:
+			errs = append(errs, writeOnlyErrors(nestedBlockPath, nestedBlock.Block, nestedBlockValue)...)
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			for index, element := range listElements(nestedBlockValue) {
+				errs = append(errs, writeOnlyErrors(nestedBlockPath.WithElementKeyInt(index), nestedBlock.Block, element)...)
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeMap:
+			elements := mapElements(nestedBlockValue)
+
+			for _, key := range slices.Sorted(maps.Keys(elements)) {
+				errs = append(errs, writeOnlyErrors(nestedBlockPath.WithElementKeyString(key), nestedBlock.Block, elements[key])...)
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			for _, element := range listElements(nestedBlockValue) {
+				errs = append(errs, writeOnlyErrors(nestedBlockPath.WithElementKeyValue(element), nestedBlock.Block, element)...)
+			}
+		}
+	}
+
+	return errs
+}
+
+// decodeValue returns the value of the DynamicValue, or a null value if it is
+// missing.
+func decodeValue(value *tfprotov5.DynamicValue, typ tftypes.Type) (tftypes.Value, error) {
+	if value == nil {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	return value.Unmarshal(typ)
+}
+
+// listElements returns the elements of a known list, set, or tuple value.
+func listElements(value tftypes.Value) []tftypes.Value {
+	var elements []tftypes.Value
+
+	if !value.IsKnown() || value.As(&elements) != nil {
+		return nil
+	}
+
+	return elements
+}
+
+// mapElements returns the elements of a known map value or the attributes of
+// a known object value.
+func mapElements(value tftypes.Value) map[string]tftypes.Value {
+	var elements map[string]tftypes.Value
+
+	if !value.IsKnown() || value.As(&elements) != nil {
+		return nil
+	}
+
+	return elements
+}
+
+// objectAttribute returns the attribute of an object value, or a null or
+// unknown value of the type if the object is null or unknown.
+func objectAttribute(object tftypes.Value, name string, typ tftypes.Type) tftypes.Value {
+	if !object.IsKnown() {
+		return tftypes.NewValue(typ, tftypes.UnknownValue)
+	}
+
+	value, ok := mapElements(object)[name]
+
+	if !ok {
+		return tftypes.NewValue(typ, nil)
+	}
+
+	return value
+}
@@ -0,0 +1,437 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5dynamicvalue"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+var consistencyTestType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"description": tftypes.String,
+		"id":          tftypes.String,
+		"name":        tftypes.String,
+		"password":    tftypes.String,
+	},
+}
+
+// newConsistencyTestServer returns a test server with the test_resource
+// schema. Tests set the PlanResourceChangeFunc and ApplyResourceChangeFunc
+// hooks to return its planned state and new state.
+func newConsistencyTestServer() *tf5testserver.TestServer {
+	return &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource": {
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:     "description",
+								Type:     tftypes.String,
+								Optional: true,
+								Computed: true,
+							},
+							{
+								Name:     "id",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "name",
+								Type:     tftypes.String,
+								Required: true,
+							},
+							{
+								Name:      "password",
+								Type:      tftypes.String,
+								Optional:  true,
+								Sensitive: true,
+								WriteOnly: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// consistencyTestValue returns a test_resource value with the id, name, and
+// password.
+func consistencyTestValue(id any, name any, password any) *tfprotov5.DynamicValue {
+	return tf5dynamicvalue.Must(consistencyTestType, tftypes.NewValue(consistencyTestType, map[string]tftypes.Value{
+		"description": tftypes.NewValue(tftypes.String, nil),
+		"id":          tftypes.NewValue(tftypes.String, id),
+		"name":        tftypes.NewValue(tftypes.String, name),
+		"password":    tftypes.NewValue(tftypes.String, password),
+	}))
+}
+
+func TestWithConsistencyChecks(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		newState        *tfprotov5.DynamicValue
+		plannedState    *tfprotov5.DynamicValue
+		requiresReplace []*tftypes.AttributePath
+		call            func(context.Context, tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error)
+		expected        []*tfprotov5.Diagnostic
+	}{
+		"ApplyResourceChange-valid": {
+			newState: consistencyTestValue("test-id", "test-name", nil),
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+					TypeName:     "test_resource",
+					PlannedState: consistencyTestValue(tftypes.UnknownValue, "test-name", nil),
+				})
+
+				return resp.Diagnostics, err
+			},
+		},
+		"ApplyResourceChange-known-value-changed": {
+			newState: consistencyTestValue("test-id", "changed-name", nil),
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+					TypeName:     "test_resource",
+					PlannedState: consistencyTestValue(tftypes.UnknownValue, "test-name", nil),
+				})
+
+				return resp.Diagnostics, err
+			},
+			expected: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Provider Produced Inconsistent Result After Apply",
+					Detail: `An underlying provider server returned a response for "test_resource" in the ApplyResourceChange RPC which Terraform would reject. ` +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf5testserver.TestServer\n" +
+						`Error: AttributeName("name"): was tftypes.String<"test-name">, but now tftypes.String<"changed-name">`,
+					Attribute: tftypes.NewAttributePath().WithAttributeName("name"),
+				},
+			},
+		},
+		"ApplyResourceChange-write-only": {
+			newState: consistencyTestValue("test-id", "test-name", "secret"),
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+					TypeName:     "test_resource",
+					PlannedState: consistencyTestValue(tftypes.UnknownValue, "test-name", nil),
+				})
+
+				return resp.Diagnostics, err
+			},
+			expected: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Provider Produced Inconsistent Result After Apply",
+					Detail: `An underlying provider server returned a response for "test_resource" in the ApplyResourceChange RPC which Terraform would reject. ` +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf5testserver.TestServer\n" +
+						`Error: AttributeName("password"): value for write-only attribute must be null`,
+					Attribute: tftypes.NewAttributePath().WithAttributeName("password"),
+				},
+			},
+		},
+		"PlanResourceChange-valid": {
+			plannedState:    consistencyTestValue(tftypes.UnknownValue, "test-name", nil),
+			requiresReplace: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("name")},
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+					TypeName:         "test_resource",
+					Config:           consistencyTestValue(nil, "test-name", "secret"),
+					ProposedNewState: consistencyTestValue(nil, "test-name", "secret"),
+				})
+
+				return resp.Diagnostics, err
+			},
+		},
+		"PlanResourceChange-non-computed-changed": {
+			plannedState: consistencyTestValue(tftypes.UnknownValue, "changed-name", nil),
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+					TypeName:         "test_resource",
+					Config:           consistencyTestValue(nil, "test-name", nil),
+					ProposedNewState: consistencyTestValue(nil, "test-name", nil),
+				})
+
+				return resp.Diagnostics, err
+			},
+			expected: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Provider Produced Invalid Plan",
+					Detail: `An underlying provider server returned a response for "test_resource" in the PlanResourceChange RPC which Terraform would reject. ` +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf5testserver.TestServer\n" +
+						`Error: AttributeName("name"): planned value tftypes.String<"changed-name"> does not match config value tftypes.String<"test-name">`,
+					Attribute: tftypes.NewAttributePath().WithAttributeName("name"),
+				},
+			},
+		},
+		"PlanResourceChange-prior-value-kept": {
+			plannedState: consistencyTestValue("test-id", "prior-name", nil),
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+					TypeName:         "test_resource",
+					Config:           consistencyTestValue(nil, "test-name", nil),
+					ProposedNewState: consistencyTestValue(nil, "test-name", nil),
+					PriorState:       consistencyTestValue("test-id", "prior-name", nil),
+				})
+
+				return resp.Diagnostics, err
+			},
+		},
+		"PlanResourceChange-requires-replace-missing": {
+			plannedState:    consistencyTestValue(tftypes.UnknownValue, "test-name", nil),
+			requiresReplace: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("missing")},
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+					TypeName:         "test_resource",
+					Config:           consistencyTestValue(nil, "test-name", nil),
+					ProposedNewState: consistencyTestValue(nil, "test-name", nil),
+				})
+
+				return resp.Diagnostics, err
+			},
+			expected: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Provider Produced Invalid Plan",
+					Detail: `An underlying provider server returned a response for "test_resource" in the PlanResourceChange RPC which Terraform would reject. ` +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf5testserver.TestServer\n" +
+						`Error: RequiresReplace path AttributeName("missing") does not exist in the resource schema`,
+				},
+			},
+		},
+		"PlanResourceChange-write-only": {
+			plannedState: consistencyTestValue(tftypes.UnknownValue, "test-name", "secret"),
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+					TypeName:         "test_resource",
+					Config:           consistencyTestValue(nil, "test-name", "secret"),
+					ProposedNewState: consistencyTestValue(nil, "test-name", "secret"),
+				})
+
+				return resp.Diagnostics, err
+			},
+			expected: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Provider Produced Invalid Plan",
+					Detail: `An underlying provider server returned a response for "test_resource" in the PlanResourceChange RPC which Terraform would reject. ` +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf5testserver.TestServer\n" +
+						`Error: AttributeName("password"): value for write-only attribute must be null`,
+					Attribute: tftypes.NewAttributePath().WithAttributeName("password"),
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			testServer := newConsistencyTestServer()
+			testServer.ApplyResourceChangeFunc = func(_ context.Context, _ *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+				return &tfprotov5.ApplyResourceChangeResponse{
+					NewState: testCase.newState,
+				}, nil
+			}
+			testServer.PlanResourceChangeFunc = func(_ context.Context, _ *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+				return &tfprotov5.PlanResourceChangeResponse{
+					PlannedState:    testCase.plannedState,
+					RequiresReplace: testCase.requiresReplace,
+				}, nil
+			}
+
+			muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+				ctx,
+				[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+				tf5muxserver.WithConsistencyChecks(),
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error setting up muxer: %s", err)
+			}
+
+			got, err := testCase.call(ctx, muxServer.ProviderServer())
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
+
+// consistencyTestPlan returns a function which plans test_resource with the
+// prior state on the mux server, where the test server plans the id, and
+// returns the diagnostics.
+func consistencyTestPlan(ctx context.Context, t *testing.T, muxServer tfprotov5.ProviderServer, testServer *tf5testserver.TestServer) func(priorState *tfprotov5.DynamicValue, plannedID any) []*tfprotov5.Diagnostic {
+	t.Helper()
+
+	return func(priorState *tfprotov5.DynamicValue, plannedID any) []*tfprotov5.Diagnostic {
+		testServer.PlanResourceChangeFunc = func(_ context.Context, _ *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+			return &tfprotov5.PlanResourceChangeResponse{
+				PlannedState: consistencyTestValue(plannedID, "test-name", nil),
+			}, nil
+		}
+
+		resp, err := muxServer.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "test_resource",
+			Config:           consistencyTestValue(nil, "test-name", nil),
+			PriorState:       priorState,
+			ProposedNewState: consistencyTestValue(nil, "test-name", nil),
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return resp.Diagnostics
+	}
+}
+
+func TestWithConsistencyChecks_FinalPlan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newConsistencyTestServer()
+	testServer.ApplyResourceChangeFunc = func(_ context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+		return &tfprotov5.ApplyResourceChangeResponse{
+			NewState: req.PlannedState,
+		}, nil
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithConsistencyChecks(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	plan := consistencyTestPlan(ctx, t, muxServer.ProviderServer(), testServer)
+	priorState := consistencyTestValue("test-id", "test-name", nil)
+
+	if diags := plan(priorState, tftypes.UnknownValue); len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics planning: %v", diags)
+	}
+
+	if diags := plan(priorState, "planned-id"); len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics planning unknown value as known: %v", diags)
+	}
+
+	expected := []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Provider Produced Inconsistent Final Plan",
+			Detail: `An underlying provider server returned a response for "test_resource" in the PlanResourceChange RPC which Terraform would reject. ` +
+				"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+				"Underlying server: *tf5testserver.TestServer\n" +
+				`Error: AttributeName("id"): was tftypes.String<"planned-id">, but now tftypes.String<"changed-id">`,
+			Attribute: tftypes.NewAttributePath().WithAttributeName("id"),
+		},
+	}
+
+	if diff := cmp.Diff(plan(priorState, "changed-id"), expected); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+
+	_, err = muxServer.ProviderServer().ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:     "test_resource",
+		PlannedState: consistencyTestValue("changed-id", "test-name", nil),
+		PriorState:   priorState,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The plan is forgotten once it is applied.
+	if diags := plan(priorState, "other-id"); len(diags) > 0 {
+		t.Errorf("unexpected diagnostics planning after apply: %v", diags)
+	}
+}
+
+func TestWithConsistencyChecks_FinalPlanCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newConsistencyTestServer()
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithConsistencyChecks(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	plan := consistencyTestPlan(ctx, t, muxServer.ProviderServer(), testServer)
+
+	// Instances of count and for_each which are created have the same
+	// null prior state, so their plans are not compared.
+	for _, id := range []string{"first-id", "second-id"} {
+		if diags := plan(nil, id); len(diags) > 0 {
+			t.Errorf("unexpected diagnostics planning %s: %v", id, diags)
+		}
+	}
+}
+
+func TestWithConsistencyChecks_FinalPlanEvicted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newConsistencyTestServer()
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithConsistencyChecks(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	plan := consistencyTestPlan(ctx, t, muxServer.ProviderServer(), testServer)
+	priorState := consistencyTestValue("test-id", "test-name", nil)
+
+	if diags := plan(priorState, "planned-id"); len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics planning: %v", diags)
+	}
+
+	// The plans of 10000 other resources are kept instead, which is the
+	// maximum number of plans.
+	for i := range 10000 {
+		if diags := plan(consistencyTestValue(fmt.Sprintf("other-id-%d", i), "test-name", nil), "planned-id"); len(diags) > 0 {
+			t.Fatalf("unexpected diagnostics planning other resource: %v", diags)
+		}
+	}
+
+	if diags := plan(priorState, "changed-id"); len(diags) > 0 {
+		t.Errorf("unexpected diagnostics planning evicted resource: %v", diags)
+	}
+}
@@ -3,145 +3,288 @@
 
 package tf5muxserver
 
-import "github.com/hashicorp/terraform-plugin-go/tfprotov5"
+import (
+	"fmt"
+	"time"
 
-func actionDuplicateError(actionType string) *tfprotov5.Diagnostic {
-	return &tfprotov5.Diagnostic{
-		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Invalid Provider Server Combination",
-		Detail: "The combined provider has multiple implementations of the same action type across underlying providers. " +
-			"Actions must be implemented by only one underlying provider. " +
-			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Duplicate action: " + actionType,
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+// appendResponseDiagnostic appends the diagnostic to a unary RPC response,
+// returning false if the response is nil or has no diagnostics, such as the
+// responses of streaming RPCs.
+func appendResponseDiagnostic(resp any, diagnostic *tfprotov5.Diagnostic) bool {
+	switch r := resp.(type) {
+	case *tfprotov5.ApplyResourceChangeResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.CloseEphemeralResourceResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.ConfigureProviderResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.GenerateResourceConfigResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.GetFunctionsResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.GetMetadataResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.GetProviderSchemaResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.GetResourceIdentitySchemasResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.ImportResourceStateResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.MoveResourceStateResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.OpenEphemeralResourceResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.PlanActionResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.PlanResourceChangeResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.PrepareProviderConfigResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.ReadDataSourceResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.ReadResourceResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.RenewEphemeralResourceResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.UpgradeResourceIdentityResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.UpgradeResourceStateResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.ValidateActionConfigResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.ValidateDataSourceConfigResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.ValidateEphemeralResourceConfigResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.ValidateListResourceConfigResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
+	case *tfprotov5.ValidateResourceTypeConfigResponse:
+		if r != nil {
+			r.Diagnostics = append(r.Diagnostics, diagnostic)
+
+			return true
+		}
 	}
+
+	return false
 }
 
-func actionMissingError(actionType string) *tfprotov5.Diagnostic {
-	return &tfprotov5.Diagnostic{
-		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Action Not Implemented",
-		Detail: "The combined provider does not implement the requested action. " +
-			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Missing action: " + actionType,
+func canceledCallDiagnostic(info *CallInfo) *tfprotov5.Diagnostic {
+	detail := fmt.Sprintf("The request was canceled while waiting for the concurrency limits of the underlying provider server for the %s RPC", info.RPC)
+
+	if info.TypeName != "" {
+		detail += fmt.Sprintf(" for %q", info.TypeName)
 	}
-}
 
-func dataSourceDuplicateError(typeName string) *tfprotov5.Diagnostic {
 	return &tfprotov5.Diagnostic{
 		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Invalid Provider Server Combination",
-		Detail: "The combined provider has multiple implementations of the same data source type across underlying providers. " +
-			"Data source types must be implemented by only one underlying provider. " +
-			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Duplicate data source type: " + typeName,
+		Summary:  "Provider Server Call Canceled",
+		Detail: detail + ", so the underlying provider server was not called.\n\n" +
+			fmt.Sprintf("Underlying server: %T", info.Server),
 	}
 }
 
-func dataSourceMissingError(typeName string) *tfprotov5.Diagnostic {
-	return &tfprotov5.Diagnostic{
-		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Data Source Not Implemented",
-		Detail: "The combined provider does not implement the requested data source type. " +
-			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Missing data source type: " + typeName,
+func countErrorDiagnostics(diagnostics []*tfprotov5.Diagnostic) int {
+	var count int
+
+	for _, diagnostic := range diagnostics {
+		if isErrorDiagnostic(diagnostic) {
+			count++
+		}
 	}
+
+	return count
 }
 
-func ephemeralResourceDuplicateError(typeName string) *tfprotov5.Diagnostic {
-	return &tfprotov5.Diagnostic{
-		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Invalid Provider Server Combination",
-		Detail: "The combined provider has multiple implementations of the same ephemeral resource type across underlying providers. " +
-			"Ephemeral resource types must be implemented by only one underlying provider. " +
-			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Duplicate ephemeral resource type: " + typeName,
+func diagnosticsHasError(diagnostics []*tfprotov5.Diagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if isErrorDiagnostic(diagnostic) {
+			return true
+		}
 	}
+
+	return false
 }
 
-func ephemeralResourceMissingError(typeName string) *tfprotov5.Diagnostic {
+func downgradeIncompatibleError(err error) *tfprotov5.Diagnostic {
 	return &tfprotov5.Diagnostic{
 		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Ephemeral Resource Not Implemented",
-		Detail: "The combined provider does not implement the requested ephemeral resource type. " +
+		Summary:  "Invalid Provider Server Combination",
+		Detail: "The combined provider has a protocol version 6 underlying provider which is not compatible with protocol version 5. " +
+			"Protocol version 6 providers must not implement protocol version 6 only features, such as nested attributes, to be combined into a protocol version 5 provider. " +
 			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Missing ephemeral resource type: " + typeName,
+			"Incompatibility: " + err.Error(),
 	}
 }
 
-func listResourceDuplicateError(typeName string) *tfprotov5.Diagnostic {
-	return &tfprotov5.Diagnostic{
-		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Invalid Provider Server Combination",
-		Detail: "The combined provider has multiple implementations of the same list resource type across underlying providers. " +
-			"List resource types must be implemented by only one underlying provider. " +
-			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Duplicate list resource type: " + typeName,
+// inconsistentResponseDiagnostics returns an error diagnostic for each error
+// of WithConsistencyChecks, with the attribute path of the error.
+func inconsistentResponseDiagnostics(info *CallInfo, summary string, errs []consistencyError) []*tfprotov5.Diagnostic {
+	diagnostics := make([]*tfprotov5.Diagnostic, 0, len(errs))
+
+	for _, err := range errs {
+		diagnostics = append(diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  summary,
+			Detail: fmt.Sprintf("An underlying provider server returned a response for %q in the %s RPC which Terraform would reject. ", info.TypeName, info.RPC) +
+				"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+				fmt.Sprintf("Underlying server: %T\n", info.Server) +
+				fmt.Sprintf("Error: %s", err),
+			Attribute: err.path,
+		})
 	}
+
+	return diagnostics
 }
 
-func listResourceMissingError(typeName string) *tfprotov5.Diagnostic {
+func invalidValueDiagnostic(info *CallInfo, typeName string, field string, err error) *tfprotov5.Diagnostic {
 	return &tfprotov5.Diagnostic{
 		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "List Resource Not Implemented",
-		Detail: "The combined provider does not implement the requested list resource type. " +
+		Summary:  "Invalid Provider Server Response",
+		Detail: fmt.Sprintf("An underlying provider server returned an invalid %s value for %q in the %s RPC response. ", field, typeName, info.RPC) +
 			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Missing list resource type: " + typeName,
+			fmt.Sprintf("Underlying server: %T\n", info.Server) +
+			fmt.Sprintf("Error: %s", err),
 	}
 }
 
-func diagnosticsHasError(diagnostics []*tfprotov5.Diagnostic) bool {
-	for _, diagnostic := range diagnostics {
-		if diagnostic == nil {
-			continue
-		}
+// invokeActionEventDiagnostics returns the diagnostics of an InvokeAction
+// event, which are only present in completed events.
+func invokeActionEventDiagnostics(event tfprotov5.InvokeActionEvent) []*tfprotov5.Diagnostic {
+	completed, ok := event.Type.(tfprotov5.CompletedInvokeActionEventType)
 
-		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
-			return true
-		}
+	if !ok {
+		return nil
 	}
 
-	return false
+	return completed.Diagnostics
 }
 
-func functionDuplicateError(name string) *tfprotov5.Diagnostic {
-	return &tfprotov5.Diagnostic{
-		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Invalid Provider Server Combination",
-		Detail: "The combined provider has multiple implementations of the same function name across underlying providers. " +
-			"Functions must be implemented by only one underlying provider. " +
-			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Duplicate function: " + name,
+func isErrorDiagnostic(diagnostic *tfprotov5.Diagnostic) bool {
+	if diagnostic == nil {
+		return false
 	}
+
+	return diagnostic.Severity == tfprotov5.DiagnosticSeverityError
 }
 
-func functionMissingError(name string) *tfprotov5.Diagnostic {
+func newErrorDiagnostic(summary string, detail string) *tfprotov5.Diagnostic {
 	return &tfprotov5.Diagnostic{
 		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Function Not Implemented",
-		Detail: "The combined provider does not implement the requested function. " +
-			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Missing function: " + name,
+		Summary:  summary,
+		Detail:   detail,
 	}
 }
 
-func resourceDuplicateError(typeName string) *tfprotov5.Diagnostic {
-	return &tfprotov5.Diagnostic{
-		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Invalid Provider Server Combination",
-		Detail: "The combined provider has multiple implementations of the same resource type across underlying providers. " +
-			"Resource types must be implemented by only one underlying provider. " +
-			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Duplicate resource type: " + typeName,
+func panicDiagnostic(info *CallInfo, r any) *tfprotov5.Diagnostic {
+	detail := fmt.Sprintf("An underlying provider server panicked while handling the %s RPC", info.RPC)
+
+	if info.TypeName != "" {
+		detail += fmt.Sprintf(" for %q", info.TypeName)
 	}
-}
 
-func resourceMissingError(typeName string) *tfprotov5.Diagnostic {
 	return &tfprotov5.Diagnostic{
 		Severity: tfprotov5.DiagnosticSeverityError,
-		Summary:  "Resource Not Implemented",
-		Detail: "The combined provider does not implement the requested resource type. " +
+		Summary:  "Provider Server Panic",
+		Detail: detail + ". The provider logs contain the stack trace of the panic. " +
 			"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
-			"Missing resource type: " + typeName,
+			fmt.Sprintf("Underlying server: %T\n", info.Server) +
+			fmt.Sprintf("Panic: %v", r),
 	}
 }
 
@@ -155,3 +298,138 @@ func resourceIdentityDuplicateError(typeName string) *tfprotov5.Diagnostic {
 			"Duplicate identity type for resource: " + typeName,
 	}
 }
+
+// responseDiagnostics returns the diagnostics of a unary RPC response, which
+// may be nil. Function and StopProvider errors are returned as error
+// diagnostics. The diagnostics of streaming RPCs are in the streamed elements,
+// so nil is returned for their responses.
+func responseDiagnostics(resp any) []*tfprotov5.Diagnostic {
+	switch r := resp.(type) {
+	case *tfprotov5.ApplyResourceChangeResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.CallFunctionResponse:
+		if r != nil && r.Error != nil {
+			return []*tfprotov5.Diagnostic{
+				newErrorDiagnostic("Function Error", r.Error.Text),
+			}
+		}
+	case *tfprotov5.CloseEphemeralResourceResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.ConfigureProviderResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.GenerateResourceConfigResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.GetFunctionsResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.GetMetadataResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.GetProviderSchemaResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.GetResourceIdentitySchemasResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.ImportResourceStateResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.MoveResourceStateResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.OpenEphemeralResourceResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.PlanActionResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.PlanResourceChangeResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.PrepareProviderConfigResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.ReadDataSourceResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.ReadResourceResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.RenewEphemeralResourceResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.StopProviderResponse:
+		if r != nil && r.Error != "" {
+			return []*tfprotov5.Diagnostic{
+				newErrorDiagnostic("Stop Provider Error", r.Error),
+			}
+		}
+	case *tfprotov5.UpgradeResourceIdentityResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.UpgradeResourceStateResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.ValidateActionConfigResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.ValidateDataSourceConfigResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.ValidateEphemeralResourceConfigResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.ValidateListResourceConfigResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	case *tfprotov5.ValidateResourceTypeConfigResponse:
+		if r != nil {
+			return r.Diagnostics
+		}
+	}
+
+	return nil
+}
+
+func slowCallDiagnostic(info *CallInfo, threshold time.Duration, duration time.Duration) *tfprotov5.Diagnostic {
+	detail := fmt.Sprintf("An underlying provider server took %s to handle the %s RPC", duration.Round(time.Millisecond), info.RPC)
+
+	if info.TypeName != "" {
+		detail += fmt.Sprintf(" for %q", info.TypeName)
+	}
+
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  "Slow Provider Server Call",
+		Detail: detail + fmt.Sprintf(", which is longer than the threshold of %s. ", threshold) +
+			"This may be an issue in the provider implementation or the remote system it calls.\n\n" +
+			fmt.Sprintf("Underlying server: %T", info.Server),
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6tov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
+)
+
+// FromProtocol6 returns a protocol version 5 server function for the given
+// protocol version 6 server function, so it can be passed to NewMuxServer
+// alongside protocol version 5 servers. The server is downgraded with
+// tf6to5server.DowngradeServer, however its compatibility with protocol
+// version 5 is checked when the mux server discovers it, where any
+// incompatibility is returned as an error diagnostic.
+func FromProtocol6(v6server func() tfprotov6.ProviderServer) func() tfprotov5.ProviderServer {
+	return func() tfprotov5.ProviderServer {
+		// DowngradeServer only returns errors from schema validation, which
+		// is deferred.
+		server, _ := tf6to5server.DowngradeServer(context.Background(), v6server, tf6to5server.WithDeferredSchemaValidation())
+
+		return &downgradedServer{
+			ProviderServer: server,
+		}
+	}
+}
+
+// downgradedServer is a protocol version 6 server from FromProtocol6, which
+// NewMuxServer replaces with its downgrade.
+type downgradedServer struct {
+	tfprotov5.ProviderServer
+}
+
+// downgrade is a protocol version 6 underlying server which is downgraded to
+// protocol version 5. Its methods return no diagnostics if it is nil, which
+// is for protocol version 5 underlying servers.
+type downgrade struct {
+	server tfprotov5.ProviderServer
+
+	// mu guards diagnostics and checked, which are set once the check has
+	// a result which does not change, and warnings.
+	mu          sync.Mutex
+	diagnostics []*tfprotov5.Diagnostic
+	checked     bool
+
+	// warnings are the warning diagnostics of the schemas of the check,
+	// until they are returned by takeWarnings.
+	warnings []*tfprotov5.Diagnostic
+}
+
+// check returns the error diagnostics of the server if it is not compatible
+// with protocol version 5, which are an error diagnostic for nested
+// attributes or the error diagnostics of its schemas. Types of servers with
+// diagnostics must not be routed.
+//
+// The schemas are those of the GetProviderSchema RPC of the downgraded
+// server, which caches them, so the underlying server is not called again
+// when they are later requested. Fields which are dropped in the downgrade,
+// such as state stores, are compatible, as the downgraded server returns a
+// warning diagnostic for them, which is kept for takeWarnings.
+func (d *downgrade) check(ctx context.Context) ([]*tfprotov5.Diagnostic, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.checked {
+		return d.diagnostics, nil
+	}
+
+	resp, err := d.server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if errors.Is(err, tfprotov6tov5.ErrSchemaAttributeNestedTypeNotImplemented) {
+		d.diagnostics = []*tfprotov5.Diagnostic{
+			downgradeIncompatibleError(err),
+		}
+		d.checked = true
+
+		return d.diagnostics, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Error diagnostics of the schemas may be transient, so they are not
+	// kept and the server is checked again on the next call.
+	if resp != nil && diagnosticsHasError(resp.Diagnostics) {
+		var diagnostics []*tfprotov5.Diagnostic
+
+		for _, diagnostic := range resp.Diagnostics {
+			if isErrorDiagnostic(diagnostic) {
+				diagnostics = append(diagnostics, diagnostic)
+			}
+		}
+
+		return diagnostics, nil
+	}
+
+	d.checked = true
+
+	if resp != nil {
+		d.warnings = resp.Diagnostics
+	}
+
+	return nil, nil
+}
+
+// takeWarnings returns the warning diagnostics of the schemas of the check
+// which are not in diagnostics, the diagnostics of a later GetProviderSchema
+// call of the server. The downgraded server only returns the warning
+// diagnostic of dropped fields once, so it would otherwise be lost if the
+// check made the first call. The warnings are only returned once.
+func (d *downgrade) takeWarnings(diagnostics []*tfprotov5.Diagnostic) []*tfprotov5.Diagnostic {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var warnings []*tfprotov5.Diagnostic
+
+	for _, warning := range d.warnings {
+		if warning == nil || warning.Severity != tfprotov5.DiagnosticSeverityWarning {
+			continue
+		}
+
+		if !slices.ContainsFunc(diagnostics, func(diagnostic *tfprotov5.Diagnostic) bool {
+			return diagnostic != nil && diagnostic.Summary == warning.Summary && diagnostic.Detail == warning.Detail
+		}) {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	d.warnings = nil
+
+	return warnings
+}
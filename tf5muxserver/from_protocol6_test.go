@@ -0,0 +1,415 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+// incompatibleTestSchema is a protocol version 6 schema with two nested
+// attributes, which are not compatible with protocol version 5.
+func incompatibleTestSchema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name: "test_attribute1",
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+					},
+					Optional: true,
+				},
+				{
+					Name: "test_attribute2",
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeList,
+					},
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// incompatibleTestDiagnostics returns the diagnostics of the first nested
+// attribute of incompatibleTestSchema.
+func incompatibleTestDiagnostics(schemaKind string, typeName string) []*tfprotov5.Diagnostic {
+	return []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Invalid Provider Server Combination",
+			Detail: "The combined provider has a protocol version 6 underlying provider which is not compatible with protocol version 5. " +
+				"Protocol version 6 providers must not implement protocol version 6 only features, such as nested attributes, to be combined into a protocol version 5 provider. " +
+				"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+				"Incompatibility: unable to convert " + schemaKind + " \"" + typeName + "\" schema: unable to convert attribute \"test_attribute1\" schema: " +
+				"SchemaAttribute NestedType is not implemented in protocol version 5",
+		},
+	}
+}
+
+func TestFromProtocol6(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource_server1": {},
+			},
+		},
+	}
+	testServer2 := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource_server2": {},
+			},
+		},
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, testServer1.ProviderServer, tf5muxserver.FromProtocol6(testServer2.ProviderServer))
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up factory: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource_server2",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if testServer1.ReadResourceCalled["test_resource_server2"] {
+		t.Errorf("unexpected test_resource_server2 ReadResource called on server1")
+	}
+
+	if !testServer2.ReadResourceCalled["test_resource_server2"] {
+		t.Errorf("expected test_resource_server2 ReadResource to be called on server2")
+	}
+}
+
+func TestFromProtocol6Incompatible(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource_server1": {},
+			},
+		},
+	}
+	testServer2 := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource_server2": incompatibleTestSchema(),
+			},
+		},
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, testServer1.ProviderServer, tf5muxserver.FromProtocol6(testServer2.ProviderServer))
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up factory: %s", err)
+	}
+
+	schemaResp, err := muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(schemaResp.Diagnostics, incompatibleTestDiagnostics("resource", "test_resource_server2")); diff != "" {
+		t.Errorf("unexpected GetProviderSchema diagnostics difference: %s", diff)
+	}
+
+	if _, ok := schemaResp.ResourceSchemas["test_resource_server1"]; !ok {
+		t.Errorf("expected test_resource_server1 schema")
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource_server1",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !testServer1.ReadResourceCalled["test_resource_server1"] {
+		t.Errorf("expected test_resource_server1 ReadResource to be called on server1")
+	}
+}
+
+func TestFromProtocol6IncompatibleGetMetadata(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf5testserver.TestServer{
+		GetMetadataResponse: &tfprotov5.GetMetadataResponse{
+			Resources: []tfprotov5.ResourceMetadata{
+				{TypeName: "test_resource_server1"},
+			},
+		},
+	}
+	testServer2 := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			DataSources: []tfprotov6.DataSourceMetadata{
+				{TypeName: "test_data_source_server2"},
+			},
+		},
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			DataSourceSchemas: map[string]*tfprotov6.Schema{
+				"test_data_source_server2": incompatibleTestSchema(),
+			},
+		},
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, testServer1.ProviderServer, tf5muxserver.FromProtocol6(testServer2.ProviderServer))
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up factory: %s", err)
+	}
+
+	metadataResp, err := muxServer.ProviderServer().GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedDiagnostics := incompatibleTestDiagnostics("data source", "test_data_source_server2")
+
+	if diff := cmp.Diff(metadataResp.Diagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected GetMetadata diagnostics difference: %s", diff)
+	}
+
+	if len(metadataResp.DataSources) > 0 {
+		t.Errorf("unexpected data sources: %v", metadataResp.DataSources)
+	}
+
+	readResp, err := muxServer.ProviderServer().ReadDataSource(ctx, &tfprotov5.ReadDataSourceRequest{
+		TypeName: "test_data_source_server2",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(readResp.Diagnostics) == 0 {
+		t.Errorf("expected diagnostics")
+	}
+
+	if testServer2.ReadDataSourceCalled["test_data_source_server2"] {
+		t.Errorf("unexpected test_data_source_server2 ReadDataSource called on server2")
+	}
+}
+
+func TestFromProtocol6IncompatibleServerDiscovery(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		getMetadataResponse *tfprotov6.GetMetadataResponse
+	}{
+		"GetMetadata": {
+			getMetadataResponse: &tfprotov6.GetMetadataResponse{
+				DataSources: []tfprotov6.DataSourceMetadata{
+					{TypeName: "test_data_source_server2"},
+				},
+			},
+		},
+		"GetProviderSchema": {},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			testServer1 := &tf5testserver.TestServer{
+				GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+					ResourceSchemas: map[string]*tfprotov5.Schema{
+						"test_resource_server1": {},
+					},
+				},
+			}
+			testServer2 := &tf6testserver.TestServer{
+				GetMetadataResponse: testCase.getMetadataResponse,
+				GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+					DataSourceSchemas: map[string]*tfprotov6.Schema{
+						"test_data_source_server2": incompatibleTestSchema(),
+					},
+				},
+			}
+
+			muxServer, err := tf5muxserver.NewMuxServer(ctx, testServer1.ProviderServer, tf5muxserver.FromProtocol6(testServer2.ProviderServer))
+
+			if err != nil {
+				t.Fatalf("unexpected error setting up factory: %s", err)
+			}
+
+			// ReadDataSource without a prior GetProviderSchema or GetMetadata
+			// performs server discovery.
+			resp, err := muxServer.ProviderServer().ReadDataSource(ctx, &tfprotov5.ReadDataSourceRequest{
+				TypeName: "test_data_source_server2",
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(resp.Diagnostics, incompatibleTestDiagnostics("data source", "test_data_source_server2")); diff != "" {
+				t.Errorf("unexpected ReadDataSource diagnostics difference: %s", diff)
+			}
+
+			if testServer2.ReadDataSourceCalled["test_data_source_server2"] {
+				t.Errorf("unexpected test_data_source_server2 ReadDataSource called on server2")
+			}
+		})
+	}
+}
+
+func TestFromProtocol6SchemaDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource_server1": {},
+			},
+		},
+	}
+	testServer2 := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "test error summary",
+					Detail:   "test error detail",
+				},
+			},
+			ResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource_server2": {},
+			},
+		},
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, testServer1.ProviderServer, tf5muxserver.FromProtocol6(testServer2.ProviderServer))
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up factory: %s", err)
+	}
+
+	schemaResp, err := muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedDiagnostics := []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "test error summary",
+			Detail:   "test error detail",
+		},
+	}
+
+	if diff := cmp.Diff(schemaResp.Diagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected GetProviderSchema diagnostics difference: %s", diff)
+	}
+
+	if _, ok := schemaResp.ResourceSchemas["test_resource_server2"]; ok {
+		t.Errorf("unexpected test_resource_server2 schema")
+	}
+}
+
+func TestFromProtocol6StateStores(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var getProviderSchemaCalls atomic.Int32
+
+	testServer1 := &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource_server1": {},
+			},
+		},
+	}
+	testServer2 := &tf6testserver.TestServer{
+		GetProviderSchemaFunc: func(_ context.Context, _ *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+			getProviderSchemaCalls.Add(1)
+
+			return &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"test_resource_server2": {},
+				},
+				StateStoreSchemas: map[string]*tfprotov6.Schema{
+					"test_state_store": {},
+				},
+			}, nil
+		},
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, testServer1.ProviderServer, tf5muxserver.FromProtocol6(testServer2.ProviderServer))
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up factory: %s", err)
+	}
+
+	schemaResp, err := muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Dropped state stores are reported by the downgraded server as a
+	// warning, rather than making the server incompatible. The warning is
+	// returned although the compatibility check made the first call.
+	expectedDiagnostics := []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "Protocol Version Translation Loss",
+			Detail: "The GetProviderSchemaResponse.StateStoreSchemas field could not be translated between protocol versions 5 and 6, so it was dropped or changed: not implemented in protocol version 5. " +
+				"The behavior of the provider may differ from that of the underlying provider server.",
+		},
+	}
+
+	if diff := cmp.Diff(schemaResp.Diagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+
+	if _, ok := schemaResp.ResourceSchemas["test_resource_server2"]; !ok {
+		t.Errorf("expected test_resource_server2 schema")
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource_server2",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !testServer2.ReadResourceCalled["test_resource_server2"] {
+		t.Errorf("expected test_resource_server2 ReadResource to be called on server2")
+	}
+
+	// The compatibility check uses the schemas cached by the downgraded
+	// server, rather than calling the underlying server again.
+	if got := getProviderSchemaCalls.Load(); got != 1 {
+		t.Errorf("expected 1 GetProviderSchema call, got %d", got)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+// CallInfo describes a call from the mux server to an underlying server,
+// which is passed to each Interceptor.
+type CallInfo struct {
+	// RPC is the name of the RPC, such as "ReadResource".
+	RPC string
+
+	// Server is the underlying server being called.
+	Server tfprotov5.ProviderServer
+
+	// TypeName is the action, data source, ephemeral resource, function,
+	// list resource, or resource type name which the request was routed by.
+	// It is empty for RPCs which are sent to every underlying server, such as
+	// ConfigureProvider, and for server discovery.
+	TypeName string
+
+	// serverIndex is the index of Server in the underlying servers of the mux
+	// server, which differs from its index in the servers passed to
+	// NewMuxServerWithOptions when nested mux servers are flattened.
+	serverIndex int
+}
+
+// Handler calls the next Interceptor in the chain, or the underlying server
+// if there are no more interceptors. The request and response are the
+// terraform-plugin-go types of the RPC, such as
+// *tfprotov5.ReadResourceRequest and *tfprotov5.ReadResourceResponse.
+type Handler func(ctx context.Context, req any) (any, error)
+
+// Interceptor is called for each call from the mux server to an underlying
+// server, including those made to discover the types each server implements.
+// The request is one of the terraform-plugin-go request types, such as
+// *tfprotov5.ReadResourceRequest, and the response must be the matching
+// response type, such as *tfprotov5.ReadResourceResponse.
+//
+// Interceptors can modify the request before calling handler, return a
+// response without calling handler, or modify the response returned by
+// handler. For the streaming ListResource and InvokeAction RPCs, the response
+// contains an iterator which can be wrapped to observe or modify each
+// streamed result or event.
+//
+// Use TypedInterceptor to create an Interceptor for a single RPC.
+type Interceptor func(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error)
+
+// TypedInterceptor returns an Interceptor which calls interceptor for RPCs
+// with the request type Req and response type Resp, such as
+// *tfprotov5.ReadResourceRequest and *tfprotov5.ReadResourceResponse. Calls
+// of all other RPCs are passed to the next handler unchanged.
+func TypedInterceptor[Req, Resp any](interceptor func(ctx context.Context, info *CallInfo, req Req, handler func(context.Context, Req) (Resp, error)) (Resp, error)) Interceptor {
+	return func(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+		typedReq, ok := req.(Req)
+
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		typedHandler := func(ctx context.Context, req Req) (Resp, error) {
+			resp, err := handler(ctx, req)
+
+			return typedResponse[Resp](info, resp, err)
+		}
+
+		return interceptor(ctx, info, typedReq, typedHandler)
+	}
+}
+
+// WithInterceptors is a NewMuxServerWithOptions option which adds
+// interceptors that are called for each call to an underlying server. The
+// first interceptor is the outermost, so it is called first and receives the
+// response last.
+func WithInterceptors(interceptors ...Interceptor) MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+// intercept calls handler through the interceptors.
+func intercept[Req, Resp any](ctx context.Context, interceptors []Interceptor, info *CallInfo, req Req, handler func(context.Context, Req) (Resp, error)) (Resp, error) {
+	if len(interceptors) == 0 {
+		return handler(ctx, req)
+	}
+
+	next := func(ctx context.Context, req any) (any, error) {
+		typedReq, ok := req.(Req)
+
+		if !ok {
+			return nil, fmt.Errorf("interceptor passed unexpected %s request type: %T", info.RPC, req)
+		}
+
+		return handler(ctx, typedReq)
+	}
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		nextHandler := next
+
+		next = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, info, req, nextHandler)
+		}
+	}
+
+	resp, err := next(ctx, req)
+
+	return typedResponse[Resp](info, resp, err)
+}
+
+// typedResponse converts a response returned by an Interceptor or Handler to
+// the response type of the RPC.
+func typedResponse[Resp any](info *CallInfo, resp any, err error) (Resp, error) {
+	var typedResp Resp
+
+	if resp == nil {
+		return typedResp, err
+	}
+
+	typedResp, ok := resp.(Resp)
+
+	if !ok {
+		return typedResp, fmt.Errorf("interceptor returned unexpected %s response type: %T", info.RPC, resp)
+	}
+
+	return typedResp, err
+}
+
+// observeStream returns an iterator which calls observe with each element of
+// the stream and done once the stream has ended, including when the
+// consumer stops early.
+func observeStream[T any](stream iter.Seq[T], observe func(T), done func()) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		defer done()
+
+		for element := range stream {
+			observe(element)
+
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
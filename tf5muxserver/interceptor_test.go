@@ -0,0 +1,344 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+func TestWithInterceptors_Order(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf5testserver.TestServer{
+		GetMetadataResponse: &tfprotov5.GetMetadataResponse{
+			Resources: []tfprotov5.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+		},
+	}
+
+	var calls []string
+
+	recordingInterceptor := func(name string) tf5muxserver.Interceptor {
+		return func(ctx context.Context, info *tf5muxserver.CallInfo, req any, handler tf5muxserver.Handler) (any, error) {
+			calls = append(calls, name+" before "+info.RPC+" "+info.TypeName)
+
+			resp, err := handler(ctx, req)
+
+			calls = append(calls, name+" after "+info.RPC+" "+info.TypeName)
+
+			return resp, err
+		}
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithInterceptors(recordingInterceptor("first"), recordingInterceptor("second")),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedCalls := []string{
+		"first before GetMetadata ",
+		"second before GetMetadata ",
+		"second after GetMetadata ",
+		"first after GetMetadata ",
+		"first before ReadResource test_resource",
+		"second before ReadResource test_resource",
+		"second after ReadResource test_resource",
+		"first after ReadResource test_resource",
+	}
+
+	if diff := cmp.Diff(calls, expectedCalls); diff != "" {
+		t.Errorf("unexpected calls difference: %s", diff)
+	}
+
+	if !testServer.ReadResourceCalled["test_resource"] {
+		t.Errorf("expected test_resource ReadResource to be called")
+	}
+}
+
+func TestWithInterceptors_FanOut(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf5testserver.TestServer{}
+	testServer2 := &tf5testserver.TestServer{}
+
+	var servers []tfprotov5.ProviderServer
+
+	interceptor := tf5muxserver.TypedInterceptor(func(ctx context.Context, info *tf5muxserver.CallInfo, req *tfprotov5.ConfigureProviderRequest, handler func(context.Context, *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error)) (*tfprotov5.ConfigureProviderResponse, error) {
+		servers = append(servers, info.Server)
+
+		return handler(ctx, req)
+	})
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer},
+		tf5muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(servers) != 2 || servers[0] != testServer1 || servers[1] != testServer2 {
+		t.Errorf("expected interceptor to be called for each server, got: %v", servers)
+	}
+
+	if !testServer1.ConfigureProviderCalled || !testServer2.ConfigureProviderCalled {
+		t.Errorf("expected ConfigureProvider to be called on each server")
+	}
+}
+
+func TestWithInterceptors_ModifyRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf5testserver.TestServer{
+		GetMetadataResponse: &tfprotov5.GetMetadataResponse{
+			Resources: []tfprotov5.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+		},
+	}
+
+	interceptor := tf5muxserver.TypedInterceptor(func(ctx context.Context, _ *tf5muxserver.CallInfo, req *tfprotov5.ReadResourceRequest, handler func(context.Context, *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error)) (*tfprotov5.ReadResourceResponse, error) {
+		modifiedReq := *req
+		modifiedReq.TypeName = "test_resource_modified"
+
+		return handler(ctx, &modifiedReq)
+	})
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !testServer.ReadResourceCalled["test_resource_modified"] {
+		t.Errorf("expected modified request to be sent to the server")
+	}
+}
+
+func TestWithInterceptors_ShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf5testserver.TestServer{
+		GetMetadataResponse: &tfprotov5.GetMetadataResponse{
+			Resources: []tfprotov5.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+		},
+	}
+
+	expectedDiagnostics := []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Denied",
+		},
+	}
+
+	interceptor := tf5muxserver.TypedInterceptor(func(_ context.Context, _ *tf5muxserver.CallInfo, _ *tfprotov5.ApplyResourceChangeRequest, _ func(context.Context, *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error)) (*tfprotov5.ApplyResourceChangeResponse, error) {
+		return &tfprotov5.ApplyResourceChangeResponse{
+			Diagnostics: expectedDiagnostics,
+		}, nil
+	})
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+
+	if testServer.ApplyResourceChangeCalled["test_resource"] {
+		t.Errorf("expected server ApplyResourceChange to not be called")
+	}
+
+	// Other RPCs are passed through unchanged.
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !testServer.ReadResourceCalled["test_resource"] {
+		t.Errorf("expected server ReadResource to be called")
+	}
+}
+
+func TestWithInterceptors_Stream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf5testserver.TestServer{
+		GetMetadataResponse: &tfprotov5.GetMetadataResponse{
+			ListResources: []tfprotov5.ListResourceMetadata{
+				{
+					TypeName: "test_list_resource",
+				},
+			},
+		},
+	}
+
+	interceptor := tf5muxserver.TypedInterceptor(func(ctx context.Context, _ *tf5muxserver.CallInfo, req *tfprotov5.ListResourceRequest, handler func(context.Context, *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error)) (*tfprotov5.ListResourceServerStream, error) {
+		resp, err := handler(ctx, req)
+
+		if err != nil {
+			return resp, err
+		}
+
+		// The test server returns no stream, so one is added with a result
+		// for each call.
+		if resp == nil {
+			resp = &tfprotov5.ListResourceServerStream{
+				Results: slices.Values([]tfprotov5.ListResourceResult{
+					{
+						DisplayName: "one",
+					},
+					{
+						DisplayName: "two",
+					},
+				}),
+			}
+		}
+
+		results := resp.Results
+
+		resp.Results = func(yield func(tfprotov5.ListResourceResult) bool) {
+			for result := range results {
+				result.DisplayName = "intercepted " + result.DisplayName
+
+				if !yield(result) {
+					return
+				}
+			}
+		}
+
+		return resp, nil
+	})
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_list_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var displayNames []string
+
+	for result := range resp.Results {
+		displayNames = append(displayNames, result.DisplayName)
+	}
+
+	if diff := cmp.Diff(displayNames, []string{"intercepted one", "intercepted two"}); diff != "" {
+		t.Errorf("unexpected display names difference: %s", diff)
+	}
+
+	if !testServer.ListResourceCalled["test_list_resource"] {
+		t.Errorf("expected server ListResource to be called")
+	}
+}
+
+func TestWithInterceptors_UnexpectedResponseType(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf5testserver.TestServer{}
+
+	interceptor := func(_ context.Context, _ *tf5muxserver.CallInfo, _ any, _ tf5muxserver.Handler) (any, error) {
+		return &tfprotov5.ReadResourceResponse{}, nil
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().StopProvider(ctx, &tfprotov5.StopProviderRequest{})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
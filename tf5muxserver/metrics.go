@@ -0,0 +1,77 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-mux/muxmetrics"
+)
+
+// WithMetrics is a NewMuxServerWithOptions option which records each call to
+// an underlying server, including those made to discover the types each
+// server implements, with metrics. Calls are labeled by the RPC name, the Go
+// type of the underlying server, and the type name of the request. Streaming
+// RPCs are recorded once the stream has ended.
+//
+// Calls are recorded after any interceptors from WithInterceptors, so calls
+// which are handled by an interceptor without calling the underlying server
+// are not recorded.
+func WithMetrics(metrics muxmetrics.Metrics) MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.metrics = metrics
+	}
+}
+
+// recordMetrics returns the Interceptor added by WithMetrics.
+func recordMetrics(metrics muxmetrics.Metrics) Interceptor {
+	return func(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+		labels := muxmetrics.Labels{
+			RPC:      info.RPC,
+			Server:   fmt.Sprintf("%T", info.Server),
+			TypeName: info.TypeName,
+		}
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		var errorDiagnostics int
+
+		record := func() {
+			metrics.RecordCall(ctx, labels, muxmetrics.CallResult{
+				Duration:         time.Since(start),
+				ErrorDiagnostics: errorDiagnostics,
+				Err:              err,
+			})
+		}
+
+		switch typedResp := resp.(type) {
+		case *tfprotov5.InvokeActionServerStream:
+			if typedResp != nil && typedResp.Events != nil {
+				typedResp.Events = observeStream(typedResp.Events, func(event tfprotov5.InvokeActionEvent) {
+					errorDiagnostics += countErrorDiagnostics(invokeActionEventDiagnostics(event))
+				}, record)
+
+				return resp, err
+			}
+		case *tfprotov5.ListResourceServerStream:
+			if typedResp != nil && typedResp.Results != nil {
+				typedResp.Results = observeStream(typedResp.Results, func(result tfprotov5.ListResourceResult) {
+					errorDiagnostics += countErrorDiagnostics(result.Diagnostics)
+				}, record)
+
+				return resp, err
+			}
+		}
+
+		errorDiagnostics = countErrorDiagnostics(responseDiagnostics(resp))
+		record()
+
+		return resp, err
+	}
+}
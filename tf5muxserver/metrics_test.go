@@ -0,0 +1,221 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/muxmetrics"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+// ignoreDurations ignores the durations of muxmetrics.Series, which vary
+// between test runs.
+var ignoreDurations = cmpopts.IgnoreFields(muxmetrics.Series{}, "TotalDuration", "MinDuration", "MaxDuration")
+
+func TestWithMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf5testserver.TestServer{
+		GetMetadataResponse: &tfprotov5.GetMetadataResponse{
+			Resources: []tfprotov5.ResourceMetadata{
+				{
+					TypeName: "test_resource_server1",
+				},
+			},
+		},
+	}
+	testServer2 := newPanickingServer()
+	metrics := muxmetrics.NewInMemory()
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer},
+		tf5muxserver.WithMetrics(metrics),
+		tf5muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	for _, typeName := range []string{"test_resource_server1", "test_resource_server1", "test_resource"} {
+		_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+			TypeName: typeName,
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	_, err = muxServer.ProviderServer().ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []muxmetrics.Series{
+		{
+			Labels: muxmetrics.Labels{
+				RPC:    "ConfigureProvider",
+				Server: "*tf5testserver.TestServer",
+			},
+			Calls: 2,
+		},
+		{
+			Labels: muxmetrics.Labels{
+				RPC:    "GetMetadata",
+				Server: "*tf5testserver.TestServer",
+			},
+			Calls: 2,
+		},
+		{
+			Labels: muxmetrics.Labels{
+				RPC:      "ReadResource",
+				Server:   "*tf5testserver.TestServer",
+				TypeName: "test_resource",
+			},
+			Calls:            1,
+			ErrorDiagnostics: 1,
+		},
+		{
+			Labels: muxmetrics.Labels{
+				RPC:      "ReadResource",
+				Server:   "*tf5testserver.TestServer",
+				TypeName: "test_resource_server1",
+			},
+			Calls: 2,
+		},
+	}
+
+	if diff := cmp.Diff(metrics.Snapshot(), expected, ignoreDurations); diff != "" {
+		t.Errorf("unexpected metrics difference: %s", diff)
+	}
+}
+
+func TestWithMetrics_Error(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf5testserver.TestServer{}
+	metrics := muxmetrics.NewInMemory()
+
+	interceptor := tf5muxserver.TypedInterceptor(func(_ context.Context, _ *tf5muxserver.CallInfo, _ *tfprotov5.StopProviderRequest, _ func(context.Context, *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error)) (*tfprotov5.StopProviderResponse, error) {
+		return nil, errors.New("test error")
+	})
+
+	// Interceptors are called before metrics are recorded, so a server
+	// returning an error is simulated with a nested mux server.
+	nestedServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up nested muxer: %s", err)
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{nestedServer.ProviderServer},
+		tf5muxserver.WithMetrics(metrics),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().StopProvider(ctx, &tfprotov5.StopProviderRequest{})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	expected := []muxmetrics.Series{
+		{
+			Labels: muxmetrics.Labels{
+				RPC:    "StopProvider",
+				Server: "*tf5muxserver.muxServer",
+			},
+			Calls:  1,
+			Errors: 1,
+		},
+	}
+
+	if diff := cmp.Diff(metrics.Snapshot(), expected, ignoreDurations); diff != "" {
+		t.Errorf("unexpected metrics difference: %s", diff)
+	}
+}
+
+func TestWithMetrics_ListResource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+	metrics := muxmetrics.NewInMemory()
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithMetrics(metrics),
+		tf5muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_list_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	listResourceLabels := muxmetrics.Labels{
+		RPC:      "ListResource",
+		Server:   "*tf5testserver.TestServer",
+		TypeName: "test_list_resource",
+	}
+
+	for _, series := range metrics.Snapshot() {
+		if series.Labels == listResourceLabels {
+			t.Fatalf("expected ListResource to not be recorded before the stream has ended")
+		}
+	}
+
+	for range resp.Results {
+		// The stream is consumed to end it.
+	}
+
+	expected := []muxmetrics.Series{
+		{
+			Labels: muxmetrics.Labels{
+				RPC:    "GetMetadata",
+				Server: "*tf5testserver.TestServer",
+			},
+			Calls: 1,
+		},
+		{
+			Labels:           listResourceLabels,
+			Calls:            1,
+			ErrorDiagnostics: 1,
+		},
+	}
+
+	if diff := cmp.Diff(metrics.Snapshot(), expected, ignoreDurations); diff != "" {
+		t.Errorf("unexpected metrics difference: %s", diff)
+	}
+}
@@ -5,484 +5,415 @@ package tf5muxserver
 
 import (
 	"context"
-	"sync"
+	"io"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/muxmetrics"
+	"github.com/hashicorp/terraform-plugin-mux/muxrouter"
+	"github.com/hashicorp/terraform-plugin-mux/muxtrace"
 )
 
 var _ tfprotov5.ProviderServer = &muxServer{}
 
+// router is the muxrouter.Router implementation for protocol version 5, which
+// routes to the index of the underlying server in muxServer.servers.
+type router = muxrouter.Router[int, *tfprotov5.ServerCapabilities, *tfprotov5.Diagnostic]
+
+// routingBuilder is the muxrouter.Builder implementation for protocol version 5.
+type routingBuilder = muxrouter.Builder[int, *tfprotov5.ServerCapabilities, *tfprotov5.Diagnostic]
+
 // muxServer is a gRPC server implementation that stands in front of other
 // gRPC servers, routing requests to them as if they were a single server. It
 // should always be instantiated by calling NewMuxServer().
 type muxServer struct {
-	// Routing for actions
-	actions map[string]tfprotov5.ProviderServer
-
-	// Routing for data source types
-	dataSources map[string]tfprotov5.ProviderServer
-
-	// Routing for ephemeral resource types
-	ephemeralResources map[string]tfprotov5.ProviderServer
-
-	// Routing for list resource types
-	listResources map[string]tfprotov5.ProviderServer
+	// router contains the routing for actions, data sources, ephemeral
+	// resources, list resources, functions, and resources.
+	router *router
 
-	// Routing for functions
-	functions map[string]tfprotov5.ProviderServer
-
-	// Routing for resource types
-	resources map[string]tfprotov5.ProviderServer
-
-	// Resource capabilities are cached during GetMetadata/GetProviderSchema
-	resourceCapabilities map[string]*tfprotov5.ServerCapabilities
-
-	// serverDiscoveryComplete is whether the mux server's underlying server
-	// discovery of resource types has been completed against all servers.
-	// If false during a resource type specific RPC, the mux server needs to
-	// pre-emptively call the GetMetadata RPC or GetProviderSchema RPC (as a
-	// fallback) so it knows which underlying server should receive the RPC.
-	serverDiscoveryComplete bool
+	// Underlying servers for requests that should be handled by all servers
+	servers []tfprotov5.ProviderServer
 
-	// serverDiscoveryDiagnostics caches diagnostics found during server
-	// discovery so they can be returned for later requests if necessary.
-	serverDiscoveryDiagnostics []*tfprotov5.Diagnostic
+	// downgrades are the downgrades of the underlying protocol version 6
+	// servers, by index in servers, which are nil for protocol version 5
+	// servers.
+	downgrades []*downgrade
 
-	// serverDiscoveryMutex is a mutex to protect concurrent server discovery
-	// access from race conditions.
-	serverDiscoveryMutex sync.RWMutex
+	// interceptors are called for each call to an underlying server.
+	interceptors []Interceptor
 
-	// Underlying servers for requests that should be handled by all servers
-	servers []tfprotov5.ProviderServer
+	// tracer creates spans for RPCs and server discovery, if not nil.
+	tracer muxtrace.Tracer
 }
 
-// ProviderServer is a function compatible with tf6server.Serve.
+// ProviderServer is a function compatible with tf5server.Serve.
 func (s *muxServer) ProviderServer() tfprotov5.ProviderServer {
 	return s
 }
 
-func (s *muxServer) getActionServer(ctx context.Context, actionType string) (tfprotov5.ProviderServer, []*tfprotov5.Diagnostic, error) {
-	s.serverDiscoveryMutex.RLock()
-	server, ok := s.actions[actionType]
-	discoveryComplete := s.serverDiscoveryComplete
-	s.serverDiscoveryMutex.RUnlock()
+// lookup returns the underlying server which implements the kind and name, and
+// its index in servers. The server is nil if the diagnostics contain an error.
+func (s *muxServer) lookup(ctx context.Context, kind muxrouter.Kind, name string) (tfprotov5.ProviderServer, int, []*tfprotov5.Diagnostic, error) {
+	route, diags, err := s.router.Lookup(ctx, kind, name)
 
-	if discoveryComplete {
-		if ok {
-			return server, s.serverDiscoveryDiagnostics, nil
-		}
-
-		return nil, []*tfprotov5.Diagnostic{
-			actionMissingError(actionType),
-		}, nil
+	if err != nil || diagnosticsHasError(diags) {
+		return nil, -1, diags, err
 	}
 
-	err := s.serverDiscovery(ctx)
+	return s.servers[route.Server], route.Server, diags, err
+}
 
-	if err != nil || diagnosticsHasError(s.serverDiscoveryDiagnostics) {
-		return nil, s.serverDiscoveryDiagnostics, err
-	}
+func (s *muxServer) getActionServer(ctx context.Context, actionType string) (tfprotov5.ProviderServer, int, []*tfprotov5.Diagnostic, error) {
+	return s.lookup(ctx, muxrouter.KindAction, actionType)
+}
 
-	s.serverDiscoveryMutex.RLock()
-	server, ok = s.actions[actionType]
-	s.serverDiscoveryMutex.RUnlock()
+func (s *muxServer) getDataSourceServer(ctx context.Context, typeName string) (tfprotov5.ProviderServer, int, []*tfprotov5.Diagnostic, error) {
+	return s.lookup(ctx, muxrouter.KindDataSource, typeName)
+}
 
-	if !ok {
-		return nil, []*tfprotov5.Diagnostic{
-			actionMissingError(actionType),
-		}, nil
-	}
+func (s *muxServer) getEphemeralResourceServer(ctx context.Context, typeName string) (tfprotov5.ProviderServer, int, []*tfprotov5.Diagnostic, error) {
+	return s.lookup(ctx, muxrouter.KindEphemeralResource, typeName)
+}
 
-	return server, s.serverDiscoveryDiagnostics, nil
+func (s *muxServer) getListResourceServer(ctx context.Context, typeName string) (tfprotov5.ProviderServer, int, []*tfprotov5.Diagnostic, error) {
+	return s.lookup(ctx, muxrouter.KindListResource, typeName)
 }
 
-func (s *muxServer) getDataSourceServer(ctx context.Context, typeName string) (tfprotov5.ProviderServer, []*tfprotov5.Diagnostic, error) {
-	s.serverDiscoveryMutex.RLock()
-	server, ok := s.dataSources[typeName]
-	discoveryComplete := s.serverDiscoveryComplete
-	s.serverDiscoveryMutex.RUnlock()
+func (s *muxServer) getFunctionServer(ctx context.Context, name string) (tfprotov5.ProviderServer, int, []*tfprotov5.Diagnostic, error) {
+	return s.lookup(ctx, muxrouter.KindFunction, name)
+}
 
-	if discoveryComplete {
-		if ok {
-			return server, s.serverDiscoveryDiagnostics, nil
-		}
+func (s *muxServer) getResourceServer(ctx context.Context, typeName string) (tfprotov5.ProviderServer, int, []*tfprotov5.Diagnostic, error) {
+	return s.lookup(ctx, muxrouter.KindResource, typeName)
+}
 
-		return nil, []*tfprotov5.Diagnostic{
-			dataSourceMissingError(typeName),
-		}, nil
-	}
+// serverDiscovery returns the types implemented by an underlying server by
+// calling its GetMetadata RPC and falling back to the GetProviderSchema RPC.
+// It is intended to only be called by the router.
+//
+// The error return represents gRPC errors, which except for the GetMetadata
+// call returning the gRPC unimplemented error, is always returned.
+func (s *muxServer) serverDiscovery(ctx context.Context, serverIndex int) (*muxrouter.ServerTypes[*tfprotov5.ServerCapabilities, *tfprotov5.Diagnostic], error) {
+	server := s.servers[serverIndex]
+	ctx, span := s.startDiscoverySpan(ctx, server)
+	defer span.End()
 
-	err := s.serverDiscovery(ctx)
+	ctx = logging.Tfprotov5ProviderServerContext(ctx, server)
 
-	if err != nil || diagnosticsHasError(s.serverDiscoveryDiagnostics) {
-		return nil, s.serverDiscoveryDiagnostics, err
-	}
+	// Downgraded protocol version 6 servers which are not compatible with
+	// protocol version 5 are not routed.
+	downgradeDiags, err := s.downgrades[serverIndex].check(ctx)
 
-	s.serverDiscoveryMutex.RLock()
-	server, ok = s.dataSources[typeName]
-	s.serverDiscoveryMutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
 
-	if !ok {
-		return nil, []*tfprotov5.Diagnostic{
-			dataSourceMissingError(typeName),
+	if len(downgradeDiags) > 0 {
+		return &muxrouter.ServerTypes[*tfprotov5.ServerCapabilities, *tfprotov5.Diagnostic]{
+			Diagnostics: downgradeDiags,
 		}, nil
 	}
 
-	return server, s.serverDiscoveryDiagnostics, nil
-}
+	ctx = logging.RpcContext(ctx, "GetMetadata")
 
-func (s *muxServer) getEphemeralResourceServer(ctx context.Context, typeName string) (tfprotov5.ProviderServer, []*tfprotov5.Diagnostic, error) {
-	s.serverDiscoveryMutex.RLock()
-	server, ok := s.ephemeralResources[typeName]
-	discoveryComplete := s.serverDiscoveryComplete
-	s.serverDiscoveryMutex.RUnlock()
+	logging.MuxTrace(ctx, "calling GetMetadata for discovery")
+	metadataResp, err := intercept(ctx, s.interceptors, &CallInfo{RPC: "GetMetadata", Server: server, serverIndex: serverIndex}, &tfprotov5.GetMetadataRequest{}, server.GetMetadata)
 
-	if discoveryComplete {
-		if ok {
-			return server, s.serverDiscoveryDiagnostics, nil
+	if err == nil && metadataResp != nil {
+		serverTypes := &muxrouter.ServerTypes[*tfprotov5.ServerCapabilities, *tfprotov5.Diagnostic]{
+			Capabilities: metadataResp.ServerCapabilities,
+			Diagnostics:  metadataResp.Diagnostics,
+			TypeNames:    make(map[muxrouter.Kind][]string),
 		}
 
-		return nil, []*tfprotov5.Diagnostic{
-			ephemeralResourceMissingError(typeName),
-		}, nil
-	}
-
-	err := s.serverDiscovery(ctx)
-
-	if err != nil || diagnosticsHasError(s.serverDiscoveryDiagnostics) {
-		return nil, s.serverDiscoveryDiagnostics, err
-	}
+		for _, serverAction := range metadataResp.Actions {
+			serverTypes.TypeNames[muxrouter.KindAction] = append(serverTypes.TypeNames[muxrouter.KindAction], serverAction.TypeName)
+		}
 
-	s.serverDiscoveryMutex.RLock()
-	server, ok = s.ephemeralResources[typeName]
-	s.serverDiscoveryMutex.RUnlock()
+		for _, serverDataSource := range metadataResp.DataSources {
+			serverTypes.TypeNames[muxrouter.KindDataSource] = append(serverTypes.TypeNames[muxrouter.KindDataSource], serverDataSource.TypeName)
+		}
 
-	if !ok {
-		return nil, []*tfprotov5.Diagnostic{
-			ephemeralResourceMissingError(typeName),
-		}, nil
-	}
+		for _, serverEphemeralResource := range metadataResp.EphemeralResources {
+			serverTypes.TypeNames[muxrouter.KindEphemeralResource] = append(serverTypes.TypeNames[muxrouter.KindEphemeralResource], serverEphemeralResource.TypeName)
+		}
 
-	return server, s.serverDiscoveryDiagnostics, nil
-}
+		for _, serverListResource := range metadataResp.ListResources {
+			serverTypes.TypeNames[muxrouter.KindListResource] = append(serverTypes.TypeNames[muxrouter.KindListResource], serverListResource.TypeName)
+		}
 
-func (s *muxServer) getListResourceServer(ctx context.Context, typeName string) (tfprotov5.ProviderServer, []*tfprotov5.Diagnostic, error) {
-	s.serverDiscoveryMutex.RLock()
-	server, ok := s.listResources[typeName]
-	discoveryComplete := s.serverDiscoveryComplete
-	s.serverDiscoveryMutex.RUnlock()
+		for _, serverFunction := range metadataResp.Functions {
+			serverTypes.TypeNames[muxrouter.KindFunction] = append(serverTypes.TypeNames[muxrouter.KindFunction], serverFunction.Name)
+		}
 
-	if discoveryComplete {
-		if ok {
-			return server, s.serverDiscoveryDiagnostics, nil
+		for _, serverResource := range metadataResp.Resources {
+			serverTypes.TypeNames[muxrouter.KindResource] = append(serverTypes.TypeNames[muxrouter.KindResource], serverResource.TypeName)
 		}
 
-		return nil, []*tfprotov5.Diagnostic{
-			listResourceMissingError(typeName),
-		}, nil
+		return serverTypes, nil
 	}
 
-	err := s.serverDiscovery(ctx)
+	// Only continue if the gRPC error was an unimplemented code, otherwise
+	// return any other gRPC error immediately.
+	grpcStatus, ok := status.FromError(err)
 
-	if err != nil || diagnosticsHasError(s.serverDiscoveryDiagnostics) {
-		return nil, s.serverDiscoveryDiagnostics, err
+	if !ok || grpcStatus.Code() != codes.Unimplemented {
+		return nil, err
 	}
 
-	s.serverDiscoveryMutex.RLock()
-	server, ok = s.listResources[typeName]
-	s.serverDiscoveryMutex.RUnlock()
+	logging.MuxTrace(ctx, "calling GetProviderSchema for discovery")
+	providerSchemaResp, err := intercept(ctx, s.interceptors, &CallInfo{RPC: "GetProviderSchema", Server: server, serverIndex: serverIndex}, &tfprotov5.GetProviderSchemaRequest{}, server.GetProviderSchema)
 
-	if !ok {
-		return nil, []*tfprotov5.Diagnostic{
-			listResourceMissingError(typeName),
-		}, nil
+	if err != nil {
+		return nil, err
 	}
 
-	return server, s.serverDiscoveryDiagnostics, nil
-}
-
-func (s *muxServer) getFunctionServer(ctx context.Context, name string) (tfprotov5.ProviderServer, []*tfprotov5.Diagnostic, error) {
-	s.serverDiscoveryMutex.RLock()
-	server, ok := s.functions[name]
-	discoveryComplete := s.serverDiscoveryComplete
-	s.serverDiscoveryMutex.RUnlock()
-
-	if discoveryComplete {
-		if ok {
-			return server, s.serverDiscoveryDiagnostics, nil
-		}
-
-		return nil, []*tfprotov5.Diagnostic{
-			functionMissingError(name),
-		}, nil
+	serverTypes := &muxrouter.ServerTypes[*tfprotov5.ServerCapabilities, *tfprotov5.Diagnostic]{
+		Capabilities: providerSchemaResp.ServerCapabilities,
+		Diagnostics:  providerSchemaResp.Diagnostics,
+		TypeNames:    make(map[muxrouter.Kind][]string),
 	}
 
-	err := s.serverDiscovery(ctx)
-
-	if err != nil || diagnosticsHasError(s.serverDiscoveryDiagnostics) {
-		return nil, s.serverDiscoveryDiagnostics, err
+	for actionType := range providerSchemaResp.ActionSchemas {
+		serverTypes.TypeNames[muxrouter.KindAction] = append(serverTypes.TypeNames[muxrouter.KindAction], actionType)
 	}
 
-	s.serverDiscoveryMutex.RLock()
-	server, ok = s.functions[name]
-	s.serverDiscoveryMutex.RUnlock()
-
-	if !ok {
-		return nil, []*tfprotov5.Diagnostic{
-			functionMissingError(name),
-		}, nil
+	for typeName := range providerSchemaResp.DataSourceSchemas {
+		serverTypes.TypeNames[muxrouter.KindDataSource] = append(serverTypes.TypeNames[muxrouter.KindDataSource], typeName)
 	}
 
-	return server, s.serverDiscoveryDiagnostics, nil
-}
-
-func (s *muxServer) getResourceServer(ctx context.Context, typeName string) (tfprotov5.ProviderServer, []*tfprotov5.Diagnostic, error) {
-	s.serverDiscoveryMutex.RLock()
-	server, ok := s.resources[typeName]
-	discoveryComplete := s.serverDiscoveryComplete
-	s.serverDiscoveryMutex.RUnlock()
-
-	if discoveryComplete {
-		if ok {
-			return server, s.serverDiscoveryDiagnostics, nil
-		}
-
-		return nil, []*tfprotov5.Diagnostic{
-			resourceMissingError(typeName),
-		}, nil
+	for typeName := range providerSchemaResp.EphemeralResourceSchemas {
+		serverTypes.TypeNames[muxrouter.KindEphemeralResource] = append(serverTypes.TypeNames[muxrouter.KindEphemeralResource], typeName)
 	}
 
-	err := s.serverDiscovery(ctx)
-
-	if err != nil || diagnosticsHasError(s.serverDiscoveryDiagnostics) {
-		return nil, s.serverDiscoveryDiagnostics, err
+	for typeName := range providerSchemaResp.ListResourceSchemas {
+		serverTypes.TypeNames[muxrouter.KindListResource] = append(serverTypes.TypeNames[muxrouter.KindListResource], typeName)
 	}
 
-	s.serverDiscoveryMutex.RLock()
-	server, ok = s.resources[typeName]
-	s.serverDiscoveryMutex.RUnlock()
+	for name := range providerSchemaResp.Functions {
+		serverTypes.TypeNames[muxrouter.KindFunction] = append(serverTypes.TypeNames[muxrouter.KindFunction], name)
+	}
 
-	if !ok {
-		return nil, []*tfprotov5.Diagnostic{
-			resourceMissingError(typeName),
-		}, nil
+	for typeName := range providerSchemaResp.ResourceSchemas {
+		serverTypes.TypeNames[muxrouter.KindResource] = append(serverTypes.TypeNames[muxrouter.KindResource], typeName)
 	}
 
-	return server, s.serverDiscoveryDiagnostics, nil
+	return serverTypes, nil
 }
 
-// serverDiscovery will populate the mux server "routing" for functions and
-// resource types by calling all underlying server GetMetadata RPC and falling
-// back to GetProviderSchema RPC. It is intended to only be called through
-// getActionServer, getDataSourceServer, getEphemeralResourceServer, getListResourceServer,
-// getFunctionServer, and getResourceServer.
+// NewMuxServer returns a muxed server that will route gRPC requests between
+// tfprotov5.ProviderServers specified. Protocol version 6 servers can be
+// included by wrapping them with FromProtocol6. The GetProviderSchema method
+// of each is called to verify that the overall muxed server is compatible by
+// ensuring:
 //
-// The error return represents gRPC errors, which except for the GetMetadata
-// call returning the gRPC unimplemented error, is always returned.
-func (s *muxServer) serverDiscovery(ctx context.Context) error {
-	s.serverDiscoveryMutex.Lock()
-	defer s.serverDiscoveryMutex.Unlock()
-
-	// Return early if subsequent concurrent operations reached this logic.
-	if s.serverDiscoveryComplete {
-		return nil
-	}
-
-	logging.MuxTrace(ctx, "starting underlying server discovery via GetMetadata or GetProviderSchema")
+//   - All provider schemas exactly match
+//   - All provider meta schemas exactly match
+//   - Only one provider implements each action
+//   - Only one provider implements each managed resource
+//   - Only one provider implements each data source
+//   - Only one provider implements each function
+//   - Only one provider implements each ephemeral resource
+//   - Only one provider implements each list resource
+//   - Only one provider implements each resource identity
+//   - All protocol version 6 servers from FromProtocol6 are compatible with
+//     protocol version 5, which is checked when they are discovered
+//
+// Servers returned by NewMuxServer can themselves be underlying servers, such
+// as a shared set of servers that is already combined. Their underlying
+// servers are added directly to the returned server, so each request is only
+// routed once and logs and errors refer to the server which handles it.
+func NewMuxServer(ctx context.Context, servers ...func() tfprotov5.ProviderServer) (*muxServer, error) {
+	return NewMuxServerWithOptions(ctx, servers)
+}
 
-	for _, server := range s.servers {
-		ctx := logging.Tfprotov5ProviderServerContext(ctx, server)
-		ctx = logging.RpcContext(ctx, "GetMetadata")
+// NewMuxServerWithOptions is the same as NewMuxServer, with options such as
+// WithConsistencyChecks, WithInterceptors, WithMetrics, WithPanicRecovery,
+// WithRecording, WithResponseValidation, WithServerConcurrencyLimit,
+// WithSlowCallThresholds, WithTracer, and WithTypeConcurrencyLimit.
+func NewMuxServerWithOptions(_ context.Context, servers []func() tfprotov5.ProviderServer, opts ...MuxServerOption) (*muxServer, error) {
+	var options muxServerOptions
 
-		logging.MuxTrace(ctx, "calling GetMetadata for discovery")
-		metadataResp, err := server.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+	for _, opt := range opts {
+		opt(&options)
+	}
 
-		// GetMetadata call was successful, populate caches and move on to next
-		// underlying server.
-		if err == nil && metadataResp != nil {
-			// Collect all underlying server diagnostics, but skip early return.
-			s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, metadataResp.Diagnostics...)
+	result := muxServer{
+		interceptors: options.interceptors,
+	}
 
-			for _, serverAction := range metadataResp.Actions {
-				if _, ok := s.actions[serverAction.TypeName]; ok {
-					s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, actionDuplicateError(serverAction.TypeName))
+	// schemas is shared by the options which decode DynamicValues.
+	schemas := newSchemaCache()
 
-					continue
-				}
+	// Concurrency is limited before calls are traced, measured and detected
+	// as slow, so their durations exclude the time waiting for the limits.
+	// Calls which panic still release the limits, as they are released once
+	// the call returns.
+	var limiter *concurrencyLimiter
 
-				s.actions[serverAction.TypeName] = server
-			}
+	if len(options.serverConcurrencyLimits) > 0 || len(options.typeConcurrencyLimits) > 0 {
+		var err error
 
-			for _, serverDataSource := range metadataResp.DataSources {
-				if _, ok := s.dataSources[serverDataSource.TypeName]; ok {
-					s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, dataSourceDuplicateError(serverDataSource.TypeName))
+		limiter, err = newConcurrencyLimiter(options.serverConcurrencyLimits, options.typeConcurrencyLimits, len(servers))
 
-					continue
-				}
+		if err != nil {
+			return nil, err
+		}
 
-				s.dataSources[serverDataSource.TypeName] = server
-			}
+		result.interceptors = append(result.interceptors, limiter.intercept)
+	}
 
-			for _, serverEphemeralResource := range metadataResp.EphemeralResources {
-				if _, ok := s.ephemeralResources[serverEphemeralResource.TypeName]; ok {
-					s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, ephemeralResourceDuplicateError(serverEphemeralResource.TypeName))
+	if options.tracer != nil {
+		result.tracer = options.tracer
+		result.interceptors = append(result.interceptors, traceCalls(options.tracer))
+	}
 
-					continue
-				}
+	if options.metrics != nil {
+		result.interceptors = append(result.interceptors, recordMetrics(options.metrics))
+	}
 
-				s.ephemeralResources[serverEphemeralResource.TypeName] = server
-			}
+	// Slow calls are detected next to metrics, so both measure the same
+	// duration of each call.
+	if len(options.slowCallThresholds) > 0 {
+		result.interceptors = append(result.interceptors, detectSlowCalls(options.slowCallThresholds, options.slowCallDiagnostics))
+	}
 
-			for _, serverListResource := range metadataResp.ListResources {
-				if _, ok := s.listResources[serverListResource.TypeName]; ok {
-					s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, listResourceDuplicateError(serverListResource.TypeName))
+	// Consistency is checked after responses are validated, so values which
+	// do not conform to the schema are only returned once.
+	if options.consistencyChecks {
+		result.interceptors = append(result.interceptors, newConsistencyChecker(schemas).intercept)
+	}
 
-					continue
-				}
+	// Responses are validated after they are recorded, so recordings contain
+	// the values returned by the underlying server, and before they are
+	// traced and measured, so those include the error diagnostics.
+	if options.responseValidation {
+		result.interceptors = append(result.interceptors, validateResponses(schemas))
+	}
 
-				s.listResources[serverListResource.TypeName] = server
-			}
+	// Calls are recorded after panics are recovered, so recordings contain
+	// the response of a recovered panic.
+	if options.recording != nil {
+		result.interceptors = append(result.interceptors, newRecorder(options.recording, schemas).intercept)
+	}
 
-			for _, serverFunction := range metadataResp.Functions {
-				if _, ok := s.functions[serverFunction.Name]; ok {
-					s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, functionDuplicateError(serverFunction.Name))
+	// Payloads are logged after panics are recovered, so logs contain the
+	// response of a recovered panic.
+	if logging.MuxPayloadsEnabled() {
+		result.interceptors = append(result.interceptors, logPayloads(schemas))
+	}
 
-					continue
-				}
+	// Panics are recovered closest to the underlying server, so other
+	// interceptors receive the response of a recovered panic.
+	if options.panicRecovery {
+		result.interceptors = append(result.interceptors, recoverPanics)
+	}
 
-				s.functions[serverFunction.Name] = server
-			}
+	// serverIndexes are the index in servers of each underlying server.
+	var serverIndexes []int
 
-			for _, serverResource := range metadataResp.Resources {
-				if _, ok := s.resources[serverResource.TypeName]; ok {
-					s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, resourceDuplicateError(serverResource.TypeName))
+	for serverIndex, server := range servers {
+		underlyingServer := server()
 
-					continue
-				}
+		// Nested mux servers are flattened, so requests are routed directly
+		// to their underlying servers rather than through a second layer of
+		// discovery and logging. Nested mux servers with interceptors are
+		// kept, so their interceptors are still called.
+		if nestedServer, ok := underlyingServer.(*muxServer); ok && len(nestedServer.interceptors) == 0 {
+			result.servers = append(result.servers, nestedServer.servers...)
+			result.downgrades = append(result.downgrades, nestedServer.downgrades...)
 
-				s.resources[serverResource.TypeName] = server
-				s.resourceCapabilities[serverResource.TypeName] = metadataResp.ServerCapabilities
+			for range nestedServer.servers {
+				serverIndexes = append(serverIndexes, serverIndex)
 			}
 
 			continue
 		}
 
-		// Only continue if the gRPC error was an unimplemented code, otherwise
-		// return any other gRPC error immediately.
-		grpcStatus, ok := status.FromError(err)
+		// Servers from FromProtocol6 are replaced by their downgrade, which
+		// is checked when the server is discovered.
+		if downgraded, ok := underlyingServer.(*downgradedServer); ok {
+			result.servers = append(result.servers, downgraded.ProviderServer)
+			result.downgrades = append(result.downgrades, &downgrade{server: downgraded.ProviderServer})
+			serverIndexes = append(serverIndexes, serverIndex)
 
-		if !ok || grpcStatus.Code() != codes.Unimplemented {
-			return err
-		}
-
-		logging.MuxTrace(ctx, "calling GetProviderSchema for discovery")
-		providerSchemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
-
-		if err != nil {
-			return err
-		}
-
-		// Collect all underlying server diagnostics, but skip early return.
-		s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, providerSchemaResp.Diagnostics...)
-
-		for actionType := range providerSchemaResp.ActionSchemas {
-			if _, ok := s.actions[actionType]; ok {
-				s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, actionDuplicateError(actionType))
-
-				continue
-			}
-
-			s.actions[actionType] = server
+			continue
 		}
 
-		for typeName := range providerSchemaResp.DataSourceSchemas {
-			if _, ok := s.dataSources[typeName]; ok {
-				s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, dataSourceDuplicateError(typeName))
-
-				continue
-			}
-
-			s.dataSources[typeName] = server
-		}
+		result.servers = append(result.servers, underlyingServer)
+		result.downgrades = append(result.downgrades, nil)
+		serverIndexes = append(serverIndexes, serverIndex)
+	}
 
-		for typeName := range providerSchemaResp.EphemeralResourceSchemas {
-			if _, ok := s.ephemeralResources[typeName]; ok {
-				s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, ephemeralResourceDuplicateError(typeName))
+	if limiter != nil {
+		limiter.serverIndexes = serverIndexes
+	}
 
-				continue
-			}
+	// The router routes to the index of each underlying server in servers.
+	serverPositions := make([]int, len(result.servers))
 
-			s.ephemeralResources[typeName] = server
-		}
+	for position := range serverPositions {
+		serverPositions[position] = position
+	}
 
-		for typeName := range providerSchemaResp.ListResourceSchemas {
-			if _, ok := s.listResources[typeName]; ok {
-				s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, listResourceDuplicateError(typeName))
+	result.router = muxrouter.New(
+		muxrouter.Protocol[int, *tfprotov5.ServerCapabilities, *tfprotov5.Diagnostic]{
+			Discover:           result.serverDiscovery,
+			NewErrorDiagnostic: newErrorDiagnostic,
+			IsErrorDiagnostic:  isErrorDiagnostic,
+		},
+		serverPositions...,
+	)
 
-				continue
-			}
+	return &result, nil
+}
 
-			s.listResources[typeName] = server
-		}
+// MuxServerOption is an option for the NewMuxServerWithOptions function.
+type MuxServerOption func(*muxServerOptions)
 
-		for name := range providerSchemaResp.Functions {
-			if _, ok := s.functions[name]; ok {
-				s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, functionDuplicateError(name))
+// muxServerOptions contains the configuration of NewMuxServerWithOptions.
+type muxServerOptions struct {
+	// consistencyChecks enables checking the consistency of planned and new
+	// states of resources.
+	consistencyChecks bool
 
-				continue
-			}
+	// interceptors are called for each call to an underlying server.
+	interceptors []Interceptor
 
-			s.functions[name] = server
-		}
+	// metrics records each call to an underlying server.
+	metrics muxmetrics.Metrics
 
-		for typeName := range providerSchemaResp.ResourceSchemas {
-			if _, ok := s.resources[typeName]; ok {
-				s.serverDiscoveryDiagnostics = append(s.serverDiscoveryDiagnostics, resourceDuplicateError(typeName))
+	// panicRecovery enables recovering panics in underlying servers.
+	panicRecovery bool
 
-				continue
-			}
+	// recording is written with each call to an underlying server.
+	recording io.Writer
 
-			s.resources[typeName] = server
-			s.resourceCapabilities[typeName] = providerSchemaResp.ServerCapabilities
-		}
-	}
+	// responseValidation enables validating the values returned by
+	// underlying servers against their schema.
+	responseValidation bool
 
-	s.serverDiscoveryComplete = true
+	// serverConcurrencyLimits are the maximum numbers of concurrent calls
+	// to underlying servers, keyed by the index of the server.
+	serverConcurrencyLimits map[int]int
 
-	return nil
-}
+	// slowCallDiagnostics enables returning a warning diagnostic in the
+	// responses of slow calls.
+	slowCallDiagnostics bool
 
-// NewMuxServer returns a muxed server that will route gRPC requests between
-// tfprotov5.ProviderServers specified. The GetProviderSchema method of each
-// is called to verify that the overall muxed server is compatible by ensuring:
-//
-//   - All provider schemas exactly match
-//   - All provider meta schemas exactly match
-//   - Only one provider implements each action
-//   - Only one provider implements each managed resource
-//   - Only one provider implements each data source
-//   - Only one provider implements each function
-//   - Only one provider implements each ephemeral resource
-//   - Only one provider implements each list resource
-//   - Only one provider implements each resource identity
-func NewMuxServer(_ context.Context, servers ...func() tfprotov5.ProviderServer) (*muxServer, error) {
-	result := muxServer{
-		actions:              make(map[string]tfprotov5.ProviderServer),
-		dataSources:          make(map[string]tfprotov5.ProviderServer),
-		ephemeralResources:   make(map[string]tfprotov5.ProviderServer),
-		listResources:        make(map[string]tfprotov5.ProviderServer),
-		functions:            make(map[string]tfprotov5.ProviderServer),
-		resources:            make(map[string]tfprotov5.ProviderServer),
-		resourceCapabilities: make(map[string]*tfprotov5.ServerCapabilities),
-	}
+	// slowCallThresholds are the durations, keyed by RPC, after which calls
+	// to underlying servers are logged as slow.
+	slowCallThresholds map[string]time.Duration
 
-	for _, server := range servers {
-		result.servers = append(result.servers, server())
-	}
+	// tracer creates spans for RPCs, server discovery, and each call to an
+	// underlying server.
+	tracer muxtrace.Tracer
 
-	return &result, nil
+	// typeConcurrencyLimits are the maximum numbers of concurrent calls for
+	// type names.
+	typeConcurrencyLimits map[string]int
 }
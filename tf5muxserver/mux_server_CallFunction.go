@@ -18,8 +18,10 @@ func (s *muxServer) CallFunction(ctx context.Context, req *tfprotov5.CallFunctio
 	rpc := "CallFunction"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getFunctionServer(ctx, req.Name)
+	server, serverIndex, diags, err := s.getFunctionServer(ctx, req.Name)
 
 	if err != nil {
 		return nil, err
@@ -48,5 +50,5 @@ func (s *muxServer) CallFunction(ctx context.Context, req *tfprotov5.CallFunctio
 	ctx = logging.Tfprotov5ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return server.CallFunction(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.Name}, req, server.CallFunction)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 
 	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/muxrouter"
 )
 
 // GetFunctions merges the functions returned by the tfprotov5.ProviderServers
@@ -19,20 +20,37 @@ func (s *muxServer) GetFunctions(ctx context.Context, req *tfprotov5.GetFunction
 	rpc := "GetFunctions"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	s.serverDiscoveryMutex.Lock()
-	defer s.serverDiscoveryMutex.Unlock()
+	// Routing is built separately and published after all underlying servers
+	// have responded, so concurrent routed RPCs are not blocked.
+	routing := s.router.NewBuilder()
 
 	resp := &tfprotov5.GetFunctionsResponse{
 		Functions: make(map[string]*tfprotov5.Function),
 	}
 
-	for _, server := range s.servers {
+	for serverIndex, server := range s.servers {
 		ctx := logging.Tfprotov5ProviderServerContext(ctx, server)
 
+		// Downgraded protocol version 6 servers which are not compatible
+		// with protocol version 5 are not called.
+		downgradeDiags, err := s.downgrades[serverIndex].check(ctx)
+
+		if err != nil {
+			return resp, fmt.Errorf("error checking protocol version 5 compatibility of %T: %w", server, err)
+		}
+
+		if len(downgradeDiags) > 0 {
+			resp.Diagnostics = append(resp.Diagnostics, downgradeDiags...)
+
+			continue
+		}
+
 		logging.MuxTrace(ctx, "calling downstream server")
 
-		serverResp, err := server.GetFunctions(ctx, &tfprotov5.GetFunctionsRequest{})
+		serverResp, err := intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex}, &tfprotov5.GetFunctionsRequest{}, server.GetFunctions)
 
 		if err != nil {
 			return resp, fmt.Errorf("error calling GetFunctions for %T: %w", server, err)
@@ -41,19 +59,19 @@ func (s *muxServer) GetFunctions(ctx context.Context, req *tfprotov5.GetFunction
 		resp.Diagnostics = append(resp.Diagnostics, serverResp.Diagnostics...)
 
 		for name, definition := range serverResp.Functions {
-			if _, ok := resp.Functions[name]; ok {
-				resp.Diagnostics = append(resp.Diagnostics, functionDuplicateError(name))
+			if diag, ok := routing.Add(muxrouter.KindFunction, name, serverIndex, nil); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.functions[name] = server
 			resp.Functions[name] = definition
 		}
 	}
 
 	// Intentionally not setting overall server discovery as complete, as data
 	// sources and resources are not discovered via this RPC.
+	s.router.Publish(routing, muxrouter.KindFunction)
 
 	return resp, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 
 	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/muxrouter"
 )
 
 // GetMetadata merges the metadata returned by the
@@ -20,9 +21,12 @@ func (s *muxServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataR
 	rpc := "GetMetadata"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	s.serverDiscoveryMutex.Lock()
-	defer s.serverDiscoveryMutex.Unlock()
+	// Routing is built separately and published after all underlying servers
+	// have responded, so concurrent routed RPCs are not blocked.
+	routing := s.router.NewBuilder()
 
 	resp := &tfprotov5.GetMetadataResponse{
 		Actions:            make([]tfprotov5.ActionMetadata, 0),
@@ -34,11 +38,25 @@ func (s *muxServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataR
 		ServerCapabilities: serverCapabilities,
 	}
 
-	for _, server := range s.servers {
+	for serverIndex, server := range s.servers {
 		ctx := logging.Tfprotov5ProviderServerContext(ctx, server)
+		// Downgraded protocol version 6 servers which are not compatible
+		// with protocol version 5 are not called.
+		downgradeDiags, err := s.downgrades[serverIndex].check(ctx)
+
+		if err != nil {
+			return resp, fmt.Errorf("error checking protocol version 5 compatibility of %T: %w", server, err)
+		}
+
+		if len(downgradeDiags) > 0 {
+			resp.Diagnostics = append(resp.Diagnostics, downgradeDiags...)
+
+			continue
+		}
+
 		logging.MuxTrace(ctx, "calling downstream server")
 
-		serverResp, err := server.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+		serverResp, err := intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex}, &tfprotov5.GetMetadataRequest{}, server.GetMetadata)
 
 		if err != nil {
 			return resp, fmt.Errorf("error calling GetMetadata for %T: %w", server, err)
@@ -47,132 +65,67 @@ func (s *muxServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataR
 		resp.Diagnostics = append(resp.Diagnostics, serverResp.Diagnostics...)
 
 		for _, action := range serverResp.Actions {
-			if actionMetadataContainsTypeName(resp.Actions, action.TypeName) {
-				resp.Diagnostics = append(resp.Diagnostics, actionDuplicateError(action.TypeName))
+			if diag, ok := routing.Add(muxrouter.KindAction, action.TypeName, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.actions[action.TypeName] = server
 			resp.Actions = append(resp.Actions, action)
 		}
 
 		for _, datasource := range serverResp.DataSources {
-			if datasourceMetadataContainsTypeName(resp.DataSources, datasource.TypeName) {
-				resp.Diagnostics = append(resp.Diagnostics, dataSourceDuplicateError(datasource.TypeName))
+			if diag, ok := routing.Add(muxrouter.KindDataSource, datasource.TypeName, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.dataSources[datasource.TypeName] = server
 			resp.DataSources = append(resp.DataSources, datasource)
 		}
 
 		for _, ephemeralResource := range serverResp.EphemeralResources {
-			if ephemeralResourceMetadataContainsTypeName(resp.EphemeralResources, ephemeralResource.TypeName) {
-				resp.Diagnostics = append(resp.Diagnostics, ephemeralResourceDuplicateError(ephemeralResource.TypeName))
+			if diag, ok := routing.Add(muxrouter.KindEphemeralResource, ephemeralResource.TypeName, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.ephemeralResources[ephemeralResource.TypeName] = server
 			resp.EphemeralResources = append(resp.EphemeralResources, ephemeralResource)
 		}
 
 		for _, listResource := range serverResp.ListResources {
-			if listResourceMetadataContainsTypeName(resp.ListResources, listResource.TypeName) {
-				resp.Diagnostics = append(resp.Diagnostics, listResourceDuplicateError(listResource.TypeName))
+			if diag, ok := routing.Add(muxrouter.KindListResource, listResource.TypeName, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.listResources[listResource.TypeName] = server
 			resp.ListResources = append(resp.ListResources, listResource)
 		}
 
 		for _, function := range serverResp.Functions {
-			if functionMetadataContainsName(resp.Functions, function.Name) {
-				resp.Diagnostics = append(resp.Diagnostics, functionDuplicateError(function.Name))
+			if diag, ok := routing.Add(muxrouter.KindFunction, function.Name, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.functions[function.Name] = server
 			resp.Functions = append(resp.Functions, function)
 		}
 
 		for _, resource := range serverResp.Resources {
-			if resourceMetadataContainsTypeName(resp.Resources, resource.TypeName) {
-				resp.Diagnostics = append(resp.Diagnostics, resourceDuplicateError(resource.TypeName))
+			if diag, ok := routing.Add(muxrouter.KindResource, resource.TypeName, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.resources[resource.TypeName] = server
-			s.resourceCapabilities[resource.TypeName] = serverResp.ServerCapabilities
 			resp.Resources = append(resp.Resources, resource)
 		}
 	}
 
-	return resp, nil
-}
-
-func actionMetadataContainsTypeName(metadatas []tfprotov5.ActionMetadata, typeName string) bool {
-	for _, metadata := range metadatas {
-		if typeName == metadata.TypeName {
-			return true
-		}
-	}
-
-	return false
-}
-
-func datasourceMetadataContainsTypeName(metadatas []tfprotov5.DataSourceMetadata, typeName string) bool {
-	for _, metadata := range metadatas {
-		if typeName == metadata.TypeName {
-			return true
-		}
-	}
-
-	return false
-}
-
-func ephemeralResourceMetadataContainsTypeName(metadatas []tfprotov5.EphemeralResourceMetadata, typeName string) bool {
-	for _, metadata := range metadatas {
-		if typeName == metadata.TypeName {
-			return true
-		}
-	}
-
-	return false
-}
-
-func listResourceMetadataContainsTypeName(metadatas []tfprotov5.ListResourceMetadata, typeName string) bool {
-	for _, metadata := range metadatas {
-		if typeName == metadata.TypeName {
-			return true
-		}
-	}
-
-	return false
-}
+	s.router.Publish(routing)
 
-func functionMetadataContainsName(metadatas []tfprotov5.FunctionMetadata, name string) bool {
-	for _, metadata := range metadatas {
-		if name == metadata.Name {
-			return true
-		}
-	}
-
-	return false
-}
-
-func resourceMetadataContainsTypeName(metadatas []tfprotov5.ResourceMetadata, typeName string) bool {
-	for _, metadata := range metadatas {
-		if typeName == metadata.TypeName {
-			return true
-		}
-	}
-
-	return false
+	return resp, nil
 }
@@ -16,8 +16,10 @@ func (s *muxServer) InvokeAction(ctx context.Context, req *tfprotov5.InvokeActio
 	rpc := "InvokeAction"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getActionServer(ctx, req.ActionType)
+	server, serverIndex, diags, err := s.getActionServer(ctx, req.ActionType)
 
 	if err != nil {
 		return nil, err
@@ -61,5 +63,5 @@ func (s *muxServer) InvokeAction(ctx context.Context, req *tfprotov5.InvokeActio
 	ctx = logging.Tfprotov5ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return actionServer.InvokeAction(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.ActionType}, req, actionServer.InvokeAction)
 }
@@ -16,8 +16,10 @@ func (s *muxServer) MoveResourceState(ctx context.Context, req *tfprotov5.MoveRe
 	rpc := "MoveResourceState"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getResourceServer(ctx, req.TargetTypeName)
+	server, serverIndex, diags, err := s.getResourceServer(ctx, req.TargetTypeName)
 
 	if err != nil {
 		return nil, err
@@ -32,5 +34,5 @@ func (s *muxServer) MoveResourceState(ctx context.Context, req *tfprotov5.MoveRe
 	ctx = logging.Tfprotov5ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return server.MoveResourceState(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.TargetTypeName}, req, server.MoveResourceState)
 }
@@ -15,8 +15,10 @@ func (s *muxServer) PlanAction(ctx context.Context, req *tfprotov5.PlanActionReq
 	rpc := "PlanAction"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getActionServer(ctx, req.ActionType)
+	server, serverIndex, diags, err := s.getActionServer(ctx, req.ActionType)
 
 	if err != nil {
 		return nil, err
@@ -48,5 +50,5 @@ func (s *muxServer) PlanAction(ctx context.Context, req *tfprotov5.PlanActionReq
 	ctx = logging.Tfprotov5ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return actionServer.PlanAction(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.ActionType}, req, actionServer.PlanAction)
 }
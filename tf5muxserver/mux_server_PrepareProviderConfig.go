@@ -19,16 +19,18 @@ func (s *muxServer) PrepareProviderConfig(ctx context.Context, req *tfprotov5.Pr
 	rpc := "PrepareProviderConfig"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
 	resp := &tfprotov5.PrepareProviderConfigResponse{
 		PreparedConfig: req.Config, // ignored by Terraform anyways
 	}
 
-	for _, server := range s.servers {
+	for serverIndex, server := range s.servers {
 		ctx = logging.Tfprotov5ProviderServerContext(ctx, server)
 		logging.MuxTrace(ctx, "calling downstream server")
 
-		res, err := server.PrepareProviderConfig(ctx, req)
+		res, err := intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex}, req, server.PrepareProviderConfig)
 
 		if err != nil {
 			return resp, fmt.Errorf("error from %T validating provider config: %w", server, err)
@@ -15,8 +15,10 @@ func (s *muxServer) RenewEphemeralResource(ctx context.Context, req *tfprotov5.R
 	rpc := "RenewEphemeralResource"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getEphemeralResourceServer(ctx, req.TypeName)
+	server, serverIndex, diags, err := s.getEphemeralResourceServer(ctx, req.TypeName)
 
 	if err != nil {
 		return nil, err
@@ -31,5 +33,5 @@ func (s *muxServer) RenewEphemeralResource(ctx context.Context, req *tfprotov5.R
 	ctx = logging.Tfprotov5ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return server.RenewEphemeralResource(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.TypeName}, req, server.RenewEphemeralResource)
 }
@@ -17,8 +17,10 @@ func (s *muxServer) UpgradeResourceIdentity(ctx context.Context, req *tfprotov5.
 	rpc := "UpgradeResourceIdentity"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getResourceServer(ctx, req.TypeName)
+	server, serverIndex, diags, err := s.getResourceServer(ctx, req.TypeName)
 
 	if err != nil {
 		return nil, err
@@ -33,5 +35,5 @@ func (s *muxServer) UpgradeResourceIdentity(ctx context.Context, req *tfprotov5.
 	ctx = logging.Tfprotov5ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return server.UpgradeResourceIdentity(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.TypeName}, req, server.UpgradeResourceIdentity)
 }
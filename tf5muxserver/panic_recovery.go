@@ -0,0 +1,206 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"runtime/debug"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+)
+
+// WithPanicRecovery is a NewMuxServerWithOptions option which recovers panics
+// in underlying servers, rather than crashing the provider process. Each
+// recovered panic is logged with its stack trace and returned to Terraform
+// as an error diagnostic, which names the RPC, type, and underlying server.
+// Panics in the ListResource and InvokeAction stream iterators are also
+// recovered, ending the stream with the error diagnostic.
+//
+// When ApplyResourceChange panics with a prior state, the prior state and
+// planned private state are returned, so Terraform keeps tracking the
+// resource as it was before the apply. When there is no prior state, such as
+// resource creation, no new state is returned.
+//
+// Panics are recovered after any interceptors from WithInterceptors, so
+// interceptors receive the error diagnostic response. Panics in interceptors
+// are not recovered.
+func WithPanicRecovery() MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.panicRecovery = true
+	}
+}
+
+// recoverPanics is the Interceptor added by WithPanicRecovery.
+func recoverPanics(ctx context.Context, info *CallInfo, req any, handler Handler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logPanic(ctx, r)
+
+			resp, err = errorResponse(info, req, panicDiagnostic(info, r))
+		}
+	}()
+
+	resp, err = handler(ctx, req)
+
+	switch typedResp := resp.(type) {
+	case *tfprotov5.InvokeActionServerStream:
+		if typedResp != nil && typedResp.Events != nil {
+			typedResp.Events = recoverStream(ctx, info, typedResp.Events, func(diag *tfprotov5.Diagnostic) tfprotov5.InvokeActionEvent {
+				return tfprotov5.InvokeActionEvent{
+					Type: tfprotov5.CompletedInvokeActionEventType{
+						Diagnostics: []*tfprotov5.Diagnostic{diag},
+					},
+				}
+			})
+		}
+	case *tfprotov5.ListResourceServerStream:
+		if typedResp != nil && typedResp.Results != nil {
+			typedResp.Results = recoverStream(ctx, info, typedResp.Results, func(diag *tfprotov5.Diagnostic) tfprotov5.ListResourceResult {
+				return tfprotov5.ListResourceResult{
+					Diagnostics: []*tfprotov5.Diagnostic{diag},
+				}
+			})
+		}
+	}
+
+	return resp, err
+}
+
+// recoverStream returns an iterator which recovers panics in the stream
+// iterator, ending the stream with the element returned by panicElement.
+// Panics in the consumer of the stream are not recovered.
+func recoverStream[T any](ctx context.Context, info *CallInfo, stream iter.Seq[T], panicElement func(*tfprotov5.Diagnostic) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		yielding := false
+
+		defer func() {
+			if yielding {
+				return
+			}
+
+			if r := recover(); r != nil {
+				logPanic(ctx, r)
+
+				yield(panicElement(panicDiagnostic(info, r)))
+			}
+		}()
+
+		for element := range stream {
+			yielding = true
+
+			if !yield(element) {
+				return
+			}
+
+			yielding = false
+		}
+	}
+}
+
+// logPanic logs a recovered panic with its stack trace.
+func logPanic(ctx context.Context, r any) {
+	logging.MuxError(ctx, "recovered panic from downstream server", map[string]interface{}{
+		logging.KeyPanic:      fmt.Sprint(r),
+		logging.KeyPanicStack: string(debug.Stack()),
+	})
+}
+
+// errorResponse returns the response of the RPC with the error diagnostic,
+// for calls which the underlying server did not handle, such as those which
+// panicked.
+func errorResponse(info *CallInfo, req any, diag *tfprotov5.Diagnostic) (any, error) {
+	diags := []*tfprotov5.Diagnostic{diag}
+
+	switch typedReq := req.(type) {
+	case *tfprotov5.ApplyResourceChangeRequest:
+		resp := &tfprotov5.ApplyResourceChangeResponse{
+			Diagnostics: diags,
+		}
+
+		if typedReq.PriorState != nil {
+			resp.NewState = typedReq.PriorState
+			resp.Private = typedReq.PlannedPrivate
+		}
+
+		return resp, nil
+	case *tfprotov5.CallFunctionRequest:
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{
+				Text: diag.Summary + ": " + diag.Detail,
+			},
+		}, nil
+	case *tfprotov5.CloseEphemeralResourceRequest:
+		return &tfprotov5.CloseEphemeralResourceResponse{Diagnostics: diags}, nil
+	case *tfprotov5.ConfigureProviderRequest:
+		return &tfprotov5.ConfigureProviderResponse{Diagnostics: diags}, nil
+	case *tfprotov5.GenerateResourceConfigRequest:
+		return &tfprotov5.GenerateResourceConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov5.GetFunctionsRequest:
+		return &tfprotov5.GetFunctionsResponse{Diagnostics: diags}, nil
+	case *tfprotov5.GetMetadataRequest:
+		return &tfprotov5.GetMetadataResponse{Diagnostics: diags}, nil
+	case *tfprotov5.GetProviderSchemaRequest:
+		return &tfprotov5.GetProviderSchemaResponse{Diagnostics: diags}, nil
+	case *tfprotov5.GetResourceIdentitySchemasRequest:
+		return &tfprotov5.GetResourceIdentitySchemasResponse{Diagnostics: diags}, nil
+	case *tfprotov5.ImportResourceStateRequest:
+		return &tfprotov5.ImportResourceStateResponse{Diagnostics: diags}, nil
+	case *tfprotov5.InvokeActionRequest:
+		return &tfprotov5.InvokeActionServerStream{
+			Events: func(yield func(tfprotov5.InvokeActionEvent) bool) {
+				yield(tfprotov5.InvokeActionEvent{
+					Type: tfprotov5.CompletedInvokeActionEventType{
+						Diagnostics: diags,
+					},
+				})
+			},
+		}, nil
+	case *tfprotov5.ListResourceRequest:
+		return &tfprotov5.ListResourceServerStream{
+			Results: func(yield func(tfprotov5.ListResourceResult) bool) {
+				yield(tfprotov5.ListResourceResult{
+					Diagnostics: diags,
+				})
+			},
+		}, nil
+	case *tfprotov5.MoveResourceStateRequest:
+		return &tfprotov5.MoveResourceStateResponse{Diagnostics: diags}, nil
+	case *tfprotov5.OpenEphemeralResourceRequest:
+		return &tfprotov5.OpenEphemeralResourceResponse{Diagnostics: diags}, nil
+	case *tfprotov5.PlanActionRequest:
+		return &tfprotov5.PlanActionResponse{Diagnostics: diags}, nil
+	case *tfprotov5.PlanResourceChangeRequest:
+		return &tfprotov5.PlanResourceChangeResponse{Diagnostics: diags}, nil
+	case *tfprotov5.PrepareProviderConfigRequest:
+		return &tfprotov5.PrepareProviderConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov5.ReadDataSourceRequest:
+		return &tfprotov5.ReadDataSourceResponse{Diagnostics: diags}, nil
+	case *tfprotov5.ReadResourceRequest:
+		return &tfprotov5.ReadResourceResponse{Diagnostics: diags}, nil
+	case *tfprotov5.RenewEphemeralResourceRequest:
+		return &tfprotov5.RenewEphemeralResourceResponse{Diagnostics: diags}, nil
+	case *tfprotov5.StopProviderRequest:
+		return &tfprotov5.StopProviderResponse{Error: diag.Summary + ": " + diag.Detail}, nil
+	case *tfprotov5.UpgradeResourceIdentityRequest:
+		return &tfprotov5.UpgradeResourceIdentityResponse{Diagnostics: diags}, nil
+	case *tfprotov5.UpgradeResourceStateRequest:
+		return &tfprotov5.UpgradeResourceStateResponse{Diagnostics: diags}, nil
+	case *tfprotov5.ValidateActionConfigRequest:
+		return &tfprotov5.ValidateActionConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov5.ValidateDataSourceConfigRequest:
+		return &tfprotov5.ValidateDataSourceConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov5.ValidateEphemeralResourceConfigRequest:
+		return &tfprotov5.ValidateEphemeralResourceConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov5.ValidateListResourceConfigRequest:
+		return &tfprotov5.ValidateListResourceConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov5.ValidateResourceTypeConfigRequest:
+		return &tfprotov5.ValidateResourceTypeConfigResponse{Diagnostics: diags}, nil
+	}
+
+	return nil, fmt.Errorf("unable to return %s error diagnostic, unexpected request type: %T", info.RPC, req)
+}
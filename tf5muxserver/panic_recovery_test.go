@@ -0,0 +1,307 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+// newPanickingServer returns a test server which panics in resource RPCs and
+// in the ListResource results iterator after the first result.
+func newPanickingServer() *tf5testserver.TestServer {
+	return &tf5testserver.TestServer{
+		ApplyResourceChangeFunc: func(_ context.Context, _ *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+			panic("test apply panic")
+		},
+		GetMetadataResponse: &tfprotov5.GetMetadataResponse{
+			ListResources: []tfprotov5.ListResourceMetadata{
+				{
+					TypeName: "test_list_resource",
+				},
+			},
+			Resources: []tfprotov5.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+		},
+		ListResourceFunc: func(_ context.Context, _ *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+			return &tfprotov5.ListResourceServerStream{
+				Results: func(yield func(tfprotov5.ListResourceResult) bool) {
+					if !yield(tfprotov5.ListResourceResult{DisplayName: "one"}) {
+						return
+					}
+
+					panic("test list panic")
+				},
+			}, nil
+		},
+		ReadResourceFunc: func(_ context.Context, _ *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+			panic("test read panic")
+		},
+	}
+}
+
+func TestWithPanicRecovery_ApplyResourceChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		request  *tfprotov5.ApplyResourceChangeRequest
+		expected *tfprotov5.ApplyResourceChangeResponse
+	}{
+		"create": {
+			request: &tfprotov5.ApplyResourceChangeRequest{
+				TypeName:       "test_resource",
+				PlannedPrivate: []byte(`{"planned":true}`),
+			},
+			expected: &tfprotov5.ApplyResourceChangeResponse{
+				Diagnostics: []*tfprotov5.Diagnostic{
+					{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "Provider Server Panic",
+						Detail: `An underlying provider server panicked while handling the ApplyResourceChange RPC for "test_resource". ` +
+							"The provider logs contain the stack trace of the panic. " +
+							"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+							"Underlying server: *tf5testserver.TestServer\n" +
+							"Panic: test apply panic",
+					},
+				},
+			},
+		},
+		"update": {
+			request: &tfprotov5.ApplyResourceChangeRequest{
+				TypeName: "test_resource",
+				PriorState: &tfprotov5.DynamicValue{
+					JSON: []byte(`{"id":"prior"}`),
+				},
+				PlannedPrivate: []byte(`{"planned":true}`),
+			},
+			expected: &tfprotov5.ApplyResourceChangeResponse{
+				Diagnostics: []*tfprotov5.Diagnostic{
+					{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "Provider Server Panic",
+						Detail: `An underlying provider server panicked while handling the ApplyResourceChange RPC for "test_resource". ` +
+							"The provider logs contain the stack trace of the panic. " +
+							"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+							"Underlying server: *tf5testserver.TestServer\n" +
+							"Panic: test apply panic",
+					},
+				},
+				NewState: &tfprotov5.DynamicValue{
+					JSON: []byte(`{"id":"prior"}`),
+				},
+				Private: []byte(`{"planned":true}`),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			testServer := newPanickingServer()
+
+			muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+				ctx,
+				[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+				tf5muxserver.WithPanicRecovery(),
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error setting up muxer: %s", err)
+			}
+
+			got, err := muxServer.ProviderServer().ApplyResourceChange(ctx, testCase.request)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected response difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithPanicRecovery_Interceptors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+
+	var interceptedDiagnostics []*tfprotov5.Diagnostic
+
+	interceptor := tf5muxserver.TypedInterceptor(func(ctx context.Context, info *tf5muxserver.CallInfo, req *tfprotov5.ReadResourceRequest, handler func(context.Context, *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error)) (*tfprotov5.ReadResourceResponse, error) {
+		resp, err := handler(ctx, req)
+
+		if resp != nil {
+			interceptedDiagnostics = resp.Diagnostics
+		}
+
+		return resp, err
+	})
+
+	// The panic recovery option is before the interceptor, but panics are
+	// always recovered before interceptors receive the response.
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithPanicRecovery(),
+		tf5muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedDiagnostics := []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Provider Server Panic",
+			Detail: `An underlying provider server panicked while handling the ReadResource RPC for "test_resource". ` +
+				"The provider logs contain the stack trace of the panic. " +
+				"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+				"Underlying server: *tf5testserver.TestServer\n" +
+				"Panic: test read panic",
+		},
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+
+	if diff := cmp.Diff(interceptedDiagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected intercepted diagnostics difference: %s", diff)
+	}
+}
+
+func TestWithPanicRecovery_ListResource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_list_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var results []tfprotov5.ListResourceResult
+
+	for result := range resp.Results {
+		results = append(results, result)
+	}
+
+	expectedResults := []tfprotov5.ListResourceResult{
+		{
+			DisplayName: "one",
+		},
+		{
+			Diagnostics: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Provider Server Panic",
+					Detail: `An underlying provider server panicked while handling the ListResource RPC for "test_list_resource". ` +
+						"The provider logs contain the stack trace of the panic. " +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf5testserver.TestServer\n" +
+						"Panic: test list panic",
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(results, expectedResults); diff != "" {
+		t.Errorf("unexpected results difference: %s", diff)
+	}
+}
+
+func TestWithPanicRecovery_ListResourceConsumerPanic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_list_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer func() {
+		if r := recover(); r != "test consumer panic" {
+			t.Errorf("expected consumer panic to not be recovered, got: %v", r)
+		}
+	}()
+
+	for range resp.Results {
+		panic("test consumer panic")
+	}
+}
+
+func TestWithPanicRecovery_NotEnabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, testServer.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	defer func() {
+		if r := recover(); r != "test read panic" {
+			t.Errorf("expected panic to not be recovered, got: %v", r)
+		}
+	}()
+
+	_, _ = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+}
@@ -0,0 +1,462 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov5toproto"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tftypesjson"
+)
+
+// WithRecording is a NewMuxServerWithOptions option which writes each call to
+// an underlying server, including those made to discover the types each
+// server implements, as a line of JSON to w. NewReplayServers serves the
+// responses of a recording again, such as to reproduce an issue in a unit
+// test without the credentials of the provider.
+//
+// Each line contains the RPC name, the Go type and index of the underlying
+// server, the type name of the request, and the request and response as the
+// JSON encoding of the protocol's Protocol Buffers messages, or the gRPC
+// error. Streamed ListResource results and InvokeAction events are recorded
+// once the stream has ended. DynamicValues additionally contain the value
+// decoded with the schemas from the underlying server's recorded
+// GetProviderSchema and GetResourceIdentitySchemas responses, if any.
+//
+// Recordings contain every value sent to and returned by the underlying
+// servers, including sensitive values such as credentials in the provider
+// configuration, and should be handled accordingly.
+//
+// Calls are recorded after any interceptors from WithInterceptors, so calls
+// which are handled by an interceptor without calling the underlying server
+// are not recorded.
+func WithRecording(w io.Writer) MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.recording = w
+	}
+}
+
+// recordingEntry is a line of a recording.
+type recordingEntry struct {
+	RPC            string            `json:"rpc"`
+	Server         string            `json:"server"`
+	ServerIndex    int               `json:"server_index"`
+	TypeName       string            `json:"type_name,omitempty"`
+	Request        json.RawMessage   `json:"request,omitempty"`
+	Response       json.RawMessage   `json:"response,omitempty"`
+	ResponseStream []json.RawMessage `json:"response_stream,omitempty"`
+	Error          string            `json:"error,omitempty"`
+	ErrorCode      codes.Code        `json:"error_code,omitempty"`
+}
+
+// err returns the recorded gRPC error, if any.
+func (e recordingEntry) err() error {
+	if e.ErrorCode == codes.OK {
+		return nil
+	}
+
+	return status.Error(e.ErrorCode, e.Error)
+}
+
+// recorder writes the recording of WithRecording.
+type recorder struct {
+	// schemas contains the schemas which DynamicValues are decoded with.
+	// Underlying servers are not called for missing schemas, so the
+	// recording only contains calls of the mux server.
+	schemas *schemaCache
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newRecorder(w io.Writer, schemas *schemaCache) *recorder {
+	return &recorder{
+		schemas: schemas,
+		w:       w,
+	}
+}
+
+// intercept is the Interceptor added by WithRecording.
+func (r *recorder) intercept(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+	entry := recordingEntry{
+		RPC:         info.RPC,
+		Server:      fmt.Sprintf("%T", info.Server),
+		ServerIndex: info.serverIndex,
+		TypeName:    info.TypeName,
+	}
+
+	// The request is encoded before calling the underlying server, which
+	// could modify it.
+	entry.Request = r.encode(ctx, info, recordingMessage(req))
+
+	resp, err := handler(ctx, req)
+
+	// Errors are recorded as gRPC errors, as they would be returned over
+	// gRPC to Terraform.
+	if err != nil {
+		grpcStatus := status.Convert(err)
+		entry.Error = grpcStatus.Message()
+		entry.ErrorCode = grpcStatus.Code()
+	}
+
+	switch typedResp := resp.(type) {
+	case *tfprotov5.GetProviderSchemaResponse, *tfprotov5.GetResourceIdentitySchemasResponse:
+		r.schemas.observe(info.serverIndex, typedResp)
+	case *tfprotov5.InvokeActionServerStream:
+		if typedResp != nil && typedResp.Events != nil {
+			typedResp.Events = observeStream(typedResp.Events, func(event tfprotov5.InvokeActionEvent) {
+				entry.ResponseStream = append(entry.ResponseStream, r.encode(ctx, info, tfprotov5toproto.InvokeActionEvent(&event)))
+			}, func() {
+				r.write(ctx, entry)
+			})
+
+			return resp, err
+		}
+	case *tfprotov5.ListResourceServerStream:
+		if typedResp != nil && typedResp.Results != nil {
+			typedResp.Results = observeStream(typedResp.Results, func(result tfprotov5.ListResourceResult) {
+				entry.ResponseStream = append(entry.ResponseStream, r.encode(ctx, info, tfprotov5toproto.ListResourceResult(&result)))
+			}, func() {
+				r.write(ctx, entry)
+			})
+
+			return resp, err
+		}
+	}
+
+	entry.Response = r.encode(ctx, info, recordingMessage(resp))
+	r.write(ctx, entry)
+
+	return resp, err
+}
+
+// encode returns the JSON encoding of the message, with the decoded value
+// added to each DynamicValue. Encoding errors are logged, as they should not
+// affect the call.
+func (r *recorder) encode(ctx context.Context, info *CallInfo, message proto.Message) json.RawMessage {
+	if message == nil || !message.ProtoReflect().IsValid() {
+		return nil
+	}
+
+	object, err := messageObject(message)
+
+	if err != nil {
+		logging.MuxError(ctx, "error encoding recording message", map[string]interface{}{logging.KeyError: err.Error()})
+
+		return nil
+	}
+
+	schemas := r.schemas.lookup(info.serverIndex)
+
+	decodeDynamicValues(object, "", func(field string) tftypes.Type {
+		return recordingValueType(schemas, info.RPC, info.TypeName, field)
+	})
+
+	data, err := json.Marshal(object)
+
+	if err != nil {
+		logging.MuxError(ctx, "error encoding recording message", map[string]interface{}{logging.KeyError: err.Error()})
+
+		return nil
+	}
+
+	return data
+}
+
+// write writes the entry as a line of JSON. Write errors are logged, as they
+// should not affect the call.
+func (r *recorder) write(ctx context.Context, entry recordingEntry) {
+	data, err := json.Marshal(entry)
+
+	if err != nil {
+		logging.MuxError(ctx, "error encoding recording entry", map[string]interface{}{logging.KeyError: err.Error()})
+
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.w.Write(append(data, '\n')); err != nil {
+		logging.MuxError(ctx, "error writing recording entry", map[string]interface{}{logging.KeyError: err.Error()})
+	}
+}
+
+// messageObject returns the JSON encoding of a message as a JSON value, with
+// numbers as json.Number.
+func messageObject(message proto.Message) (any, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var object any
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// recordingValueType returns the type which the DynamicValue of the field of
+// a request or response is decoded with, or nil if it is not decoded.
+func recordingValueType(schemas *serverSchemas, rpc string, typeName string, field string) tftypes.Type {
+	switch field {
+	case "identity_data":
+		return schemas.identityType(typeName)
+	case "include_resource_object":
+		return tftypes.Bool
+	case "limit":
+		return tftypes.Number
+	}
+
+	return schemaValueType(recordingSchema(schemas, rpc, typeName, field))
+}
+
+// recordingSchema returns the schema which the DynamicValue of the field of a
+// request or response is decoded with, or nil if it is not decoded with a
+// schema.
+func recordingSchema(schemas *serverSchemas, rpc string, typeName string, field string) *tfprotov5.Schema {
+	switch field {
+	case "identity_data", "include_resource_object", "limit", "raw_identity", "raw_state", "source_identity", "source_state":
+		return nil
+	case "provider_meta":
+		return schemas.providerMetaSchema()
+	}
+
+	switch rpc {
+	case "ConfigureProvider", "PrepareProviderConfig":
+		return schemas.providerConfigSchema()
+	case "InvokeAction", "PlanAction", "ValidateActionConfig":
+		return schemas.actionSchema(typeName)
+	case "ReadDataSource", "ValidateDataSourceConfig":
+		return schemas.dataSourceSchema(typeName)
+	case "CloseEphemeralResource", "OpenEphemeralResource", "RenewEphemeralResource", "ValidateEphemeralResourceConfig":
+		return schemas.ephemeralResourceSchema(typeName)
+	case "ListResource", "ValidateListResourceConfig":
+		if field == "resource_object" {
+			return schemas.resourceSchema(typeName)
+		}
+
+		return schemas.listResourceSchema(typeName)
+	case "ApplyResourceChange", "GenerateResourceConfig", "ImportResourceState", "MoveResourceState",
+		"PlanResourceChange", "ReadResource", "UpgradeResourceState", "ValidateResourceTypeConfig":
+		return schemas.resourceSchema(typeName)
+	}
+
+	return nil
+}
+
+// decodeDynamicValues adds the decoded value to each DynamicValue in the JSON
+// encoding of a message, or the error decoding it. The type of each value is
+// returned by valueType for the name of the field containing it.
+func decodeDynamicValues(object any, field string, valueType func(field string) tftypes.Type) {
+	switch object := object.(type) {
+	case map[string]any:
+		if !isDynamicValue(object) {
+			for name, value := range object {
+				decodeDynamicValues(value, name, valueType)
+			}
+
+			return
+		}
+
+		typ := valueType(field)
+
+		if typ == nil {
+			return
+		}
+
+		value, err := decodeDynamicValue(object, typ)
+
+		if err != nil {
+			object["decode_error"] = err.Error()
+
+			return
+		}
+
+		object["decoded"] = tftypesjson.Value(value)
+	case []any:
+		for _, element := range object {
+			decodeDynamicValues(element, field, valueType)
+		}
+	}
+}
+
+// decodeDynamicValue decodes the JSON encoding of a DynamicValue.
+func decodeDynamicValue(object map[string]any, typ tftypes.Type) (tftypes.Value, error) {
+	dynamicValue := &tfprotov5.DynamicValue{}
+
+	for field, target := range map[string]*[]byte{"json": &dynamicValue.JSON, "msgpack": &dynamicValue.MsgPack} {
+		encoded, ok := object[field].(string)
+
+		if !ok {
+			continue
+		}
+
+		data, err := base64.StdEncoding.DecodeString(encoded)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		*target = data
+	}
+
+	return dynamicValue.Unmarshal(typ)
+}
+
+// isDynamicValue returns true if the JSON encoding of a message only
+// contains the fields of a DynamicValue. Fields with other messages
+// containing bytes, such as the RawState of UpgradeResourceState, have no
+// type and are not decoded.
+func isDynamicValue(object map[string]any) bool {
+	if len(object) == 0 {
+		return false
+	}
+
+	for field := range object {
+		if field != "json" && field != "msgpack" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// recordingMessage returns the Protocol Buffers message of a request or
+// response, or nil for other types such as streams.
+func recordingMessage(v any) proto.Message {
+	switch typed := v.(type) {
+	case *tfprotov5.ApplyResourceChangeRequest:
+		return tfprotov5toproto.ApplyResourceChangeRequest(typed)
+	case *tfprotov5.ApplyResourceChangeResponse:
+		return tfprotov5toproto.ApplyResourceChangeResponse(typed)
+	case *tfprotov5.CallFunctionRequest:
+		return tfprotov5toproto.CallFunctionRequest(typed)
+	case *tfprotov5.CallFunctionResponse:
+		return tfprotov5toproto.CallFunctionResponse(typed)
+	case *tfprotov5.CloseEphemeralResourceRequest:
+		return tfprotov5toproto.CloseEphemeralResourceRequest(typed)
+	case *tfprotov5.CloseEphemeralResourceResponse:
+		return tfprotov5toproto.CloseEphemeralResourceResponse(typed)
+	case *tfprotov5.ConfigureProviderRequest:
+		return tfprotov5toproto.ConfigureProviderRequest(typed)
+	case *tfprotov5.ConfigureProviderResponse:
+		return tfprotov5toproto.ConfigureProviderResponse(typed)
+	case *tfprotov5.GenerateResourceConfigRequest:
+		return tfprotov5toproto.GenerateResourceConfigRequest(typed)
+	case *tfprotov5.GenerateResourceConfigResponse:
+		return tfprotov5toproto.GenerateResourceConfigResponse(typed)
+	case *tfprotov5.GetFunctionsRequest:
+		return tfprotov5toproto.GetFunctionsRequest(typed)
+	case *tfprotov5.GetFunctionsResponse:
+		return tfprotov5toproto.GetFunctionsResponse(typed)
+	case *tfprotov5.GetMetadataRequest:
+		return tfprotov5toproto.GetMetadataRequest(typed)
+	case *tfprotov5.GetMetadataResponse:
+		return tfprotov5toproto.GetMetadataResponse(typed)
+	case *tfprotov5.GetProviderSchemaRequest:
+		return tfprotov5toproto.GetProviderSchemaRequest(typed)
+	case *tfprotov5.GetProviderSchemaResponse:
+		return tfprotov5toproto.GetProviderSchemaResponse(typed)
+	case *tfprotov5.GetResourceIdentitySchemasRequest:
+		return tfprotov5toproto.GetResourceIdentitySchemasRequest(typed)
+	case *tfprotov5.GetResourceIdentitySchemasResponse:
+		return tfprotov5toproto.GetResourceIdentitySchemasResponse(typed)
+	case *tfprotov5.ImportResourceStateRequest:
+		return tfprotov5toproto.ImportResourceStateRequest(typed)
+	case *tfprotov5.ImportResourceStateResponse:
+		return tfprotov5toproto.ImportResourceStateResponse(typed)
+	case *tfprotov5.InvokeActionRequest:
+		return tfprotov5toproto.InvokeActionRequest(typed)
+	case *tfprotov5.ListResourceRequest:
+		return tfprotov5toproto.ListResourceRequest(typed)
+	case *tfprotov5.MoveResourceStateRequest:
+		return tfprotov5toproto.MoveResourceStateRequest(typed)
+	case *tfprotov5.MoveResourceStateResponse:
+		return tfprotov5toproto.MoveResourceStateResponse(typed)
+	case *tfprotov5.OpenEphemeralResourceRequest:
+		return tfprotov5toproto.OpenEphemeralResourceRequest(typed)
+	case *tfprotov5.OpenEphemeralResourceResponse:
+		return tfprotov5toproto.OpenEphemeralResourceResponse(typed)
+	case *tfprotov5.PlanActionRequest:
+		return tfprotov5toproto.PlanActionRequest(typed)
+	case *tfprotov5.PlanActionResponse:
+		return tfprotov5toproto.PlanActionResponse(typed)
+	case *tfprotov5.PlanResourceChangeRequest:
+		return tfprotov5toproto.PlanResourceChangeRequest(typed)
+	case *tfprotov5.PlanResourceChangeResponse:
+		return tfprotov5toproto.PlanResourceChangeResponse(typed)
+	case *tfprotov5.PrepareProviderConfigRequest:
+		return tfprotov5toproto.PrepareProviderConfigRequest(typed)
+	case *tfprotov5.PrepareProviderConfigResponse:
+		return tfprotov5toproto.PrepareProviderConfigResponse(typed)
+	case *tfprotov5.ReadDataSourceRequest:
+		return tfprotov5toproto.ReadDataSourceRequest(typed)
+	case *tfprotov5.ReadDataSourceResponse:
+		return tfprotov5toproto.ReadDataSourceResponse(typed)
+	case *tfprotov5.ReadResourceRequest:
+		return tfprotov5toproto.ReadResourceRequest(typed)
+	case *tfprotov5.ReadResourceResponse:
+		return tfprotov5toproto.ReadResourceResponse(typed)
+	case *tfprotov5.RenewEphemeralResourceRequest:
+		return tfprotov5toproto.RenewEphemeralResourceRequest(typed)
+	case *tfprotov5.RenewEphemeralResourceResponse:
+		return tfprotov5toproto.RenewEphemeralResourceResponse(typed)
+	case *tfprotov5.StopProviderRequest:
+		return tfprotov5toproto.StopProviderRequest(typed)
+	case *tfprotov5.StopProviderResponse:
+		return tfprotov5toproto.StopProviderResponse(typed)
+	case *tfprotov5.UpgradeResourceIdentityRequest:
+		return tfprotov5toproto.UpgradeResourceIdentityRequest(typed)
+	case *tfprotov5.UpgradeResourceIdentityResponse:
+		return tfprotov5toproto.UpgradeResourceIdentityResponse(typed)
+	case *tfprotov5.UpgradeResourceStateRequest:
+		return tfprotov5toproto.UpgradeResourceStateRequest(typed)
+	case *tfprotov5.UpgradeResourceStateResponse:
+		return tfprotov5toproto.UpgradeResourceStateResponse(typed)
+	case *tfprotov5.ValidateActionConfigRequest:
+		return tfprotov5toproto.ValidateActionConfigRequest(typed)
+	case *tfprotov5.ValidateActionConfigResponse:
+		return tfprotov5toproto.ValidateActionConfigResponse(typed)
+	case *tfprotov5.ValidateDataSourceConfigRequest:
+		return tfprotov5toproto.ValidateDataSourceConfigRequest(typed)
+	case *tfprotov5.ValidateDataSourceConfigResponse:
+		return tfprotov5toproto.ValidateDataSourceConfigResponse(typed)
+	case *tfprotov5.ValidateEphemeralResourceConfigRequest:
+		return tfprotov5toproto.ValidateEphemeralResourceConfigRequest(typed)
+	case *tfprotov5.ValidateEphemeralResourceConfigResponse:
+		return tfprotov5toproto.ValidateEphemeralResourceConfigResponse(typed)
+	case *tfprotov5.ValidateListResourceConfigRequest:
+		return tfprotov5toproto.ValidateListResourceConfigRequest(typed)
+	case *tfprotov5.ValidateListResourceConfigResponse:
+		return tfprotov5toproto.ValidateListResourceConfigResponse(typed)
+	case *tfprotov5.ValidateResourceTypeConfigRequest:
+		return tfprotov5toproto.ValidateResourceTypeConfigRequest(typed)
+	case *tfprotov5.ValidateResourceTypeConfigResponse:
+		return tfprotov5toproto.ValidateResourceTypeConfigResponse(typed)
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5dynamicvalue"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+// recordingTestType is the type of the test_resource schema of
+// newRecordingTestServer.
+var recordingTestType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"id":   tftypes.String,
+		"name": tftypes.String,
+	},
+}
+
+// newRecordingTestServer returns a test server which returns the values of
+// test_resource, so they can be recorded and replayed.
+func newRecordingTestServer() *tf5testserver.TestServer {
+	schema := &tfprotov5.Schema{
+		Block: &tfprotov5.SchemaBlock{
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:     "name",
+					Type:     tftypes.String,
+					Optional: true,
+				},
+			},
+		},
+	}
+
+	return &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			ListResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource": {
+					Block: &tfprotov5.SchemaBlock{},
+				},
+			},
+			ResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource": schema,
+			},
+		},
+		ImportResourceStateFunc: func(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+			return nil, status.Errorf(codes.NotFound, "test import error for %s", req.ID)
+		},
+		ListResourceFunc: func(_ context.Context, _ *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+			return &tfprotov5.ListResourceServerStream{
+				Results: func(yield func(tfprotov5.ListResourceResult) bool) {
+					for _, id := range []string{"one", "two"} {
+						result := tfprotov5.ListResourceResult{
+							DisplayName: id,
+							Resource: tf5dynamicvalue.Must(recordingTestType, tftypes.NewValue(recordingTestType, map[string]tftypes.Value{
+								"id":   tftypes.NewValue(tftypes.String, id),
+								"name": tftypes.NewValue(tftypes.String, nil),
+							})),
+						}
+
+						if !yield(result) {
+							return
+						}
+					}
+				},
+			}, nil
+		},
+		ReadResourceFunc: func(_ context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+			return &tfprotov5.ReadResourceResponse{
+				NewState: tf5dynamicvalue.Must(recordingTestType, tftypes.NewValue(recordingTestType, map[string]tftypes.Value{
+					"id":   tftypes.NewValue(tftypes.String, "test-id"),
+					"name": tftypes.NewValue(tftypes.String, "read"),
+				})),
+				Private: req.Private,
+			}, nil
+		},
+	}
+}
+
+func TestWithRecording(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf5testserver.TestServer{}
+	testServer2 := newRecordingTestServer()
+
+	var recording bytes.Buffer
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer},
+		tf5muxserver.WithRecording(&recording),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource",
+		CurrentState: tf5dynamicvalue.Must(recordingTestType, tftypes.NewValue(recordingTestType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "test-id"),
+			"name": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		})),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(recording.String()), "\n")
+
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 recorded calls, got: %s", recording.String())
+	}
+
+	var got map[string]any
+
+	if err := json.Unmarshal([]byte(lines[2]), &got); err != nil {
+		t.Fatalf("unexpected error decoding recording: %s", err)
+	}
+
+	expected := map[string]any{
+		"rpc":          "ReadResource",
+		"server":       "*tf5testserver.TestServer",
+		"server_index": float64(1),
+		"type_name":    "test_resource",
+		"request": map[string]any{
+			"type_name": "test_resource",
+			"current_state": map[string]any{
+				"msgpack": got["request"].(map[string]any)["current_state"].(map[string]any)["msgpack"], //nolint:forcetypeassert
+				"decoded": map[string]any{
+					"id":   "test-id",
+					"name": "<unknown>",
+				},
+			},
+		},
+		"response": map[string]any{
+			"new_state": map[string]any{
+				"msgpack": got["response"].(map[string]any)["new_state"].(map[string]any)["msgpack"], //nolint:forcetypeassert
+				"decoded": map[string]any{
+					"id":   "test-id",
+					"name": "read",
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected recording difference: %s", diff)
+	}
+}
+
+func TestNewReplayServers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf5testserver.TestServer{}
+	testServer2 := newRecordingTestServer()
+
+	var recording bytes.Buffer
+
+	recordedServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer},
+		tf5muxserver.WithRecording(&recording),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	expected := runReplayTestCalls(ctx, t, recordedServer.ProviderServer())
+
+	replayServers, err := tf5muxserver.NewReplayServers(&recording)
+
+	if err != nil {
+		t.Fatalf("unexpected error reading recording: %s", err)
+	}
+
+	if len(replayServers) != 2 {
+		t.Fatalf("expected 2 replay servers, got %d", len(replayServers))
+	}
+
+	replayedServer, err := tf5muxserver.NewMuxServer(ctx, replayServers...)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up replay muxer: %s", err)
+	}
+
+	got := runReplayTestCalls(ctx, t, replayedServer.ProviderServer())
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected replay difference: %s", diff)
+	}
+}
+
+// replayTestResults are the results of runReplayTestCalls.
+type replayTestResults struct {
+	ImportResourceStateError string
+	ListResourceResults      []tfprotov5.ListResourceResult
+	ReadResource             *tfprotov5.ReadResourceResponse
+}
+
+// runReplayTestCalls calls the server with the RPCs of TestNewReplayServers.
+func runReplayTestCalls(ctx context.Context, t *testing.T, server tfprotov5.ProviderServer) replayTestResults {
+	t.Helper()
+
+	var results replayTestResults
+
+	_, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected GetProviderSchema error: %s", err)
+	}
+
+	results.ReadResource, err = server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource",
+		Private:  []byte(`{"private":true}`),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected ReadResource error: %s", err)
+	}
+
+	listResp, err := server.(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected ListResource error: %s", err)
+	}
+
+	for result := range listResp.Results {
+		results.ListResourceResults = append(results.ListResourceResults, result)
+	}
+
+	_, err = server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: "test_resource",
+		ID:       "missing",
+	})
+
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected ImportResourceState not found error, got: %s", err)
+	}
+
+	results.ImportResourceStateError = err.Error()
+
+	return results
+}
@@ -0,0 +1,362 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin5"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov5fromproto"
+)
+
+var _ tfprotov5.ProviderServer = &replayServer{}
+
+// NewReplayServers returns servers which serve the responses of a recording
+// written by WithRecording, such as to reproduce an issue in a unit test
+// without the credentials of the provider. One server is returned for each
+// underlying server of the recording, in the same order, so combining them
+// with NewMuxServer routes requests as they were recorded.
+//
+// Each call is served the next response recorded for the RPC, underlying
+// server, and type name of the request, regardless of the other request
+// fields. Once all of those responses have been served, the last one is
+// served again. RPCs without recorded responses return the gRPC
+// unimplemented error.
+func NewReplayServers(r io.Reader) ([]func() tfprotov5.ProviderServer, error) {
+	var servers []*replayServer
+
+	decoder := json.NewDecoder(r)
+
+	for {
+		var entry recordingEntry
+
+		err := decoder.Decode(&entry)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading recording: %w", err)
+		}
+
+		if entry.ServerIndex < 0 {
+			return nil, fmt.Errorf("error reading recording: %s call has invalid server index %d", entry.RPC, entry.ServerIndex)
+		}
+
+		for len(servers) <= entry.ServerIndex {
+			servers = append(servers, &replayServer{
+				entries: make(map[replayKey][]recordingEntry),
+				served:  make(map[replayKey]int),
+			})
+		}
+
+		key := replayKey{rpc: entry.RPC, typeName: entry.TypeName}
+		servers[entry.ServerIndex].entries[key] = append(servers[entry.ServerIndex].entries[key], entry)
+	}
+
+	result := make([]func() tfprotov5.ProviderServer, 0, len(servers))
+
+	for _, server := range servers {
+		result = append(result, server.ProviderServer)
+	}
+
+	return result, nil
+}
+
+// replayKey is the RPC and type name which recorded responses are served
+// for.
+type replayKey struct {
+	rpc      string
+	typeName string
+}
+
+// replayServer is a tfprotov5.ProviderServer which serves the responses of a
+// recording. It should always be instantiated by calling NewReplayServers().
+type replayServer struct {
+	mu      sync.Mutex
+	entries map[replayKey][]recordingEntry
+	served  map[replayKey]int
+}
+
+// ProviderServer is a function compatible with tf5server.Serve.
+func (s *replayServer) ProviderServer() tfprotov5.ProviderServer {
+	return s
+}
+
+// next returns the next recorded call of the RPC and type name.
+func (s *replayServer) next(rpc string, typeName string) (recordingEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := replayKey{rpc: rpc, typeName: typeName}
+	entries := s.entries[key]
+
+	if len(entries) == 0 {
+		return recordingEntry{}, status.Errorf(codes.Unimplemented, "no recorded %s response for %q", rpc, typeName)
+	}
+
+	index := min(s.served[key], len(entries)-1)
+	s.served[key]++
+
+	return entries[index], nil
+}
+
+// replayResponse returns the next recorded response of the RPC and type name
+// and the recorded error, if any. The response is nil if the recorded
+// response was nil.
+func replayResponse[M proto.Message](s *replayServer, rpc string, typeName string, message M) (M, error) {
+	var none M
+
+	entry, err := s.next(rpc, typeName)
+
+	if err != nil {
+		return none, err
+	}
+
+	if entry.Response == nil {
+		return none, entry.err()
+	}
+
+	// The decoded values of DynamicValues are discarded, as only the
+	// encoded values are part of the message.
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(entry.Response, message); err != nil {
+		return none, fmt.Errorf("error replaying %s response: %w", rpc, err)
+	}
+
+	return message, entry.err()
+}
+
+// replayStream returns the next recorded stream of the RPC and type name, or
+// the recorded error.
+func replayStream[M proto.Message, T any](s *replayServer, rpc string, typeName string, newMessage func() M, convert func(M) T) (iter.Seq[T], error) {
+	entry, err := s.next(rpc, typeName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := entry.err(); err != nil {
+		return nil, err
+	}
+
+	elements := make([]T, 0, len(entry.ResponseStream))
+
+	for _, data := range entry.ResponseStream {
+		message := newMessage()
+
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, message); err != nil {
+			return nil, fmt.Errorf("error replaying %s response: %w", rpc, err)
+		}
+
+		elements = append(elements, convert(message))
+	}
+
+	return slices.Values(elements), nil
+}
+
+func (s *replayServer) ApplyResourceChange(_ context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	resp, err := replayResponse(s, "ApplyResourceChange", req.TypeName, &tfplugin5.ApplyResourceChange_Response{})
+
+	return tfprotov5fromproto.ApplyResourceChangeResponse(resp), err
+}
+
+func (s *replayServer) CallFunction(_ context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	resp, err := replayResponse(s, "CallFunction", req.Name, &tfplugin5.CallFunction_Response{})
+
+	return tfprotov5fromproto.CallFunctionResponse(resp), err
+}
+
+func (s *replayServer) CloseEphemeralResource(_ context.Context, req *tfprotov5.CloseEphemeralResourceRequest) (*tfprotov5.CloseEphemeralResourceResponse, error) {
+	resp, err := replayResponse(s, "CloseEphemeralResource", req.TypeName, &tfplugin5.CloseEphemeralResource_Response{})
+
+	return tfprotov5fromproto.CloseEphemeralResourceResponse(resp), err
+}
+
+func (s *replayServer) ConfigureProvider(_ context.Context, _ *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	resp, err := replayResponse(s, "ConfigureProvider", "", &tfplugin5.Configure_Response{})
+
+	return tfprotov5fromproto.ConfigureProviderResponse(resp), err
+}
+
+func (s *replayServer) GenerateResourceConfig(_ context.Context, req *tfprotov5.GenerateResourceConfigRequest) (*tfprotov5.GenerateResourceConfigResponse, error) {
+	resp, err := replayResponse(s, "GenerateResourceConfig", req.TypeName, &tfplugin5.GenerateResourceConfig_Response{})
+
+	return tfprotov5fromproto.GenerateResourceConfigResponse(resp), err
+}
+
+func (s *replayServer) GetFunctions(_ context.Context, _ *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	resp, err := replayResponse(s, "GetFunctions", "", &tfplugin5.GetFunctions_Response{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.GetFunctionsResponse(resp)
+}
+
+func (s *replayServer) GetMetadata(_ context.Context, _ *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := replayResponse(s, "GetMetadata", "", &tfplugin5.GetMetadata_Response{})
+
+	return tfprotov5fromproto.GetMetadataResponse(resp), err
+}
+
+func (s *replayServer) GetProviderSchema(_ context.Context, _ *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := replayResponse(s, "GetProviderSchema", "", &tfplugin5.GetProviderSchema_Response{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.GetProviderSchemaResponse(resp)
+}
+
+func (s *replayServer) GetResourceIdentitySchemas(_ context.Context, _ *tfprotov5.GetResourceIdentitySchemasRequest) (*tfprotov5.GetResourceIdentitySchemasResponse, error) {
+	resp, err := replayResponse(s, "GetResourceIdentitySchemas", "", &tfplugin5.GetResourceIdentitySchemas_Response{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.GetResourceIdentitySchemasResponse(resp)
+}
+
+func (s *replayServer) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	resp, err := replayResponse(s, "ImportResourceState", req.TypeName, &tfplugin5.ImportResourceState_Response{})
+
+	return tfprotov5fromproto.ImportResourceStateResponse(resp), err
+}
+
+func (s *replayServer) InvokeAction(_ context.Context, req *tfprotov5.InvokeActionRequest) (*tfprotov5.InvokeActionServerStream, error) {
+	events, err := replayStream(s, "InvokeAction", req.ActionType, func() *tfplugin5.InvokeAction_Event { return &tfplugin5.InvokeAction_Event{} }, tfprotov5fromproto.InvokeActionEvent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.InvokeActionServerStream{
+		Events: events,
+	}, nil
+}
+
+func (s *replayServer) ListResource(_ context.Context, req *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+	results, err := replayStream(s, "ListResource", req.TypeName, func() *tfplugin5.ListResource_Event { return &tfplugin5.ListResource_Event{} }, tfprotov5fromproto.ListResourceResult)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.ListResourceServerStream{
+		Results: results,
+	}, nil
+}
+
+func (s *replayServer) MoveResourceState(_ context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	resp, err := replayResponse(s, "MoveResourceState", req.TargetTypeName, &tfplugin5.MoveResourceState_Response{})
+
+	return tfprotov5fromproto.MoveResourceStateResponse(resp), err
+}
+
+func (s *replayServer) OpenEphemeralResource(_ context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
+	resp, err := replayResponse(s, "OpenEphemeralResource", req.TypeName, &tfplugin5.OpenEphemeralResource_Response{})
+
+	return tfprotov5fromproto.OpenEphemeralResourceResponse(resp), err
+}
+
+func (s *replayServer) PlanAction(_ context.Context, req *tfprotov5.PlanActionRequest) (*tfprotov5.PlanActionResponse, error) {
+	resp, err := replayResponse(s, "PlanAction", req.ActionType, &tfplugin5.PlanAction_Response{})
+
+	return tfprotov5fromproto.PlanActionResponse(resp), err
+}
+
+func (s *replayServer) PlanResourceChange(_ context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := replayResponse(s, "PlanResourceChange", req.TypeName, &tfplugin5.PlanResourceChange_Response{})
+
+	return tfprotov5fromproto.PlanResourceChangeResponse(resp), err
+}
+
+func (s *replayServer) PrepareProviderConfig(_ context.Context, _ *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	resp, err := replayResponse(s, "PrepareProviderConfig", "", &tfplugin5.PrepareProviderConfig_Response{})
+
+	return tfprotov5fromproto.PrepareProviderConfigResponse(resp), err
+}
+
+func (s *replayServer) ReadDataSource(_ context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	resp, err := replayResponse(s, "ReadDataSource", req.TypeName, &tfplugin5.ReadDataSource_Response{})
+
+	return tfprotov5fromproto.ReadDataSourceResponse(resp), err
+}
+
+func (s *replayServer) ReadResource(_ context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	resp, err := replayResponse(s, "ReadResource", req.TypeName, &tfplugin5.ReadResource_Response{})
+
+	return tfprotov5fromproto.ReadResourceResponse(resp), err
+}
+
+func (s *replayServer) RenewEphemeralResource(_ context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
+	resp, err := replayResponse(s, "RenewEphemeralResource", req.TypeName, &tfplugin5.RenewEphemeralResource_Response{})
+
+	return tfprotov5fromproto.RenewEphemeralResourceResponse(resp), err
+}
+
+func (s *replayServer) StopProvider(_ context.Context, _ *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	resp, err := replayResponse(s, "StopProvider", "", &tfplugin5.Stop_Response{})
+
+	return tfprotov5fromproto.StopProviderResponse(resp), err
+}
+
+func (s *replayServer) UpgradeResourceIdentity(_ context.Context, req *tfprotov5.UpgradeResourceIdentityRequest) (*tfprotov5.UpgradeResourceIdentityResponse, error) {
+	resp, err := replayResponse(s, "UpgradeResourceIdentity", req.TypeName, &tfplugin5.UpgradeResourceIdentity_Response{})
+
+	return tfprotov5fromproto.UpgradeResourceIdentityResponse(resp), err
+}
+
+func (s *replayServer) UpgradeResourceState(_ context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	resp, err := replayResponse(s, "UpgradeResourceState", req.TypeName, &tfplugin5.UpgradeResourceState_Response{})
+
+	return tfprotov5fromproto.UpgradeResourceStateResponse(resp), err
+}
+
+func (s *replayServer) ValidateActionConfig(_ context.Context, req *tfprotov5.ValidateActionConfigRequest) (*tfprotov5.ValidateActionConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateActionConfig", req.ActionType, &tfplugin5.ValidateActionConfig_Response{})
+
+	return tfprotov5fromproto.ValidateActionConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateDataSourceConfig(_ context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateDataSourceConfig", req.TypeName, &tfplugin5.ValidateDataSourceConfig_Response{})
+
+	return tfprotov5fromproto.ValidateDataSourceConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateEphemeralResourceConfig(_ context.Context, req *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateEphemeralResourceConfig", req.TypeName, &tfplugin5.ValidateEphemeralResourceConfig_Response{})
+
+	return tfprotov5fromproto.ValidateEphemeralResourceConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateListResourceConfig(_ context.Context, req *tfprotov5.ValidateListResourceConfigRequest) (*tfprotov5.ValidateListResourceConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateListResourceConfig", req.TypeName, &tfplugin5.ValidateListResourceConfig_Response{})
+
+	return tfprotov5fromproto.ValidateListResourceConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateResourceTypeConfig(_ context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateResourceTypeConfig", req.TypeName, &tfplugin5.ValidateResourceTypeConfig_Response{})
+
+	return tfprotov5fromproto.ValidateResourceTypeConfigResponse(resp), err
+}
@@ -0,0 +1,236 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+)
+
+// WithResponseValidation is a NewMuxServerWithOptions option which validates
+// the values returned by underlying servers against the schema of their
+// type, such as the NewState of an ApplyResourceChange response against the
+// resource schema. Values which do not conform to the schema, such as values
+// with missing attributes or attributes of the wrong type, are returned as an
+// error diagnostic with the type name. Unknown values are also returned as an
+// error diagnostic in the responses which Terraform requires to be wholly
+// known, which are the new states of ApplyResourceChange and ReadResource and
+// the state of ReadDataSource, unless the response is deferred.
+//
+// The validated values are the states and identities of resources, the state
+// of data sources, the result of ephemeral resources and functions, and
+// generated resource configurations. Values are only validated if the schema
+// of the type is known. The schemas of each underlying server are those
+// returned to the mux server, or are fetched on first use by calling its
+// GetProviderSchema and GetResourceIdentitySchemas RPCs without interceptors.
+//
+// Responses are validated before they are returned to any interceptors from
+// WithInterceptors.
+func WithResponseValidation() MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.responseValidation = true
+	}
+}
+
+// validateResponses returns the Interceptor added by WithResponseValidation.
+func validateResponses(schemas *schemaCache) Interceptor {
+	return func(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+		resp, err := handler(ctx, req)
+
+		if err != nil {
+			return resp, err
+		}
+
+		v := &responseValidator{
+			cache: schemas,
+			ctx:   ctx,
+			info:  info,
+		}
+
+		switch typedResp := resp.(type) {
+		case *tfprotov5.ApplyResourceChangeResponse:
+			if typedResp != nil {
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "NewState", typedResp.NewState, (*serverSchemas).resourceType, true)...)
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "NewIdentity", identityData(typedResp.NewIdentity), (*serverSchemas).identityType, true)...)
+			}
+		case *tfprotov5.CallFunctionResponse:
+			if typedResp != nil && typedResp.Error == nil {
+				if diags := v.validate(info.TypeName, "Result", typedResp.Result, (*serverSchemas).functionReturnType, false); len(diags) > 0 {
+					typedResp.Error = &tfprotov5.FunctionError{
+						Text: diags[0].Summary + ": " + diags[0].Detail,
+					}
+				}
+			}
+		case *tfprotov5.GenerateResourceConfigResponse:
+			if typedResp != nil {
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "Config", typedResp.Config, (*serverSchemas).resourceType, false)...)
+			}
+		case *tfprotov5.ImportResourceStateResponse:
+			if typedResp != nil {
+				for _, importedResource := range typedResp.ImportedResources {
+					if importedResource == nil {
+						continue
+					}
+
+					typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(importedResource.TypeName, "State", importedResource.State, (*serverSchemas).resourceType, false)...)
+					typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(importedResource.TypeName, "Identity", identityData(importedResource.Identity), (*serverSchemas).identityType, false)...)
+				}
+			}
+		case *tfprotov5.ListResourceServerStream:
+			if typedResp != nil && typedResp.Results != nil {
+				results := typedResp.Results
+
+				typedResp.Results = func(yield func(tfprotov5.ListResourceResult) bool) {
+					for result := range results {
+						result.Diagnostics = append(result.Diagnostics, v.validate(info.TypeName, "Resource", result.Resource, (*serverSchemas).resourceType, false)...)
+						result.Diagnostics = append(result.Diagnostics, v.validate(info.TypeName, "Identity", identityData(result.Identity), (*serverSchemas).identityType, false)...)
+
+						if !yield(result) {
+							return
+						}
+					}
+				}
+			}
+		case *tfprotov5.MoveResourceStateResponse:
+			if typedResp != nil {
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "TargetState", typedResp.TargetState, (*serverSchemas).resourceType, false)...)
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "TargetIdentity", identityData(typedResp.TargetIdentity), (*serverSchemas).identityType, false)...)
+			}
+		case *tfprotov5.OpenEphemeralResourceResponse:
+			if typedResp != nil {
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "Result", typedResp.Result, (*serverSchemas).ephemeralResourceType, false)...)
+			}
+		case *tfprotov5.PlanResourceChangeResponse:
+			if typedResp != nil {
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "PlannedState", typedResp.PlannedState, (*serverSchemas).resourceType, false)...)
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "PlannedIdentity", identityData(typedResp.PlannedIdentity), (*serverSchemas).identityType, false)...)
+			}
+		case *tfprotov5.ReadDataSourceResponse:
+			if typedResp != nil {
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "State", typedResp.State, (*serverSchemas).dataSourceType, typedResp.Deferred == nil)...)
+			}
+		case *tfprotov5.ReadResourceResponse:
+			if typedResp != nil {
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "NewState", typedResp.NewState, (*serverSchemas).resourceType, typedResp.Deferred == nil)...)
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "NewIdentity", identityData(typedResp.NewIdentity), (*serverSchemas).identityType, typedResp.Deferred == nil)...)
+			}
+		case *tfprotov5.UpgradeResourceIdentityResponse:
+			if typedResp != nil {
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "UpgradedIdentity", identityData(typedResp.UpgradedIdentity), (*serverSchemas).identityType, false)...)
+			}
+		case *tfprotov5.UpgradeResourceStateResponse:
+			if typedResp != nil {
+				typedResp.Diagnostics = append(typedResp.Diagnostics, v.validate(info.TypeName, "UpgradedState", typedResp.UpgradedState, (*serverSchemas).resourceType, false)...)
+			}
+		}
+
+		return resp, err
+	}
+}
+
+// responseValidator validates the values of a response, fetching the schemas
+// of the underlying server on first use.
+type responseValidator struct {
+	cache   *schemaCache
+	ctx     context.Context
+	info    *CallInfo
+	schemas *serverSchemas
+	fetched bool
+}
+
+// serverSchemas returns the schemas of the underlying server. Errors fetching
+// the schemas are logged, as they should not affect the call.
+func (v *responseValidator) serverSchemas() *serverSchemas {
+	if v.fetched {
+		return v.schemas
+	}
+
+	v.fetched = true
+
+	schemas, err := v.cache.get(v.ctx, v.info)
+
+	if err != nil {
+		logging.MuxError(v.ctx, "error fetching schemas for response validation", map[string]interface{}{logging.KeyError: err.Error()})
+	}
+
+	v.schemas = schemas
+
+	return schemas
+}
+
+// validate returns an error diagnostic if the value does not conform to the
+// type returned by valueType for the type name, or if known is true and the
+// value is not wholly known. Missing values and values of types without a
+// schema are not validated.
+func (v *responseValidator) validate(typeName string, field string, value *tfprotov5.DynamicValue, valueType func(*serverSchemas, string) tftypes.Type, known bool) []*tfprotov5.Diagnostic {
+	if value == nil {
+		return nil
+	}
+
+	typ := valueType(v.serverSchemas(), typeName)
+
+	if typ == nil {
+		return nil
+	}
+
+	decoded, err := value.Unmarshal(typ)
+
+	if err == nil && known {
+		err = unknownValueError(decoded)
+	}
+
+	if err != nil {
+		return []*tfprotov5.Diagnostic{
+			invalidValueDiagnostic(v.info, typeName, field, err),
+		}
+	}
+
+	return nil
+}
+
+// identityData returns the identity value of the resource identity data, if
+// any.
+func identityData(identity *tfprotov5.ResourceIdentityData) *tfprotov5.DynamicValue {
+	if identity == nil {
+		return nil
+	}
+
+	return identity.IdentityData
+}
+
+// unknownValueError returns an error with the path of the first unknown value
+// in the value, if any.
+func unknownValueError(value tftypes.Value) error {
+	var unknownPath *tftypes.AttributePath
+
+	_ = tftypes.Walk(value, func(path *tftypes.AttributePath, value tftypes.Value) (bool, error) {
+		if unknownPath != nil {
+			return false, nil
+		}
+
+		if !value.IsKnown() {
+			unknownPath = path
+
+			return false, nil
+		}
+
+		return true, nil
+	})
+
+	if unknownPath == nil {
+		return nil
+	}
+
+	if len(unknownPath.Steps()) == 0 {
+		return errors.New("unknown value, which must be known in this response")
+	}
+
+	return fmt.Errorf("unknown value at %s, which must be known in this response", unknownPath)
+}
@@ -0,0 +1,176 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5dynamicvalue"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+// newValidationTestServer returns a test server with the schemas, which
+// returns the new state of test_resource in ApplyResourceChange,
+// PlanResourceChange and ReadResource responses.
+func newValidationTestServer(getProviderSchemaResponse *tfprotov5.GetProviderSchemaResponse, newState *tfprotov5.DynamicValue) *tf5testserver.TestServer {
+	return &tf5testserver.TestServer{
+		GetProviderSchemaResponse: getProviderSchemaResponse,
+		ApplyResourceChangeFunc: func(_ context.Context, _ *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+			return &tfprotov5.ApplyResourceChangeResponse{
+				NewState: newState,
+			}, nil
+		},
+		PlanResourceChangeFunc: func(_ context.Context, _ *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+			return &tfprotov5.PlanResourceChangeResponse{
+				PlannedState: newState,
+			}, nil
+		},
+		ReadResourceFunc: func(_ context.Context, _ *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+			return &tfprotov5.ReadResourceResponse{
+				NewState: newState,
+			}, nil
+		},
+	}
+}
+
+func TestWithResponseValidation(t *testing.T) {
+	t.Parallel()
+
+	schemaType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":   tftypes.String,
+			"name": tftypes.String,
+		},
+	}
+	missingAttributeType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id": tftypes.String,
+		},
+	}
+	unknownState := tf5dynamicvalue.Must(schemaType, tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "test-id"),
+		"name": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	}))
+
+	testCases := map[string]struct {
+		newState *tfprotov5.DynamicValue
+		call     func(context.Context, tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error)
+		expected []*tfprotov5.Diagnostic
+	}{
+		"ApplyResourceChange-valid": {
+			newState: tf5dynamicvalue.Must(schemaType, tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":   tftypes.NewValue(tftypes.String, "test-id"),
+				"name": tftypes.NewValue(tftypes.String, nil),
+			})),
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{TypeName: "test_resource"})
+
+				return resp.Diagnostics, err
+			},
+		},
+		"ApplyResourceChange-unknown": {
+			newState: unknownState,
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{TypeName: "test_resource"})
+
+				return resp.Diagnostics, err
+			},
+			expected: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Invalid Provider Server Response",
+					Detail: `An underlying provider server returned an invalid NewState value for "test_resource" in the ApplyResourceChange RPC response. ` +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf5testserver.TestServer\n" +
+						`Error: unknown value at AttributeName("name"), which must be known in this response`,
+				},
+			},
+		},
+		"PlanResourceChange-unknown": {
+			newState: unknownState,
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{TypeName: "test_resource"})
+
+				return resp.Diagnostics, err
+			},
+		},
+		"ReadResource-missing-attribute": {
+			newState: tf5dynamicvalue.Must(missingAttributeType, tftypes.NewValue(missingAttributeType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "test-id"),
+			})),
+			call: func(ctx context.Context, server tfprotov5.ProviderServer) ([]*tfprotov5.Diagnostic, error) {
+				resp, err := server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{TypeName: "test_resource"})
+
+				return resp.Diagnostics, err
+			},
+			expected: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Invalid Provider Server Response",
+					Detail: `An underlying provider server returned an invalid NewState value for "test_resource" in the ReadResource RPC response. ` +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf5testserver.TestServer\n" +
+						"Error: error decoding object; expected 2 attributes, got 1",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			testServer := newValidationTestServer(
+				&tfprotov5.GetProviderSchemaResponse{
+					ResourceSchemas: map[string]*tfprotov5.Schema{
+						"test_resource": {
+							Block: &tfprotov5.SchemaBlock{
+								Attributes: []*tfprotov5.SchemaAttribute{
+									{
+										Name:     "id",
+										Type:     tftypes.String,
+										Computed: true,
+									},
+									{
+										Name:     "name",
+										Type:     tftypes.String,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+				testCase.newState,
+			)
+
+			muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+				ctx,
+				[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+				tf5muxserver.WithResponseValidation(),
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error setting up muxer: %s", err)
+			}
+
+			got, err := testCase.call(ctx, muxServer.ProviderServer())
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+)
+
+// WithSlowCallThresholds is a NewMuxServerWithOptions option which logs a
+// warning for each call to an underlying server which takes longer than the
+// threshold for its RPC, keyed by RPC name, such as:
+//
+//	tf5muxserver.WithSlowCallThresholds(map[string]time.Duration{
+//		"ConfigureProvider": 10 * time.Second,
+//		"ReadResource":      30 * time.Second,
+//	})
+//
+// Calls of RPCs without a threshold are not timed. Calls are timed from the
+// request to the response, except for the streams of the InvokeAction and
+// ListResource RPCs, which are timed from their first element to their last.
+// The warning is logged through the mux logging subsystem, controlled by the
+// TF_LOG_SDK_MUX environment variable, with the RPC, type name, underlying
+// server, duration, and threshold.
+func WithSlowCallThresholds(thresholds map[string]time.Duration) MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.slowCallThresholds = maps.Clone(thresholds)
+	}
+}
+
+// WithSlowCallDiagnostics is a NewMuxServerWithOptions option which, in
+// addition to the log of WithSlowCallThresholds, returns a warning diagnostic
+// in the response of each slow call. Diagnostics are not returned in the
+// responses of the CallFunction and StopProvider RPCs, which have no warning
+// diagnostics, or of streaming RPCs.
+func WithSlowCallDiagnostics() MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.slowCallDiagnostics = true
+	}
+}
+
+// detectSlowCalls returns the Interceptor added by WithSlowCallThresholds.
+func detectSlowCalls(thresholds map[string]time.Duration, diagnostics bool) Interceptor {
+	return func(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+		threshold, ok := thresholds[info.RPC]
+
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		switch typedResp := resp.(type) {
+		case *tfprotov5.InvokeActionServerStream:
+			if typedResp != nil && typedResp.Events != nil {
+				typedResp.Events = timeStream(ctx, info, threshold, typedResp.Events)
+
+				return resp, err
+			}
+		case *tfprotov5.ListResourceServerStream:
+			if typedResp != nil && typedResp.Results != nil {
+				typedResp.Results = timeStream(ctx, info, threshold, typedResp.Results)
+
+				return resp, err
+			}
+		}
+
+		duration := time.Since(start)
+
+		if duration <= threshold {
+			return resp, err
+		}
+
+		logSlowCall(ctx, info, threshold, duration)
+
+		if diagnostics {
+			appendResponseDiagnostic(resp, slowCallDiagnostic(info, threshold, duration))
+		}
+
+		return resp, err
+	}
+}
+
+// timeStream returns the stream, logging a slow call if the time between its
+// first and last elements is longer than the threshold.
+func timeStream[T any](ctx context.Context, info *CallInfo, threshold time.Duration, stream iter.Seq[T]) iter.Seq[T] {
+	var first, last time.Time
+
+	return observeStream(stream, func(T) {
+		last = time.Now()
+
+		if first.IsZero() {
+			first = last
+		}
+	}, func() {
+		if duration := last.Sub(first); duration > threshold {
+			logSlowCall(ctx, info, threshold, duration)
+		}
+	})
+}
+
+// logSlowCall logs a warning for a call which took longer than the threshold.
+func logSlowCall(ctx context.Context, info *CallInfo, threshold time.Duration, duration time.Duration) {
+	fields := map[string]interface{}{
+		logging.KeyTfRpc:            info.RPC,
+		logging.KeyTfMuxProvider:    fmt.Sprintf("%T", info.Server),
+		logging.KeyTfMuxDurationMs:  duration.Milliseconds(),
+		logging.KeyTfMuxThresholdMs: threshold.Milliseconds(),
+	}
+
+	if info.TypeName != "" {
+		fields[logging.KeyTfTypeName] = info.TypeName
+	}
+
+	logging.MuxWarn(ctx, "slow downstream server call", fields)
+}
@@ -0,0 +1,223 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklogtest"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+func TestWithSlowCallThresholds(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		opts                []tf5muxserver.MuxServerOption
+		expectedDiagnostics []*tfprotov5.Diagnostic
+		expectedLogs        []map[string]interface{}
+	}{
+		"no-threshold": {
+			opts: []tf5muxserver.MuxServerOption{
+				tf5muxserver.WithSlowCallThresholds(map[string]time.Duration{
+					"ConfigureProvider": time.Nanosecond,
+				}),
+			},
+		},
+		"threshold-not-exceeded": {
+			opts: []tf5muxserver.MuxServerOption{
+				tf5muxserver.WithSlowCallThresholds(map[string]time.Duration{
+					"ReadResource": time.Hour,
+				}),
+				tf5muxserver.WithSlowCallDiagnostics(),
+			},
+		},
+		"threshold-exceeded": {
+			opts: []tf5muxserver.MuxServerOption{
+				tf5muxserver.WithSlowCallThresholds(map[string]time.Duration{
+					"ReadResource": time.Nanosecond,
+				}),
+			},
+			expectedLogs: []map[string]interface{}{
+				{
+					"@level":              "warn",
+					"@message":            "slow downstream server call",
+					"tf_mux_provider":     "*tf5testserver.TestServer",
+					"tf_mux_threshold_ms": float64(0),
+					"tf_rpc":              "ReadResource",
+					"tf_type_name":        "test_resource",
+				},
+			},
+		},
+		"threshold-exceeded-diagnostics": {
+			opts: []tf5muxserver.MuxServerOption{
+				tf5muxserver.WithSlowCallThresholds(map[string]time.Duration{
+					"ReadResource": time.Nanosecond,
+				}),
+				tf5muxserver.WithSlowCallDiagnostics(),
+			},
+			expectedDiagnostics: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "Slow Provider Server Call",
+				},
+			},
+			expectedLogs: []map[string]interface{}{
+				{
+					"@level":              "warn",
+					"@message":            "slow downstream server call",
+					"tf_mux_provider":     "*tf5testserver.TestServer",
+					"tf_mux_threshold_ms": float64(0),
+					"tf_rpc":              "ReadResource",
+					"tf_type_name":        "test_resource",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var output bytes.Buffer
+
+			ctx := tfsdklogtest.RootLogger(context.Background(), &output)
+			testServer := newValidationTestServer(
+				&tfprotov5.GetProviderSchemaResponse{
+					ResourceSchemas: map[string]*tfprotov5.Schema{
+						"test_resource": {},
+					},
+				},
+				nil,
+			)
+
+			muxServer, err := tf5muxserver.NewMuxServerWithOptions(ctx, []func() tfprotov5.ProviderServer{testServer.ProviderServer}, testCase.opts...)
+
+			if err != nil {
+				t.Fatalf("unexpected error setting up muxer: %s", err)
+			}
+
+			resp, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+				TypeName: "test_resource",
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// The details of diagnostics contain the duration of the call,
+			// so only their prefix is compared.
+			for _, diagnostic := range resp.Diagnostics {
+				if !strings.HasPrefix(diagnostic.Detail, `An underlying provider server took `) {
+					t.Errorf("unexpected diagnostic detail: %s", diagnostic.Detail)
+				}
+
+				diagnostic.Detail = ""
+			}
+
+			if diff := cmp.Diff(resp.Diagnostics, testCase.expectedDiagnostics); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+
+			entries, err := tfsdklogtest.MultilineJSONDecode(&output)
+
+			if err != nil {
+				t.Fatalf("unable to read log entries: %s", err)
+			}
+
+			var got []map[string]interface{}
+
+			for _, entry := range entries {
+				if entry["@message"] != "slow downstream server call" {
+					continue
+				}
+
+				// The duration of the call is not compared.
+				if _, ok := entry["tf_mux_duration_ms"]; !ok {
+					t.Errorf("expected tf_mux_duration_ms in log entry: %v", entry)
+				}
+
+				got = append(got, map[string]interface{}{
+					"@level":              entry["@level"],
+					"@message":            entry["@message"],
+					"tf_mux_provider":     entry["tf_mux_provider"],
+					"tf_mux_threshold_ms": entry["tf_mux_threshold_ms"],
+					"tf_rpc":              entry["tf_rpc"],
+					"tf_type_name":        entry["tf_type_name"],
+				})
+			}
+
+			if diff := cmp.Diff(got, testCase.expectedLogs); diff != "" {
+				t.Errorf("unexpected log entries difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithSlowCallThresholds_ListResource(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+
+	ctx := tfsdklogtest.RootLogger(context.Background(), &output)
+	testServer := &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			ListResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource": {},
+			},
+		},
+		// The results are returned with a delay between them.
+		ListResourceFunc: func(_ context.Context, _ *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+			return &tfprotov5.ListResourceServerStream{
+				Results: func(yield func(tfprotov5.ListResourceResult) bool) {
+					for range 2 {
+						time.Sleep(time.Millisecond)
+
+						if !yield(tfprotov5.ListResourceResult{}) {
+							return
+						}
+					}
+				},
+			}, nil
+		},
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(ctx, []func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithSlowCallThresholds(map[string]time.Duration{
+			"ListResource": time.Nanosecond,
+		}),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Contains(output.String(), "slow downstream server call") {
+		t.Fatalf("expected slow ListResource to not be logged before the stream has ended")
+	}
+
+	for range resp.Results {
+		// The stream is consumed to end it.
+	}
+
+	if !strings.Contains(output.String(), "slow downstream server call") {
+		t.Errorf("expected slow ListResource to be logged after the stream has ended, got: %s", output.String())
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5muxserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/muxtrace"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+func TestWithTracer(t *testing.T) {
+	t.Parallel()
+
+	ctx := metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+	)
+	testServer := &tf5testserver.TestServer{
+		GetMetadataResponse: &tfprotov5.GetMetadataResponse{
+			Resources: []tfprotov5.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+		},
+	}
+	tracer := muxtrace.NewInMemoryTracer()
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithTracer(tracer),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedNames := []string{
+		"downstream.GetMetadata",
+		"mux.Discover",
+		"downstream.ReadResource",
+		"mux.ReadResource",
+	}
+
+	if diff := cmp.Diff(tracer.SpanNames(), expectedNames); diff != "" {
+		t.Fatalf("unexpected span names difference: %s", diff)
+	}
+
+	spans := tracer.Spans()
+	getMetadata, discover, readResource, rpc := spans[0], spans[1], spans[2], spans[3]
+
+	if rpc.Parent.TraceID != rpc.SpanContext.TraceID || !rpc.Parent.Remote || rpc.Parent.Traceparent() != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Errorf("expected RPC span parent to be the incoming span context, got: %v", rpc.Parent)
+	}
+
+	if discover.Parent != rpc.SpanContext {
+		t.Errorf("expected discovery span parent to be the RPC span")
+	}
+
+	if getMetadata.Parent != discover.SpanContext {
+		t.Errorf("expected GetMetadata span parent to be the discovery span")
+	}
+
+	if readResource.Parent != rpc.SpanContext {
+		t.Errorf("expected ReadResource span parent to be the RPC span")
+	}
+
+	if diff := cmp.Diff(rpc.Attributes, map[string]any{"tf_rpc": "ReadResource"}); diff != "" {
+		t.Errorf("unexpected RPC span attributes difference: %s", diff)
+	}
+
+	if diff := cmp.Diff(discover.Attributes, map[string]any{"tf_mux_provider": "*tf5testserver.TestServer"}); diff != "" {
+		t.Errorf("unexpected discovery span attributes difference: %s", diff)
+	}
+
+	expectedReadResourceAttributes := map[string]any{
+		"diagnostic_error_count":   0,
+		"diagnostic_warning_count": 0,
+		"tf_mux_provider":          "*tf5testserver.TestServer",
+		"tf_rpc":                   "ReadResource",
+		"tf_type_name":             "test_resource",
+	}
+
+	if diff := cmp.Diff(readResource.Attributes, expectedReadResourceAttributes); diff != "" {
+		t.Errorf("unexpected ReadResource span attributes difference: %s", diff)
+	}
+}
+
+func TestWithTracer_ListResource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+	tracer := muxtrace.NewInMemoryTracer()
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer.ProviderServer},
+		tf5muxserver.WithTracer(tracer),
+		tf5muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_list_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for range resp.Results {
+		// The stream is consumed to end it.
+	}
+
+	expectedNames := []string{
+		"downstream.GetMetadata",
+		"mux.Discover",
+		"mux.ListResource",
+		"downstream.ListResource.chunk",
+		"downstream.ListResource.chunk",
+		"downstream.ListResource",
+	}
+
+	if diff := cmp.Diff(tracer.SpanNames(), expectedNames); diff != "" {
+		t.Fatalf("unexpected span names difference: %s", diff)
+	}
+
+	spans := tracer.Spans()
+	firstChunk, secondChunk, listResource := spans[3], spans[4], spans[5]
+
+	if firstChunk.Parent != listResource.SpanContext || secondChunk.Parent != listResource.SpanContext {
+		t.Errorf("expected chunk span parents to be the ListResource span")
+	}
+
+	expectedFirstChunkAttributes := map[string]any{
+		"diagnostic_error_count":   0,
+		"diagnostic_warning_count": 0,
+		"tf_stream_chunk":          0,
+	}
+
+	if diff := cmp.Diff(firstChunk.Attributes, expectedFirstChunkAttributes); diff != "" {
+		t.Errorf("unexpected first chunk span attributes difference: %s", diff)
+	}
+
+	// The second result is the diagnostic of the recovered panic.
+	expectedSecondChunkAttributes := map[string]any{
+		"diagnostic_error_count":   1,
+		"diagnostic_warning_count": 0,
+		"tf_stream_chunk":          1,
+	}
+
+	if diff := cmp.Diff(secondChunk.Attributes, expectedSecondChunkAttributes); diff != "" {
+		t.Errorf("unexpected second chunk span attributes difference: %s", diff)
+	}
+
+	expectedListResourceAttributes := map[string]any{
+		"diagnostic_error_count":   1,
+		"diagnostic_warning_count": 0,
+		"tf_mux_provider":          "*tf5testserver.TestServer",
+		"tf_rpc":                   "ListResource",
+		"tf_type_name":             "test_list_resource",
+	}
+
+	if diff := cmp.Diff(listResource.Attributes, expectedListResourceAttributes); diff != "" {
+		t.Errorf("unexpected ListResource span attributes difference: %s", diff)
+	}
+}
+
+func TestWithTracer_FanOut(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf5testserver.TestServer{}
+	testServer2 := &tf5testserver.TestServer{}
+	tracer := muxtrace.NewInMemoryTracer()
+
+	muxServer, err := tf5muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov5.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer},
+		tf5muxserver.WithTracer(tracer),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().StopProvider(ctx, &tfprotov5.StopProviderRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedNames := []string{
+		"downstream.StopProvider",
+		"downstream.StopProvider",
+		"mux.StopProvider",
+	}
+
+	if diff := cmp.Diff(tracer.SpanNames(), expectedNames); diff != "" {
+		t.Fatalf("unexpected span names difference: %s", diff)
+	}
+
+	spans := tracer.Spans()
+
+	if spans[0].Parent != spans[2].SpanContext || spans[1].Parent != spans[2].SpanContext {
+		t.Errorf("expected fan-out span parents to be the RPC span")
+	}
+}
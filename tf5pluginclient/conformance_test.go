@@ -0,0 +1,51 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5pluginclient
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin5"
+	"github.com/hashicorp/terraform-plugin-mux/muxconformance"
+)
+
+// TestProviderServer_Conformance sends every RPC through the copies of the
+// tfplugin5 Protocol Buffers types and their conversions in this module to a
+// terraform-plugin-go server, which fails if the copies are missing a field
+// that is implemented by terraform-plugin-go.
+func TestProviderServer_Conformance(t *testing.T) {
+	t.Parallel()
+
+	muxconformance.RunV5(
+		t,
+		func(server func() tfprotov5.ProviderServer) (tfprotov5.ProviderServer, error) {
+			conn, _ := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+				providerPlugin := &tf5server.GRPCProviderPlugin{
+					GRPCProvider: server,
+					Name:         "test",
+					Opts:         []tf5server.ServeOpt{tf5server.WithLoggingSink(t)},
+				}
+
+				// GRPCServer never returns an error.
+				_ = providerPlugin.GRPCServer(nil, s)
+			})
+
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+
+			return &providerServer{
+				client: tfplugin5.NewProviderClient(conn),
+			}, nil
+		},
+		// terraform-plugin-go servers do not convert this field from
+		// Protocol Buffers.
+		muxconformance.WithIgnoredFields("MoveResourceState request.SourceIdentitySchemaVersion"),
+	)
+}
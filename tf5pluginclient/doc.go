@@ -0,0 +1,14 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tf5pluginclient launches a provider binary that implements protocol version 5, and exposes it as a tfprotov5.ProviderServer.
+//
+// The provider binary is started with the go-plugin
+// (https://pkg.go.dev/github.com/hashicorp/go-plugin) handshake used by
+// Terraform and every RPC, including the streaming ListResource and
+// InvokeAction RPCs, is proxied to it over gRPC. This enables providers which
+// are shipped as separate binaries to be combined with in-process servers,
+// such as by the tf5muxserver package.
+//
+// Refer to the New() function for launching a provider binary.
+package tf5pluginclient
@@ -0,0 +1,404 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5pluginclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin5"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov5fromproto"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov5toproto"
+)
+
+var (
+	_ tfprotov5.ProviderServer                 = &providerServer{}
+	_ tfprotov5.ProviderServerWithActions      = &providerServer{} //nolint:staticcheck
+	_ tfprotov5.ProviderServerWithListResource = &providerServer{} //nolint:staticcheck
+)
+
+// providerServer implements tfprotov5.ProviderServer by proxying each RPC to
+// a provider binary over gRPC.
+type providerServer struct {
+	client tfplugin5.ProviderClient
+}
+
+func (s *providerServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	protoResp, err := s.client.ApplyResourceChange(ctx, tfprotov5toproto.ApplyResourceChangeRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ApplyResourceChangeResponse(protoResp), nil
+}
+
+func (s *providerServer) CallFunction(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	protoResp, err := s.client.CallFunction(ctx, tfprotov5toproto.CallFunctionRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.CallFunctionResponse(protoResp), nil
+}
+
+func (s *providerServer) CloseEphemeralResource(ctx context.Context, req *tfprotov5.CloseEphemeralResourceRequest) (*tfprotov5.CloseEphemeralResourceResponse, error) {
+	protoResp, err := s.client.CloseEphemeralResource(ctx, tfprotov5toproto.CloseEphemeralResourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.CloseEphemeralResourceResponse(protoResp), nil
+}
+
+func (s *providerServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	protoResp, err := s.client.Configure(ctx, tfprotov5toproto.ConfigureProviderRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ConfigureProviderResponse(protoResp), nil
+}
+
+func (s *providerServer) GenerateResourceConfig(ctx context.Context, req *tfprotov5.GenerateResourceConfigRequest) (*tfprotov5.GenerateResourceConfigResponse, error) {
+	protoResp, err := s.client.GenerateResourceConfig(ctx, tfprotov5toproto.GenerateResourceConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.GenerateResourceConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) GetFunctions(ctx context.Context, req *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	protoResp, err := s.client.GetFunctions(ctx, tfprotov5toproto.GetFunctionsRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tfprotov5fromproto.GetFunctionsResponse(protoResp)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert GetFunctions response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	protoResp, err := s.client.GetMetadata(ctx, tfprotov5toproto.GetMetadataRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.GetMetadataResponse(protoResp), nil
+}
+
+func (s *providerServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	protoResp, err := s.client.GetSchema(ctx, tfprotov5toproto.GetProviderSchemaRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tfprotov5fromproto.GetProviderSchemaResponse(protoResp)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert GetProviderSchema response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) GetResourceIdentitySchemas(ctx context.Context, req *tfprotov5.GetResourceIdentitySchemasRequest) (*tfprotov5.GetResourceIdentitySchemasResponse, error) {
+	protoResp, err := s.client.GetResourceIdentitySchemas(ctx, tfprotov5toproto.GetResourceIdentitySchemasRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tfprotov5fromproto.GetResourceIdentitySchemasResponse(protoResp)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert GetResourceIdentitySchemas response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	protoResp, err := s.client.ImportResourceState(ctx, tfprotov5toproto.ImportResourceStateRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ImportResourceStateResponse(protoResp), nil
+}
+
+func (s *providerServer) InvokeAction(ctx context.Context, req *tfprotov5.InvokeActionRequest) (*tfprotov5.InvokeActionServerStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	protoStream, err := s.client.InvokeAction(ctx, tfprotov5toproto.InvokeActionRequest(req))
+
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	return &tfprotov5.InvokeActionServerStream{
+		Events: func(yield func(tfprotov5.InvokeActionEvent) bool) {
+			// Stop receiving if the iteration ends early.
+			defer cancel()
+
+			for {
+				protoEvent, err := protoStream.Recv()
+
+				if errors.Is(err, io.EOF) {
+					return
+				}
+
+				if err != nil {
+					yield(tfprotov5.InvokeActionEvent{
+						Type: tfprotov5.CompletedInvokeActionEventType{
+							Diagnostics: []*tfprotov5.Diagnostic{
+								streamError("InvokeAction", err),
+							},
+						},
+					})
+
+					return
+				}
+
+				if !yield(tfprotov5fromproto.InvokeActionEvent(protoEvent)) {
+					return
+				}
+			}
+		},
+	}, nil
+}
+
+func (s *providerServer) ListResource(ctx context.Context, req *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	protoStream, err := s.client.ListResource(ctx, tfprotov5toproto.ListResourceRequest(req))
+
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	return &tfprotov5.ListResourceServerStream{
+		Results: func(yield func(tfprotov5.ListResourceResult) bool) {
+			// Stop receiving if the iteration ends early.
+			defer cancel()
+
+			for {
+				protoEvent, err := protoStream.Recv()
+
+				if errors.Is(err, io.EOF) {
+					return
+				}
+
+				if err != nil {
+					yield(tfprotov5.ListResourceResult{
+						Diagnostics: []*tfprotov5.Diagnostic{
+							streamError("ListResource", err),
+						},
+					})
+
+					return
+				}
+
+				if !yield(tfprotov5fromproto.ListResourceResult(protoEvent)) {
+					return
+				}
+			}
+		},
+	}, nil
+}
+
+func (s *providerServer) MoveResourceState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	protoResp, err := s.client.MoveResourceState(ctx, tfprotov5toproto.MoveResourceStateRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.MoveResourceStateResponse(protoResp), nil
+}
+
+func (s *providerServer) OpenEphemeralResource(ctx context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
+	protoResp, err := s.client.OpenEphemeralResource(ctx, tfprotov5toproto.OpenEphemeralResourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.OpenEphemeralResourceResponse(protoResp), nil
+}
+
+func (s *providerServer) PlanAction(ctx context.Context, req *tfprotov5.PlanActionRequest) (*tfprotov5.PlanActionResponse, error) {
+	protoResp, err := s.client.PlanAction(ctx, tfprotov5toproto.PlanActionRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.PlanActionResponse(protoResp), nil
+}
+
+func (s *providerServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	protoResp, err := s.client.PlanResourceChange(ctx, tfprotov5toproto.PlanResourceChangeRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.PlanResourceChangeResponse(protoResp), nil
+}
+
+func (s *providerServer) PrepareProviderConfig(ctx context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	protoResp, err := s.client.PrepareProviderConfig(ctx, tfprotov5toproto.PrepareProviderConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.PrepareProviderConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	protoResp, err := s.client.ReadDataSource(ctx, tfprotov5toproto.ReadDataSourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ReadDataSourceResponse(protoResp), nil
+}
+
+func (s *providerServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	protoResp, err := s.client.ReadResource(ctx, tfprotov5toproto.ReadResourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ReadResourceResponse(protoResp), nil
+}
+
+func (s *providerServer) RenewEphemeralResource(ctx context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
+	protoResp, err := s.client.RenewEphemeralResource(ctx, tfprotov5toproto.RenewEphemeralResourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.RenewEphemeralResourceResponse(protoResp), nil
+}
+
+func (s *providerServer) StopProvider(ctx context.Context, req *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	protoResp, err := s.client.Stop(ctx, tfprotov5toproto.StopProviderRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.StopProviderResponse(protoResp), nil
+}
+
+func (s *providerServer) UpgradeResourceIdentity(ctx context.Context, req *tfprotov5.UpgradeResourceIdentityRequest) (*tfprotov5.UpgradeResourceIdentityResponse, error) {
+	protoResp, err := s.client.UpgradeResourceIdentity(ctx, tfprotov5toproto.UpgradeResourceIdentityRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.UpgradeResourceIdentityResponse(protoResp), nil
+}
+
+func (s *providerServer) UpgradeResourceState(ctx context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	protoResp, err := s.client.UpgradeResourceState(ctx, tfprotov5toproto.UpgradeResourceStateRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.UpgradeResourceStateResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateActionConfig(ctx context.Context, req *tfprotov5.ValidateActionConfigRequest) (*tfprotov5.ValidateActionConfigResponse, error) {
+	protoResp, err := s.client.ValidateActionConfig(ctx, tfprotov5toproto.ValidateActionConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ValidateActionConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateDataSourceConfig(ctx context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
+	protoResp, err := s.client.ValidateDataSourceConfig(ctx, tfprotov5toproto.ValidateDataSourceConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ValidateDataSourceConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateEphemeralResourceConfig(ctx context.Context, req *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
+	protoResp, err := s.client.ValidateEphemeralResourceConfig(ctx, tfprotov5toproto.ValidateEphemeralResourceConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ValidateEphemeralResourceConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateListResourceConfig(ctx context.Context, req *tfprotov5.ValidateListResourceConfigRequest) (*tfprotov5.ValidateListResourceConfigResponse, error) {
+	protoResp, err := s.client.ValidateListResourceConfig(ctx, tfprotov5toproto.ValidateListResourceConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ValidateListResourceConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	protoResp, err := s.client.ValidateResourceTypeConfig(ctx, tfprotov5toproto.ValidateResourceTypeConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov5fromproto.ValidateResourceTypeConfigResponse(protoResp), nil
+}
+
+// streamError returns an error diagnostic for an error received from a
+// streaming RPC after the stream was opened, since the stream types cannot
+// return errors.
+func streamError(rpc string, err error) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  "Error Receiving " + rpc + " Stream",
+		Detail: "The provider returned an error while streaming the " + rpc + " response. " +
+			"This may be an issue with the provider binary or its connection.\n\n" +
+			"Error: " + err.Error(),
+	}
+}
@@ -0,0 +1,523 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5pluginclient_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/tf5pluginclient"
+)
+
+// envTestProvider is the environment variable which makes the test binary
+// run as the test provider binary, rather than running the tests. A value of
+// "serve" serves testProviderServer and "exit" exits without a handshake.
+const envTestProvider = "TF5PLUGINCLIENT_TEST_PROVIDER"
+
+// TestMain enables the test binary to also be the test provider binary, so
+// the tests can launch it with testProviderCommand.
+func TestMain(m *testing.M) {
+	switch os.Getenv(envTestProvider) {
+	case "serve":
+		err := tf5server.Serve("registry.terraform.io/hashicorp/test", func() tfprotov5.ProviderServer {
+			return testProviderServer{}
+		})
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	case "exit":
+		os.Exit(1)
+	}
+
+	os.Exit(m.Run())
+}
+
+// testProviderCommand returns a command which runs the test binary as the
+// test provider binary in the given mode.
+func testProviderCommand(mode string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0]) //nolint:gosec
+	cmd.Env = append(os.Environ(), envTestProvider+"="+mode)
+
+	return cmd
+}
+
+// newTestClient launches the test provider binary and kills it when the test
+// completes.
+func newTestClient(t *testing.T) *tf5pluginclient.Client {
+	t.Helper()
+
+	client, err := tf5pluginclient.New(context.Background(), testProviderCommand("serve"), tf5pluginclient.WithLogger(hclog.NewNullLogger()))
+
+	if err != nil {
+		t.Fatalf("unexpected error launching test provider: %s", err)
+	}
+
+	t.Cleanup(client.Kill)
+
+	return client
+}
+
+var (
+	testIdentityType = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id": tftypes.String,
+		},
+	}
+
+	testRenewAt = time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	testResourceType = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id": tftypes.String,
+			"tags": tftypes.Map{
+				ElementType: tftypes.String,
+			},
+		},
+	}
+)
+
+func testDynamicValue(value string) *tfprotov5.DynamicValue {
+	dynamicValue, err := tfprotov5.NewDynamicValue(testResourceType, tftypes.NewValue(testResourceType, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, value),
+		"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+	}))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return &dynamicValue
+}
+
+func testIdentity(value string) *tfprotov5.ResourceIdentityData {
+	dynamicValue, err := tfprotov5.NewDynamicValue(testIdentityType, tftypes.NewValue(testIdentityType, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, value),
+	}))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return &tfprotov5.ResourceIdentityData{
+		IdentityData: &dynamicValue,
+	}
+}
+
+func testProviderSchemaResponse() *tfprotov5.GetProviderSchemaResponse {
+	return &tfprotov5.GetProviderSchemaResponse{
+		ActionSchemas: map[string]*tfprotov5.ActionSchema{
+			"test_action": {
+				Schema: &tfprotov5.Schema{
+					Block: &tfprotov5.SchemaBlock{},
+				},
+			},
+		},
+		DataSourceSchemas:        map[string]*tfprotov5.Schema{},
+		EphemeralResourceSchemas: map[string]*tfprotov5.Schema{},
+		Functions: map[string]*tfprotov5.Function{
+			"test_function": {
+				Parameters: []*tfprotov5.FunctionParameter{
+					{
+						AllowNullValue: true,
+						Name:           "input",
+						Type:           tftypes.String,
+					},
+				},
+				Return: &tfprotov5.FunctionReturn{
+					Type: tftypes.String,
+				},
+				Summary: "Test function",
+			},
+		},
+		ListResourceSchemas: map[string]*tfprotov5.Schema{
+			"test_resource": {
+				Block: &tfprotov5.SchemaBlock{},
+			},
+		},
+		Provider: &tfprotov5.Schema{
+			Block: &tfprotov5.SchemaBlock{
+				Attributes: []*tfprotov5.SchemaAttribute{
+					{
+						Name:      "token",
+						Optional:  true,
+						Sensitive: true,
+						Type:      tftypes.String,
+					},
+				},
+				BlockTypes:      []*tfprotov5.SchemaNestedBlock{},
+				Description:     "Test provider",
+				DescriptionKind: tfprotov5.StringKindMarkdown,
+			},
+		},
+		ResourceSchemas: map[string]*tfprotov5.Schema{
+			"test_resource": {
+				Block: &tfprotov5.SchemaBlock{
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Computed: true,
+							Name:     "id",
+							Type:     tftypes.String,
+						},
+						{
+							Name:     "tags",
+							Optional: true,
+							Type: tftypes.Map{
+								ElementType: tftypes.String,
+							},
+						},
+					},
+					BlockTypes: []*tfprotov5.SchemaNestedBlock{
+						{
+							Block: &tfprotov5.SchemaBlock{
+								Attributes: []*tfprotov5.SchemaAttribute{
+									{
+										Name:      "value",
+										Required:  true,
+										Type:      tftypes.Number,
+										WriteOnly: true,
+									},
+								},
+								BlockTypes: []*tfprotov5.SchemaNestedBlock{},
+							},
+							MaxItems: 2,
+							Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+							TypeName: "nested",
+						},
+					},
+				},
+				Version: 1,
+			},
+		},
+		ServerCapabilities: &tfprotov5.ServerCapabilities{
+			GetProviderSchemaOptional: true,
+			PlanDestroy:               true,
+		},
+	}
+}
+
+// testProviderServer is the provider served by the test provider binary.
+// Responses are derived from requests so tests can verify both directions of
+// each RPC.
+type testProviderServer struct{}
+
+func (s testProviderServer) ApplyResourceChange(_ context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	return &tfprotov5.ApplyResourceChangeResponse{
+		NewIdentity: req.PlannedIdentity,
+		NewState:    req.PlannedState,
+		Private:     req.PlannedPrivate,
+	}, nil
+}
+
+func (s testProviderServer) CallFunction(_ context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	if len(req.Arguments) == 0 {
+		argument := int64(0)
+
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{
+				FunctionArgument: &argument,
+				Text:             "missing argument",
+			},
+		}, nil
+	}
+
+	return &tfprotov5.CallFunctionResponse{
+		Result: req.Arguments[0],
+	}, nil
+}
+
+func (s testProviderServer) CloseEphemeralResource(_ context.Context, _ *tfprotov5.CloseEphemeralResourceRequest) (*tfprotov5.CloseEphemeralResourceResponse, error) {
+	return &tfprotov5.CloseEphemeralResourceResponse{}, nil
+}
+
+func (s testProviderServer) ConfigureProvider(_ context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	return &tfprotov5.ConfigureProviderResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{
+				Severity: tfprotov5.DiagnosticSeverityWarning,
+				Summary:  "Configured",
+				Detail:   "Terraform " + req.TerraformVersion,
+			},
+		},
+	}, nil
+}
+
+func (s testProviderServer) GenerateResourceConfig(_ context.Context, req *tfprotov5.GenerateResourceConfigRequest) (*tfprotov5.GenerateResourceConfigResponse, error) {
+	return &tfprotov5.GenerateResourceConfigResponse{
+		Config: req.State,
+	}, nil
+}
+
+func (s testProviderServer) GetFunctions(_ context.Context, _ *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	return &tfprotov5.GetFunctionsResponse{
+		Functions: testProviderSchemaResponse().Functions,
+	}, nil
+}
+
+func (s testProviderServer) GetMetadata(_ context.Context, _ *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	return &tfprotov5.GetMetadataResponse{
+		Actions: []tfprotov5.ActionMetadata{
+			{
+				TypeName: "test_action",
+			},
+		},
+		Functions: []tfprotov5.FunctionMetadata{
+			{
+				Name: "test_function",
+			},
+		},
+		ListResources: []tfprotov5.ListResourceMetadata{
+			{
+				TypeName: "test_resource",
+			},
+		},
+		Resources: []tfprotov5.ResourceMetadata{
+			{
+				TypeName: "test_resource",
+			},
+		},
+		ServerCapabilities: testProviderSchemaResponse().ServerCapabilities,
+	}, nil
+}
+
+func (s testProviderServer) GetProviderSchema(_ context.Context, _ *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	return testProviderSchemaResponse(), nil
+}
+
+func (s testProviderServer) GetResourceIdentitySchemas(_ context.Context, _ *tfprotov5.GetResourceIdentitySchemasRequest) (*tfprotov5.GetResourceIdentitySchemasResponse, error) {
+	return &tfprotov5.GetResourceIdentitySchemasResponse{
+		IdentitySchemas: map[string]*tfprotov5.ResourceIdentitySchema{
+			"test_resource": {
+				IdentityAttributes: []*tfprotov5.ResourceIdentitySchemaAttribute{
+					{
+						Name:              "id",
+						RequiredForImport: true,
+						Type:              tftypes.String,
+					},
+				},
+				Version: 1,
+			},
+		},
+	}, nil
+}
+
+func (s testProviderServer) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	resp := &tfprotov5.ImportResourceStateResponse{
+		ImportedResources: []*tfprotov5.ImportedResource{
+			{
+				Identity: req.Identity,
+				State:    testDynamicValue(req.ID),
+				TypeName: req.TypeName,
+			},
+		},
+	}
+
+	if req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &tfprotov5.Deferred{
+			Reason: tfprotov5.DeferredReasonAbsentPrereq,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s testProviderServer) InvokeAction(_ context.Context, _ *tfprotov5.InvokeActionRequest) (*tfprotov5.InvokeActionServerStream, error) {
+	return &tfprotov5.InvokeActionServerStream{
+		Events: func(yield func(tfprotov5.InvokeActionEvent) bool) {
+			for _, message := range []string{"starting", "finishing"} {
+				event := tfprotov5.InvokeActionEvent{
+					Type: tfprotov5.ProgressInvokeActionEventType{
+						Message: message,
+					},
+				}
+
+				if !yield(event) {
+					return
+				}
+			}
+
+			yield(tfprotov5.InvokeActionEvent{
+				Type: tfprotov5.CompletedInvokeActionEventType{
+					Diagnostics: []*tfprotov5.Diagnostic{
+						{
+							Severity: tfprotov5.DiagnosticSeverityWarning,
+							Summary:  "Action completed",
+						},
+					},
+				},
+			})
+		},
+	}, nil
+}
+
+func (s testProviderServer) ListResource(_ context.Context, req *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+	return &tfprotov5.ListResourceServerStream{
+		Results: func(yield func(tfprotov5.ListResourceResult) bool) {
+			for i := range req.Limit {
+				result := tfprotov5.ListResourceResult{
+					DisplayName: fmt.Sprintf("result-%d", i),
+					Identity:    testIdentity(fmt.Sprintf("id-%d", i)),
+				}
+
+				if req.IncludeResource {
+					result.Resource = testDynamicValue(fmt.Sprintf("id-%d", i))
+				}
+
+				if !yield(result) {
+					return
+				}
+			}
+		},
+	}, nil
+}
+
+func (s testProviderServer) MoveResourceState(_ context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	return &tfprotov5.MoveResourceStateResponse{
+		TargetPrivate: req.SourcePrivate,
+		TargetState: &tfprotov5.DynamicValue{
+			JSON: req.SourceState.JSON,
+		},
+	}, nil
+}
+
+func (s testProviderServer) OpenEphemeralResource(_ context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
+	return &tfprotov5.OpenEphemeralResourceResponse{
+		Private: []byte(req.TypeName),
+		RenewAt: testRenewAt,
+		Result:  req.Config,
+	}, nil
+}
+
+func (s testProviderServer) PlanAction(_ context.Context, req *tfprotov5.PlanActionRequest) (*tfprotov5.PlanActionResponse, error) {
+	resp := &tfprotov5.PlanActionResponse{}
+
+	if req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &tfprotov5.Deferred{
+			Reason: tfprotov5.DeferredReasonProviderConfigUnknown,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s testProviderServer) PlanResourceChange(_ context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp := &tfprotov5.PlanResourceChangeResponse{
+		PlannedIdentity: req.PriorIdentity,
+		PlannedPrivate:  req.PriorPrivate,
+		PlannedState:    req.ProposedNewState,
+		RequiresReplace: []*tftypes.AttributePath{
+			tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("name"),
+		},
+	}
+
+	if req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &tfprotov5.Deferred{
+			Reason: tfprotov5.DeferredReasonResourceConfigUnknown,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s testProviderServer) PrepareProviderConfig(_ context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	return &tfprotov5.PrepareProviderConfigResponse{
+		PreparedConfig: req.Config,
+	}, nil
+}
+
+func (s testProviderServer) ReadDataSource(_ context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	return &tfprotov5.ReadDataSourceResponse{
+		State: req.Config,
+	}, nil
+}
+
+func (s testProviderServer) ReadResource(_ context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	return &tfprotov5.ReadResourceResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{
+				Attribute: tftypes.NewAttributePath().WithAttributeName("nested").WithElementKeyInt(1).WithAttributeName("value"),
+				Detail:    "Read " + req.TypeName,
+				Severity:  tfprotov5.DiagnosticSeverityWarning,
+				Summary:   "Test warning",
+			},
+		},
+		NewIdentity: req.CurrentIdentity,
+		NewState:    req.CurrentState,
+		Private:     req.Private,
+	}, nil
+}
+
+func (s testProviderServer) RenewEphemeralResource(_ context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
+	return &tfprotov5.RenewEphemeralResourceResponse{
+		Private: req.Private,
+		RenewAt: testRenewAt.Add(time.Hour),
+	}, nil
+}
+
+func (s testProviderServer) StopProvider(_ context.Context, _ *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	return &tfprotov5.StopProviderResponse{
+		Error: "test stop error",
+	}, nil
+}
+
+func (s testProviderServer) UpgradeResourceIdentity(_ context.Context, req *tfprotov5.UpgradeResourceIdentityRequest) (*tfprotov5.UpgradeResourceIdentityResponse, error) {
+	return &tfprotov5.UpgradeResourceIdentityResponse{
+		UpgradedIdentity: &tfprotov5.ResourceIdentityData{
+			IdentityData: &tfprotov5.DynamicValue{
+				JSON: req.RawIdentity.JSON,
+			},
+		},
+	}, nil
+}
+
+func (s testProviderServer) UpgradeResourceState(_ context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	return &tfprotov5.UpgradeResourceStateResponse{
+		UpgradedState: &tfprotov5.DynamicValue{
+			JSON: req.RawState.JSON,
+		},
+	}, nil
+}
+
+func (s testProviderServer) ValidateActionConfig(_ context.Context, _ *tfprotov5.ValidateActionConfigRequest) (*tfprotov5.ValidateActionConfigResponse, error) {
+	return &tfprotov5.ValidateActionConfigResponse{}, nil
+}
+
+func (s testProviderServer) ValidateDataSourceConfig(_ context.Context, _ *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
+	return &tfprotov5.ValidateDataSourceConfigResponse{}, nil
+}
+
+func (s testProviderServer) ValidateEphemeralResourceConfig(_ context.Context, _ *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
+	return &tfprotov5.ValidateEphemeralResourceConfigResponse{}, nil
+}
+
+func (s testProviderServer) ValidateListResourceConfig(_ context.Context, _ *tfprotov5.ValidateListResourceConfigRequest) (*tfprotov5.ValidateListResourceConfigResponse, error) {
+	return &tfprotov5.ValidateListResourceConfigResponse{}, nil
+}
+
+func (s testProviderServer) ValidateResourceTypeConfig(_ context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	resp := &tfprotov5.ValidateResourceTypeConfigResponse{}
+
+	if req.ClientCapabilities == nil || !req.ClientCapabilities.WriteOnlyAttributesAllowed {
+		resp.Diagnostics = []*tfprotov5.Diagnostic{
+			{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Write-only attributes not allowed",
+			},
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,162 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5pluginclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin5"
+)
+
+const (
+	// protocolVersion is the major version of the plugin protocol.
+	protocolVersion = 5
+
+	// pluginName is the go-plugin name of Terraform providers.
+	pluginName = "provider"
+
+	// maxRecvMsgSize matches the maximum message size that providers
+	// receive, so large responses, such as GetProviderSchema, are not
+	// rejected by the client.
+	maxRecvMsgSize = 256 << 20
+)
+
+// Handshake is the go-plugin handshake used by Terraform providers that
+// implement protocol version 5.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  protocolVersion,
+	MagicCookieKey:   "TF_PLUGIN_MAGIC_COOKIE",
+	MagicCookieValue: "d602bf8f470bc67ca7faa0386276bbdd4330efaf76d1a219cb4d6991ca9872b2",
+}
+
+// Client is a running provider binary. It should always be instantiated by
+// calling New().
+type Client struct {
+	// pluginClient manages the provider process.
+	pluginClient *plugin.Client
+
+	// server proxies RPCs to the provider process.
+	server *providerServer
+}
+
+// ClientOption is an option for the New function.
+type ClientOption func(*clientOptions)
+
+// clientOptions contains the configuration of New.
+type clientOptions struct {
+	// logger receives go-plugin logs and the provider process stderr.
+	logger hclog.Logger
+}
+
+// WithLogger is a New option which sets the logger that receives the
+// go-plugin logs, including the output of the provider binary on stderr. By
+// default, go-plugin logs to os.Stderr.
+func WithLogger(logger hclog.Logger) ClientOption {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// New starts the provider binary described by cmd, performs the go-plugin
+// handshake, and returns a Client that proxies RPCs to it over gRPC. The
+// Kill method must be called to stop the provider binary once the Client is
+// no longer needed. The command must not have been started.
+//
+// An error is returned if the provider binary cannot be started or does not
+// implement protocol version 5.
+func New(_ context.Context, cmd *exec.Cmd, opts ...ClientOption) (*Client, error) {
+	var options clientOptions
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	pluginClient := plugin.NewClient(&plugin.ClientConfig{
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		AutoMTLS:         true,
+		Cmd:              cmd,
+		GRPCDialOptions: []grpc.DialOption{
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRecvMsgSize)),
+		},
+		HandshakeConfig: Handshake,
+		Logger:          options.logger,
+		VersionedPlugins: map[int]plugin.PluginSet{
+			protocolVersion: {
+				pluginName: &grpcProviderPlugin{},
+			},
+		},
+	})
+
+	rpcClient, err := pluginClient.Client()
+
+	if err != nil {
+		pluginClient.Kill()
+
+		return nil, fmt.Errorf("unable to start provider: %w", err)
+	}
+
+	raw, err := rpcClient.Dispense(pluginName)
+
+	if err != nil {
+		pluginClient.Kill()
+
+		return nil, fmt.Errorf("unable to dispense provider: %w", err)
+	}
+
+	server, ok := raw.(*providerServer)
+
+	if !ok {
+		pluginClient.Kill()
+
+		return nil, fmt.Errorf("unexpected provider type: %T", raw)
+	}
+
+	return &Client{
+		pluginClient: pluginClient,
+		server:       server,
+	}, nil
+}
+
+// Kill stops the provider binary. The ProviderServer must not be used
+// afterwards.
+func (c *Client) Kill() {
+	c.pluginClient.Kill()
+}
+
+// ProviderServer is a function compatible with tf5server.Serve and
+// tf5muxserver.NewMuxServer, which returns the server that proxies RPCs to
+// the provider binary.
+func (c *Client) ProviderServer() tfprotov5.ProviderServer {
+	return c.server
+}
+
+var _ plugin.GRPCPlugin = &grpcProviderPlugin{}
+
+// grpcProviderPlugin implements the client half of the go-plugin GRPCPlugin
+// interface for Terraform providers. The net/rpc protocol is not supported.
+type grpcProviderPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+}
+
+// GRPCClient returns the server that proxies RPCs to the provider binary
+// over the given connection.
+func (p *grpcProviderPlugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return &providerServer{
+		client: tfplugin5.NewProviderClient(conn),
+	}, nil
+}
+
+// GRPCServer always returns an error; only the client half of the interface
+// is implemented.
+func (p *grpcProviderPlugin) GRPCServer(*plugin.GRPCBroker, *grpc.Server) error {
+	return errors.New("tf5pluginclient only implements gRPC clients")
+}
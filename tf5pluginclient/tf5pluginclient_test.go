@@ -0,0 +1,471 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5pluginclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5pluginclient"
+)
+
+var testCmpOptions = []cmp.Option{
+	cmpopts.EquateEmpty(),
+	cmp.Comparer(func(x, y tftypes.Type) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+
+		return x.Equal(y)
+	}),
+	cmp.Comparer(func(x, y *tftypes.AttributePath) bool {
+		return x.Equal(y)
+	}),
+}
+
+func TestNew_Error(t *testing.T) {
+	t.Parallel()
+
+	_, err := tf5pluginclient.New(context.Background(), testProviderCommand("exit"), tf5pluginclient.WithLogger(hclog.NewNullLogger()))
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestClient_GetProviderSchema(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(resp, testProviderSchemaResponse(), testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_GetResourceIdentitySchemas(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov5.GetResourceIdentitySchemasRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov5.GetResourceIdentitySchemasResponse{
+		IdentitySchemas: map[string]*tfprotov5.ResourceIdentitySchema{
+			"test_resource": {
+				IdentityAttributes: []*tfprotov5.ResourceIdentitySchemaAttribute{
+					{
+						Name:              "id",
+						RequiredForImport: true,
+						Type:              tftypes.String,
+					},
+				},
+				Version: 1,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_CallFunction(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{
+		Name: "test_function",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	argument := int64(0)
+	expected := &tfprotov5.CallFunctionResponse{
+		Error: &tfprotov5.FunctionError{
+			FunctionArgument: &argument,
+			Text:             "missing argument",
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_ConfigureProvider(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+		TerraformVersion: "1.14.0",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov5.ConfigureProviderResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{
+				Severity: tfprotov5.DiagnosticSeverityWarning,
+				Summary:  "Configured",
+				Detail:   "Terraform 1.14.0",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_ImportResourceState(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.ImportResourceState(context.Background(), &tfprotov5.ImportResourceStateRequest{
+		ClientCapabilities: &tfprotov5.ImportResourceStateClientCapabilities{
+			DeferralAllowed: true,
+		},
+		ID:       "test-id",
+		Identity: testIdentity("test-id"),
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov5.ImportResourceStateResponse{
+		Deferred: &tfprotov5.Deferred{
+			Reason: tfprotov5.DeferredReasonAbsentPrereq,
+		},
+		ImportedResources: []*tfprotov5.ImportedResource{
+			{
+				Identity: testIdentity("test-id"),
+				State:    testDynamicValue("test-id"),
+				TypeName: "test_resource",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_OpenEphemeralResource(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.OpenEphemeralResource(context.Background(), &tfprotov5.OpenEphemeralResourceRequest{
+		Config:   testDynamicValue("test-id"),
+		TypeName: "test_ephemeral",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov5.OpenEphemeralResourceResponse{
+		Private: []byte("test_ephemeral"),
+		RenewAt: testRenewAt,
+		Result:  testDynamicValue("test-id"),
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_PlanResourceChange(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		ClientCapabilities: &tfprotov5.PlanResourceChangeClientCapabilities{
+			DeferralAllowed: true,
+		},
+		PriorIdentity:    testIdentity("test-id"),
+		PriorPrivate:     []byte("private"),
+		ProposedNewState: testDynamicValue("test-id"),
+		TypeName:         "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov5.PlanResourceChangeResponse{
+		Deferred: &tfprotov5.Deferred{
+			Reason: tfprotov5.DeferredReasonResourceConfigUnknown,
+		},
+		PlannedIdentity: testIdentity("test-id"),
+		PlannedPrivate:  []byte("private"),
+		PlannedState:    testDynamicValue("test-id"),
+		RequiresReplace: []*tftypes.AttributePath{
+			tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("name"),
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_ReadResource(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.ReadResource(context.Background(), &tfprotov5.ReadResourceRequest{
+		CurrentIdentity: testIdentity("test-id"),
+		CurrentState:    testDynamicValue("test-id"),
+		Private:         []byte("private"),
+		TypeName:        "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov5.ReadResourceResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{
+				Attribute: tftypes.NewAttributePath().WithAttributeName("nested").WithElementKeyInt(1).WithAttributeName("value"),
+				Detail:    "Read test_resource",
+				Severity:  tfprotov5.DiagnosticSeverityWarning,
+				Summary:   "Test warning",
+			},
+		},
+		NewIdentity: testIdentity("test-id"),
+		NewState:    testDynamicValue("test-id"),
+		Private:     []byte("private"),
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_StopProvider(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.StopProvider(context.Background(), &tfprotov5.StopProviderRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.Error != "test stop error" {
+		t.Errorf("expected error %q, got %q", "test stop error", resp.Error)
+	}
+}
+
+func TestClient_ValidateResourceTypeConfig(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.ValidateResourceTypeConfig(context.Background(), &tfprotov5.ValidateResourceTypeConfigRequest{
+		ClientCapabilities: &tfprotov5.ValidateResourceTypeConfigClientCapabilities{
+			WriteOnlyAttributesAllowed: true,
+		},
+		Config:   testDynamicValue("test-id"),
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
+func TestClient_InvokeAction(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov5.ProviderServerWithActions) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement actions")
+	}
+
+	resp, err := server.InvokeAction(context.Background(), &tfprotov5.InvokeActionRequest{
+		ActionType: "test_action",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var events []tfprotov5.InvokeActionEvent
+
+	for event := range resp.Events {
+		events = append(events, event)
+	}
+
+	expected := []tfprotov5.InvokeActionEvent{
+		{
+			Type: tfprotov5.ProgressInvokeActionEventType{
+				Message: "starting",
+			},
+		},
+		{
+			Type: tfprotov5.ProgressInvokeActionEventType{
+				Message: "finishing",
+			},
+		},
+		{
+			Type: tfprotov5.CompletedInvokeActionEventType{
+				Diagnostics: []*tfprotov5.Diagnostic{
+					{
+						Severity: tfprotov5.DiagnosticSeverityWarning,
+						Summary:  "Action completed",
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(events, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected events difference: %s", diff)
+	}
+}
+
+func TestClient_ListResource(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov5.ProviderServerWithListResource) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement list resources")
+	}
+
+	resp, err := server.ListResource(context.Background(), &tfprotov5.ListResourceRequest{
+		IncludeResource: true,
+		Limit:           3,
+		TypeName:        "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var results []tfprotov5.ListResourceResult
+
+	for result := range resp.Results {
+		results = append(results, result)
+	}
+
+	expected := []tfprotov5.ListResourceResult{
+		{
+			DisplayName: "result-0",
+			Identity:    testIdentity("id-0"),
+			Resource:    testDynamicValue("id-0"),
+		},
+		{
+			DisplayName: "result-1",
+			Identity:    testIdentity("id-1"),
+			Resource:    testDynamicValue("id-1"),
+		},
+		{
+			DisplayName: "result-2",
+			Identity:    testIdentity("id-2"),
+			Resource:    testDynamicValue("id-2"),
+		},
+	}
+
+	if diff := cmp.Diff(results, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected results difference: %s", diff)
+	}
+}
+
+func TestClient_ListResource_EarlyStop(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov5.ProviderServerWithListResource) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement list resources")
+	}
+
+	resp, err := server.ListResource(context.Background(), &tfprotov5.ListResourceRequest{
+		Limit:    10,
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var count int
+
+	for range resp.Results {
+		count++
+
+		if count == 2 {
+			break
+		}
+	}
+
+	if count != 2 {
+		t.Errorf("expected 2 results, got %d", count)
+	}
+}
+
+func TestClient_MuxServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, client.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error creating mux server: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		CurrentState: testDynamicValue("test-id"),
+		TypeName:     "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(resp.NewState, testDynamicValue("test-id")); diff != "" {
+		t.Errorf("unexpected state difference: %s", diff)
+	}
+}
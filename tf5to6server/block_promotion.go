@@ -0,0 +1,801 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5to6server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov5tov6"
+)
+
+// WithBlockPromotion is an UpgradeServer option which promotes nested blocks
+// of the resource type to single nested attributes
+// (SchemaObjectNestingModeSingle), such as the MaxItems: 1 list blocks which
+// terraform-plugin-sdk uses to model single objects. This allows a resource to
+// publish the same schema as a terraform-plugin-framework replacement which
+// uses single nested attributes, before switching over. Each path is the
+// path of a block, through the type names of any blocks containing it:
+//
+//	tf5to6server.WithBlockPromotion(
+//		"examplecloud_thing",
+//		tftypes.NewAttributePath().WithAttributeName("settings"),
+//		tftypes.NewAttributePath().WithAttributeName("settings").WithAttributeName("timeouts"),
+//	)
+//
+// Promoted blocks must be list or set blocks with a MaxItems of 1. Their
+// attributes become the attributes of the nested attribute, which is
+// required if the block has a MinItems of 1, and optional otherwise. Nested
+// attributes cannot contain blocks, so any blocks nested in a promoted block
+// must also be promoted. The GetProviderSchema RPC of the returned server
+// returns an error for invalid paths.
+//
+// Values are translated between the single object and the list or set form
+// of promoted blocks in both directions: null objects in the values sent to
+// the underlying server, such as configurations, are translated to empty
+// lists, and empty lists in the values returned by the underlying server,
+// such as states, are translated to null. The JSON of the raw states of the
+// UpgradeResourceState RPC is translated to the list form, so states which
+// were stored with either form can be upgraded. Values are translated with
+// the schemas returned by the GetProviderSchema RPC of the underlying server,
+// which is called on first use, unless it was already called through the
+// returned server.
+func WithBlockPromotion(typeName string, paths ...*tftypes.AttributePath) UpgradeServerOption {
+	return withBlockPromotion(schemaKindResource, typeName, paths)
+}
+
+// WithDataSourceBlockPromotion is an UpgradeServer option which promotes
+// nested blocks of the data source type to single nested attributes, in the
+// same way as WithBlockPromotion.
+func WithDataSourceBlockPromotion(typeName string, paths ...*tftypes.AttributePath) UpgradeServerOption {
+	return withBlockPromotion(schemaKindDataSource, typeName, paths)
+}
+
+func withBlockPromotion(kind string, typeName string, paths []*tftypes.AttributePath) UpgradeServerOption {
+	return func(o *upgradeServerOptions) {
+		if o.blockPromotions == nil {
+			o.blockPromotions = make(map[string]map[string][]*tftypes.AttributePath)
+		}
+
+		if o.blockPromotions[kind] == nil {
+			o.blockPromotions[kind] = make(map[string][]*tftypes.AttributePath)
+		}
+
+		o.blockPromotions[kind][typeName] = append(o.blockPromotions[kind][typeName], paths...)
+	}
+}
+
+// Schema kinds, which select the schema used to translate a value.
+const (
+	schemaKindDataSource = "data source"
+	schemaKindResource   = "resource"
+)
+
+// blockPromotion translates the values of requests and responses between
+// the schemas of the underlying server and the promoted schemas. Its methods
+// do nothing if it is nil, which is when no blocks are promoted.
+type blockPromotion struct {
+	server tfprotov5.ProviderServer
+
+	// rules are the paths of the promoted blocks, by schema kind and type
+	// name.
+	rules map[string]map[string][]*tftypes.AttributePath
+
+	mu sync.Mutex
+
+	// schemas is the GetProviderSchema response of the underlying server,
+	// translated to protocol version 6.
+	schemas *tfprotov6.GetProviderSchemaResponse
+}
+
+// promoteProviderSchema caches the GetProviderSchema response of the
+// underlying server, translated to protocol version 6, and returns a copy of
+// it with the blocks promoted.
+func (p *blockPromotion) promoteProviderSchema(resp *tfprotov6.GetProviderSchemaResponse) (*tfprotov6.GetProviderSchemaResponse, error) {
+	if p == nil || resp == nil {
+		return resp, nil
+	}
+
+	p.mu.Lock()
+	p.schemas = resp
+	p.mu.Unlock()
+
+	promoted := *resp
+
+	for kind, typeNames := range p.rules {
+		schemas := resp.ResourceSchemas
+
+		if kind == schemaKindDataSource {
+			schemas = resp.DataSourceSchemas
+		}
+
+		promotedSchemas := make(map[string]*tfprotov6.Schema, len(schemas))
+
+		for typeName, schema := range schemas {
+			promotedSchema, err := promoteSchema(schema, typeNames[typeName])
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to promote blocks of %s %q: %w", kind, typeName, err)
+			}
+
+			promotedSchemas[typeName] = promotedSchema
+		}
+
+		for typeName := range typeNames {
+			if _, ok := schemas[typeName]; !ok {
+				return nil, fmt.Errorf("unable to promote blocks of %s %q: no schema returned by the underlying server", kind, typeName)
+			}
+		}
+
+		if kind == schemaKindDataSource {
+			promoted.DataSourceSchemas = promotedSchemas
+		} else {
+			promoted.ResourceSchemas = promotedSchemas
+		}
+	}
+
+	return &promoted, nil
+}
+
+// schema returns the schema of the kind and type name, and the paths of its
+// promoted blocks, calling the GetProviderSchema RPC of the underlying server
+// if the schemas are not yet known. A nil schema is returned for types
+// without promoted blocks, so their values are not translated.
+func (p *blockPromotion) schema(ctx context.Context, kind string, typeName string) (*tfprotov6.Schema, []*tftypes.AttributePath, error) {
+	paths := p.rules[kind][typeName]
+
+	if len(paths) == 0 {
+		return nil, nil, nil
+	}
+
+	p.mu.Lock()
+	schemas := p.schemas
+	p.mu.Unlock()
+
+	if schemas == nil {
+		resp, err := p.server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to fetch schemas for block promotion: %w", err)
+		}
+
+		schemas = tfprotov5tov6.GetProviderSchemaResponse(resp)
+
+		if _, err := p.promoteProviderSchema(schemas); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if schemas == nil {
+		return nil, nil, nil
+	}
+
+	if kind == schemaKindDataSource {
+		return schemas.DataSourceSchemas[typeName], paths, nil
+	}
+
+	return schemas.ResourceSchemas[typeName], paths, nil
+}
+
+// demoteValue translates a value sent to the underlying server from the
+// promoted schema to the schema of the underlying server.
+func (p *blockPromotion) demoteValue(ctx context.Context, kind string, typeName string, value **tfprotov5.DynamicValue) error {
+	return p.translateValue(ctx, kind, typeName, value, false)
+}
+
+// promoteValue translates a value returned by the underlying server from its
+// schema to the promoted schema.
+func (p *blockPromotion) promoteValue(ctx context.Context, kind string, typeName string, value **tfprotov5.DynamicValue) error {
+	return p.translateValue(ctx, kind, typeName, value, true)
+}
+
+// translateValue replaces the value with its translation.
+func (p *blockPromotion) translateValue(ctx context.Context, kind string, typeName string, value **tfprotov5.DynamicValue, promote bool) error {
+	if *value == nil {
+		return nil
+	}
+
+	schema, paths, err := p.schema(ctx, kind, typeName)
+
+	if err != nil {
+		return err
+	}
+
+	if schema == nil {
+		return nil
+	}
+
+	promotedSchema, err := promoteSchema(schema, paths)
+
+	if err != nil {
+		return fmt.Errorf("unable to promote blocks of %s %q: %w", kind, typeName, err)
+	}
+
+	sourceType, targetType := promotedSchema.ValueType(), schema.ValueType()
+
+	if promote {
+		sourceType, targetType = targetType, sourceType
+	}
+
+	decoded, err := (*value).Unmarshal(sourceType)
+
+	if err != nil {
+		return fmt.Errorf("unable to translate promoted blocks of %s %q value: %w", kind, typeName, err)
+	}
+
+	translated, err := translateObject(schema.Block, tftypes.NewAttributePath(), paths, decoded, targetType, promote)
+
+	if err != nil {
+		return fmt.Errorf("unable to translate promoted blocks of %s %q value: %w", kind, typeName, err)
+	}
+
+	encoded, err := tfprotov5.NewDynamicValue(targetType, translated)
+
+	if err != nil {
+		return fmt.Errorf("unable to translate promoted blocks of %s %q value: %w", kind, typeName, err)
+	}
+
+	*value = &encoded
+
+	return nil
+}
+
+// demoteRawState replaces a raw state sent to the underlying server with one
+// whose JSON has the list or set form of the promoted blocks.
+func (p *blockPromotion) demoteRawState(ctx context.Context, typeName string, rawState **tfprotov5.RawState) error {
+	if *rawState == nil || len((*rawState).JSON) == 0 {
+		return nil
+	}
+
+	schema, paths, err := p.schema(ctx, schemaKindResource, typeName)
+
+	if err != nil {
+		return err
+	}
+
+	if schema == nil {
+		return nil
+	}
+
+	var state any
+
+	if err := json.Unmarshal((*rawState).JSON, &state); err != nil {
+		return fmt.Errorf("unable to translate promoted blocks of resource %q raw state: %w", typeName, err)
+	}
+
+	encoded, err := json.Marshal(demoteJSONObject(schema.Block, tftypes.NewAttributePath(), paths, state))
+
+	if err != nil {
+		return fmt.Errorf("unable to translate promoted blocks of resource %q raw state: %w", typeName, err)
+	}
+
+	*rawState = &tfprotov5.RawState{
+		Flatmap: (*rawState).Flatmap,
+		JSON:    encoded,
+	}
+
+	return nil
+}
+
+// demoteRequest translates the values of a request to the underlying server.
+func (p *blockPromotion) demoteRequest(ctx context.Context, req any) error {
+	if p == nil {
+		return nil
+	}
+
+	switch r := req.(type) {
+	case *tfprotov5.ApplyResourceChangeRequest:
+		return errors.Join(
+			p.demoteValue(ctx, schemaKindResource, r.TypeName, &r.Config),
+			p.demoteValue(ctx, schemaKindResource, r.TypeName, &r.PriorState),
+			p.demoteValue(ctx, schemaKindResource, r.TypeName, &r.PlannedState),
+		)
+	case *tfprotov5.GenerateResourceConfigRequest:
+		return p.demoteValue(ctx, schemaKindResource, r.TypeName, &r.State)
+	case *tfprotov5.PlanResourceChangeRequest:
+		return errors.Join(
+			p.demoteValue(ctx, schemaKindResource, r.TypeName, &r.Config),
+			p.demoteValue(ctx, schemaKindResource, r.TypeName, &r.PriorState),
+			p.demoteValue(ctx, schemaKindResource, r.TypeName, &r.ProposedNewState),
+		)
+	case *tfprotov5.ReadDataSourceRequest:
+		return p.demoteValue(ctx, schemaKindDataSource, r.TypeName, &r.Config)
+	case *tfprotov5.ReadResourceRequest:
+		return p.demoteValue(ctx, schemaKindResource, r.TypeName, &r.CurrentState)
+	case *tfprotov5.UpgradeResourceStateRequest:
+		return p.demoteRawState(ctx, r.TypeName, &r.RawState)
+	case *tfprotov5.ValidateDataSourceConfigRequest:
+		return p.demoteValue(ctx, schemaKindDataSource, r.TypeName, &r.Config)
+	case *tfprotov5.ValidateResourceTypeConfigRequest:
+		return p.demoteValue(ctx, schemaKindResource, r.TypeName, &r.Config)
+	}
+
+	return nil
+}
+
+// promoteResponse translates the values of a response from the underlying
+// server, for the request type name.
+func (p *blockPromotion) promoteResponse(ctx context.Context, typeName string, resp any) error {
+	if p == nil {
+		return nil
+	}
+
+	switch r := resp.(type) {
+	case *tfprotov5.ApplyResourceChangeResponse:
+		if r != nil {
+			return p.promoteValue(ctx, schemaKindResource, typeName, &r.NewState)
+		}
+	case *tfprotov5.GenerateResourceConfigResponse:
+		if r != nil {
+			return p.promoteValue(ctx, schemaKindResource, typeName, &r.Config)
+		}
+	case *tfprotov5.ImportResourceStateResponse:
+		if r != nil {
+			for _, importedResource := range r.ImportedResources {
+				if importedResource == nil {
+					continue
+				}
+
+				if err := p.promoteValue(ctx, schemaKindResource, importedResource.TypeName, &importedResource.State); err != nil {
+					return err
+				}
+			}
+		}
+	case *tfprotov5.MoveResourceStateResponse:
+		if r != nil {
+			return p.promoteValue(ctx, schemaKindResource, typeName, &r.TargetState)
+		}
+	case *tfprotov5.PlanResourceChangeResponse:
+		if r != nil {
+			return p.promoteValue(ctx, schemaKindResource, typeName, &r.PlannedState)
+		}
+	case *tfprotov5.ReadDataSourceResponse:
+		if r != nil {
+			return p.promoteValue(ctx, schemaKindDataSource, typeName, &r.State)
+		}
+	case *tfprotov5.ReadResourceResponse:
+		if r != nil {
+			return p.promoteValue(ctx, schemaKindResource, typeName, &r.NewState)
+		}
+	case *tfprotov5.UpgradeResourceStateResponse:
+		if r != nil {
+			return p.promoteValue(ctx, schemaKindResource, typeName, &r.UpgradedState)
+		}
+	}
+
+	return nil
+}
+
+// promoteListResults returns the results with the resource objects
+// translated, replacing results which cannot be translated with an error
+// diagnostic.
+func (p *blockPromotion) promoteListResults(ctx context.Context, typeName string, results iter.Seq[tfprotov5.ListResourceResult]) iter.Seq[tfprotov5.ListResourceResult] {
+	if p == nil || results == nil {
+		return results
+	}
+
+	return func(yield func(tfprotov5.ListResourceResult) bool) {
+		for result := range results {
+			if err := p.promoteValue(ctx, schemaKindResource, typeName, &result.Resource); err != nil {
+				result = tfprotov5.ListResourceResult{
+					Diagnostics: []*tfprotov5.Diagnostic{
+						{
+							Severity: tfprotov5.DiagnosticSeverityError,
+							Summary:  "Unable to Translate List Resource Result",
+							Detail:   "The resource object of a list resource result could not be translated to the promoted schema: " + err.Error(),
+						},
+					},
+				}
+			}
+
+			if !yield(result) {
+				return
+			}
+		}
+	}
+}
+
+// promoteSchema returns a copy of the schema with the blocks at the paths
+// promoted to single nested attributes.
+func promoteSchema(schema *tfprotov6.Schema, paths []*tftypes.AttributePath) (*tfprotov6.Schema, error) {
+	if schema == nil || len(paths) == 0 {
+		return schema, nil
+	}
+
+	for _, path := range paths {
+		if err := validatePromotedBlock(schema.Block, path); err != nil {
+			return nil, err
+		}
+	}
+
+	block, err := promoteBlock(schema.Block, tftypes.NewAttributePath(), paths)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.Schema{
+		Block:   block,
+		Version: schema.Version,
+	}, nil
+}
+
+// validatePromotedBlock returns an error unless the path is the path of a
+// list or set block with a MaxItems of 1.
+func validatePromotedBlock(block *tfprotov6.SchemaBlock, path *tftypes.AttributePath) error {
+	var nestedBlock *tfprotov6.SchemaNestedBlock
+
+	for _, step := range path.Steps() {
+		name, ok := step.(tftypes.AttributeName)
+
+		if !ok || block == nil {
+			return fmt.Errorf("invalid block path %s, which must only contain the type names of blocks", path)
+		}
+
+		index := slices.IndexFunc(block.BlockTypes, func(b *tfprotov6.SchemaNestedBlock) bool {
+			return b != nil && b.TypeName == string(name)
+		})
+
+		if index < 0 {
+			return fmt.Errorf("no block at path %s", path)
+		}
+
+		nestedBlock = block.BlockTypes[index]
+		block = nestedBlock.Block
+	}
+
+	if nestedBlock == nil {
+		return fmt.Errorf("invalid empty block path")
+	}
+
+	switch nestedBlock.Nesting {
+	case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeSet:
+	default:
+		return fmt.Errorf("block at path %s cannot be promoted, as it is not a list or set block", path)
+	}
+
+	if nestedBlock.MaxItems != 1 {
+		return fmt.Errorf("block at path %s cannot be promoted, as its MaxItems is %d rather than 1", path, nestedBlock.MaxItems)
+	}
+
+	return nil
+}
+
+func promoteBlock(block *tfprotov6.SchemaBlock, path *tftypes.AttributePath, paths []*tftypes.AttributePath) (*tfprotov6.SchemaBlock, error) {
+	if block == nil {
+		return nil, nil
+	}
+
+	promoted := *block
+
+	promoted.Attributes = slices.Clone(block.Attributes)
+	promoted.BlockTypes = nil
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock == nil {
+			continue
+		}
+
+		nestedPath := path.WithAttributeName(nestedBlock.TypeName)
+
+		if containsPath(paths, nestedPath) {
+			attribute, err := promoteNestedBlock(nestedBlock, nestedPath, paths)
+
+			if err != nil {
+				return nil, err
+			}
+
+			promoted.Attributes = append(promoted.Attributes, attribute)
+
+			continue
+		}
+
+		promotedNestedBlock := *nestedBlock
+
+		promotedBlock, err := promoteBlock(nestedBlock.Block, nestedPath, paths)
+
+		if err != nil {
+			return nil, err
+		}
+
+		promotedNestedBlock.Block = promotedBlock
+
+		promoted.BlockTypes = append(promoted.BlockTypes, &promotedNestedBlock)
+	}
+
+	return &promoted, nil
+}
+
+// promoteNestedBlock returns the single nested attribute which a block is
+// promoted to.
+func promoteNestedBlock(nestedBlock *tfprotov6.SchemaNestedBlock, path *tftypes.AttributePath, paths []*tftypes.AttributePath) (*tfprotov6.SchemaAttribute, error) {
+	attribute := &tfprotov6.SchemaAttribute{
+		Name: nestedBlock.TypeName,
+		NestedType: &tfprotov6.SchemaObject{
+			Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+		},
+		Optional: nestedBlock.MinItems == 0,
+		Required: nestedBlock.MinItems > 0,
+	}
+
+	if nestedBlock.Block == nil {
+		return attribute, nil
+	}
+
+	attribute.Deprecated = nestedBlock.Block.Deprecated
+	attribute.DeprecationMessage = nestedBlock.Block.DeprecationMessage
+	attribute.Description = nestedBlock.Block.Description
+	attribute.DescriptionKind = nestedBlock.Block.DescriptionKind
+	attribute.NestedType.Attributes = slices.Clone(nestedBlock.Block.Attributes)
+
+	for _, innerBlock := range nestedBlock.Block.BlockTypes {
+		if innerBlock == nil {
+			continue
+		}
+
+		innerPath := path.WithAttributeName(innerBlock.TypeName)
+
+		if !containsPath(paths, innerPath) {
+			return nil, fmt.Errorf("block at path %s cannot be promoted, as its nested block %q is not also promoted", path, innerBlock.TypeName)
+		}
+
+		innerAttribute, err := promoteNestedBlock(innerBlock, innerPath, paths)
+
+		if err != nil {
+			return nil, err
+		}
+
+		attribute.NestedType.Attributes = append(attribute.NestedType.Attributes, innerAttribute)
+	}
+
+	return attribute, nil
+}
+
+// containsPath returns true if the paths contain the path.
+func containsPath(paths []*tftypes.AttributePath, path *tftypes.AttributePath) bool {
+	return slices.ContainsFunc(paths, path.Equal)
+}
+
+// hasPromotedBlocks returns true if the block at the path, or any block
+// nested in it, is promoted.
+func hasPromotedBlocks(paths []*tftypes.AttributePath, path *tftypes.AttributePath) bool {
+	steps := path.Steps()
+
+	return slices.ContainsFunc(paths, func(p *tftypes.AttributePath) bool {
+		return len(p.Steps()) >= len(steps) && tftypes.NewAttributePathWithSteps(p.Steps()[:len(steps)]).Equal(path)
+	})
+}
+
+// translateObject translates an object of the block, converting promoted
+// blocks from their list or set form to single objects if promote is true,
+// and from single objects to their list or set form if promote is false. The
+// targetType is the type of the translated object.
+func translateObject(block *tfprotov6.SchemaBlock, path *tftypes.AttributePath, paths []*tftypes.AttributePath, value tftypes.Value, targetType tftypes.Type, promote bool) (tftypes.Value, error) {
+	if !value.IsKnown() {
+		return tftypes.NewValue(targetType, tftypes.UnknownValue), nil
+	}
+
+	if value.IsNull() {
+		return tftypes.NewValue(targetType, nil), nil
+	}
+
+	var object map[string]tftypes.Value
+
+	if err := value.As(&object); err != nil {
+		return value, err
+	}
+
+	attributeTypes := targetType.(tftypes.Object).AttributeTypes //nolint:forcetypeassert
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock == nil {
+			continue
+		}
+
+		nestedPath := path.WithAttributeName(nestedBlock.TypeName)
+
+		if !hasPromotedBlocks(paths, nestedPath) {
+			continue
+		}
+
+		var translated tftypes.Value
+		var err error
+
+		if containsPath(paths, nestedPath) {
+			translated, err = translatePromotedBlock(nestedBlock, nestedPath, paths, object[nestedBlock.TypeName], attributeTypes[nestedBlock.TypeName], promote)
+		} else {
+			translated, err = translateNestedBlock(nestedBlock, nestedPath, paths, object[nestedBlock.TypeName], attributeTypes[nestedBlock.TypeName], promote)
+		}
+
+		if err != nil {
+			return value, fmt.Errorf("block %q: %w", nestedBlock.TypeName, err)
+		}
+
+		object[nestedBlock.TypeName] = translated
+	}
+
+	return tftypes.NewValue(targetType, object), nil
+}
+
+// translatePromotedBlock translates the value of a promoted block between
+// its list or set form and a single object.
+func translatePromotedBlock(nestedBlock *tfprotov6.SchemaNestedBlock, path *tftypes.AttributePath, paths []*tftypes.AttributePath, value tftypes.Value, targetType tftypes.Type, promote bool) (tftypes.Value, error) {
+	if !value.IsKnown() {
+		return tftypes.NewValue(targetType, tftypes.UnknownValue), nil
+	}
+
+	if !promote {
+		if value.IsNull() {
+			return tftypes.NewValue(targetType, []tftypes.Value{}), nil
+		}
+
+		element, err := translateObject(nestedBlock.Block, path, paths, value, elementType(targetType), promote)
+
+		if err != nil {
+			return value, err
+		}
+
+		return tftypes.NewValue(targetType, []tftypes.Value{element}), nil
+	}
+
+	if value.IsNull() {
+		return tftypes.NewValue(targetType, nil), nil
+	}
+
+	var elements []tftypes.Value
+
+	if err := value.As(&elements); err != nil {
+		return value, err
+	}
+
+	switch len(elements) {
+	case 0:
+		return tftypes.NewValue(targetType, nil), nil
+	case 1:
+		return translateObject(nestedBlock.Block, path, paths, elements[0], targetType, promote)
+	default:
+		return value, fmt.Errorf("promoted blocks must have at most 1 element, got %d", len(elements))
+	}
+}
+
+// translateNestedBlock translates the value of a block which is not
+// promoted, but contains promoted blocks.
+func translateNestedBlock(nestedBlock *tfprotov6.SchemaNestedBlock, path *tftypes.AttributePath, paths []*tftypes.AttributePath, value tftypes.Value, targetType tftypes.Type, promote bool) (tftypes.Value, error) {
+	switch nestedBlock.Nesting {
+	case tfprotov6.SchemaNestedBlockNestingModeSingle, tfprotov6.SchemaNestedBlockNestingModeGroup:
+		return translateObject(nestedBlock.Block, path, paths, value, targetType, promote)
+	}
+
+	if !value.IsKnown() {
+		return tftypes.NewValue(targetType, tftypes.UnknownValue), nil
+	}
+
+	if value.IsNull() {
+		return tftypes.NewValue(targetType, nil), nil
+	}
+
+	if nestedBlock.Nesting == tfprotov6.SchemaNestedBlockNestingModeMap {
+		var elements map[string]tftypes.Value
+
+		if err := value.As(&elements); err != nil {
+			return value, err
+		}
+
+		for key, element := range elements {
+			translated, err := translateObject(nestedBlock.Block, path, paths, element, elementType(targetType), promote)
+
+			if err != nil {
+				return value, err
+			}
+
+			elements[key] = translated
+		}
+
+		return tftypes.NewValue(targetType, elements), nil
+	}
+
+	var elements []tftypes.Value
+
+	if err := value.As(&elements); err != nil {
+		return value, err
+	}
+
+	for index, element := range elements {
+		translated, err := translateObject(nestedBlock.Block, path, paths, element, elementType(targetType), promote)
+
+		if err != nil {
+			return value, err
+		}
+
+		elements[index] = translated
+	}
+
+	return tftypes.NewValue(targetType, elements), nil
+}
+
+// elementType returns the element type of a list, set, or map type.
+func elementType(typ tftypes.Type) tftypes.Type {
+	switch t := typ.(type) {
+	case tftypes.List:
+		return t.ElementType
+	case tftypes.Map:
+		return t.ElementType
+	case tftypes.Set:
+		return t.ElementType
+	}
+
+	return typ
+}
+
+// demoteJSONObject translates the JSON of an object of the block, decoded
+// with encoding/json, converting promoted blocks which are single objects to
+// their list form. Promoted blocks which are already lists are kept.
+func demoteJSONObject(block *tfprotov6.SchemaBlock, path *tftypes.AttributePath, paths []*tftypes.AttributePath, value any) any {
+	object, ok := value.(map[string]any)
+
+	if !ok || block == nil {
+		return value
+	}
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock == nil {
+			continue
+		}
+
+		nestedPath := path.WithAttributeName(nestedBlock.TypeName)
+
+		if !hasPromotedBlocks(paths, nestedPath) {
+			continue
+		}
+
+		nestedValue, ok := object[nestedBlock.TypeName]
+
+		if !ok {
+			continue
+		}
+
+		if containsPath(paths, nestedPath) {
+			switch v := nestedValue.(type) {
+			case nil:
+				object[nestedBlock.TypeName] = []any{}
+			case map[string]any:
+				object[nestedBlock.TypeName] = []any{demoteJSONObject(nestedBlock.Block, nestedPath, paths, v)}
+			case []any:
+				for index, element := range v {
+					v[index] = demoteJSONObject(nestedBlock.Block, nestedPath, paths, element)
+				}
+			}
+
+			continue
+		}
+
+		switch v := nestedValue.(type) {
+		case map[string]any:
+			if nestedBlock.Nesting != tfprotov6.SchemaNestedBlockNestingModeMap {
+				object[nestedBlock.TypeName] = demoteJSONObject(nestedBlock.Block, nestedPath, paths, v)
+
+				continue
+			}
+
+			for key, element := range v {
+				v[key] = demoteJSONObject(nestedBlock.Block, nestedPath, paths, element)
+			}
+		case []any:
+			for index, element := range v {
+				v[index] = demoteJSONObject(nestedBlock.Block, nestedPath, paths, element)
+			}
+		}
+	}
+
+	return object
+}
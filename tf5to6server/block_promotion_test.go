@@ -0,0 +1,427 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5to6server_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+)
+
+// blockPromotionTestSchema is a resource schema with a MaxItems: 1 list
+// block, which contains another.
+var blockPromotionTestSchema = &tfprotov5.Schema{
+	Block: &tfprotov5.SchemaBlock{
+		Attributes: []*tfprotov5.SchemaAttribute{
+			{
+				Name:     "id",
+				Type:     tftypes.String,
+				Computed: true,
+			},
+		},
+		BlockTypes: []*tfprotov5.SchemaNestedBlock{
+			{
+				TypeName: "settings",
+				Block: &tfprotov5.SchemaBlock{
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:     "name",
+							Type:     tftypes.String,
+							Required: true,
+						},
+					},
+					BlockTypes: []*tfprotov5.SchemaNestedBlock{
+						{
+							TypeName: "timeouts",
+							Block: &tfprotov5.SchemaBlock{
+								Attributes: []*tfprotov5.SchemaAttribute{
+									{
+										Name:     "create",
+										Type:     tftypes.String,
+										Optional: true,
+									},
+								},
+							},
+							MaxItems: 1,
+							Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+						},
+					},
+					Description: "settings description",
+				},
+				MaxItems: 1,
+				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+			},
+			{
+				TypeName: "rule",
+				Block: &tfprotov5.SchemaBlock{
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:     "value",
+							Type:     tftypes.String,
+							Optional: true,
+						},
+					},
+				},
+				Nesting: tfprotov5.SchemaNestedBlockNestingModeList,
+			},
+		},
+	},
+}
+
+// blockPromotionTestPaths are the paths of the promoted blocks of
+// blockPromotionTestSchema.
+var blockPromotionTestPaths = []*tftypes.AttributePath{
+	tftypes.NewAttributePath().WithAttributeName("settings"),
+	tftypes.NewAttributePath().WithAttributeName("settings").WithAttributeName("timeouts"),
+}
+
+// blockPromotionTestServer is a test server which records the values sent by
+// ReadResource and UpgradeResourceState requests. ReadResource returns the
+// current state as the new state.
+type blockPromotionTestServer struct {
+	tf5testserver.TestServer
+
+	currentState *tfprotov5.DynamicValue
+	rawState     *tfprotov5.RawState
+}
+
+func (s *blockPromotionTestServer) ProviderServer() tfprotov5.ProviderServer {
+	return s
+}
+
+func (s *blockPromotionTestServer) ReadResource(_ context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	s.currentState = req.CurrentState
+
+	return &tfprotov5.ReadResourceResponse{
+		NewState: req.CurrentState,
+	}, nil
+}
+
+func (s *blockPromotionTestServer) UpgradeResourceState(_ context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	s.rawState = req.RawState
+
+	return &tfprotov5.UpgradeResourceStateResponse{}, nil
+}
+
+func TestUpgradeServer_WithBlockPromotion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v5server := &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource": blockPromotionTestSchema,
+			},
+		},
+	}
+
+	v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer, tf5to6server.WithBlockPromotion("test_resource", blockPromotionTestPaths...))
+
+	if err != nil {
+		t.Fatalf("unexpected error upgrading server: %s", err)
+	}
+
+	resp, err := v6server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name: "settings",
+					NestedType: &tfprotov6.SchemaObject{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "name",
+								Type:     tftypes.String,
+								Required: true,
+							},
+							{
+								Name: "timeouts",
+								NestedType: &tfprotov6.SchemaObject{
+									Attributes: []*tfprotov6.SchemaAttribute{
+										{
+											Name:     "create",
+											Type:     tftypes.String,
+											Optional: true,
+										},
+									},
+									Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+								},
+								Optional: true,
+							},
+						},
+						Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+					},
+					Optional:    true,
+					Description: "settings description",
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "rule",
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "value",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+					Nesting: tfprotov6.SchemaNestedBlockNestingModeList,
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp.ResourceSchemas["test_resource"], expected); diff != "" {
+		t.Errorf("unexpected schema difference: %s", diff)
+	}
+}
+
+func TestUpgradeServer_WithBlockPromotion_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		typeName      string
+		path          *tftypes.AttributePath
+		expectedError string
+	}{
+		"missing-block": {
+			typeName:      "test_resource",
+			path:          tftypes.NewAttributePath().WithAttributeName("missing"),
+			expectedError: `unable to promote blocks of resource "test_resource": no block at path AttributeName("missing")`,
+		},
+		"max-items": {
+			typeName:      "test_resource",
+			path:          tftypes.NewAttributePath().WithAttributeName("rule"),
+			expectedError: `unable to promote blocks of resource "test_resource": block at path AttributeName("rule") cannot be promoted, as its MaxItems is 0 rather than 1`,
+		},
+		"nested-block-not-promoted": {
+			typeName:      "test_resource",
+			path:          tftypes.NewAttributePath().WithAttributeName("settings"),
+			expectedError: `unable to promote blocks of resource "test_resource": block at path AttributeName("settings") cannot be promoted, as its nested block "timeouts" is not also promoted`,
+		},
+		"missing-schema": {
+			typeName:      "test_missing",
+			path:          tftypes.NewAttributePath().WithAttributeName("settings"),
+			expectedError: `unable to promote blocks of resource "test_missing": no schema returned by the underlying server`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			v5server := &tf5testserver.TestServer{
+				GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+					ResourceSchemas: map[string]*tfprotov5.Schema{
+						"test_resource": blockPromotionTestSchema,
+					},
+				},
+			}
+
+			v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer, tf5to6server.WithBlockPromotion(testCase.typeName, testCase.path))
+
+			if err != nil {
+				t.Fatalf("unexpected error upgrading server: %s", err)
+			}
+
+			_, err = v6server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+			if err == nil {
+				t.Fatalf("expected error, got none")
+			}
+
+			if diff := cmp.Diff(err.Error(), testCase.expectedError); diff != "" {
+				t.Errorf("unexpected error difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestUpgradeServer_WithBlockPromotion_Values(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v5server := &blockPromotionTestServer{
+		TestServer: tf5testserver.TestServer{
+			GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov5.Schema{
+					"test_resource": blockPromotionTestSchema,
+				},
+			},
+		},
+	}
+
+	v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer, tf5to6server.WithBlockPromotion("test_resource", blockPromotionTestPaths...))
+
+	if err != nil {
+		t.Fatalf("unexpected error upgrading server: %s", err)
+	}
+
+	schemaResp, err := v6server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v5Type := blockPromotionTestSchema.ValueType()
+	v6Type := schemaResp.ResourceSchemas["test_resource"].ValueType()
+	attributeType := func(typ tftypes.Type, attributeName string) tftypes.Type {
+		return typ.(tftypes.Object).AttributeTypes[attributeName] //nolint:forcetypeassert
+	}
+	elementType := func(typ tftypes.Type) tftypes.Type {
+		return typ.(tftypes.List).ElementType //nolint:forcetypeassert
+	}
+	v5SettingsType := elementType(attributeType(v5Type, "settings"))
+	v6SettingsType := attributeType(v6Type, "settings")
+
+	v6Value := tftypes.NewValue(v6Type, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "test-id"),
+		"rule": tftypes.NewValue(attributeType(v6Type, "rule"), []tftypes.Value{}),
+		"settings": tftypes.NewValue(v6SettingsType, map[string]tftypes.Value{
+			"name":     tftypes.NewValue(tftypes.String, "test-name"),
+			"timeouts": tftypes.NewValue(attributeType(v6SettingsType, "timeouts"), nil),
+		}),
+	})
+	v5Value := tftypes.NewValue(v5Type, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "test-id"),
+		"rule": tftypes.NewValue(attributeType(v5Type, "rule"), []tftypes.Value{}),
+		"settings": tftypes.NewValue(attributeType(v5Type, "settings"), []tftypes.Value{
+			tftypes.NewValue(v5SettingsType, map[string]tftypes.Value{
+				"name":     tftypes.NewValue(tftypes.String, "test-name"),
+				"timeouts": tftypes.NewValue(attributeType(v5SettingsType, "timeouts"), []tftypes.Value{}),
+			}),
+		}),
+	})
+
+	currentState, err := tfprotov6.NewDynamicValue(v6Type, v6Value)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	resp, err := v6server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "test_resource",
+		CurrentState: &currentState,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Promoted blocks are sent to the underlying server in their list form,
+	// with null objects as empty lists.
+	gotV5Value, err := v5server.currentState.Unmarshal(v5Type)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(gotV5Value, v5Value); diff != "" {
+		t.Errorf("unexpected underlying server value difference: %s", diff)
+	}
+
+	// Empty lists are returned as null objects, so the value round-trips.
+	gotV6Value, err := resp.NewState.Unmarshal(v6Type)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(gotV6Value, v6Value); diff != "" {
+		t.Errorf("unexpected value difference: %s", diff)
+	}
+}
+
+func TestUpgradeServer_WithBlockPromotion_RawState(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		rawState string
+		expected string
+	}{
+		"list-form": {
+			rawState: `{"id":"test-id","rule":[],"settings":[{"name":"test-name","timeouts":[]}]}`,
+			expected: `{"id":"test-id","rule":[],"settings":[{"name":"test-name","timeouts":[]}]}`,
+		},
+		"object-form": {
+			rawState: `{"id":"test-id","rule":[],"settings":{"name":"test-name","timeouts":null}}`,
+			expected: `{"id":"test-id","rule":[],"settings":[{"name":"test-name","timeouts":[]}]}`,
+		},
+		"null": {
+			rawState: `{"id":"test-id","rule":[],"settings":null}`,
+			expected: `{"id":"test-id","rule":[],"settings":[]}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			v5server := &blockPromotionTestServer{
+				TestServer: tf5testserver.TestServer{
+					GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+						ResourceSchemas: map[string]*tfprotov5.Schema{
+							"test_resource": blockPromotionTestSchema,
+						},
+					},
+				},
+			}
+
+			v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer, tf5to6server.WithBlockPromotion("test_resource", blockPromotionTestPaths...))
+
+			if err != nil {
+				t.Fatalf("unexpected error upgrading server: %s", err)
+			}
+
+			// The GetProviderSchema RPC is not called first, so the schemas
+			// are fetched on first use.
+			_, err = v6server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: "test_resource",
+				RawState: &tfprotov6.RawState{
+					JSON: []byte(testCase.rawState),
+				},
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got, expected any
+
+			if err := json.Unmarshal(v5server.rawState.JSON, &got); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := json.Unmarshal([]byte(testCase.expected), &expected); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(got, expected); diff != "" {
+				t.Errorf("unexpected raw state difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5to6server
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// WithDeferralNegotiation is an UpgradeServer option which negotiates
+// deferrals between Terraform and the underlying server, rather than passing
+// the DeferralAllowed client capabilities and the deferrals of responses
+// through unchanged.
+//
+// Deferrals returned by the underlying server for requests whose client
+// capabilities do not allow them are removed from the responses and
+// replaced with error diagnostics, as Terraform otherwise reports them as
+// protocol violations. If serverSupportsDeferrals is false, such as for
+// servers built with a version of terraform-plugin-sdk which does not
+// implement deferrals, the DeferralAllowed client capabilities are also
+// removed from the requests to the underlying server, so it does not
+// attempt to defer.
+func WithDeferralNegotiation(serverSupportsDeferrals bool) UpgradeServerOption {
+	return func(o *upgradeServerOptions) {
+		o.deferralNegotiation = true
+		o.serverSupportsDeferrals = serverSupportsDeferrals
+	}
+}
+
+// deferralNegotiation removes the DeferralAllowed client capabilities of
+// requests to the underlying server and the deferrals of responses which
+// were not allowed. Its methods do nothing if it is nil, which is when
+// deferrals are not negotiated.
+type deferralNegotiation struct {
+	// serverSupportsDeferrals is false if the DeferralAllowed client
+	// capabilities are removed from requests.
+	serverSupportsDeferrals bool
+}
+
+// negotiateRequest removes the DeferralAllowed client capability of a
+// request to the underlying server, unless it supports deferrals. The client
+// capabilities are modified, as they are translated copies of those of the
+// request of the returned server.
+func (n *deferralNegotiation) negotiateRequest(req any) {
+	if n == nil || n.serverSupportsDeferrals {
+		return
+	}
+
+	switch r := req.(type) {
+	case *tfprotov5.ConfigureProviderRequest:
+		if r.ClientCapabilities != nil {
+			r.ClientCapabilities.DeferralAllowed = false
+		}
+	case *tfprotov5.ImportResourceStateRequest:
+		if r.ClientCapabilities != nil {
+			r.ClientCapabilities.DeferralAllowed = false
+		}
+	case *tfprotov5.OpenEphemeralResourceRequest:
+		if r.ClientCapabilities != nil {
+			r.ClientCapabilities.DeferralAllowed = false
+		}
+	case *tfprotov5.PlanActionRequest:
+		if r.ClientCapabilities != nil {
+			r.ClientCapabilities.DeferralAllowed = false
+		}
+	case *tfprotov5.PlanResourceChangeRequest:
+		if r.ClientCapabilities != nil {
+			r.ClientCapabilities.DeferralAllowed = false
+		}
+	case *tfprotov5.ReadDataSourceRequest:
+		if r.ClientCapabilities != nil {
+			r.ClientCapabilities.DeferralAllowed = false
+		}
+	case *tfprotov5.ReadResourceRequest:
+		if r.ClientCapabilities != nil {
+			r.ClientCapabilities.DeferralAllowed = false
+		}
+	}
+}
+
+// negotiateResponse replaces the deferral of a response with an error
+// diagnostic, unless the client capabilities of the request allowed it.
+func (n *deferralNegotiation) negotiateResponse(req any, resp any) {
+	if n == nil || deferralAllowed(req) {
+		return
+	}
+
+	switch r := resp.(type) {
+	case *tfprotov6.ImportResourceStateResponse:
+		if r != nil && r.Deferred != nil {
+			r.Diagnostics = append(r.Diagnostics, deferralDiagnostic("ImportResourceState", r.Deferred))
+			r.Deferred = nil
+		}
+	case *tfprotov6.OpenEphemeralResourceResponse:
+		if r != nil && r.Deferred != nil {
+			r.Diagnostics = append(r.Diagnostics, deferralDiagnostic("OpenEphemeralResource", r.Deferred))
+			r.Deferred = nil
+		}
+	case *tfprotov6.PlanActionResponse:
+		if r != nil && r.Deferred != nil {
+			r.Diagnostics = append(r.Diagnostics, deferralDiagnostic("PlanAction", r.Deferred))
+			r.Deferred = nil
+		}
+	case *tfprotov6.PlanResourceChangeResponse:
+		if r != nil && r.Deferred != nil {
+			r.Diagnostics = append(r.Diagnostics, deferralDiagnostic("PlanResourceChange", r.Deferred))
+			r.Deferred = nil
+		}
+	case *tfprotov6.ReadDataSourceResponse:
+		if r != nil && r.Deferred != nil {
+			r.Diagnostics = append(r.Diagnostics, deferralDiagnostic("ReadDataSource", r.Deferred))
+			r.Deferred = nil
+		}
+	case *tfprotov6.ReadResourceResponse:
+		if r != nil && r.Deferred != nil {
+			r.Diagnostics = append(r.Diagnostics, deferralDiagnostic("ReadResource", r.Deferred))
+			r.Deferred = nil
+		}
+	}
+}
+
+// deferralAllowed returns true if the client capabilities of the request
+// allow deferrals.
+func deferralAllowed(req any) bool {
+	switch r := req.(type) {
+	case *tfprotov6.ImportResourceStateRequest:
+		return r.ClientCapabilities != nil && r.ClientCapabilities.DeferralAllowed
+	case *tfprotov6.OpenEphemeralResourceRequest:
+		return r.ClientCapabilities != nil && r.ClientCapabilities.DeferralAllowed
+	case *tfprotov6.PlanActionRequest:
+		return r.ClientCapabilities != nil && r.ClientCapabilities.DeferralAllowed
+	case *tfprotov6.PlanResourceChangeRequest:
+		return r.ClientCapabilities != nil && r.ClientCapabilities.DeferralAllowed
+	case *tfprotov6.ReadDataSourceRequest:
+		return r.ClientCapabilities != nil && r.ClientCapabilities.DeferralAllowed
+	case *tfprotov6.ReadResourceRequest:
+		return r.ClientCapabilities != nil && r.ClientCapabilities.DeferralAllowed
+	}
+
+	return true
+}
+
+func deferralDiagnostic(rpc string, deferred *tfprotov6.Deferred) *tfprotov6.Diagnostic {
+	return &tfprotov6.Diagnostic{
+		Severity: tfprotov6.DiagnosticSeverityError,
+		Summary:  "Deferral Not Allowed",
+		Detail: fmt.Sprintf("The provider deferred the %s call with reason %s, however Terraform did not allow deferrals for the call. ", rpc, deferred.Reason) +
+			"This is always an issue with the provider and should be reported to the provider developers.",
+	}
+}
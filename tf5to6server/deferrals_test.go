@@ -0,0 +1,143 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5to6server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+)
+
+// deferralTestServer is a test server which records the client capabilities
+// of PlanResourceChange requests and always defers.
+type deferralTestServer struct {
+	tf5testserver.TestServer
+
+	clientCapabilities *tfprotov5.PlanResourceChangeClientCapabilities
+}
+
+func (s *deferralTestServer) ProviderServer() tfprotov5.ProviderServer {
+	return s
+}
+
+func (s *deferralTestServer) PlanResourceChange(_ context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	s.clientCapabilities = req.ClientCapabilities
+
+	return &tfprotov5.PlanResourceChangeResponse{
+		Deferred: &tfprotov5.Deferred{
+			Reason: tfprotov5.DeferredReasonProviderConfigUnknown,
+		},
+	}, nil
+}
+
+func TestUpgradeServer_WithDeferralNegotiation(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		opts                       []tf5to6server.UpgradeServerOption
+		clientCapabilities         *tfprotov6.PlanResourceChangeClientCapabilities
+		expectedClientCapabilities *tfprotov5.PlanResourceChangeClientCapabilities
+		expectedResponse           *tfprotov6.PlanResourceChangeResponse
+	}{
+		"no-negotiation": {
+			expectedResponse: &tfprotov6.PlanResourceChangeResponse{
+				Deferred: &tfprotov6.Deferred{
+					Reason: tfprotov6.DeferredReasonProviderConfigUnknown,
+				},
+			},
+		},
+		"deferral-allowed": {
+			opts: []tf5to6server.UpgradeServerOption{
+				tf5to6server.WithDeferralNegotiation(true),
+			},
+			clientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{
+				DeferralAllowed: true,
+			},
+			expectedClientCapabilities: &tfprotov5.PlanResourceChangeClientCapabilities{
+				DeferralAllowed: true,
+			},
+			expectedResponse: &tfprotov6.PlanResourceChangeResponse{
+				Deferred: &tfprotov6.Deferred{
+					Reason: tfprotov6.DeferredReasonProviderConfigUnknown,
+				},
+			},
+		},
+		"deferral-not-allowed": {
+			opts: []tf5to6server.UpgradeServerOption{
+				tf5to6server.WithDeferralNegotiation(true),
+			},
+			expectedResponse: &tfprotov6.PlanResourceChangeResponse{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityError,
+						Summary:  "Deferral Not Allowed",
+						Detail: "The provider deferred the PlanResourceChange call with reason PROVIDER_CONFIG_UNKNOWN, however Terraform did not allow deferrals for the call. " +
+							"This is always an issue with the provider and should be reported to the provider developers.",
+					},
+				},
+			},
+		},
+		"server-not-supporting-deferrals": {
+			opts: []tf5to6server.UpgradeServerOption{
+				tf5to6server.WithDeferralNegotiation(false),
+			},
+			clientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{
+				DeferralAllowed: true,
+			},
+			expectedClientCapabilities: &tfprotov5.PlanResourceChangeClientCapabilities{},
+			// The client allowed the deferral, so it is kept.
+			expectedResponse: &tfprotov6.PlanResourceChangeResponse{
+				Deferred: &tfprotov6.Deferred{
+					Reason: tfprotov6.DeferredReasonProviderConfigUnknown,
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			v5server := &deferralTestServer{}
+
+			v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer, testCase.opts...)
+
+			if err != nil {
+				t.Fatalf("unexpected error upgrading server: %s", err)
+			}
+
+			req := &tfprotov6.PlanResourceChangeRequest{
+				ClientCapabilities: testCase.clientCapabilities,
+				TypeName:           "test_resource",
+			}
+			deferralAllowed := req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed
+
+			resp, err := v6server.PlanResourceChange(ctx, req)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(v5server.clientCapabilities, testCase.expectedClientCapabilities); diff != "" {
+				t.Errorf("unexpected client capabilities difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(resp, testCase.expectedResponse); diff != "" {
+				t.Errorf("unexpected response difference: %s", diff)
+			}
+
+			// The client capabilities of the request are not modified.
+			if req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed != deferralAllowed {
+				t.Errorf("unexpected request client capabilities modification")
+			}
+		})
+	}
+}
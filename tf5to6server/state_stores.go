@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5to6server
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// StateStoreServer is an optional interface for protocol version 5 servers
+// which serve state stores. State stores are not implemented in protocol
+// version 5, so the state store RPCs and schemas use the protocol version 6
+// types. UpgradeServer detects servers which implement the interface, adds
+// their state stores to the GetMetadata and GetProviderSchema responses, and
+// calls the server for the state store RPCs rather than returning "not
+// implemented" error diagnostics.
+//
+// The interface must be implemented by the server passed to UpgradeServer
+// itself, as it is not implemented by tf5muxserver servers.
+type StateStoreServer interface {
+	tfprotov6.StateStoreServer
+
+	// StateStoreSchemas returns the schemas of the state stores, by type
+	// name.
+	StateStoreSchemas(context.Context) map[string]*tfprotov6.Schema
+}
+
+// addStateStoreMetadata adds the state stores of the underlying server, if it
+// implements StateStoreServer, to the GetMetadata response.
+func (s v5tov6Server) addStateStoreMetadata(ctx context.Context, resp *tfprotov6.GetMetadataResponse) {
+	stateStoreServer, ok := s.v5Server.(StateStoreServer)
+
+	if !ok || resp == nil {
+		return
+	}
+
+	for _, typeName := range slices.Sorted(maps.Keys(stateStoreServer.StateStoreSchemas(ctx))) {
+		resp.StateStores = append(resp.StateStores, tfprotov6.StateStoreMetadata{
+			TypeName: typeName,
+		})
+	}
+}
+
+// addStateStoreSchemas adds the state store schemas of the underlying server,
+// if it implements StateStoreServer, to the GetProviderSchema response.
+func (s v5tov6Server) addStateStoreSchemas(ctx context.Context, resp *tfprotov6.GetProviderSchemaResponse) {
+	stateStoreServer, ok := s.v5Server.(StateStoreServer)
+
+	if !ok || resp == nil {
+		return
+	}
+
+	resp.StateStoreSchemas = maps.Clone(stateStoreServer.StateStoreSchemas(ctx))
+}
@@ -0,0 +1,246 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5to6server_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf5testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+)
+
+var _ tf5to6server.StateStoreServer = &stateStoreTestServer{}
+
+// stateStoreTestServer is a protocol version 5 server which implements
+// tf5to6server.StateStoreServer, recording the state store RPCs called.
+type stateStoreTestServer struct {
+	tf5testserver.TestServer
+
+	stateStoreCalls []string
+}
+
+func (s *stateStoreTestServer) ProviderServer() tfprotov5.ProviderServer {
+	return s
+}
+
+func (s *stateStoreTestServer) StateStoreSchemas(_ context.Context) map[string]*tfprotov6.Schema {
+	return map[string]*tfprotov6.Schema{
+		"test_state_store": {
+			Block: &tfprotov6.SchemaBlock{
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{
+						Name:     "bucket",
+						Type:     tftypes.String,
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *stateStoreTestServer) ValidateStateStoreConfig(_ context.Context, _ *tfprotov6.ValidateStateStoreConfigRequest) (*tfprotov6.ValidateStateStoreConfigResponse, error) {
+	s.stateStoreCalls = append(s.stateStoreCalls, "ValidateStateStoreConfig")
+
+	return &tfprotov6.ValidateStateStoreConfigResponse{}, nil
+}
+
+func (s *stateStoreTestServer) ConfigureStateStore(_ context.Context, _ *tfprotov6.ConfigureStateStoreRequest) (*tfprotov6.ConfigureStateStoreResponse, error) {
+	s.stateStoreCalls = append(s.stateStoreCalls, "ConfigureStateStore")
+
+	return &tfprotov6.ConfigureStateStoreResponse{}, nil
+}
+
+func (s *stateStoreTestServer) ReadStateBytes(_ context.Context, _ *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+	s.stateStoreCalls = append(s.stateStoreCalls, "ReadStateBytes")
+
+	return &tfprotov6.ReadStateBytesStream{
+		Chunks: slices.Values([]tfprotov6.ReadStateByteChunk{
+			{
+				StateByteChunk: tfprotov6.StateByteChunk{
+					Bytes: []byte("test state"),
+				},
+			},
+		}),
+	}, nil
+}
+
+func (s *stateStoreTestServer) WriteStateBytes(_ context.Context, _ *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
+	s.stateStoreCalls = append(s.stateStoreCalls, "WriteStateBytes")
+
+	return &tfprotov6.WriteStateBytesResponse{}, nil
+}
+
+func (s *stateStoreTestServer) GetStates(_ context.Context, _ *tfprotov6.GetStatesRequest) (*tfprotov6.GetStatesResponse, error) {
+	s.stateStoreCalls = append(s.stateStoreCalls, "GetStates")
+
+	return &tfprotov6.GetStatesResponse{
+		StateIDs: []string{"default"},
+	}, nil
+}
+
+func (s *stateStoreTestServer) DeleteState(_ context.Context, _ *tfprotov6.DeleteStateRequest) (*tfprotov6.DeleteStateResponse, error) {
+	s.stateStoreCalls = append(s.stateStoreCalls, "DeleteState")
+
+	return &tfprotov6.DeleteStateResponse{}, nil
+}
+
+func (s *stateStoreTestServer) LockState(_ context.Context, _ *tfprotov6.LockStateRequest) (*tfprotov6.LockStateResponse, error) {
+	s.stateStoreCalls = append(s.stateStoreCalls, "LockState")
+
+	return &tfprotov6.LockStateResponse{
+		LockID: "test-lock",
+	}, nil
+}
+
+func (s *stateStoreTestServer) UnlockState(_ context.Context, _ *tfprotov6.UnlockStateRequest) (*tfprotov6.UnlockStateResponse, error) {
+	s.stateStoreCalls = append(s.stateStoreCalls, "UnlockState")
+
+	return &tfprotov6.UnlockStateResponse{}, nil
+}
+
+func TestUpgradeServer_StateStoreServer_Schemas(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v5server := &stateStoreTestServer{}
+
+	v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error upgrading server: %s", err)
+	}
+
+	metadataResp, err := v6server.GetMetadata(ctx, &tfprotov6.GetMetadataRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected GetMetadata error: %s", err)
+	}
+
+	expectedStateStores := []tfprotov6.StateStoreMetadata{
+		{
+			TypeName: "test_state_store",
+		},
+	}
+
+	if diff := cmp.Diff(metadataResp.StateStores, expectedStateStores); diff != "" {
+		t.Errorf("unexpected state stores difference: %s", diff)
+	}
+
+	schemaResp, err := v6server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected GetProviderSchema error: %s", err)
+	}
+
+	if diff := cmp.Diff(schemaResp.StateStoreSchemas, v5server.StateStoreSchemas(ctx)); diff != "" {
+		t.Errorf("unexpected state store schemas difference: %s", diff)
+	}
+}
+
+func TestUpgradeServer_StateStoreServer_RPCs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v5server := &stateStoreTestServer{}
+
+	s, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error upgrading server: %s", err)
+	}
+
+	//nolint:staticcheck // Intentionally verifying interface implementation
+	v6server, ok := s.(tfprotov6.ProviderServerWithStateStores)
+
+	if !ok {
+		t.Fatal("expected server to implement tfprotov6.ProviderServerWithStateStores")
+	}
+
+	validateResp, err := v6server.ValidateStateStoreConfig(ctx, &tfprotov6.ValidateStateStoreConfigRequest{TypeName: "test_state_store"})
+
+	if err != nil || len(validateResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected ValidateStateStoreConfig error or diagnostics: %v, %v", err, validateResp)
+	}
+
+	configureResp, err := v6server.ConfigureStateStore(ctx, &tfprotov6.ConfigureStateStoreRequest{TypeName: "test_state_store"})
+
+	if err != nil || len(configureResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected ConfigureStateStore error or diagnostics: %v, %v", err, configureResp)
+	}
+
+	lockResp, err := v6server.LockState(ctx, &tfprotov6.LockStateRequest{TypeName: "test_state_store", StateID: "default"})
+
+	if err != nil {
+		t.Fatalf("unexpected LockState error: %s", err)
+	}
+
+	if lockResp.LockID != "test-lock" {
+		t.Errorf("expected lock ID %q, got %q", "test-lock", lockResp.LockID)
+	}
+
+	readResp, err := v6server.ReadStateBytes(ctx, &tfprotov6.ReadStateBytesRequest{TypeName: "test_state_store", StateID: "default"})
+
+	if err != nil {
+		t.Fatalf("unexpected ReadStateBytes error: %s", err)
+	}
+
+	for chunk := range readResp.Chunks {
+		if string(chunk.Bytes) != "test state" {
+			t.Errorf("expected state bytes %q, got %q", "test state", chunk.Bytes)
+		}
+	}
+
+	_, err = v6server.WriteStateBytes(ctx, &tfprotov6.WriteStateBytesStream{
+		Chunks: func(yield func(*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic) bool) {},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected WriteStateBytes error: %s", err)
+	}
+
+	getStatesResp, err := v6server.GetStates(ctx, &tfprotov6.GetStatesRequest{TypeName: "test_state_store"})
+
+	if err != nil {
+		t.Fatalf("unexpected GetStates error: %s", err)
+	}
+
+	if diff := cmp.Diff(getStatesResp.StateIDs, []string{"default"}); diff != "" {
+		t.Errorf("unexpected state IDs difference: %s", diff)
+	}
+
+	_, err = v6server.UnlockState(ctx, &tfprotov6.UnlockStateRequest{TypeName: "test_state_store", StateID: "default", LockID: "test-lock"})
+
+	if err != nil {
+		t.Fatalf("unexpected UnlockState error: %s", err)
+	}
+
+	_, err = v6server.DeleteState(ctx, &tfprotov6.DeleteStateRequest{TypeName: "test_state_store", StateID: "default"})
+
+	if err != nil {
+		t.Fatalf("unexpected DeleteState error: %s", err)
+	}
+
+	expectedCalls := []string{
+		"ValidateStateStoreConfig",
+		"ConfigureStateStore",
+		"LockState",
+		"ReadStateBytes",
+		"WriteStateBytes",
+		"GetStates",
+		"UnlockState",
+		"DeleteState",
+	}
+
+	if diff := cmp.Diff(v5server.stateStoreCalls, expectedCalls); diff != "" {
+		t.Errorf("unexpected state store calls difference: %s", diff)
+	}
+}
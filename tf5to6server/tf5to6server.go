@@ -7,42 +7,152 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
 	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov5tov6"
 	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6tov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server/translate"
 )
 
 // UpgradeServer wraps a protocol version 5 ProviderServer in a protocol
 // version 6 server. Protocol version 6 is fully forwards compatible with
-// protocol version 5, so no additional validation is performed.
+// protocol version 5, so no additional validation is performed. Deferred
+// reasons which are not implemented in protocol version 6 are reported as a
+// warning diagnostic, once per server.
+//
+// State stores are served if the protocol version 5 server implements the
+// StateStoreServer interface. Blocks can be promoted to nested attributes,
+// which are not implemented in protocol version 5, with the
+// WithBlockPromotion option. Deferrals can be negotiated with the
+// WithDeferralNegotiation option.
+//
+// The translated first GetProviderSchema response without error diagnostics
+// is cached and a copy of it is returned by later GetProviderSchema calls
+// without calling the underlying server.
 //
 // Protocol version 6 servers require Terraform CLI 1.0 or later.
 //
 // Terraform CLI 1.1.5 or later is required for terraform-provider-sdk based
 // protocol version 5 servers to properly upgrade to protocol version 6.
-func UpgradeServer(_ context.Context, v5server func() tfprotov5.ProviderServer) (tfprotov6.ProviderServer, error) {
-	return v5tov6Server{
+func UpgradeServer(_ context.Context, v5server func() tfprotov5.ProviderServer, opts ...UpgradeServerOption) (tfprotov6.ProviderServer, error) {
+	var options upgradeServerOptions
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	server := v5tov6Server{
+		losses:   &lossReporter{},
+		schemas:  &providerSchemaCache{},
 		v5Server: v5server(),
-	}, nil
+	}
+
+	if options.deferralNegotiation {
+		server.deferrals = &deferralNegotiation{
+			serverSupportsDeferrals: options.serverSupportsDeferrals,
+		}
+	}
+
+	if len(options.blockPromotions) > 0 {
+		server.promotion = &blockPromotion{
+			rules:  options.blockPromotions,
+			server: server.v5Server,
+		}
+	}
+
+	return server, nil
+}
+
+// UpgradeServerOption is an option for the UpgradeServer function.
+type UpgradeServerOption func(*upgradeServerOptions)
+
+// upgradeServerOptions contains the configuration of UpgradeServer.
+type upgradeServerOptions struct {
+	// blockPromotions are the paths of the blocks promoted to nested
+	// attributes, by schema kind and type name.
+	blockPromotions map[string]map[string][]*tftypes.AttributePath
+
+	// deferralNegotiation enables negotiating deferrals.
+	deferralNegotiation bool
+
+	// serverSupportsDeferrals keeps the DeferralAllowed client capabilities
+	// of requests when deferrals are negotiated.
+	serverSupportsDeferrals bool
 }
 
 var _ tfprotov6.ProviderServer = v5tov6Server{}
 
 type v5tov6Server struct {
-	v5Server tfprotov5.ProviderServer
+	// deferrals is nil unless deferrals are negotiated.
+	deferrals *deferralNegotiation
+
+	// promotion is nil unless blocks are promoted to nested attributes.
+	losses    *lossReporter
+	promotion *blockPromotion
+	schemas   *providerSchemaCache
+	v5Server  tfprotov5.ProviderServer
+}
+
+// providerSchemaCache contains the translated GetProviderSchema response of
+// the underlying server, once one without error diagnostics is returned.
+type providerSchemaCache struct {
+	mu   sync.Mutex
+	resp *tfprotov6.GetProviderSchemaResponse
+}
+
+// get returns a copy of the cached response with its own Diagnostics, so
+// callers can append diagnostics to it, or nil if no response is cached.
+func (c *providerSchemaCache) get() *tfprotov6.GetProviderSchemaResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return copyProviderSchemaResponse(c.resp)
+}
+
+// set caches a copy of the response, so later changes to it by the caller
+// are not cached.
+func (c *providerSchemaCache) set(resp *tfprotov6.GetProviderSchemaResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resp = copyProviderSchemaResponse(resp)
+}
+
+// copyProviderSchemaResponse returns a shallow copy of the response with its
+// own Diagnostics. The schemas are shared, as they are not changed after
+// translation.
+func copyProviderSchemaResponse(resp *tfprotov6.GetProviderSchemaResponse) *tfprotov6.GetProviderSchemaResponse {
+	if resp == nil {
+		return nil
+	}
+
+	respCopy := *resp
+	respCopy.Diagnostics = slices.Clone(resp.Diagnostics)
+
+	return &respCopy
 }
 
 func (s v5tov6Server) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
 	v5Req := tfprotov6tov5.ApplyResourceChangeRequest(req)
+
+	if err := s.promotion.demoteRequest(ctx, v5Req); err != nil {
+		return nil, err
+	}
+
 	v5Resp, err := s.v5Server.ApplyResourceChange(ctx, v5Req)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.promotion.promoteResponse(ctx, req.TypeName, v5Resp); err != nil {
+		return nil, err
+	}
+
 	return tfprotov5tov6.ApplyResourceChangeResponse(v5Resp), nil
 }
 
@@ -70,6 +180,9 @@ func (s v5tov6Server) CloseEphemeralResource(ctx context.Context, req *tfprotov6
 
 func (s v5tov6Server) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
 	v5Req := tfprotov6tov5.ConfigureProviderRequest(req)
+
+	s.deferrals.negotiateRequest(v5Req)
+
 	v5Resp, err := s.v5Server.ConfigureProvider(ctx, v5Req)
 
 	if err != nil {
@@ -98,10 +211,18 @@ func (s v5tov6Server) GetMetadata(ctx context.Context, req *tfprotov6.GetMetadat
 		return nil, err
 	}
 
-	return tfprotov5tov6.GetMetadataResponse(v5Resp), nil
+	v6Resp := tfprotov5tov6.GetMetadataResponse(v5Resp)
+
+	s.addStateStoreMetadata(ctx, v6Resp)
+
+	return v6Resp, nil
 }
 
 func (s v5tov6Server) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	if v6Resp := s.schemas.get(); v6Resp != nil {
+		return v6Resp, nil
+	}
+
 	v5Req := tfprotov6tov5.GetProviderSchemaRequest(req)
 	v5Resp, err := s.v5Server.GetProviderSchema(ctx, v5Req)
 
@@ -109,7 +230,27 @@ func (s v5tov6Server) GetProviderSchema(ctx context.Context, req *tfprotov6.GetP
 		return nil, err
 	}
 
-	return tfprotov5tov6.GetProviderSchemaResponse(v5Resp), nil
+	v6Resp, err := s.promotion.promoteProviderSchema(tfprotov5tov6.GetProviderSchemaResponse(v5Resp))
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.addStateStoreSchemas(ctx, v6Resp)
+
+	if v6Resp == nil {
+		return nil, nil
+	}
+
+	if !slices.ContainsFunc(v6Resp.Diagnostics, isErrorDiagnostic) {
+		s.schemas.set(v6Resp)
+	}
+
+	return v6Resp, nil
+}
+
+func isErrorDiagnostic(diagnostic *tfprotov6.Diagnostic) bool {
+	return diagnostic != nil && diagnostic.Severity == tfprotov6.DiagnosticSeverityError
 }
 
 func (s v5tov6Server) GetResourceIdentitySchemas(ctx context.Context, req *tfprotov6.GetResourceIdentitySchemasRequest) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
@@ -126,13 +267,28 @@ func (s v5tov6Server) GetResourceIdentitySchemas(ctx context.Context, req *tfpro
 
 func (s v5tov6Server) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
 	v5Req := tfprotov6tov5.ImportResourceStateRequest(req)
+
+	s.deferrals.negotiateRequest(v5Req)
+
 	v5Resp, err := s.v5Server.ImportResourceState(ctx, v5Req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov5tov6.ImportResourceStateResponse(v5Resp), nil
+	if err := s.promotion.promoteResponse(ctx, req.TypeName, v5Resp); err != nil {
+		return nil, err
+	}
+
+	v6Resp := tfprotov5tov6.ImportResourceStateResponse(v5Resp)
+
+	if v6Resp != nil {
+		v6Resp.Diagnostics = append(v6Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v5Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v6Resp)
+
+	return v6Resp, nil
 }
 
 func (s v5tov6Server) MoveResourceState(ctx context.Context, req *tfprotov6.MoveResourceStateRequest) (*tfprotov6.MoveResourceStateResponse, error) {
@@ -143,29 +299,62 @@ func (s v5tov6Server) MoveResourceState(ctx context.Context, req *tfprotov6.Move
 		return nil, err
 	}
 
+	if err := s.promotion.promoteResponse(ctx, req.TargetTypeName, v5Resp); err != nil {
+		return nil, err
+	}
+
 	return tfprotov5tov6.MoveResourceStateResponse(v5Resp), nil
 }
 
 func (s v5tov6Server) OpenEphemeralResource(ctx context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
 	v5Req := tfprotov6tov5.OpenEphemeralResourceRequest(req)
 
+	s.deferrals.negotiateRequest(v5Req)
+
 	v5Resp, err := s.v5Server.OpenEphemeralResource(ctx, v5Req)
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov5tov6.OpenEphemeralResourceResponse(v5Resp), nil
+	v6Resp := tfprotov5tov6.OpenEphemeralResourceResponse(v5Resp)
+
+	if v6Resp != nil {
+		v6Resp.Diagnostics = append(v6Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v5Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v6Resp)
+
+	return v6Resp, nil
 }
 
 func (s v5tov6Server) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
 	v5Req := tfprotov6tov5.PlanResourceChangeRequest(req)
+
+	s.deferrals.negotiateRequest(v5Req)
+
+	if err := s.promotion.demoteRequest(ctx, v5Req); err != nil {
+		return nil, err
+	}
+
 	v5Resp, err := s.v5Server.PlanResourceChange(ctx, v5Req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov5tov6.PlanResourceChangeResponse(v5Resp), nil
+	if err := s.promotion.promoteResponse(ctx, req.TypeName, v5Resp); err != nil {
+		return nil, err
+	}
+
+	v6Resp := tfprotov5tov6.PlanResourceChangeResponse(v5Resp)
+
+	if v6Resp != nil {
+		v6Resp.Diagnostics = append(v6Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v5Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v6Resp)
+
+	return v6Resp, nil
 }
 
 // ProviderServer is a function compatible with tf6server.Serve.
@@ -175,24 +364,62 @@ func (s v5tov6Server) ProviderServer() tfprotov6.ProviderServer {
 
 func (s v5tov6Server) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
 	v5Req := tfprotov6tov5.ReadDataSourceRequest(req)
+
+	s.deferrals.negotiateRequest(v5Req)
+
+	if err := s.promotion.demoteRequest(ctx, v5Req); err != nil {
+		return nil, err
+	}
+
 	v5Resp, err := s.v5Server.ReadDataSource(ctx, v5Req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov5tov6.ReadDataSourceResponse(v5Resp), nil
+	if err := s.promotion.promoteResponse(ctx, req.TypeName, v5Resp); err != nil {
+		return nil, err
+	}
+
+	v6Resp := tfprotov5tov6.ReadDataSourceResponse(v5Resp)
+
+	if v6Resp != nil {
+		v6Resp.Diagnostics = append(v6Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v5Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v6Resp)
+
+	return v6Resp, nil
 }
 
 func (s v5tov6Server) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
 	v5Req := tfprotov6tov5.ReadResourceRequest(req)
+
+	s.deferrals.negotiateRequest(v5Req)
+
+	if err := s.promotion.demoteRequest(ctx, v5Req); err != nil {
+		return nil, err
+	}
+
 	v5Resp, err := s.v5Server.ReadResource(ctx, v5Req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov5tov6.ReadResourceResponse(v5Resp), nil
+	if err := s.promotion.promoteResponse(ctx, req.TypeName, v5Resp); err != nil {
+		return nil, err
+	}
+
+	v6Resp := tfprotov5tov6.ReadResourceResponse(v5Resp)
+
+	if v6Resp != nil {
+		v6Resp.Diagnostics = append(v6Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v5Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v6Resp)
+
+	return v6Resp, nil
 }
 
 func (s v5tov6Server) RenewEphemeralResource(ctx context.Context, req *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
@@ -219,12 +446,21 @@ func (s v5tov6Server) StopProvider(ctx context.Context, req *tfprotov6.StopProvi
 
 func (s v5tov6Server) UpgradeResourceState(ctx context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
 	v5Req := tfprotov6tov5.UpgradeResourceStateRequest(req)
+
+	if err := s.promotion.demoteRequest(ctx, v5Req); err != nil {
+		return nil, err
+	}
+
 	v5Resp, err := s.v5Server.UpgradeResourceState(ctx, v5Req)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.promotion.promoteResponse(ctx, req.TypeName, v5Resp); err != nil {
+		return nil, err
+	}
+
 	return tfprotov5tov6.UpgradeResourceStateResponse(v5Resp), nil
 }
 
@@ -241,6 +477,11 @@ func (s v5tov6Server) UpgradeResourceIdentity(ctx context.Context, req *tfprotov
 
 func (s v5tov6Server) ValidateDataResourceConfig(ctx context.Context, req *tfprotov6.ValidateDataResourceConfigRequest) (*tfprotov6.ValidateDataResourceConfigResponse, error) {
 	v5Req := tfprotov6tov5.ValidateDataSourceConfigRequest(req)
+
+	if err := s.promotion.demoteRequest(ctx, v5Req); err != nil {
+		return nil, err
+	}
+
 	v5Resp, err := s.v5Server.ValidateDataSourceConfig(ctx, v5Req)
 
 	if err != nil {
@@ -274,6 +515,11 @@ func (s v5tov6Server) ValidateProviderConfig(ctx context.Context, req *tfprotov6
 
 func (s v5tov6Server) ValidateResourceConfig(ctx context.Context, req *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
 	v5Req := tfprotov6tov5.ValidateResourceTypeConfigRequest(req)
+
+	if err := s.promotion.demoteRequest(ctx, v5Req); err != nil {
+		return nil, err
+	}
+
 	v5Resp, err := s.v5Server.ValidateResourceTypeConfig(ctx, v5Req)
 
 	if err != nil {
@@ -340,6 +586,10 @@ func (s v5tov6Server) ListResource(ctx context.Context, req *tfprotov6.ListResou
 		return nil, err
 	}
 
+	if v5Resp != nil {
+		v5Resp.Results = s.promotion.promoteListResults(ctx, req.TypeName, v5Resp.Results)
+	}
+
 	return tfprotov5tov6.ListResourceServerStream(v5Resp), nil
 }
 
@@ -392,13 +642,23 @@ func (s v5tov6Server) PlanAction(ctx context.Context, req *tfprotov6.PlanActionR
 
 	v5Req := tfprotov6tov5.PlanActionRequest(req)
 
+	s.deferrals.negotiateRequest(v5Req)
+
 	// v5Resp, err := s.v5Server.PlanAction(ctx, v5Req)
 	v5Resp, err := actionServer.PlanAction(ctx, v5Req)
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov5tov6.PlanActionResponse(v5Resp), nil
+	v6Resp := tfprotov5tov6.PlanActionResponse(v5Resp)
+
+	if v6Resp != nil {
+		v6Resp.Diagnostics = append(v6Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v5Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v6Resp)
+
+	return v6Resp, nil
 }
 
 func (s v5tov6Server) InvokeAction(ctx context.Context, req *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
@@ -437,6 +697,10 @@ func (s v5tov6Server) InvokeAction(ctx context.Context, req *tfprotov6.InvokeAct
 }
 
 func (s v5tov6Server) ValidateStateStoreConfig(ctx context.Context, req *tfprotov6.ValidateStateStoreConfigRequest) (*tfprotov6.ValidateStateStoreConfigResponse, error) {
+	if stateStoreServer, ok := s.v5Server.(StateStoreServer); ok {
+		return stateStoreServer.ValidateStateStoreConfig(ctx, req)
+	}
+
 	return &tfprotov6.ValidateStateStoreConfigResponse{
 		Diagnostics: []*tfprotov6.Diagnostic{
 			{
@@ -444,7 +708,7 @@ func (s v5tov6Server) ValidateStateStoreConfig(ctx context.Context, req *tfproto
 				Summary:  "ValidateStateStoreConfig Not Implemented",
 				Detail: fmt.Sprintf(
 					"A ValidateStateStoreConfig call was received by the provider for state store %q, however the underlying provider server was built with protocol version 5 and does not support state stores. "+
-						"State stores are a protocol version 6 feature, and this call should not have been made. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
+						"State stores are a protocol version 6 feature, and this call should not have been made unless the server implements the tf5to6server.StateStoreServer interface. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
 					req.TypeName),
 			},
 		},
@@ -452,6 +716,10 @@ func (s v5tov6Server) ValidateStateStoreConfig(ctx context.Context, req *tfproto
 }
 
 func (s v5tov6Server) ConfigureStateStore(ctx context.Context, req *tfprotov6.ConfigureStateStoreRequest) (*tfprotov6.ConfigureStateStoreResponse, error) {
+	if stateStoreServer, ok := s.v5Server.(StateStoreServer); ok {
+		return stateStoreServer.ConfigureStateStore(ctx, req)
+	}
+
 	return &tfprotov6.ConfigureStateStoreResponse{
 		Diagnostics: []*tfprotov6.Diagnostic{
 			{
@@ -459,7 +727,7 @@ func (s v5tov6Server) ConfigureStateStore(ctx context.Context, req *tfprotov6.Co
 				Summary:  "ConfigureStateStore Not Implemented",
 				Detail: fmt.Sprintf(
 					"A ConfigureStateStore call was received by the provider for state store %q, however the underlying provider server was built with protocol version 5 and does not support state stores. "+
-						"State stores are a protocol version 6 feature, and this call should not have been made. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
+						"State stores are a protocol version 6 feature, and this call should not have been made unless the server implements the tf5to6server.StateStoreServer interface. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
 					req.TypeName),
 			},
 		},
@@ -467,6 +735,10 @@ func (s v5tov6Server) ConfigureStateStore(ctx context.Context, req *tfprotov6.Co
 }
 
 func (s v5tov6Server) ReadStateBytes(ctx context.Context, req *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+	if stateStoreServer, ok := s.v5Server.(StateStoreServer); ok {
+		return stateStoreServer.ReadStateBytes(ctx, req)
+	}
+
 	return &tfprotov6.ReadStateBytesStream{
 		Chunks: slices.Values([]tfprotov6.ReadStateByteChunk{
 			{
@@ -476,7 +748,7 @@ func (s v5tov6Server) ReadStateBytes(ctx context.Context, req *tfprotov6.ReadSta
 						Summary:  "ReadStateBytes Not Implemented",
 						Detail: fmt.Sprintf(
 							"A ReadStateBytes call was received by the provider for state store %q, however the underlying provider server was built with protocol version 5 and does not support state stores. "+
-								"State stores are a protocol version 6 feature, and this call should not have been made. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
+								"State stores are a protocol version 6 feature, and this call should not have been made unless the server implements the tf5to6server.StateStoreServer interface. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
 							req.TypeName),
 					},
 				},
@@ -486,19 +758,27 @@ func (s v5tov6Server) ReadStateBytes(ctx context.Context, req *tfprotov6.ReadSta
 }
 
 func (s v5tov6Server) WriteStateBytes(ctx context.Context, req *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
+	if stateStoreServer, ok := s.v5Server.(StateStoreServer); ok {
+		return stateStoreServer.WriteStateBytes(ctx, req)
+	}
+
 	return &tfprotov6.WriteStateBytesResponse{
 		Diagnostics: []*tfprotov6.Diagnostic{
 			{
 				Severity: tfprotov6.DiagnosticSeverityError,
 				Summary:  "WriteStateBytes Not Implemented",
 				Detail: "A WriteStateBytes call was received by the provider, however the underlying provider server was built with protocol version 5 and does not support state stores. " +
-					"State stores are a protocol version 6 feature, and this call should not have been made. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
+					"State stores are a protocol version 6 feature, and this call should not have been made unless the server implements the tf5to6server.StateStoreServer interface. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
 			},
 		},
 	}, nil
 }
 
 func (s v5tov6Server) GetStates(ctx context.Context, req *tfprotov6.GetStatesRequest) (*tfprotov6.GetStatesResponse, error) {
+	if stateStoreServer, ok := s.v5Server.(StateStoreServer); ok {
+		return stateStoreServer.GetStates(ctx, req)
+	}
+
 	return &tfprotov6.GetStatesResponse{
 		Diagnostics: []*tfprotov6.Diagnostic{
 			{
@@ -506,7 +786,7 @@ func (s v5tov6Server) GetStates(ctx context.Context, req *tfprotov6.GetStatesReq
 				Summary:  "GetStates Not Implemented",
 				Detail: fmt.Sprintf(
 					"A GetStates call was received by the provider for state store %q, however the underlying provider server was built with protocol version 5 and does not support state stores. "+
-						"State stores are a protocol version 6 feature, and this call should not have been made. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
+						"State stores are a protocol version 6 feature, and this call should not have been made unless the server implements the tf5to6server.StateStoreServer interface. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
 					req.TypeName),
 			},
 		},
@@ -514,6 +794,10 @@ func (s v5tov6Server) GetStates(ctx context.Context, req *tfprotov6.GetStatesReq
 }
 
 func (s v5tov6Server) DeleteState(ctx context.Context, req *tfprotov6.DeleteStateRequest) (*tfprotov6.DeleteStateResponse, error) {
+	if stateStoreServer, ok := s.v5Server.(StateStoreServer); ok {
+		return stateStoreServer.DeleteState(ctx, req)
+	}
+
 	return &tfprotov6.DeleteStateResponse{
 		Diagnostics: []*tfprotov6.Diagnostic{
 			{
@@ -521,7 +805,7 @@ func (s v5tov6Server) DeleteState(ctx context.Context, req *tfprotov6.DeleteStat
 				Summary:  "DeleteState Not Implemented",
 				Detail: fmt.Sprintf(
 					"A DeleteState call was received by the provider for state store %q, however the underlying provider server was built with protocol version 5 and does not support state stores. "+
-						"State stores are a protocol version 6 feature, and this call should not have been made. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
+						"State stores are a protocol version 6 feature, and this call should not have been made unless the server implements the tf5to6server.StateStoreServer interface. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
 					req.TypeName),
 			},
 		},
@@ -529,6 +813,10 @@ func (s v5tov6Server) DeleteState(ctx context.Context, req *tfprotov6.DeleteStat
 }
 
 func (s v5tov6Server) LockState(ctx context.Context, req *tfprotov6.LockStateRequest) (*tfprotov6.LockStateResponse, error) {
+	if stateStoreServer, ok := s.v5Server.(StateStoreServer); ok {
+		return stateStoreServer.LockState(ctx, req)
+	}
+
 	return &tfprotov6.LockStateResponse{
 		Diagnostics: []*tfprotov6.Diagnostic{
 			{
@@ -536,7 +824,7 @@ func (s v5tov6Server) LockState(ctx context.Context, req *tfprotov6.LockStateReq
 				Summary:  "LockState Not Implemented",
 				Detail: fmt.Sprintf(
 					"A LockState call was received by the provider for state store %q, however the underlying provider server was built with protocol version 5 and does not support state stores. "+
-						"State stores are a protocol version 6 feature, and this call should not have been made. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
+						"State stores are a protocol version 6 feature, and this call should not have been made unless the server implements the tf5to6server.StateStoreServer interface. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
 					req.TypeName),
 			},
 		},
@@ -544,6 +832,10 @@ func (s v5tov6Server) LockState(ctx context.Context, req *tfprotov6.LockStateReq
 }
 
 func (s v5tov6Server) UnlockState(ctx context.Context, req *tfprotov6.UnlockStateRequest) (*tfprotov6.UnlockStateResponse, error) {
+	if stateStoreServer, ok := s.v5Server.(StateStoreServer); ok {
+		return stateStoreServer.UnlockState(ctx, req)
+	}
+
 	return &tfprotov6.UnlockStateResponse{
 		Diagnostics: []*tfprotov6.Diagnostic{
 			{
@@ -551,7 +843,7 @@ func (s v5tov6Server) UnlockState(ctx context.Context, req *tfprotov6.UnlockStat
 				Summary:  "UnlockState Not Implemented",
 				Detail: fmt.Sprintf(
 					"An UnlockState call was received by the provider for state store %q, however the underlying provider server was built with protocol version 5 and does not support state stores. "+
-						"State stores are a protocol version 6 feature, and this call should not have been made. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
+						"State stores are a protocol version 6 feature, and this call should not have been made unless the server implements the tf5to6server.StateStoreServer interface. This is a bug in Terraform or terraform-plugin-mux and should be reported to the provider developers.",
 					req.TypeName),
 			},
 		},
@@ -560,11 +852,20 @@ func (s v5tov6Server) UnlockState(ctx context.Context, req *tfprotov6.UnlockStat
 
 func (s v5tov6Server) GenerateResourceConfig(ctx context.Context, req *tfprotov6.GenerateResourceConfigRequest) (*tfprotov6.GenerateResourceConfigResponse, error) {
 	v5Req := tfprotov6tov5.GenerateResourceConfigRequest(req)
+
+	if err := s.promotion.demoteRequest(ctx, v5Req); err != nil {
+		return nil, err
+	}
+
 	v5Resp, err := s.v5Server.GenerateResourceConfig(ctx, v5Req)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.promotion.promoteResponse(ctx, req.TypeName, v5Resp); err != nil {
+		return nil, err
+	}
+
 	return tfprotov5tov6.GenerateResourceConfigResponse(v5Resp), nil
 }
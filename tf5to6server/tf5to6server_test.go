@@ -5,9 +5,11 @@ package tf5to6server_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -137,6 +139,144 @@ func TestUpgradeServer(t *testing.T) {
 	}
 }
 
+// schemaCountingTestServer counts the calls of the GetProviderSchema RPC.
+type schemaCountingTestServer struct {
+	tf5testserver.TestServer
+
+	getProviderSchemaCalls int
+}
+
+func (s *schemaCountingTestServer) ProviderServer() tfprotov5.ProviderServer {
+	return s
+}
+
+func (s *schemaCountingTestServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	s.getProviderSchemaCalls++
+
+	return s.TestServer.GetProviderSchema(ctx, req)
+}
+
+func TestUpgradeServer_GetProviderSchemaCalls(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		opts                           []tf5to6server.UpgradeServerOption
+		getProviderSchemaResponse      *tfprotov5.GetProviderSchemaResponse
+		expectedGetProviderSchemaCalls int
+	}{
+		"default": {
+			getProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov5.Schema{
+					"test_resource": {},
+				},
+			},
+			expectedGetProviderSchemaCalls: 1,
+		},
+		"diagnostics": {
+			getProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+				Diagnostics: []*tfprotov5.Diagnostic{
+					{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "test error summary",
+					},
+				},
+			},
+			// Responses with error diagnostics are not cached.
+			expectedGetProviderSchemaCalls: 2,
+		},
+		"WithBlockPromotion": {
+			opts: []tf5to6server.UpgradeServerOption{
+				tf5to6server.WithBlockPromotion("test_resource", blockPromotionTestPaths...),
+			},
+			getProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov5.Schema{
+					"test_resource": blockPromotionTestSchema,
+				},
+			},
+			expectedGetProviderSchemaCalls: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			v5server := &schemaCountingTestServer{
+				TestServer: tf5testserver.TestServer{
+					GetProviderSchemaResponse: testCase.getProviderSchemaResponse,
+				},
+			}
+
+			v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer, testCase.opts...)
+
+			if err != nil {
+				t.Fatalf("unexpected error upgrading server: %s", err)
+			}
+
+			for range 2 {
+				_, err = v6server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+				if err != nil {
+					t.Fatalf("unexpected error calling GetProviderSchema: %s", err)
+				}
+			}
+
+			if v5server.getProviderSchemaCalls != testCase.expectedGetProviderSchemaCalls {
+				t.Errorf("expected %d GetProviderSchema calls, got: %d", testCase.expectedGetProviderSchemaCalls, v5server.getProviderSchemaCalls)
+			}
+		})
+	}
+}
+
+func TestUpgradeServer_GetProviderSchemaMutation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v5server := &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			Diagnostics: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "test warning summary",
+				},
+			},
+		},
+	}
+
+	v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error upgrading server: %s", err)
+	}
+
+	// Callers such as mux servers append diagnostics to the response, which
+	// must not change the responses of later calls.
+	for range 2 {
+		resp, err := v6server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+		if err != nil {
+			t.Fatalf("unexpected error calling GetProviderSchema: %s", err)
+		}
+
+		expected := []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityWarning,
+				Summary:  "test warning summary",
+			},
+		}
+
+		if diff := cmp.Diff(resp.Diagnostics, expected); diff != "" {
+			t.Errorf("unexpected diagnostics difference: %s", diff)
+		}
+
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov6.Diagnostic{
+			Severity: tfprotov6.DiagnosticSeverityWarning,
+			Summary:  "test caller summary",
+		})
+	}
+}
+
 func TestV5ToV6ServerApplyResourceChange(t *testing.T) {
 	t.Parallel()
 
@@ -319,6 +459,79 @@ func TestV5ToV6ServerGetMetadata(t *testing.T) {
 	}
 }
 
+func TestV5ToV6ServerGetMetadata_NoTranslationLoss(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v5server := &tf5testserver.TestServer{
+		GetMetadataResponse: &tfprotov5.GetMetadataResponse{
+			Actions: []tfprotov5.ActionMetadata{
+				{
+					TypeName: "test_action",
+				},
+			},
+			DataSources: []tfprotov5.DataSourceMetadata{
+				{
+					TypeName: "test_data_source",
+				},
+			},
+			Diagnostics: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "test summary",
+				},
+			},
+			EphemeralResources: []tfprotov5.EphemeralResourceMetadata{
+				{
+					TypeName: "test_ephemeral_resource",
+				},
+			},
+			Functions: []tfprotov5.FunctionMetadata{
+				{
+					Name: "test_function",
+				},
+			},
+			ListResources: []tfprotov5.ListResourceMetadata{
+				{
+					TypeName: "test_list_resource",
+				},
+			},
+			Resources: []tfprotov5.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+			ServerCapabilities: &tfprotov5.ServerCapabilities{
+				GetProviderSchemaOptional: true,
+				MoveResourceState:         true,
+			},
+		},
+	}
+
+	v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error upgrading server: %s", err)
+	}
+
+	resp, err := v6server.GetMetadata(ctx, &tfprotov6.GetMetadataRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []*tfprotov6.Diagnostic{
+		{
+			Severity: tfprotov6.DiagnosticSeverityWarning,
+			Summary:  "test summary",
+		},
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expected); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+}
+
 func TestV5ToV6ServerGetProviderSchema(t *testing.T) {
 	t.Parallel()
 
@@ -559,6 +772,66 @@ func TestV5ToV6ServerReadResource(t *testing.T) {
 	}
 }
 
+func TestV5ToV6ServerReadResource_DeferredReasonLoss(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v5server := &tf5testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov5.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov5.Schema{
+				"test_resource": {},
+			},
+		},
+		ReadResourceFunc: func(_ context.Context, _ *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+			return &tfprotov5.ReadResourceResponse{
+				Deferred: &tfprotov5.Deferred{
+					Reason: tfprotov5.DeferredReason(99),
+				},
+			}, nil
+		},
+	}
+
+	v6server, err := tf5to6server.UpgradeServer(context.Background(), v5server.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error upgrading server: %s", err)
+	}
+
+	resp, err := v6server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []*tfprotov6.Diagnostic{
+		{
+			Severity: tfprotov6.DiagnosticSeverityWarning,
+			Summary:  "Protocol Version Translation Loss",
+			Detail: "The ReadResourceResponse.Deferred.Reason field could not be translated between protocol versions 5 and 6, so it was dropped or changed: deferred reason 99 is not implemented in protocol version 6. " +
+				"The behavior of the provider may differ from that of the underlying provider server.",
+		},
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expected); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+
+	// The loss is only reported once per server.
+	resp, err = v6server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(resp.Diagnostics) > 0 {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
 func TestV5ToV6ServerRenewEphemeralResource(t *testing.T) {
 	t.Parallel()
 
@@ -1288,3 +1561,46 @@ func TestV5ToV6ServerGenerateResourceConfig(t *testing.T) {
 		t.Errorf("expected test_resource GenerateResourceConfig to be called")
 	}
 }
+
+func BenchmarkUpgradeServer_GetProviderSchema(b *testing.B) {
+	ctx := context.Background()
+	resp := &tfprotov5.GetProviderSchemaResponse{
+		ResourceSchemas: make(map[string]*tfprotov5.Schema, 500),
+	}
+
+	for i := range 500 {
+		block := &tfprotov5.SchemaBlock{}
+
+		for j := range 20 {
+			block.Attributes = append(block.Attributes, &tfprotov5.SchemaAttribute{
+				Name:     fmt.Sprintf("attribute_%d", j),
+				Type:     tftypes.String,
+				Optional: true,
+			})
+		}
+
+		resp.ResourceSchemas[fmt.Sprintf("test_resource_%d", i)] = &tfprotov5.Schema{
+			Block: block,
+		}
+	}
+
+	v5server := &tf5testserver.TestServer{
+		GetProviderSchemaResponse: resp,
+	}
+
+	v6server, err := tf5to6server.UpgradeServer(ctx, v5server.ProviderServer)
+
+	if err != nil {
+		b.Fatalf("unexpected error: %s", err)
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, err := v6server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+		if err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package translate translates protocol version 5 requests, responses,
+// schemas, and other types into their protocol version 6 equivalents, as used
+// by the UpgradeServer function of the tf5to6server package. This allows
+// building custom adapters and test tooling across protocol versions.
+//
+// Every protocol version 5 type has a protocol version 6 equivalent, so these
+// translations cannot lose information and do not return errors. Refer to the
+// tf6to5server/translate package for the reverse translations.
+//
+// Translations return new values, but share byte slices and maps, such as
+// those of DynamicValue and RawState, rather than copying them, so the byte
+// slices and maps of neither value should be modified after translation.
+package translate
@@ -6,13 +6,606 @@ package translate
 import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
 	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov5tov6"
 )
 
-func Schema(in *tfprotov5.Schema) *tfprotov6.Schema {
-	return tfprotov5tov6.Schema(in)
+// ActionMetadata translates a protocol version 5 ActionMetadata into a protocol
+// version 6 ActionMetadata.
+func ActionMetadata(in tfprotov5.ActionMetadata) tfprotov6.ActionMetadata {
+	return tfprotov5tov6.ActionMetadata(in)
+}
+
+// ActionSchema translates a protocol version 5 ActionSchema into a protocol
+// version 6 ActionSchema.
+func ActionSchema(in *tfprotov5.ActionSchema) *tfprotov6.ActionSchema {
+	return tfprotov5tov6.ActionSchema(in)
+}
+
+// ApplyResourceChangeRequest translates a protocol version 5
+// ApplyResourceChangeRequest into a protocol version 6
+// ApplyResourceChangeRequest.
+func ApplyResourceChangeRequest(in *tfprotov5.ApplyResourceChangeRequest) *tfprotov6.ApplyResourceChangeRequest {
+	return tfprotov5tov6.ApplyResourceChangeRequest(in)
+}
+
+// ApplyResourceChangeResponse translates a protocol version 5
+// ApplyResourceChangeResponse into a protocol version 6
+// ApplyResourceChangeResponse.
+func ApplyResourceChangeResponse(in *tfprotov5.ApplyResourceChangeResponse) *tfprotov6.ApplyResourceChangeResponse {
+	return tfprotov5tov6.ApplyResourceChangeResponse(in)
+}
+
+// CallFunctionRequest translates a protocol version 5 CallFunctionRequest into
+// a protocol version 6 CallFunctionRequest.
+func CallFunctionRequest(in *tfprotov5.CallFunctionRequest) *tfprotov6.CallFunctionRequest {
+	return tfprotov5tov6.CallFunctionRequest(in)
+}
+
+// CallFunctionResponse translates a protocol version 5 CallFunctionResponse
+// into a protocol version 6 CallFunctionResponse.
+func CallFunctionResponse(in *tfprotov5.CallFunctionResponse) *tfprotov6.CallFunctionResponse {
+	return tfprotov5tov6.CallFunctionResponse(in)
+}
+
+// CloseEphemeralResourceRequest translates a protocol version 5
+// CloseEphemeralResourceRequest into a protocol version 6
+// CloseEphemeralResourceRequest.
+func CloseEphemeralResourceRequest(in *tfprotov5.CloseEphemeralResourceRequest) *tfprotov6.CloseEphemeralResourceRequest {
+	return tfprotov5tov6.CloseEphemeralResourceRequest(in)
+}
+
+// CloseEphemeralResourceResponse translates a protocol version 5
+// CloseEphemeralResourceResponse into a protocol version 6
+// CloseEphemeralResourceResponse.
+func CloseEphemeralResourceResponse(in *tfprotov5.CloseEphemeralResourceResponse) *tfprotov6.CloseEphemeralResourceResponse {
+	return tfprotov5tov6.CloseEphemeralResourceResponse(in)
+}
+
+// ConfigureProviderClientCapabilities translates a protocol version 5
+// ConfigureProviderClientCapabilities into a protocol version 6
+// ConfigureProviderClientCapabilities.
+func ConfigureProviderClientCapabilities(in *tfprotov5.ConfigureProviderClientCapabilities) *tfprotov6.ConfigureProviderClientCapabilities {
+	return tfprotov5tov6.ConfigureProviderClientCapabilities(in)
+}
+
+// ConfigureProviderRequest translates a protocol version 5
+// ConfigureProviderRequest into a protocol version 6 ConfigureProviderRequest.
+func ConfigureProviderRequest(in *tfprotov5.ConfigureProviderRequest) *tfprotov6.ConfigureProviderRequest {
+	return tfprotov5tov6.ConfigureProviderRequest(in)
+}
+
+// ConfigureProviderResponse translates a protocol version 5
+// ConfigureProviderResponse into a protocol version 6
+// ConfigureProviderResponse.
+func ConfigureProviderResponse(in *tfprotov5.ConfigureProviderResponse) *tfprotov6.ConfigureProviderResponse {
+	return tfprotov5tov6.ConfigureProviderResponse(in)
+}
+
+// DataSourceMetadata translates a protocol version 5 DataSourceMetadata into a
+// protocol version 6 DataSourceMetadata.
+func DataSourceMetadata(in tfprotov5.DataSourceMetadata) tfprotov6.DataSourceMetadata {
+	return tfprotov5tov6.DataSourceMetadata(in)
+}
+
+// Deferred translates a protocol version 5 Deferred into a protocol version 6
+// Deferred.
+func Deferred(in *tfprotov5.Deferred) *tfprotov6.Deferred {
+	return tfprotov5tov6.Deferred(in)
+}
+
+// Diagnostics translates protocol version 5 Diagnostic values into protocol
+// version 6 Diagnostic values.
+func Diagnostics(in []*tfprotov5.Diagnostic) []*tfprotov6.Diagnostic {
+	return tfprotov5tov6.Diagnostics(in)
+}
+
+// DynamicValue translates a protocol version 5 DynamicValue into a protocol
+// version 6 DynamicValue.
+func DynamicValue(in *tfprotov5.DynamicValue) *tfprotov6.DynamicValue {
+	return tfprotov5tov6.DynamicValue(in)
+}
+
+// EphemeralResourceMetadata translates a protocol version 5
+// EphemeralResourceMetadata into a protocol version 6
+// EphemeralResourceMetadata.
+func EphemeralResourceMetadata(in tfprotov5.EphemeralResourceMetadata) tfprotov6.EphemeralResourceMetadata {
+	return tfprotov5tov6.EphemeralResourceMetadata(in)
+}
+
+// Function translates a protocol version 5 Function into a protocol version 6
+// Function.
+func Function(in *tfprotov5.Function) *tfprotov6.Function {
+	return tfprotov5tov6.Function(in)
+}
+
+// FunctionError translates a protocol version 5 FunctionError into a protocol
+// version 6 FunctionError.
+func FunctionError(in *tfprotov5.FunctionError) *tfprotov6.FunctionError {
+	return tfprotov5tov6.FunctionError(in)
+}
+
+// FunctionMetadata translates a protocol version 5 FunctionMetadata into a
+// protocol version 6 FunctionMetadata.
+func FunctionMetadata(in tfprotov5.FunctionMetadata) tfprotov6.FunctionMetadata {
+	return tfprotov5tov6.FunctionMetadata(in)
+}
+
+// FunctionParameter translates a protocol version 5 FunctionParameter into a
+// protocol version 6 FunctionParameter.
+func FunctionParameter(in *tfprotov5.FunctionParameter) *tfprotov6.FunctionParameter {
+	return tfprotov5tov6.FunctionParameter(in)
+}
+
+// FunctionReturn translates a protocol version 5 FunctionReturn into a protocol
+// version 6 FunctionReturn.
+func FunctionReturn(in *tfprotov5.FunctionReturn) *tfprotov6.FunctionReturn {
+	return tfprotov5tov6.FunctionReturn(in)
+}
+
+// GenerateResourceConfigRequest translates a protocol version 5
+// GenerateResourceConfigRequest into a protocol version 6
+// GenerateResourceConfigRequest.
+func GenerateResourceConfigRequest(in *tfprotov5.GenerateResourceConfigRequest) *tfprotov6.GenerateResourceConfigRequest {
+	return tfprotov5tov6.GenerateResourceConfigRequest(in)
+}
+
+// GenerateResourceConfigResponse translates a protocol version 5
+// GenerateResourceConfigResponse into a protocol version 6
+// GenerateResourceConfigResponse.
+func GenerateResourceConfigResponse(in *tfprotov5.GenerateResourceConfigResponse) *tfprotov6.GenerateResourceConfigResponse {
+	return tfprotov5tov6.GenerateResourceConfigResponse(in)
+}
+
+// GetFunctionsRequest translates a protocol version 5 GetFunctionsRequest into
+// a protocol version 6 GetFunctionsRequest.
+func GetFunctionsRequest(in *tfprotov5.GetFunctionsRequest) *tfprotov6.GetFunctionsRequest {
+	return tfprotov5tov6.GetFunctionsRequest(in)
+}
+
+// GetFunctionsResponse translates a protocol version 5 GetFunctionsResponse
+// into a protocol version 6 GetFunctionsResponse.
+func GetFunctionsResponse(in *tfprotov5.GetFunctionsResponse) *tfprotov6.GetFunctionsResponse {
+	return tfprotov5tov6.GetFunctionsResponse(in)
+}
+
+// GetMetadataRequest translates a protocol version 5 GetMetadataRequest into a
+// protocol version 6 GetMetadataRequest.
+func GetMetadataRequest(in *tfprotov5.GetMetadataRequest) *tfprotov6.GetMetadataRequest {
+	return tfprotov5tov6.GetMetadataRequest(in)
+}
+
+// GetMetadataResponse translates a protocol version 5 GetMetadataResponse into
+// a protocol version 6 GetMetadataResponse.
+func GetMetadataResponse(in *tfprotov5.GetMetadataResponse) *tfprotov6.GetMetadataResponse {
+	return tfprotov5tov6.GetMetadataResponse(in)
+}
+
+// GetProviderSchemaRequest translates a protocol version 5
+// GetProviderSchemaRequest into a protocol version 6 GetProviderSchemaRequest.
+func GetProviderSchemaRequest(in *tfprotov5.GetProviderSchemaRequest) *tfprotov6.GetProviderSchemaRequest {
+	return tfprotov5tov6.GetProviderSchemaRequest(in)
+}
+
+// GetProviderSchemaResponse translates a protocol version 5
+// GetProviderSchemaResponse into a protocol version 6
+// GetProviderSchemaResponse.
+func GetProviderSchemaResponse(in *tfprotov5.GetProviderSchemaResponse) *tfprotov6.GetProviderSchemaResponse {
+	return tfprotov5tov6.GetProviderSchemaResponse(in)
+}
+
+// GetResourceIdentitySchemasRequest translates a protocol version 5
+// GetResourceIdentitySchemasRequest into a protocol version 6
+// GetResourceIdentitySchemasRequest.
+func GetResourceIdentitySchemasRequest(in *tfprotov5.GetResourceIdentitySchemasRequest) *tfprotov6.GetResourceIdentitySchemasRequest {
+	return tfprotov5tov6.GetResourceIdentitySchemasRequest(in)
+}
+
+// GetResourceIdentitySchemasResponse translates a protocol version 5
+// GetResourceIdentitySchemasResponse into a protocol version 6
+// GetResourceIdentitySchemasResponse.
+func GetResourceIdentitySchemasResponse(in *tfprotov5.GetResourceIdentitySchemasResponse) *tfprotov6.GetResourceIdentitySchemasResponse {
+	return tfprotov5tov6.GetResourceIdentitySchemasResponse(in)
+}
+
+// ImportResourceStateClientCapabilities translates a protocol version 5
+// ImportResourceStateClientCapabilities into a protocol version 6
+// ImportResourceStateClientCapabilities.
+func ImportResourceStateClientCapabilities(in *tfprotov5.ImportResourceStateClientCapabilities) *tfprotov6.ImportResourceStateClientCapabilities {
+	return tfprotov5tov6.ImportResourceStateClientCapabilities(in)
+}
+
+// ImportResourceStateRequest translates a protocol version 5
+// ImportResourceStateRequest into a protocol version 6
+// ImportResourceStateRequest.
+func ImportResourceStateRequest(in *tfprotov5.ImportResourceStateRequest) *tfprotov6.ImportResourceStateRequest {
+	return tfprotov5tov6.ImportResourceStateRequest(in)
+}
+
+// ImportResourceStateResponse translates a protocol version 5
+// ImportResourceStateResponse into a protocol version 6
+// ImportResourceStateResponse.
+func ImportResourceStateResponse(in *tfprotov5.ImportResourceStateResponse) *tfprotov6.ImportResourceStateResponse {
+	return tfprotov5tov6.ImportResourceStateResponse(in)
+}
+
+// ImportedResources translates protocol version 5 ImportedResource values into
+// protocol version 6 ImportedResource values.
+func ImportedResources(in []*tfprotov5.ImportedResource) []*tfprotov6.ImportedResource {
+	return tfprotov5tov6.ImportedResources(in)
+}
+
+// InvokeActionClientCapabilities translates a protocol version 5
+// InvokeActionClientCapabilities into a protocol version 6
+// InvokeActionClientCapabilities.
+func InvokeActionClientCapabilities(in *tfprotov5.InvokeActionClientCapabilities) *tfprotov6.InvokeActionClientCapabilities {
+	return tfprotov5tov6.InvokeActionClientCapabilities(in)
+}
+
+// InvokeActionEvent translates a protocol version 5 InvokeActionEvent into a
+// protocol version 6 InvokeActionEvent.
+func InvokeActionEvent(in tfprotov5.InvokeActionEvent) tfprotov6.InvokeActionEvent {
+	return tfprotov5tov6.InvokeActionEvent(in)
+}
+
+// InvokeActionRequest translates a protocol version 5 InvokeActionRequest into
+// a protocol version 6 InvokeActionRequest.
+func InvokeActionRequest(in *tfprotov5.InvokeActionRequest) *tfprotov6.InvokeActionRequest {
+	return tfprotov5tov6.InvokeActionRequest(in)
+}
+
+// InvokeActionServerStream translates a protocol version 5
+// InvokeActionServerStream into a protocol version 6 InvokeActionServerStream.
+// The stream is translated as it is iterated.
+func InvokeActionServerStream(in *tfprotov5.InvokeActionServerStream) *tfprotov6.InvokeActionServerStream {
+	return tfprotov5tov6.InvokeActionServerStream(in)
+}
+
+// ListResourceMetadata translates a protocol version 5 ListResourceMetadata
+// into a protocol version 6 ListResourceMetadata.
+func ListResourceMetadata(in tfprotov5.ListResourceMetadata) tfprotov6.ListResourceMetadata {
+	return tfprotov5tov6.ListResourceMetadata(in)
+}
+
+// ListResourceRequest translates a protocol version 5 ListResourceRequest into
+// a protocol version 6 ListResourceRequest.
+func ListResourceRequest(in *tfprotov5.ListResourceRequest) *tfprotov6.ListResourceRequest {
+	return tfprotov5tov6.ListResourceRequest(in)
+}
+
+// ListResourceResult translates a protocol version 5 ListResourceResult into a
+// protocol version 6 ListResourceResult.
+func ListResourceResult(in tfprotov5.ListResourceResult) tfprotov6.ListResourceResult {
+	return tfprotov5tov6.ListResourceResult(in)
+}
+
+// ListResourceServerStream translates a protocol version 5
+// ListResourceServerStream into a protocol version 6 ListResourceServerStream.
+// The stream is translated as it is iterated.
+func ListResourceServerStream(in *tfprotov5.ListResourceServerStream) *tfprotov6.ListResourceServerStream {
+	return tfprotov5tov6.ListResourceServerStream(in)
+}
+
+// MoveResourceStateRequest translates a protocol version 5
+// MoveResourceStateRequest into a protocol version 6 MoveResourceStateRequest.
+func MoveResourceStateRequest(in *tfprotov5.MoveResourceStateRequest) *tfprotov6.MoveResourceStateRequest {
+	return tfprotov5tov6.MoveResourceStateRequest(in)
+}
+
+// MoveResourceStateResponse translates a protocol version 5
+// MoveResourceStateResponse into a protocol version 6
+// MoveResourceStateResponse.
+func MoveResourceStateResponse(in *tfprotov5.MoveResourceStateResponse) *tfprotov6.MoveResourceStateResponse {
+	return tfprotov5tov6.MoveResourceStateResponse(in)
+}
+
+// OpenEphemeralResourceClientCapabilities translates a protocol version 5
+// OpenEphemeralResourceClientCapabilities into a protocol version 6
+// OpenEphemeralResourceClientCapabilities.
+func OpenEphemeralResourceClientCapabilities(in *tfprotov5.OpenEphemeralResourceClientCapabilities) *tfprotov6.OpenEphemeralResourceClientCapabilities {
+	return tfprotov5tov6.OpenEphemeralResourceClientCapabilities(in)
 }
 
+// OpenEphemeralResourceRequest translates a protocol version 5
+// OpenEphemeralResourceRequest into a protocol version 6
+// OpenEphemeralResourceRequest.
+func OpenEphemeralResourceRequest(in *tfprotov5.OpenEphemeralResourceRequest) *tfprotov6.OpenEphemeralResourceRequest {
+	return tfprotov5tov6.OpenEphemeralResourceRequest(in)
+}
+
+// OpenEphemeralResourceResponse translates a protocol version 5
+// OpenEphemeralResourceResponse into a protocol version 6
+// OpenEphemeralResourceResponse.
+func OpenEphemeralResourceResponse(in *tfprotov5.OpenEphemeralResourceResponse) *tfprotov6.OpenEphemeralResourceResponse {
+	return tfprotov5tov6.OpenEphemeralResourceResponse(in)
+}
+
+// PlanActionClientCapabilities translates a protocol version 5
+// PlanActionClientCapabilities into a protocol version 6
+// PlanActionClientCapabilities.
+func PlanActionClientCapabilities(in *tfprotov5.PlanActionClientCapabilities) *tfprotov6.PlanActionClientCapabilities {
+	return tfprotov5tov6.PlanActionClientCapabilities(in)
+}
+
+// PlanActionRequest translates a protocol version 5 PlanActionRequest into a
+// protocol version 6 PlanActionRequest.
+func PlanActionRequest(in *tfprotov5.PlanActionRequest) *tfprotov6.PlanActionRequest {
+	return tfprotov5tov6.PlanActionRequest(in)
+}
+
+// PlanActionResponse translates a protocol version 5 PlanActionResponse into a
+// protocol version 6 PlanActionResponse.
+func PlanActionResponse(in *tfprotov5.PlanActionResponse) *tfprotov6.PlanActionResponse {
+	return tfprotov5tov6.PlanActionResponse(in)
+}
+
+// PlanResourceChangeClientCapabilities translates a protocol version 5
+// PlanResourceChangeClientCapabilities into a protocol version 6
+// PlanResourceChangeClientCapabilities.
+func PlanResourceChangeClientCapabilities(in *tfprotov5.PlanResourceChangeClientCapabilities) *tfprotov6.PlanResourceChangeClientCapabilities {
+	return tfprotov5tov6.PlanResourceChangeClientCapabilities(in)
+}
+
+// PlanResourceChangeRequest translates a protocol version 5
+// PlanResourceChangeRequest into a protocol version 6
+// PlanResourceChangeRequest.
+func PlanResourceChangeRequest(in *tfprotov5.PlanResourceChangeRequest) *tfprotov6.PlanResourceChangeRequest {
+	return tfprotov5tov6.PlanResourceChangeRequest(in)
+}
+
+// PlanResourceChangeResponse translates a protocol version 5
+// PlanResourceChangeResponse into a protocol version 6
+// PlanResourceChangeResponse.
+func PlanResourceChangeResponse(in *tfprotov5.PlanResourceChangeResponse) *tfprotov6.PlanResourceChangeResponse {
+	return tfprotov5tov6.PlanResourceChangeResponse(in)
+}
+
+// RawState translates a protocol version 5 RawState into a protocol version 6
+// RawState.
+func RawState(in *tfprotov5.RawState) *tfprotov6.RawState {
+	return tfprotov5tov6.RawState(in)
+}
+
+// ReadDataSourceClientCapabilities translates a protocol version 5
+// ReadDataSourceClientCapabilities into a protocol version 6
+// ReadDataSourceClientCapabilities.
+func ReadDataSourceClientCapabilities(in *tfprotov5.ReadDataSourceClientCapabilities) *tfprotov6.ReadDataSourceClientCapabilities {
+	return tfprotov5tov6.ReadDataSourceClientCapabilities(in)
+}
+
+// ReadDataSourceRequest translates a protocol version 5 ReadDataSourceRequest
+// into a protocol version 6 ReadDataSourceRequest.
+func ReadDataSourceRequest(in *tfprotov5.ReadDataSourceRequest) *tfprotov6.ReadDataSourceRequest {
+	return tfprotov5tov6.ReadDataSourceRequest(in)
+}
+
+// ReadDataSourceResponse translates a protocol version 5 ReadDataSourceResponse
+// into a protocol version 6 ReadDataSourceResponse.
+func ReadDataSourceResponse(in *tfprotov5.ReadDataSourceResponse) *tfprotov6.ReadDataSourceResponse {
+	return tfprotov5tov6.ReadDataSourceResponse(in)
+}
+
+// ReadResourceClientCapabilities translates a protocol version 5
+// ReadResourceClientCapabilities into a protocol version 6
+// ReadResourceClientCapabilities.
+func ReadResourceClientCapabilities(in *tfprotov5.ReadResourceClientCapabilities) *tfprotov6.ReadResourceClientCapabilities {
+	return tfprotov5tov6.ReadResourceClientCapabilities(in)
+}
+
+// ReadResourceRequest translates a protocol version 5 ReadResourceRequest into
+// a protocol version 6 ReadResourceRequest.
+func ReadResourceRequest(in *tfprotov5.ReadResourceRequest) *tfprotov6.ReadResourceRequest {
+	return tfprotov5tov6.ReadResourceRequest(in)
+}
+
+// ReadResourceResponse translates a protocol version 5 ReadResourceResponse
+// into a protocol version 6 ReadResourceResponse.
+func ReadResourceResponse(in *tfprotov5.ReadResourceResponse) *tfprotov6.ReadResourceResponse {
+	return tfprotov5tov6.ReadResourceResponse(in)
+}
+
+// RenewEphemeralResourceRequest translates a protocol version 5
+// RenewEphemeralResourceRequest into a protocol version 6
+// RenewEphemeralResourceRequest.
+func RenewEphemeralResourceRequest(in *tfprotov5.RenewEphemeralResourceRequest) *tfprotov6.RenewEphemeralResourceRequest {
+	return tfprotov5tov6.RenewEphemeralResourceRequest(in)
+}
+
+// RenewEphemeralResourceResponse translates a protocol version 5
+// RenewEphemeralResourceResponse into a protocol version 6
+// RenewEphemeralResourceResponse.
+func RenewEphemeralResourceResponse(in *tfprotov5.RenewEphemeralResourceResponse) *tfprotov6.RenewEphemeralResourceResponse {
+	return tfprotov5tov6.RenewEphemeralResourceResponse(in)
+}
+
+// ResourceIdentityData translates a protocol version 5 ResourceIdentityData
+// into a protocol version 6 ResourceIdentityData.
+func ResourceIdentityData(in *tfprotov5.ResourceIdentityData) *tfprotov6.ResourceIdentityData {
+	return tfprotov5tov6.ResourceIdentityData(in)
+}
+
+// ResourceIdentitySchema translates a protocol version 5 ResourceIdentitySchema
+// into a protocol version 6 ResourceIdentitySchema.
 func ResourceIdentitySchema(in *tfprotov5.ResourceIdentitySchema) *tfprotov6.ResourceIdentitySchema {
 	return tfprotov5tov6.ResourceIdentitySchema(in)
 }
+
+// ResourceIdentitySchemaAttribute translates a protocol version 5
+// ResourceIdentitySchemaAttribute into a protocol version 6
+// ResourceIdentitySchemaAttribute.
+func ResourceIdentitySchemaAttribute(in *tfprotov5.ResourceIdentitySchemaAttribute) *tfprotov6.ResourceIdentitySchemaAttribute {
+	return tfprotov5tov6.ResourceIdentitySchemaAttribute(in)
+}
+
+// ResourceMetadata translates a protocol version 5 ResourceMetadata into a
+// protocol version 6 ResourceMetadata.
+func ResourceMetadata(in tfprotov5.ResourceMetadata) tfprotov6.ResourceMetadata {
+	return tfprotov5tov6.ResourceMetadata(in)
+}
+
+// Schema translates a protocol version 5 Schema into a protocol version 6
+// Schema.
+func Schema(in *tfprotov5.Schema) *tfprotov6.Schema {
+	return tfprotov5tov6.Schema(in)
+}
+
+// SchemaAttribute translates a protocol version 5 SchemaAttribute into a
+// protocol version 6 SchemaAttribute.
+func SchemaAttribute(in *tfprotov5.SchemaAttribute) *tfprotov6.SchemaAttribute {
+	return tfprotov5tov6.SchemaAttribute(in)
+}
+
+// SchemaBlock translates a protocol version 5 SchemaBlock into a protocol
+// version 6 SchemaBlock.
+func SchemaBlock(in *tfprotov5.SchemaBlock) *tfprotov6.SchemaBlock {
+	return tfprotov5tov6.SchemaBlock(in)
+}
+
+// SchemaNestedBlock translates a protocol version 5 SchemaNestedBlock into a
+// protocol version 6 SchemaNestedBlock.
+func SchemaNestedBlock(in *tfprotov5.SchemaNestedBlock) *tfprotov6.SchemaNestedBlock {
+	return tfprotov5tov6.SchemaNestedBlock(in)
+}
+
+// ServerCapabilities translates a protocol version 5 ServerCapabilities into a
+// protocol version 6 ServerCapabilities.
+func ServerCapabilities(in *tfprotov5.ServerCapabilities) *tfprotov6.ServerCapabilities {
+	return tfprotov5tov6.ServerCapabilities(in)
+}
+
+// StopProviderRequest translates a protocol version 5 StopProviderRequest into
+// a protocol version 6 StopProviderRequest.
+func StopProviderRequest(in *tfprotov5.StopProviderRequest) *tfprotov6.StopProviderRequest {
+	return tfprotov5tov6.StopProviderRequest(in)
+}
+
+// StopProviderResponse translates a protocol version 5 StopProviderResponse
+// into a protocol version 6 StopProviderResponse.
+func StopProviderResponse(in *tfprotov5.StopProviderResponse) *tfprotov6.StopProviderResponse {
+	return tfprotov5tov6.StopProviderResponse(in)
+}
+
+// StringKind translates a protocol version 5 StringKind into a protocol version
+// 6 StringKind.
+func StringKind(in tfprotov5.StringKind) tfprotov6.StringKind {
+	return tfprotov5tov6.StringKind(in)
+}
+
+// UpgradeResourceIdentityRequest translates a protocol version 5
+// UpgradeResourceIdentityRequest into a protocol version 6
+// UpgradeResourceIdentityRequest.
+func UpgradeResourceIdentityRequest(in *tfprotov5.UpgradeResourceIdentityRequest) *tfprotov6.UpgradeResourceIdentityRequest {
+	return tfprotov5tov6.UpgradeResourceIdentityRequest(in)
+}
+
+// UpgradeResourceIdentityResponse translates a protocol version 5
+// UpgradeResourceIdentityResponse into a protocol version 6
+// UpgradeResourceIdentityResponse.
+func UpgradeResourceIdentityResponse(in *tfprotov5.UpgradeResourceIdentityResponse) *tfprotov6.UpgradeResourceIdentityResponse {
+	return tfprotov5tov6.UpgradeResourceIdentityResponse(in)
+}
+
+// UpgradeResourceStateRequest translates a protocol version 5
+// UpgradeResourceStateRequest into a protocol version 6
+// UpgradeResourceStateRequest.
+func UpgradeResourceStateRequest(in *tfprotov5.UpgradeResourceStateRequest) *tfprotov6.UpgradeResourceStateRequest {
+	return tfprotov5tov6.UpgradeResourceStateRequest(in)
+}
+
+// UpgradeResourceStateResponse translates a protocol version 5
+// UpgradeResourceStateResponse into a protocol version 6
+// UpgradeResourceStateResponse.
+func UpgradeResourceStateResponse(in *tfprotov5.UpgradeResourceStateResponse) *tfprotov6.UpgradeResourceStateResponse {
+	return tfprotov5tov6.UpgradeResourceStateResponse(in)
+}
+
+// ValidateActionConfigRequest translates a protocol version 5
+// ValidateActionConfigRequest into a protocol version 6
+// ValidateActionConfigRequest.
+func ValidateActionConfigRequest(in *tfprotov5.ValidateActionConfigRequest) *tfprotov6.ValidateActionConfigRequest {
+	return tfprotov5tov6.ValidateActionConfigRequest(in)
+}
+
+// ValidateActionConfigResponse translates a protocol version 5
+// ValidateActionConfigResponse into a protocol version 6
+// ValidateActionConfigResponse.
+func ValidateActionConfigResponse(in *tfprotov5.ValidateActionConfigResponse) *tfprotov6.ValidateActionConfigResponse {
+	return tfprotov5tov6.ValidateActionConfigResponse(in)
+}
+
+// ValidateDataResourceConfigRequest translates a protocol version 5
+// ValidateDataSourceConfigRequest into a protocol version 6
+// ValidateDataResourceConfigRequest.
+func ValidateDataResourceConfigRequest(in *tfprotov5.ValidateDataSourceConfigRequest) *tfprotov6.ValidateDataResourceConfigRequest {
+	return tfprotov5tov6.ValidateDataResourceConfigRequest(in)
+}
+
+// ValidateDataResourceConfigResponse translates a protocol version 5
+// ValidateDataSourceConfigResponse into a protocol version 6
+// ValidateDataResourceConfigResponse.
+func ValidateDataResourceConfigResponse(in *tfprotov5.ValidateDataSourceConfigResponse) *tfprotov6.ValidateDataResourceConfigResponse {
+	return tfprotov5tov6.ValidateDataResourceConfigResponse(in)
+}
+
+// ValidateEphemeralResourceConfigRequest translates a protocol version 5
+// ValidateEphemeralResourceConfigRequest into a protocol version 6
+// ValidateEphemeralResourceConfigRequest.
+func ValidateEphemeralResourceConfigRequest(in *tfprotov5.ValidateEphemeralResourceConfigRequest) *tfprotov6.ValidateEphemeralResourceConfigRequest {
+	return tfprotov5tov6.ValidateEphemeralResourceConfigRequest(in)
+}
+
+// ValidateEphemeralResourceConfigResponse translates a protocol version 5
+// ValidateEphemeralResourceConfigResponse into a protocol version 6
+// ValidateEphemeralResourceConfigResponse.
+func ValidateEphemeralResourceConfigResponse(in *tfprotov5.ValidateEphemeralResourceConfigResponse) *tfprotov6.ValidateEphemeralResourceConfigResponse {
+	return tfprotov5tov6.ValidateEphemeralResourceConfigResponse(in)
+}
+
+// ValidateListResourceConfigRequest translates a protocol version 5
+// ValidateListResourceConfigRequest into a protocol version 6
+// ValidateListResourceConfigRequest.
+func ValidateListResourceConfigRequest(in *tfprotov5.ValidateListResourceConfigRequest) *tfprotov6.ValidateListResourceConfigRequest {
+	return tfprotov5tov6.ValidateListResourceConfigRequest(in)
+}
+
+// ValidateListResourceConfigResponse translates a protocol version 5
+// ValidateListResourceConfigResponse into a protocol version 6
+// ValidateListResourceConfigResponse.
+func ValidateListResourceConfigResponse(in *tfprotov5.ValidateListResourceConfigResponse) *tfprotov6.ValidateListResourceConfigResponse {
+	return tfprotov5tov6.ValidateListResourceConfigResponse(in)
+}
+
+// ValidateProviderConfigRequest translates a protocol version 5
+// PrepareProviderConfigRequest into a protocol version 6
+// ValidateProviderConfigRequest.
+func ValidateProviderConfigRequest(in *tfprotov5.PrepareProviderConfigRequest) *tfprotov6.ValidateProviderConfigRequest {
+	return tfprotov5tov6.ValidateProviderConfigRequest(in)
+}
+
+// ValidateProviderConfigResponse translates a protocol version 5
+// PrepareProviderConfigResponse into a protocol version 6
+// ValidateProviderConfigResponse.
+func ValidateProviderConfigResponse(in *tfprotov5.PrepareProviderConfigResponse) *tfprotov6.ValidateProviderConfigResponse {
+	return tfprotov5tov6.ValidateProviderConfigResponse(in)
+}
+
+// ValidateResourceConfigClientCapabilities translates a protocol version 5
+// ValidateResourceTypeConfigClientCapabilities into a protocol version 6
+// ValidateResourceConfigClientCapabilities.
+func ValidateResourceConfigClientCapabilities(in *tfprotov5.ValidateResourceTypeConfigClientCapabilities) *tfprotov6.ValidateResourceConfigClientCapabilities {
+	return tfprotov5tov6.ValidateResourceConfigClientCapabilities(in)
+}
+
+// ValidateResourceConfigRequest translates a protocol version 5
+// ValidateResourceTypeConfigRequest into a protocol version 6
+// ValidateResourceConfigRequest.
+func ValidateResourceConfigRequest(in *tfprotov5.ValidateResourceTypeConfigRequest) *tfprotov6.ValidateResourceConfigRequest {
+	return tfprotov5tov6.ValidateResourceConfigRequest(in)
+}
+
+// ValidateResourceConfigResponse translates a protocol version 5
+// ValidateResourceTypeConfigResponse into a protocol version 6
+// ValidateResourceConfigResponse.
+func ValidateResourceConfigResponse(in *tfprotov5.ValidateResourceTypeConfigResponse) *tfprotov6.ValidateResourceConfigResponse {
+	return tfprotov5tov6.ValidateResourceConfigResponse(in)
+}
@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package translate_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server/translate"
+)
+
+func TestDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	in := []*tfprotov5.Diagnostic{
+		{
+			Detail:   "test detail",
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "test summary",
+		},
+	}
+	expected := []*tfprotov6.Diagnostic{
+		{
+			Detail:   "test detail",
+			Severity: tfprotov6.DiagnosticSeverityWarning,
+			Summary:  "test summary",
+		},
+	}
+
+	got := translate.Diagnostics(in)
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestListResourceServerStream(t *testing.T) {
+	t.Parallel()
+
+	in := &tfprotov5.ListResourceServerStream{
+		Results: slices.Values([]tfprotov5.ListResourceResult{
+			{
+				DisplayName: "test display name",
+			},
+		}),
+	}
+	expected := []tfprotov6.ListResourceResult{
+		{
+			DisplayName: "test display name",
+		},
+	}
+
+	got := slices.Collect(translate.ListResourceServerStream(in).Results)
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestPlanResourceChangeRequest(t *testing.T) {
+	t.Parallel()
+
+	in := &tfprotov5.PlanResourceChangeRequest{
+		ClientCapabilities: &tfprotov5.PlanResourceChangeClientCapabilities{
+			DeferralAllowed: true,
+		},
+		PriorPrivate: []byte("test"),
+		TypeName:     "test_resource",
+	}
+	expected := &tfprotov6.PlanResourceChangeRequest{
+		ClientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{
+			DeferralAllowed: true,
+		},
+		PriorPrivate: []byte("test"),
+		TypeName:     "test_resource",
+	}
+
+	got := translate.PlanResourceChangeRequest(in)
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
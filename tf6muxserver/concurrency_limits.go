@@ -0,0 +1,210 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+)
+
+// WithServerConcurrencyLimit is a NewMuxServerWithOptions option which limits
+// the number of concurrent calls to an underlying server, by its index in the
+// servers passed to NewMuxServerWithOptions. Calls over the limit wait until
+// an earlier call to the server has returned. This allows throttling an
+// underlying server which is not safe to call concurrently, or which calls
+// an API with strict rate limits, without lowering the parallelism of
+// Terraform for the whole provider.
+//
+// Calls of the streaming InvokeAction, ListResource, and ReadStateBytes RPCs
+// count towards the limit until their stream has ended or their context is
+// canceled. Calls which are canceled while waiting return an error diagnostic
+// without calling the underlying server. The time each call waited is logged
+// at DEBUG level, and is not part of the durations of WithMetrics,
+// WithSlowCallThresholds, and WithTracer.
+//
+// Calls which are subject to both a server limit and a type limit from
+// WithTypeConcurrencyLimit wait for the type limit first.
+func WithServerConcurrencyLimit(serverIndex int, limit int) MuxServerOption {
+	return func(o *muxServerOptions) {
+		if o.serverConcurrencyLimits == nil {
+			o.serverConcurrencyLimits = make(map[int]int)
+		}
+
+		o.serverConcurrencyLimits[serverIndex] = limit
+	}
+}
+
+// WithTypeConcurrencyLimit is a NewMuxServerWithOptions option which limits
+// the number of concurrent calls for a type name, such as a resource type,
+// in the same way as WithServerConcurrencyLimit. The limit applies to each
+// call which is routed by the type name, across all RPCs.
+func WithTypeConcurrencyLimit(typeName string, limit int) MuxServerOption {
+	return func(o *muxServerOptions) {
+		if o.typeConcurrencyLimits == nil {
+			o.typeConcurrencyLimits = make(map[string]int)
+		}
+
+		o.typeConcurrencyLimits[typeName] = limit
+	}
+}
+
+// concurrencyLimiter is the Interceptor added by WithServerConcurrencyLimit
+// and WithTypeConcurrencyLimit. Semaphores are buffered channels, which are
+// nil for servers and types without a limit.
+type concurrencyLimiter struct {
+	// serverIndexes are the index in the servers passed to
+	// NewMuxServerWithOptions, which serverSemaphores are stored by, of each
+	// underlying server of the mux server. They differ when nested mux
+	// servers are flattened.
+	serverIndexes    []int
+	serverSemaphores []chan struct{}
+
+	typeSemaphores map[string]chan struct{}
+}
+
+// newConcurrencyLimiter returns a concurrencyLimiter for the limits, which
+// are validated against the number of servers passed to
+// NewMuxServerWithOptions.
+func newConcurrencyLimiter(serverLimits map[int]int, typeLimits map[string]int, servers int) (*concurrencyLimiter, error) {
+	limiter := &concurrencyLimiter{
+		serverSemaphores: make([]chan struct{}, servers),
+		typeSemaphores:   make(map[string]chan struct{}, len(typeLimits)),
+	}
+
+	for serverIndex, limit := range serverLimits {
+		if serverIndex < 0 || serverIndex >= servers {
+			return nil, fmt.Errorf("invalid concurrency limit for server index %d, which must be less than the number of servers (%d)", serverIndex, servers)
+		}
+
+		if limit < 1 {
+			return nil, fmt.Errorf("invalid concurrency limit for server index %d, which must be at least 1: %d", serverIndex, limit)
+		}
+
+		limiter.serverSemaphores[serverIndex] = make(chan struct{}, limit)
+	}
+
+	for typeName, limit := range typeLimits {
+		if limit < 1 {
+			return nil, fmt.Errorf("invalid concurrency limit for type %q, which must be at least 1: %d", typeName, limit)
+		}
+
+		limiter.typeSemaphores[typeName] = make(chan struct{}, limit)
+	}
+
+	return limiter, nil
+}
+
+// intercept waits for the semaphores of the call before calling handler.
+func (l *concurrencyLimiter) intercept(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+	var semaphores []chan struct{}
+
+	if semaphore := l.typeSemaphores[info.TypeName]; info.TypeName != "" && semaphore != nil {
+		semaphores = append(semaphores, semaphore)
+	}
+
+	if semaphore := l.serverSemaphore(info.serverIndex); semaphore != nil {
+		semaphores = append(semaphores, semaphore)
+	}
+
+	if len(semaphores) == 0 {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+	acquired := 0
+
+	release := func() {
+		for _, semaphore := range semaphores[:acquired] {
+			<-semaphore
+		}
+	}
+
+	for _, semaphore := range semaphores {
+		select {
+		case semaphore <- struct{}{}:
+			acquired++
+		case <-ctx.Done():
+			release()
+
+			logging.MuxDebug(ctx, "canceled waiting for downstream server concurrency limit", map[string]interface{}{
+				logging.KeyTfMuxWaitMs: time.Since(start).Milliseconds(),
+			})
+
+			return errorResponse(info, req, canceledCallDiagnostic(info))
+		}
+	}
+
+	logging.MuxDebug(ctx, "waited for downstream server concurrency limit", map[string]interface{}{
+		logging.KeyTfMuxWaitMs: time.Since(start).Milliseconds(),
+	})
+
+	// The semaphores are released once the call has returned, or once the
+	// stream of a streaming RPC has ended or its context is canceled, as the
+	// stream may never be read. Streams may also be read more than once, so
+	// their semaphores are only released once.
+	streaming := false
+
+	defer func() {
+		if !streaming {
+			release()
+		}
+	}()
+
+	resp, err := handler(ctx, req)
+
+	var releaseOnce sync.Once
+
+	releaseStream := func() {
+		releaseOnce.Do(release)
+	}
+
+	// stopCancel is set before the stream is returned, so before done can
+	// be called.
+	stopCancel := func() bool { return false }
+
+	done := func() {
+		stopCancel()
+		releaseStream()
+	}
+
+	switch typedResp := resp.(type) {
+	case *tfprotov6.InvokeActionServerStream:
+		if typedResp != nil && typedResp.Events != nil {
+			streaming = true
+			typedResp.Events = observeStream(typedResp.Events, func(tfprotov6.InvokeActionEvent) {}, done)
+		}
+	case *tfprotov6.ListResourceServerStream:
+		if typedResp != nil && typedResp.Results != nil {
+			streaming = true
+			typedResp.Results = observeStream(typedResp.Results, func(tfprotov6.ListResourceResult) {}, done)
+		}
+	case *tfprotov6.ReadStateBytesStream:
+		if typedResp != nil && typedResp.Chunks != nil {
+			streaming = true
+			typedResp.Chunks = observeStream(typedResp.Chunks, func(tfprotov6.ReadStateByteChunk) {}, done)
+		}
+	}
+
+	if streaming {
+		stopCancel = context.AfterFunc(ctx, releaseStream)
+	}
+
+	return resp, err
+}
+
+// serverSemaphore returns the semaphore of the underlying server, or nil if
+// it has no limit.
+func (l *concurrencyLimiter) serverSemaphore(serverIndex int) chan struct{} {
+	if serverIndex < 0 || serverIndex >= len(l.serverIndexes) {
+		return nil
+	}
+
+	return l.serverSemaphores[l.serverIndexes[serverIndex]]
+}
@@ -0,0 +1,541 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklogtest"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+)
+
+// concurrencyTestCalls records the maximum number of concurrent ReadResource
+// calls of the test server from newConcurrencyTestServer. Calls wait for
+// unblock if it is set.
+type concurrencyTestCalls struct {
+	mu        sync.Mutex
+	active    int
+	maxActive int
+	calls     int
+
+	started chan struct{}
+	unblock chan struct{}
+}
+
+// newConcurrencyTestServer returns a test server with the resource and list
+// resource types, and the calls of its ReadResource hook.
+func newConcurrencyTestServer(typeNames ...string) (*tf6testserver.TestServer, *concurrencyTestCalls) {
+	resourceSchemas := make(map[string]*tfprotov6.Schema, len(typeNames))
+
+	for _, typeName := range typeNames {
+		resourceSchemas[typeName] = &tfprotov6.Schema{}
+	}
+
+	calls := &concurrencyTestCalls{
+		started: make(chan struct{}, 1),
+	}
+
+	testServer := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			ListResourceSchemas: resourceSchemas,
+			ResourceSchemas:     resourceSchemas,
+		},
+		ListResourceFunc: func(_ context.Context, _ *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
+			return &tfprotov6.ListResourceServerStream{
+				Results: func(yield func(tfprotov6.ListResourceResult) bool) {
+					yield(tfprotov6.ListResourceResult{DisplayName: "test"})
+				},
+			}, nil
+		},
+		ReadResourceFunc: calls.readResource,
+	}
+
+	return testServer, calls
+}
+
+func (c *concurrencyTestCalls) readResource(_ context.Context, _ *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	c.mu.Lock()
+	c.active++
+	c.calls++
+	c.maxActive = max(c.maxActive, c.active)
+	c.mu.Unlock()
+
+	select {
+	case c.started <- struct{}{}:
+	default:
+	}
+
+	if c.unblock != nil {
+		<-c.unblock
+	}
+
+	time.Sleep(time.Millisecond)
+
+	c.mu.Lock()
+	c.active--
+	c.mu.Unlock()
+
+	return &tfprotov6.ReadResourceResponse{}, nil
+}
+
+// uncomparableTestServer is a test server whose dynamic type cannot be
+// compared, as it is a struct containing a slice.
+type uncomparableTestServer struct {
+	*tf6testserver.TestServer
+
+	typeNames []string
+}
+
+func TestWithServerConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		limit             int
+		expectedMaxActive int
+	}{
+		"limit-1": {
+			limit:             1,
+			expectedMaxActive: 1,
+		},
+		"limit-2": {
+			limit:             2,
+			expectedMaxActive: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			limitedServer, limitedCalls := newConcurrencyTestServer("test_resource1")
+			otherServer, _ := newConcurrencyTestServer("test_resource2")
+
+			muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+				ctx,
+				[]func() tfprotov6.ProviderServer{limitedServer.ProviderServer, otherServer.ProviderServer},
+				tf6muxserver.WithServerConcurrencyLimit(0, testCase.limit),
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error setting up muxer: %s", err)
+			}
+
+			// The first call discovers the underlying servers, so the calls
+			// below only wait for the limit.
+			_, err = muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var wg sync.WaitGroup
+
+			for range 5 {
+				for _, typeName := range []string{"test_resource1", "test_resource2"} {
+					wg.Go(func() {
+						resp, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+							TypeName: typeName,
+						})
+
+						if err != nil {
+							t.Errorf("unexpected error: %s", err)
+						}
+
+						if len(resp.Diagnostics) > 0 {
+							t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+						}
+					})
+				}
+			}
+
+			wg.Wait()
+
+			if limitedCalls.calls != 5 {
+				t.Errorf("expected 5 calls to the limited server, got %d", limitedCalls.calls)
+			}
+
+			if limitedCalls.maxActive > testCase.expectedMaxActive {
+				t.Errorf("expected at most %d concurrent calls to the limited server, got %d", testCase.expectedMaxActive, limitedCalls.maxActive)
+			}
+		})
+	}
+}
+
+func TestWithServerConcurrencyLimit_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		opts          []tf6muxserver.MuxServerOption
+		expectedError string
+	}{
+		"server-index-out-of-range": {
+			opts: []tf6muxserver.MuxServerOption{
+				tf6muxserver.WithServerConcurrencyLimit(1, 1),
+			},
+			expectedError: "invalid concurrency limit for server index 1, which must be less than the number of servers (1)",
+		},
+		"server-limit-zero": {
+			opts: []tf6muxserver.MuxServerOption{
+				tf6muxserver.WithServerConcurrencyLimit(0, 0),
+			},
+			expectedError: "invalid concurrency limit for server index 0, which must be at least 1: 0",
+		},
+		"type-limit-zero": {
+			opts: []tf6muxserver.MuxServerOption{
+				tf6muxserver.WithTypeConcurrencyLimit("test_resource", 0),
+			},
+			expectedError: `invalid concurrency limit for type "test_resource", which must be at least 1: 0`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testServer, _ := newConcurrencyTestServer("test_resource")
+
+			_, err := tf6muxserver.NewMuxServerWithOptions(context.Background(), []func() tfprotov6.ProviderServer{testServer.ProviderServer}, testCase.opts...)
+
+			if err == nil {
+				t.Fatalf("expected error, got none")
+			}
+
+			if diff := cmp.Diff(err.Error(), testCase.expectedError); diff != "" {
+				t.Errorf("unexpected error difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithTypeConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer, testCalls := newConcurrencyTestServer("test_resource1", "test_resource2")
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithTypeConcurrencyLimit("test_resource1", 1),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	testCalls.unblock = make(chan struct{})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		_, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName: "test_resource1",
+		})
+
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}()
+
+	<-testCalls.started
+
+	// Calls for other types are not limited.
+	otherDone := make(chan struct{})
+
+	go func() {
+		defer close(otherDone)
+
+		_, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName: "test_resource2",
+		})
+
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}()
+
+	<-testCalls.started
+
+	// Calls for the limited type wait until they are canceled.
+	canceledCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	resp, err := muxServer.ProviderServer().ReadResource(canceledCtx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource1",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	close(testCalls.unblock)
+	<-done
+	<-otherDone
+
+	if testCalls.calls != 2 {
+		t.Errorf("expected the canceled call to not call the server, got %d calls", testCalls.calls)
+	}
+
+	// The details of diagnostics contain the underlying server, so only their
+	// prefix is compared.
+	for _, diagnostic := range resp.Diagnostics {
+		if !strings.HasPrefix(diagnostic.Detail, `The request was canceled while waiting for the concurrency limits of the underlying provider server for the ReadResource RPC for "test_resource1"`) {
+			t.Errorf("unexpected diagnostic detail: %s", diagnostic.Detail)
+		}
+
+		diagnostic.Detail = ""
+	}
+
+	expectedDiagnostics := []*tfprotov6.Diagnostic{
+		{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  "Provider Server Call Canceled",
+		},
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+}
+
+func TestWithServerConcurrencyLimit_UncomparableServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	concurrencyTestServer, testCalls := newConcurrencyTestServer("test_resource")
+	testServer := uncomparableTestServer{
+		TestServer: concurrencyTestServer,
+		typeNames:  []string{"test_resource"},
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{func() tfprotov6.ProviderServer { return testServer }},
+		tf6muxserver.WithServerConcurrencyLimit(0, 1),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(resp.Diagnostics) > 0 {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if testCalls.calls != 1 {
+		t.Errorf("expected 1 call, got %d", testCalls.calls)
+	}
+}
+
+func TestWithServerConcurrencyLimit_Streams(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		// read is called with the stream of the first call, and its context
+		// cancel function.
+		read func(*tfprotov6.ListResourceServerStream, context.CancelFunc)
+	}{
+		"canceled-unread": {
+			read: func(_ *tfprotov6.ListResourceServerStream, cancel context.CancelFunc) {
+				cancel()
+			},
+		},
+		"read": {
+			read: func(stream *tfprotov6.ListResourceServerStream, _ context.CancelFunc) {
+				for range stream.Results {
+				}
+			},
+		},
+		"read-twice": {
+			read: func(stream *tfprotov6.ListResourceServerStream, _ context.CancelFunc) {
+				for range stream.Results {
+				}
+
+				for range stream.Results {
+				}
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testServer, _ := newConcurrencyTestServer("test_resource")
+
+			muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+				context.Background(),
+				[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+				tf6muxserver.WithServerConcurrencyLimit(0, 1),
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error setting up muxer: %s", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stream, err := muxServer.ListResource(ctx, &tfprotov6.ListResourceRequest{
+				TypeName: "test_resource",
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			testCase.read(stream, cancel)
+
+			// The limit is released by the first call, so the next calls do
+			// not wait until they are canceled. Each is read, so releases
+			// the limit again.
+			for range 2 {
+				nextCtx, nextCancel := context.WithTimeout(context.Background(), time.Second)
+				defer nextCancel()
+
+				nextStream, err := muxServer.ListResource(nextCtx, &tfprotov6.ListResourceRequest{
+					TypeName: "test_resource",
+				})
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				var results []tfprotov6.ListResourceResult
+
+				for result := range nextStream.Results {
+					results = append(results, result)
+				}
+
+				expectedResults := []tfprotov6.ListResourceResult{
+					{DisplayName: "test"},
+				}
+
+				if diff := cmp.Diff(results, expectedResults); diff != "" {
+					t.Fatalf("unexpected results difference: %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestWithServerConcurrencyLimit_SlowCallThresholds(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+
+	ctx := tfsdklogtest.RootLogger(context.Background(), &output)
+	testServer, _ := newConcurrencyTestServer("test_resource")
+
+	// ApplyResourceChange calls hold the limit until unblock is closed.
+	applyStarted := make(chan struct{})
+	unblock := make(chan struct{})
+
+	testServer.ApplyResourceChangeFunc = func(_ context.Context, _ *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+		close(applyStarted)
+		<-unblock
+
+		return &tfprotov6.ApplyResourceChangeResponse{}, nil
+	}
+
+	threshold := 10 * time.Millisecond
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithServerConcurrencyLimit(0, 1),
+		tf6muxserver.WithSlowCallThresholds(map[string]time.Duration{
+			"ReadResource": threshold,
+		}),
+		tf6muxserver.WithSlowCallDiagnostics(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	applyDone := make(chan struct{})
+
+	go func() {
+		defer close(applyDone)
+
+		_, err := muxServer.ProviderServer().ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: "test_resource",
+		})
+
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}()
+
+	<-applyStarted
+
+	var resp *tfprotov6.ReadResourceResponse
+	var elapsed time.Duration
+
+	readDone := make(chan struct{})
+
+	go func() {
+		defer close(readDone)
+
+		start := time.Now()
+
+		resp, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName: "test_resource",
+		})
+
+		elapsed = time.Since(start)
+	}()
+
+	// The ReadResource call waits for the limit for longer than the
+	// threshold, which is not part of its duration.
+	time.Sleep(10 * threshold)
+	close(unblock)
+	<-applyDone
+	<-readDone
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if elapsed < threshold {
+		t.Fatalf("expected ReadResource call to wait longer than %s, waited %s", threshold, elapsed)
+	}
+
+	if len(resp.Diagnostics) > 0 {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	entries, err := tfsdklogtest.MultilineJSONDecode(&output)
+
+	if err != nil {
+		t.Fatalf("unable to read log entries: %s", err)
+	}
+
+	for _, entry := range entries {
+		if entry["@message"] == "slow downstream server call" {
+			t.Errorf("unexpected slow call log entry: %v", entry)
+		}
+	}
+}
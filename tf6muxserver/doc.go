@@ -11,5 +11,22 @@
 //   - https://pkg.go.dev/github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server
 //   - https://pkg.go.dev/github.com/hashicorp/terraform-plugin-mux/tf5to6server
 //
-// Refer to the NewMuxServer() function for creating a combined server.
+// Protocol version 5 provider servers can also be combined by wrapping them
+// with the FromProtocol5() function. Combined servers can also be nested
+// within another combined server.
+//
+// Refer to the NewMuxServer() function for creating a combined server, or the
+// NewMuxServerWithOptions() function for creating a combined server with
+// options, such as interceptors which are called for each call to an
+// underlying server, metrics, tracing, and recordings of those calls,
+// validation of their responses against the schemas and of the consistency
+// of planned and applied resource states, warnings for slow calls, limits on
+// concurrent calls, or recovery of underlying server panics. Recordings can
+// be replayed with the NewReplayServers() function.
+//
+// Combined servers log at the level of the TF_LOG_SDK_MUX environment
+// variable. If the TF_LOG_SDK_MUX_PAYLOADS environment variable is also set to
+// a true value, such as 1, the request and response payloads of each call to
+// an underlying server are logged at TRACE level, with the values of sensitive
+// and write-only attributes and private state redacted.
 package tf6muxserver
@@ -0,0 +1,27 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+)
+
+// FromProtocol5 returns a protocol version 6 server function for the given
+// protocol version 5 server function, so it can be passed to NewMuxServer
+// alongside protocol version 6 servers. The server is upgraded with
+// tf5to6server.UpgradeServer.
+func FromProtocol5(v5server func() tfprotov5.ProviderServer) func() tfprotov6.ProviderServer {
+	return func() tfprotov6.ProviderServer {
+		// UpgradeServer never returns an error, as protocol version 6 is
+		// fully forwards compatible with protocol version 5.
+		server, _ := tf5to6server.UpgradeServer(context.Background(), v5server)
+
+		return server
+	}
+}
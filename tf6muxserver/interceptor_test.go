@@ -0,0 +1,408 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+)
+
+func TestWithInterceptors_Order(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+		},
+	}
+
+	var calls []string
+
+	recordingInterceptor := func(name string) tf6muxserver.Interceptor {
+		return func(ctx context.Context, info *tf6muxserver.CallInfo, req any, handler tf6muxserver.Handler) (any, error) {
+			calls = append(calls, name+" before "+info.RPC+" "+info.TypeName)
+
+			resp, err := handler(ctx, req)
+
+			calls = append(calls, name+" after "+info.RPC+" "+info.TypeName)
+
+			return resp, err
+		}
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithInterceptors(recordingInterceptor("first"), recordingInterceptor("second")),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedCalls := []string{
+		"first before GetMetadata ",
+		"second before GetMetadata ",
+		"second after GetMetadata ",
+		"first after GetMetadata ",
+		"first before ReadResource test_resource",
+		"second before ReadResource test_resource",
+		"second after ReadResource test_resource",
+		"first after ReadResource test_resource",
+	}
+
+	if diff := cmp.Diff(calls, expectedCalls); diff != "" {
+		t.Errorf("unexpected calls difference: %s", diff)
+	}
+
+	if !testServer.ReadResourceCalled["test_resource"] {
+		t.Errorf("expected test_resource ReadResource to be called")
+	}
+}
+
+func TestWithInterceptors_FanOut(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf6testserver.TestServer{}
+	testServer2 := &tf6testserver.TestServer{}
+
+	var servers []tfprotov6.ProviderServer
+
+	interceptor := tf6muxserver.TypedInterceptor(func(ctx context.Context, info *tf6muxserver.CallInfo, req *tfprotov6.ConfigureProviderRequest, handler func(context.Context, *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error)) (*tfprotov6.ConfigureProviderResponse, error) {
+		servers = append(servers, info.Server)
+
+		return handler(ctx, req)
+	})
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer},
+		tf6muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(servers) != 2 || servers[0] != testServer1 || servers[1] != testServer2 {
+		t.Errorf("expected interceptor to be called for each server, got: %v", servers)
+	}
+
+	if !testServer1.ConfigureProviderCalled || !testServer2.ConfigureProviderCalled {
+		t.Errorf("expected ConfigureProvider to be called on each server")
+	}
+}
+
+func TestWithInterceptors_ModifyRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+		},
+	}
+
+	interceptor := tf6muxserver.TypedInterceptor(func(ctx context.Context, _ *tf6muxserver.CallInfo, req *tfprotov6.ReadResourceRequest, handler func(context.Context, *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error)) (*tfprotov6.ReadResourceResponse, error) {
+		modifiedReq := *req
+		modifiedReq.TypeName = "test_resource_modified"
+
+		return handler(ctx, &modifiedReq)
+	})
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !testServer.ReadResourceCalled["test_resource_modified"] {
+		t.Errorf("expected modified request to be sent to the server")
+	}
+}
+
+func TestWithInterceptors_ShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+		},
+	}
+
+	expectedDiagnostics := []*tfprotov6.Diagnostic{
+		{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  "Denied",
+		},
+	}
+
+	interceptor := tf6muxserver.TypedInterceptor(func(_ context.Context, _ *tf6muxserver.CallInfo, _ *tfprotov6.ApplyResourceChangeRequest, _ func(context.Context, *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error)) (*tfprotov6.ApplyResourceChangeResponse, error) {
+		return &tfprotov6.ApplyResourceChangeResponse{
+			Diagnostics: expectedDiagnostics,
+		}, nil
+	})
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+
+	if testServer.ApplyResourceChangeCalled["test_resource"] {
+		t.Errorf("expected server ApplyResourceChange to not be called")
+	}
+
+	// Other RPCs are passed through unchanged.
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !testServer.ReadResourceCalled["test_resource"] {
+		t.Errorf("expected server ReadResource to be called")
+	}
+}
+
+func TestWithInterceptors_Stream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			ListResources: []tfprotov6.ListResourceMetadata{
+				{
+					TypeName: "test_list_resource",
+				},
+			},
+		},
+	}
+
+	interceptor := tf6muxserver.TypedInterceptor(func(ctx context.Context, _ *tf6muxserver.CallInfo, req *tfprotov6.ListResourceRequest, handler func(context.Context, *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error)) (*tfprotov6.ListResourceServerStream, error) {
+		resp, err := handler(ctx, req)
+
+		if err != nil {
+			return resp, err
+		}
+
+		// The test server returns no stream, so one is added with a result
+		// for each call.
+		if resp == nil {
+			resp = &tfprotov6.ListResourceServerStream{
+				Results: slices.Values([]tfprotov6.ListResourceResult{
+					{
+						DisplayName: "one",
+					},
+					{
+						DisplayName: "two",
+					},
+				}),
+			}
+		}
+
+		results := resp.Results
+
+		resp.Results = func(yield func(tfprotov6.ListResourceResult) bool) {
+			for result := range results {
+				result.DisplayName = "intercepted " + result.DisplayName
+
+				if !yield(result) {
+					return
+				}
+			}
+		}
+
+		return resp, nil
+	})
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov6.ProviderServerWithListResource).ListResource(ctx, &tfprotov6.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_list_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var displayNames []string
+
+	for result := range resp.Results {
+		displayNames = append(displayNames, result.DisplayName)
+	}
+
+	if diff := cmp.Diff(displayNames, []string{"intercepted one", "intercepted two"}); diff != "" {
+		t.Errorf("unexpected display names difference: %s", diff)
+	}
+
+	if !testServer.ListResourceCalled["test_list_resource"] {
+		t.Errorf("expected server ListResource to be called")
+	}
+}
+
+func TestWithInterceptors_UnexpectedResponseType(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf6testserver.TestServer{}
+
+	interceptor := func(_ context.Context, _ *tf6muxserver.CallInfo, _ any, _ tf6muxserver.Handler) (any, error) {
+		return &tfprotov6.ReadResourceResponse{}, nil
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().StopProvider(ctx, &tfprotov6.StopProviderRequest{})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestWithInterceptors_RequestStream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			StateStores: []tfprotov6.StateStoreMetadata{
+				{
+					TypeName: "test_statestore",
+				},
+			},
+		},
+	}
+
+	var chunks int
+	var typeName string
+
+	interceptor := tf6muxserver.TypedInterceptor(func(ctx context.Context, info *tf6muxserver.CallInfo, req *tfprotov6.WriteStateBytesStream, handler func(context.Context, *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error)) (*tfprotov6.WriteStateBytesResponse, error) {
+		typeName = info.TypeName
+		requestChunks := req.Chunks
+
+		return handler(ctx, &tfprotov6.WriteStateBytesStream{
+			Chunks: func(yield func(*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic) bool) {
+				for chunk, diags := range requestChunks {
+					chunks++
+
+					if !yield(chunk, diags) {
+						return
+					}
+				}
+			},
+		})
+	})
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().(tfprotov6.ProviderServerWithStateStores).WriteStateBytes(ctx, writeStateBytesStream("test_statestore")) //nolint:staticcheck
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if typeName != "test_statestore" {
+		t.Errorf("expected type name %q, got %q", "test_statestore", typeName)
+	}
+
+	// The test server stops reading after the first chunk with metadata.
+	if chunks != 1 {
+		t.Errorf("expected 1 chunk to be intercepted, got %d", chunks)
+	}
+
+	if !testServer.WriteStateBytesCalled["test_statestore"] {
+		t.Errorf("expected server WriteStateBytes to be called")
+	}
+}
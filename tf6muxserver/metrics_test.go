@@ -0,0 +1,221 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/muxmetrics"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+)
+
+// ignoreDurations ignores the durations of muxmetrics.Series, which vary
+// between test runs.
+var ignoreDurations = cmpopts.IgnoreFields(muxmetrics.Series{}, "TotalDuration", "MinDuration", "MaxDuration")
+
+func TestWithMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource_server1",
+				},
+			},
+		},
+	}
+	testServer2 := newPanickingServer()
+	metrics := muxmetrics.NewInMemory()
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer},
+		tf6muxserver.WithMetrics(metrics),
+		tf6muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	for _, typeName := range []string{"test_resource_server1", "test_resource_server1", "test_resource"} {
+		_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName: typeName,
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	_, err = muxServer.ProviderServer().ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []muxmetrics.Series{
+		{
+			Labels: muxmetrics.Labels{
+				RPC:    "ConfigureProvider",
+				Server: "*tf6testserver.TestServer",
+			},
+			Calls: 2,
+		},
+		{
+			Labels: muxmetrics.Labels{
+				RPC:    "GetMetadata",
+				Server: "*tf6testserver.TestServer",
+			},
+			Calls: 2,
+		},
+		{
+			Labels: muxmetrics.Labels{
+				RPC:      "ReadResource",
+				Server:   "*tf6testserver.TestServer",
+				TypeName: "test_resource",
+			},
+			Calls:            1,
+			ErrorDiagnostics: 1,
+		},
+		{
+			Labels: muxmetrics.Labels{
+				RPC:      "ReadResource",
+				Server:   "*tf6testserver.TestServer",
+				TypeName: "test_resource_server1",
+			},
+			Calls: 2,
+		},
+	}
+
+	if diff := cmp.Diff(metrics.Snapshot(), expected, ignoreDurations); diff != "" {
+		t.Errorf("unexpected metrics difference: %s", diff)
+	}
+}
+
+func TestWithMetrics_Error(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := &tf6testserver.TestServer{}
+	metrics := muxmetrics.NewInMemory()
+
+	interceptor := tf6muxserver.TypedInterceptor(func(_ context.Context, _ *tf6muxserver.CallInfo, _ *tfprotov6.StopProviderRequest, _ func(context.Context, *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error)) (*tfprotov6.StopProviderResponse, error) {
+		return nil, errors.New("test error")
+	})
+
+	// Interceptors are called before metrics are recorded, so a server
+	// returning an error is simulated with a nested mux server.
+	nestedServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up nested muxer: %s", err)
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{nestedServer.ProviderServer},
+		tf6muxserver.WithMetrics(metrics),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().StopProvider(ctx, &tfprotov6.StopProviderRequest{})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	expected := []muxmetrics.Series{
+		{
+			Labels: muxmetrics.Labels{
+				RPC:    "StopProvider",
+				Server: "*tf6muxserver.muxServer",
+			},
+			Calls:  1,
+			Errors: 1,
+		},
+	}
+
+	if diff := cmp.Diff(metrics.Snapshot(), expected, ignoreDurations); diff != "" {
+		t.Errorf("unexpected metrics difference: %s", diff)
+	}
+}
+
+func TestWithMetrics_ListResource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+	metrics := muxmetrics.NewInMemory()
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithMetrics(metrics),
+		tf6muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov6.ProviderServerWithListResource).ListResource(ctx, &tfprotov6.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_list_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	listResourceLabels := muxmetrics.Labels{
+		RPC:      "ListResource",
+		Server:   "*tf6testserver.TestServer",
+		TypeName: "test_list_resource",
+	}
+
+	for _, series := range metrics.Snapshot() {
+		if series.Labels == listResourceLabels {
+			t.Fatalf("expected ListResource to not be recorded before the stream has ended")
+		}
+	}
+
+	for range resp.Results {
+		// The stream is consumed to end it.
+	}
+
+	expected := []muxmetrics.Series{
+		{
+			Labels: muxmetrics.Labels{
+				RPC:    "GetMetadata",
+				Server: "*tf6testserver.TestServer",
+			},
+			Calls: 1,
+		},
+		{
+			Labels:           listResourceLabels,
+			Calls:            1,
+			ErrorDiagnostics: 1,
+		},
+	}
+
+	if diff := cmp.Diff(metrics.Snapshot(), expected, ignoreDurations); diff != "" {
+		t.Errorf("unexpected metrics difference: %s", diff)
+	}
+}
@@ -15,8 +15,10 @@ func (s *muxServer) CloseEphemeralResource(ctx context.Context, req *tfprotov6.C
 	rpc := "CloseEphemeralResource"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getEphemeralResourceServer(ctx, req.TypeName)
+	server, serverIndex, diags, err := s.getEphemeralResourceServer(ctx, req.TypeName)
 
 	if err != nil {
 		return nil, err
@@ -31,5 +33,5 @@ func (s *muxServer) CloseEphemeralResource(ctx context.Context, req *tfprotov6.C
 	ctx = logging.Tfprotov6ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return server.CloseEphemeralResource(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.TypeName}, req, server.CloseEphemeralResource)
 }
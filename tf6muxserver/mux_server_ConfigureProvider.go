@@ -19,13 +19,15 @@ func (s *muxServer) ConfigureProvider(ctx context.Context, req *tfprotov6.Config
 	rpc := "ConfigureProvider"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 	var diags []*tfprotov6.Diagnostic
 
-	for _, server := range s.servers {
+	for serverIndex, server := range s.servers {
 		ctx = logging.Tfprotov6ProviderServerContext(ctx, server)
 		logging.MuxTrace(ctx, "calling downstream server")
 
-		resp, err := server.ConfigureProvider(ctx, req)
+		resp, err := intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex}, req, server.ConfigureProvider)
 
 		if err != nil {
 			return resp, fmt.Errorf("error configuring %T: %w", server, err)
@@ -15,8 +15,10 @@ func (s *muxServer) DeleteState(ctx context.Context, req *tfprotov6.DeleteStateR
 	rpc := "DeleteState"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getStateStoreServer(ctx, req.TypeName)
+	server, serverIndex, diags, err := s.getStateStoreServer(ctx, req.TypeName)
 
 	if err != nil {
 		return nil, err
@@ -48,5 +50,5 @@ func (s *muxServer) DeleteState(ctx context.Context, req *tfprotov6.DeleteStateR
 	ctx = logging.Tfprotov6ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return stateStoreServer.DeleteState(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.TypeName}, req, stateStoreServer.DeleteState)
 }
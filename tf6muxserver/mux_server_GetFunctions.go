@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 
 	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/muxrouter"
 )
 
 // GetFunctions merges the functions returned by the tfprotov6.ProviderServers
@@ -19,20 +20,23 @@ func (s *muxServer) GetFunctions(ctx context.Context, req *tfprotov6.GetFunction
 	rpc := "GetFunctions"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	s.serverDiscoveryMutex.Lock()
-	defer s.serverDiscoveryMutex.Unlock()
+	// Routing is built separately and published after all underlying servers
+	// have responded, so concurrent routed RPCs are not blocked.
+	routing := s.router.NewBuilder()
 
 	resp := &tfprotov6.GetFunctionsResponse{
 		Functions: make(map[string]*tfprotov6.Function),
 	}
 
-	for _, server := range s.servers {
+	for serverIndex, server := range s.servers {
 		ctx := logging.Tfprotov6ProviderServerContext(ctx, server)
 
 		logging.MuxTrace(ctx, "calling downstream server")
 
-		serverResp, err := server.GetFunctions(ctx, &tfprotov6.GetFunctionsRequest{})
+		serverResp, err := intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex}, &tfprotov6.GetFunctionsRequest{}, server.GetFunctions)
 		if err != nil {
 			return resp, fmt.Errorf("error calling GetFunctions for %T: %w", server, err)
 		}
@@ -40,19 +44,19 @@ func (s *muxServer) GetFunctions(ctx context.Context, req *tfprotov6.GetFunction
 		resp.Diagnostics = append(resp.Diagnostics, serverResp.Diagnostics...)
 
 		for name, definition := range serverResp.Functions {
-			if _, ok := resp.Functions[name]; ok {
-				resp.Diagnostics = append(resp.Diagnostics, functionDuplicateError(name))
+			if diag, ok := routing.Add(muxrouter.KindFunction, name, serverIndex, nil); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.functions[name] = server
 			resp.Functions[name] = definition
 		}
 	}
 
 	// Intentionally not setting overall server discovery as complete, as data
 	// sources and resources are not discovered via this RPC.
+	s.router.Publish(routing, muxrouter.KindFunction)
 
 	return resp, nil
 }
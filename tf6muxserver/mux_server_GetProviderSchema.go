@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 
 	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/muxrouter"
 )
 
 // GetProviderSchema merges the schemas returned by the
@@ -20,9 +21,13 @@ func (s *muxServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetPro
 	rpc := "GetProviderSchema"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	s.serverDiscoveryMutex.Lock()
-	defer s.serverDiscoveryMutex.Unlock()
+	// Routing is built separately and published after all underlying servers
+	// have responded, so concurrent routed RPCs are not blocked.
+	routing := s.router.NewBuilder()
+	routing.SetDiscoveryComplete()
 
 	resp := &tfprotov6.GetProviderSchemaResponse{
 		ActionSchemas:            make(map[string]*tfprotov6.ActionSchema),
@@ -35,11 +40,11 @@ func (s *muxServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetPro
 		ServerCapabilities:       serverCapabilities,
 	}
 
-	for _, server := range s.servers {
+	for serverIndex, server := range s.servers {
 		ctx := logging.Tfprotov6ProviderServerContext(ctx, server)
 		logging.MuxTrace(ctx, "calling downstream server")
 
-		serverResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		serverResp, err := intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex}, &tfprotov6.GetProviderSchemaRequest{}, server.GetProviderSchema)
 
 		if err != nil {
 			return resp, fmt.Errorf("error calling GetProviderSchema for %T: %w", server, err)
@@ -78,85 +83,77 @@ func (s *muxServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetPro
 		}
 
 		for actionType, schema := range serverResp.ActionSchemas {
-			if _, ok := resp.ActionSchemas[actionType]; ok {
-				resp.Diagnostics = append(resp.Diagnostics, actionDuplicateError(actionType))
+			if diag, ok := routing.Add(muxrouter.KindAction, actionType, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.actions[actionType] = server
 			resp.ActionSchemas[actionType] = schema
 		}
 
 		for resourceType, schema := range serverResp.ResourceSchemas {
-			if _, ok := resp.ResourceSchemas[resourceType]; ok {
-				resp.Diagnostics = append(resp.Diagnostics, resourceDuplicateError(resourceType))
+			if diag, ok := routing.Add(muxrouter.KindResource, resourceType, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.resources[resourceType] = server
-			s.resourceCapabilities[resourceType] = serverResp.ServerCapabilities
 			resp.ResourceSchemas[resourceType] = schema
 		}
 
 		for dataSourceType, schema := range serverResp.DataSourceSchemas {
-			if _, ok := resp.DataSourceSchemas[dataSourceType]; ok {
-				resp.Diagnostics = append(resp.Diagnostics, dataSourceDuplicateError(dataSourceType))
+			if diag, ok := routing.Add(muxrouter.KindDataSource, dataSourceType, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.dataSources[dataSourceType] = server
 			resp.DataSourceSchemas[dataSourceType] = schema
 		}
 
 		for name, definition := range serverResp.Functions {
-			if _, ok := resp.Functions[name]; ok {
-				resp.Diagnostics = append(resp.Diagnostics, functionDuplicateError(name))
+			if diag, ok := routing.Add(muxrouter.KindFunction, name, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.functions[name] = server
 			resp.Functions[name] = definition
 		}
 
 		for ephemeralResourceType, schema := range serverResp.EphemeralResourceSchemas {
-			if _, ok := resp.EphemeralResourceSchemas[ephemeralResourceType]; ok {
-				resp.Diagnostics = append(resp.Diagnostics, ephemeralResourceDuplicateError(ephemeralResourceType))
+			if diag, ok := routing.Add(muxrouter.KindEphemeralResource, ephemeralResourceType, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.ephemeralResources[ephemeralResourceType] = server
 			resp.EphemeralResourceSchemas[ephemeralResourceType] = schema
 		}
 
 		for listResourceType, schema := range serverResp.ListResourceSchemas {
-			if _, ok := resp.ListResourceSchemas[listResourceType]; ok {
-				resp.Diagnostics = append(resp.Diagnostics, listResourceDuplicateError(listResourceType))
+			if diag, ok := routing.Add(muxrouter.KindListResource, listResourceType, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.listResources[listResourceType] = server
 			resp.ListResourceSchemas[listResourceType] = schema
 		}
 
 		for stateStoreType, schema := range serverResp.StateStoreSchemas {
-			if _, ok := resp.StateStoreSchemas[stateStoreType]; ok {
-				resp.Diagnostics = append(resp.Diagnostics, stateStoreDuplicateError(stateStoreType))
+			if diag, ok := routing.Add(muxrouter.KindStateStore, stateStoreType, serverIndex, serverResp.ServerCapabilities); !ok {
+				resp.Diagnostics = append(resp.Diagnostics, diag)
 
 				continue
 			}
 
-			s.stateStores[stateStoreType] = server
 			resp.StateStoreSchemas[stateStoreType] = schema
 		}
 	}
 
-	s.serverDiscoveryComplete = true
+	s.router.Publish(routing)
 
 	return resp, nil
 }
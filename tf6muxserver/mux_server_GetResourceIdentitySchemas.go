@@ -20,17 +20,19 @@ func (s *muxServer) GetResourceIdentitySchemas(ctx context.Context, req *tfproto
 	rpc := "GetResourceIdentitySchemas"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
 	resp := &tfprotov6.GetResourceIdentitySchemasResponse{
 		IdentitySchemas: map[string]*tfprotov6.ResourceIdentitySchema{},
 		Diagnostics:     []*tfprotov6.Diagnostic{},
 	}
 
-	for _, server := range s.servers {
+	for serverIndex, server := range s.servers {
 		ctx := logging.Tfprotov6ProviderServerContext(ctx, server)
 		logging.MuxTrace(ctx, "calling downstream server")
 
-		resourceIdentitySchemas, err := server.GetResourceIdentitySchemas(ctx, req)
+		resourceIdentitySchemas, err := intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex}, req, server.GetResourceIdentitySchemas)
 
 		if err != nil {
 			return resp, fmt.Errorf("error calling GetResourceIdentitySchemas for %T: %w", server, err)
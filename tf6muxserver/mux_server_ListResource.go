@@ -16,8 +16,10 @@ func (s *muxServer) ListResource(ctx context.Context, req *tfprotov6.ListResourc
 	rpc := "ListResource"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getListResourceServer(ctx, req.TypeName)
+	server, serverIndex, diags, err := s.getListResourceServer(ctx, req.TypeName)
 
 	if err != nil {
 		return nil, err
@@ -60,5 +62,5 @@ func (s *muxServer) ListResource(ctx context.Context, req *tfprotov6.ListResourc
 	ctx = logging.Tfprotov6ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return listResourceServer.ListResource(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.TypeName}, req, listResourceServer.ListResource)
 }
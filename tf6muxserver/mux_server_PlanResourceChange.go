@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/muxrouter"
 )
 
 // PlanResourceChange calls the PlanResourceChange method, passing `req`, on
@@ -18,8 +19,10 @@ func (s *muxServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanR
 	rpc := "PlanResourceChange"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getResourceServer(ctx, req.TypeName)
+	route, diags, err := s.router.Lookup(ctx, muxrouter.KindResource, req.TypeName)
 
 	if err != nil {
 		return nil, err
@@ -31,11 +34,12 @@ func (s *muxServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanR
 		}, nil
 	}
 
+	server := s.servers[route.Server]
 	ctx = logging.Tfprotov6ProviderServerContext(ctx, server)
 
 	// Prevent ServerCapabilities.PlanDestroy from sending destroy plans to
 	// servers which do not enable the capability.
-	if !serverSupportsPlanDestroy(s.resourceCapabilities[req.TypeName]) {
+	if !serverSupportsPlanDestroy(route.Capabilities) {
 		if req.ProposedNewState == nil {
 			logging.MuxTrace(ctx, "server does not enable destroy plans, returning without calling downstream server")
 
@@ -70,5 +74,5 @@ func (s *muxServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanR
 
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return server.PlanResourceChange(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: route.Server, TypeName: req.TypeName}, req, server.PlanResourceChange)
 }
@@ -17,8 +17,10 @@ func (s *muxServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataS
 	rpc := "ReadDataSource"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getDataSourceServer(ctx, req.TypeName)
+	server, serverIndex, diags, err := s.getDataSourceServer(ctx, req.TypeName)
 
 	if err != nil {
 		return nil, err
@@ -33,5 +35,5 @@ func (s *muxServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataS
 	ctx = logging.Tfprotov6ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return server.ReadDataSource(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.TypeName}, req, server.ReadDataSource)
 }
@@ -16,8 +16,10 @@ func (s *muxServer) ReadStateBytes(ctx context.Context, req *tfprotov6.ReadState
 	rpc := "ReadStateBytes"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
-	server, diags, err := s.getStateStoreServer(ctx, req.TypeName)
+	server, serverIndex, diags, err := s.getStateStoreServer(ctx, req.TypeName)
 
 	if err != nil {
 		return nil, err
@@ -53,5 +55,5 @@ func (s *muxServer) ReadStateBytes(ctx context.Context, req *tfprotov6.ReadState
 	ctx = logging.Tfprotov6ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return stateStoreServer.ReadStateBytes(ctx, req)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: req.TypeName}, req, stateStoreServer.ReadStateBytes)
 }
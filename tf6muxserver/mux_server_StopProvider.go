@@ -20,13 +20,15 @@ func (s *muxServer) StopProvider(ctx context.Context, req *tfprotov6.StopProvide
 	rpc := "StopProvider"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 	var errs []string
 
-	for _, server := range s.servers {
+	for serverIndex, server := range s.servers {
 		ctx = logging.Tfprotov6ProviderServerContext(ctx, server)
 		logging.MuxTrace(ctx, "calling downstream server")
 
-		resp, err := server.StopProvider(ctx, req)
+		resp, err := intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex}, req, server.StopProvider)
 
 		if err != nil {
 			return resp, fmt.Errorf("error stopping %T: %w", server, err)
@@ -15,6 +15,8 @@ func (s *muxServer) WriteStateBytes(ctx context.Context, req *tfprotov6.WriteSta
 	rpc := "WriteStateBytes"
 	ctx = logging.InitContext(ctx)
 	ctx = logging.RpcContext(ctx, rpc)
+	ctx, span := s.startRPCSpan(ctx, rpc)
+	defer span.End()
 
 	// The first chunk in the streamed request will contain the information we need to route (i.e. state store type name)
 	firstChunk, firstDiags, wrapped := peekWriteStateBytesStream(req)
@@ -48,7 +50,7 @@ func (s *muxServer) WriteStateBytes(ctx context.Context, req *tfprotov6.WriteSta
 		}, nil
 	}
 
-	server, diags, err := s.getStateStoreServer(ctx, firstChunk.Meta.TypeName)
+	server, serverIndex, diags, err := s.getStateStoreServer(ctx, firstChunk.Meta.TypeName)
 
 	if err != nil {
 		return nil, err
@@ -80,7 +82,7 @@ func (s *muxServer) WriteStateBytes(ctx context.Context, req *tfprotov6.WriteSta
 	ctx = logging.Tfprotov6ProviderServerContext(ctx, server)
 	logging.MuxTrace(ctx, "calling downstream server")
 
-	return stateStoreServer.WriteStateBytes(ctx, wrapped)
+	return intercept(ctx, s.interceptors, &CallInfo{RPC: rpc, Server: server, serverIndex: serverIndex, TypeName: firstChunk.Meta.TypeName}, wrapped, stateStoreServer.WriteStateBytes)
 }
 
 type streamedChunk struct {
@@ -7,7 +7,9 @@ import (
 	"context"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
@@ -1151,6 +1153,85 @@ func TestMuxServerGetResourceServer_Missing(t *testing.T) {
 	}
 }
 
+func TestMuxServerGetResourceServer_GetProviderSchemaInProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource_server1",
+				},
+			},
+		},
+	}
+	getProviderSchemaCalled := make(chan struct{})
+	getProviderSchemaUnblock := make(chan struct{})
+	testServer2 := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource_server2",
+				},
+			},
+		},
+		GetProviderSchemaFunc: func(_ context.Context, _ *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+			close(getProviderSchemaCalled)
+			<-getProviderSchemaUnblock
+
+			return &tfprotov6.GetProviderSchemaResponse{}, nil
+		},
+	}
+
+	servers := []func() tfprotov6.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer}
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, servers...)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up factory: %s", err)
+	}
+
+	// Complete server discovery via GetMetadata.
+	_, _ = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource_server1",
+	})
+
+	getProviderSchemaDone := make(chan struct{})
+
+	go func() {
+		defer close(getProviderSchemaDone)
+
+		_, _ = muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	}()
+
+	// Wait until GetProviderSchema is in progress against the second server.
+	<-getProviderSchemaCalled
+
+	// Routed RPCs must not wait for the in progress GetProviderSchema.
+	readResourceDone := make(chan struct{})
+
+	go func() {
+		defer close(readResourceDone)
+
+		_, _ = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName: "test_resource_server2",
+		})
+	}()
+
+	select {
+	case <-readResourceDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ReadResource was blocked by in progress GetProviderSchema")
+	}
+
+	close(getProviderSchemaUnblock)
+	<-getProviderSchemaDone
+
+	if !testServer2.ReadResourceCalled["test_resource_server2"] {
+		t.Errorf("expected test_resource_server2 ReadResource to be called on server2")
+	}
+}
+
 func TestNewMuxServer(t *testing.T) {
 	t.Parallel()
 
@@ -1809,3 +1890,83 @@ func TestNewMuxServer(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMuxServer_Nested(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var getMetadataCalls atomic.Int32
+
+	testServer1 := &tf6testserver.TestServer{
+		GetMetadataFunc: func(_ context.Context, _ *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
+			getMetadataCalls.Add(1)
+
+			return &tfprotov6.GetMetadataResponse{
+				Resources: []tfprotov6.ResourceMetadata{
+					{
+						TypeName: "test_resource_server1",
+					},
+				},
+			}, nil
+		},
+	}
+	testServer2 := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource_server2",
+				},
+			},
+		},
+	}
+	testServer3 := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource_server3",
+				},
+			},
+		},
+	}
+
+	nestedMuxServer, err := tf6muxserver.NewMuxServer(ctx, testServer1.ProviderServer, testServer2.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up nested muxer: %s", err)
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, nestedMuxServer.ProviderServer, testServer3.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	for _, typeName := range []string{"test_resource_server1", "test_resource_server2", "test_resource_server3"} {
+		_, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName: typeName,
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error reading %s: %s", typeName, err)
+		}
+	}
+
+	if !testServer1.ReadResourceCalled["test_resource_server1"] {
+		t.Errorf("expected test_resource_server1 ReadResource to be called on server1")
+	}
+
+	if !testServer2.ReadResourceCalled["test_resource_server2"] {
+		t.Errorf("expected test_resource_server2 ReadResource to be called on server2")
+	}
+
+	if !testServer3.ReadResourceCalled["test_resource_server3"] {
+		t.Errorf("expected test_resource_server3 ReadResource to be called on server3")
+	}
+
+	// The nested mux server discovering its own underlying servers would
+	// call GetMetadata a second time.
+	if got := getMetadataCalls.Load(); got != 1 {
+		t.Errorf("expected GetMetadata to be called once on server1, got %d", got)
+	}
+}
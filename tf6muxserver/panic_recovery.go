@@ -0,0 +1,236 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"runtime/debug"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+)
+
+// WithPanicRecovery is a NewMuxServerWithOptions option which recovers panics
+// in underlying servers, rather than crashing the provider process. Each
+// recovered panic is logged with its stack trace and returned to Terraform
+// as an error diagnostic, which names the RPC, type, and underlying server.
+// Panics in the ListResource, InvokeAction, and ReadStateBytes stream
+// iterators are also recovered, ending the stream with the error diagnostic.
+//
+// When ApplyResourceChange panics with a prior state, the prior state and
+// planned private state are returned, so Terraform keeps tracking the
+// resource as it was before the apply. When there is no prior state, such as
+// resource creation, no new state is returned.
+//
+// Panics are recovered after any interceptors from WithInterceptors, so
+// interceptors receive the error diagnostic response. Panics in interceptors
+// are not recovered.
+func WithPanicRecovery() MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.panicRecovery = true
+	}
+}
+
+// recoverPanics is the Interceptor added by WithPanicRecovery.
+func recoverPanics(ctx context.Context, info *CallInfo, req any, handler Handler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logPanic(ctx, r)
+
+			resp, err = errorResponse(info, req, panicDiagnostic(info, r))
+		}
+	}()
+
+	resp, err = handler(ctx, req)
+
+	switch typedResp := resp.(type) {
+	case *tfprotov6.InvokeActionServerStream:
+		if typedResp != nil && typedResp.Events != nil {
+			typedResp.Events = recoverStream(ctx, info, typedResp.Events, func(diag *tfprotov6.Diagnostic) tfprotov6.InvokeActionEvent {
+				return tfprotov6.InvokeActionEvent{
+					Type: tfprotov6.CompletedInvokeActionEventType{
+						Diagnostics: []*tfprotov6.Diagnostic{diag},
+					},
+				}
+			})
+		}
+	case *tfprotov6.ListResourceServerStream:
+		if typedResp != nil && typedResp.Results != nil {
+			typedResp.Results = recoverStream(ctx, info, typedResp.Results, func(diag *tfprotov6.Diagnostic) tfprotov6.ListResourceResult {
+				return tfprotov6.ListResourceResult{
+					Diagnostics: []*tfprotov6.Diagnostic{diag},
+				}
+			})
+		}
+	case *tfprotov6.ReadStateBytesStream:
+		if typedResp != nil && typedResp.Chunks != nil {
+			typedResp.Chunks = recoverStream(ctx, info, typedResp.Chunks, func(diag *tfprotov6.Diagnostic) tfprotov6.ReadStateByteChunk {
+				return tfprotov6.ReadStateByteChunk{
+					Diagnostics: []*tfprotov6.Diagnostic{diag},
+				}
+			})
+		}
+	}
+
+	return resp, err
+}
+
+// recoverStream returns an iterator which recovers panics in the stream
+// iterator, ending the stream with the element returned by panicElement.
+// Panics in the consumer of the stream are not recovered.
+func recoverStream[T any](ctx context.Context, info *CallInfo, stream iter.Seq[T], panicElement func(*tfprotov6.Diagnostic) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		yielding := false
+
+		defer func() {
+			if yielding {
+				return
+			}
+
+			if r := recover(); r != nil {
+				logPanic(ctx, r)
+
+				yield(panicElement(panicDiagnostic(info, r)))
+			}
+		}()
+
+		for element := range stream {
+			yielding = true
+
+			if !yield(element) {
+				return
+			}
+
+			yielding = false
+		}
+	}
+}
+
+// logPanic logs a recovered panic with its stack trace.
+func logPanic(ctx context.Context, r any) {
+	logging.MuxError(ctx, "recovered panic from downstream server", map[string]interface{}{
+		logging.KeyPanic:      fmt.Sprint(r),
+		logging.KeyPanicStack: string(debug.Stack()),
+	})
+}
+
+// errorResponse returns the response of the RPC with the error diagnostic,
+// for calls which the underlying server did not handle, such as those which
+// panicked.
+func errorResponse(info *CallInfo, req any, diag *tfprotov6.Diagnostic) (any, error) {
+	diags := []*tfprotov6.Diagnostic{diag}
+
+	switch typedReq := req.(type) {
+	case *tfprotov6.ApplyResourceChangeRequest:
+		resp := &tfprotov6.ApplyResourceChangeResponse{
+			Diagnostics: diags,
+		}
+
+		if typedReq.PriorState != nil {
+			resp.NewState = typedReq.PriorState
+			resp.Private = typedReq.PlannedPrivate
+		}
+
+		return resp, nil
+	case *tfprotov6.CallFunctionRequest:
+		return &tfprotov6.CallFunctionResponse{
+			Error: &tfprotov6.FunctionError{
+				Text: diag.Summary + ": " + diag.Detail,
+			},
+		}, nil
+	case *tfprotov6.CloseEphemeralResourceRequest:
+		return &tfprotov6.CloseEphemeralResourceResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ConfigureProviderRequest:
+		return &tfprotov6.ConfigureProviderResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ConfigureStateStoreRequest:
+		return &tfprotov6.ConfigureStateStoreResponse{Diagnostics: diags}, nil
+	case *tfprotov6.DeleteStateRequest:
+		return &tfprotov6.DeleteStateResponse{Diagnostics: diags}, nil
+	case *tfprotov6.GenerateResourceConfigRequest:
+		return &tfprotov6.GenerateResourceConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov6.GetFunctionsRequest:
+		return &tfprotov6.GetFunctionsResponse{Diagnostics: diags}, nil
+	case *tfprotov6.GetMetadataRequest:
+		return &tfprotov6.GetMetadataResponse{Diagnostics: diags}, nil
+	case *tfprotov6.GetProviderSchemaRequest:
+		return &tfprotov6.GetProviderSchemaResponse{Diagnostics: diags}, nil
+	case *tfprotov6.GetResourceIdentitySchemasRequest:
+		return &tfprotov6.GetResourceIdentitySchemasResponse{Diagnostics: diags}, nil
+	case *tfprotov6.GetStatesRequest:
+		return &tfprotov6.GetStatesResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ImportResourceStateRequest:
+		return &tfprotov6.ImportResourceStateResponse{Diagnostics: diags}, nil
+	case *tfprotov6.InvokeActionRequest:
+		return &tfprotov6.InvokeActionServerStream{
+			Events: func(yield func(tfprotov6.InvokeActionEvent) bool) {
+				yield(tfprotov6.InvokeActionEvent{
+					Type: tfprotov6.CompletedInvokeActionEventType{
+						Diagnostics: diags,
+					},
+				})
+			},
+		}, nil
+	case *tfprotov6.ListResourceRequest:
+		return &tfprotov6.ListResourceServerStream{
+			Results: func(yield func(tfprotov6.ListResourceResult) bool) {
+				yield(tfprotov6.ListResourceResult{
+					Diagnostics: diags,
+				})
+			},
+		}, nil
+	case *tfprotov6.LockStateRequest:
+		return &tfprotov6.LockStateResponse{Diagnostics: diags}, nil
+	case *tfprotov6.MoveResourceStateRequest:
+		return &tfprotov6.MoveResourceStateResponse{Diagnostics: diags}, nil
+	case *tfprotov6.OpenEphemeralResourceRequest:
+		return &tfprotov6.OpenEphemeralResourceResponse{Diagnostics: diags}, nil
+	case *tfprotov6.PlanActionRequest:
+		return &tfprotov6.PlanActionResponse{Diagnostics: diags}, nil
+	case *tfprotov6.PlanResourceChangeRequest:
+		return &tfprotov6.PlanResourceChangeResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ReadDataSourceRequest:
+		return &tfprotov6.ReadDataSourceResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ReadResourceRequest:
+		return &tfprotov6.ReadResourceResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ReadStateBytesRequest:
+		return &tfprotov6.ReadStateBytesStream{
+			Chunks: func(yield func(tfprotov6.ReadStateByteChunk) bool) {
+				yield(tfprotov6.ReadStateByteChunk{
+					Diagnostics: diags,
+				})
+			},
+		}, nil
+	case *tfprotov6.RenewEphemeralResourceRequest:
+		return &tfprotov6.RenewEphemeralResourceResponse{Diagnostics: diags}, nil
+	case *tfprotov6.StopProviderRequest:
+		return &tfprotov6.StopProviderResponse{Error: diag.Summary + ": " + diag.Detail}, nil
+	case *tfprotov6.UnlockStateRequest:
+		return &tfprotov6.UnlockStateResponse{Diagnostics: diags}, nil
+	case *tfprotov6.UpgradeResourceIdentityRequest:
+		return &tfprotov6.UpgradeResourceIdentityResponse{Diagnostics: diags}, nil
+	case *tfprotov6.UpgradeResourceStateRequest:
+		return &tfprotov6.UpgradeResourceStateResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ValidateActionConfigRequest:
+		return &tfprotov6.ValidateActionConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ValidateDataResourceConfigRequest:
+		return &tfprotov6.ValidateDataResourceConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ValidateEphemeralResourceConfigRequest:
+		return &tfprotov6.ValidateEphemeralResourceConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ValidateListResourceConfigRequest:
+		return &tfprotov6.ValidateListResourceConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ValidateProviderConfigRequest:
+		return &tfprotov6.ValidateProviderConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ValidateResourceConfigRequest:
+		return &tfprotov6.ValidateResourceConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov6.ValidateStateStoreConfigRequest:
+		return &tfprotov6.ValidateStateStoreConfigResponse{Diagnostics: diags}, nil
+	case *tfprotov6.WriteStateBytesStream:
+		return &tfprotov6.WriteStateBytesResponse{Diagnostics: diags}, nil
+	}
+
+	return nil, fmt.Errorf("unable to return %s error diagnostic, unexpected request type: %T", info.RPC, req)
+}
@@ -0,0 +1,372 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+)
+
+// newPanickingServer returns a test server which panics in resource RPCs, in
+// the ListResource results iterator after the first result, and in the
+// ReadStateBytes chunks iterator.
+func newPanickingServer() *tf6testserver.TestServer {
+	return &tf6testserver.TestServer{
+		ApplyResourceChangeFunc: func(_ context.Context, _ *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+			panic("test apply panic")
+		},
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			ListResources: []tfprotov6.ListResourceMetadata{
+				{
+					TypeName: "test_list_resource",
+				},
+			},
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+			StateStores: []tfprotov6.StateStoreMetadata{
+				{
+					TypeName: "test_statestore",
+				},
+			},
+		},
+		ListResourceFunc: func(_ context.Context, _ *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
+			return &tfprotov6.ListResourceServerStream{
+				Results: func(yield func(tfprotov6.ListResourceResult) bool) {
+					if !yield(tfprotov6.ListResourceResult{DisplayName: "one"}) {
+						return
+					}
+
+					panic("test list panic")
+				},
+			}, nil
+		},
+		ReadResourceFunc: func(_ context.Context, _ *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+			panic("test read panic")
+		},
+		ReadStateBytesFunc: func(_ context.Context, _ *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+			return &tfprotov6.ReadStateBytesStream{
+				Chunks: func(_ func(tfprotov6.ReadStateByteChunk) bool) {
+					panic("test read state bytes panic")
+				},
+			}, nil
+		},
+	}
+}
+
+func TestWithPanicRecovery_ApplyResourceChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		request  *tfprotov6.ApplyResourceChangeRequest
+		expected *tfprotov6.ApplyResourceChangeResponse
+	}{
+		"create": {
+			request: &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:       "test_resource",
+				PlannedPrivate: []byte(`{"planned":true}`),
+			},
+			expected: &tfprotov6.ApplyResourceChangeResponse{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityError,
+						Summary:  "Provider Server Panic",
+						Detail: `An underlying provider server panicked while handling the ApplyResourceChange RPC for "test_resource". ` +
+							"The provider logs contain the stack trace of the panic. " +
+							"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+							"Underlying server: *tf6testserver.TestServer\n" +
+							"Panic: test apply panic",
+					},
+				},
+			},
+		},
+		"update": {
+			request: &tfprotov6.ApplyResourceChangeRequest{
+				TypeName: "test_resource",
+				PriorState: &tfprotov6.DynamicValue{
+					JSON: []byte(`{"id":"prior"}`),
+				},
+				PlannedPrivate: []byte(`{"planned":true}`),
+			},
+			expected: &tfprotov6.ApplyResourceChangeResponse{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityError,
+						Summary:  "Provider Server Panic",
+						Detail: `An underlying provider server panicked while handling the ApplyResourceChange RPC for "test_resource". ` +
+							"The provider logs contain the stack trace of the panic. " +
+							"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+							"Underlying server: *tf6testserver.TestServer\n" +
+							"Panic: test apply panic",
+					},
+				},
+				NewState: &tfprotov6.DynamicValue{
+					JSON: []byte(`{"id":"prior"}`),
+				},
+				Private: []byte(`{"planned":true}`),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			testServer := newPanickingServer()
+
+			muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+				ctx,
+				[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+				tf6muxserver.WithPanicRecovery(),
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error setting up muxer: %s", err)
+			}
+
+			got, err := muxServer.ProviderServer().ApplyResourceChange(ctx, testCase.request)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected response difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithPanicRecovery_Interceptors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+
+	var interceptedDiagnostics []*tfprotov6.Diagnostic
+
+	interceptor := tf6muxserver.TypedInterceptor(func(ctx context.Context, info *tf6muxserver.CallInfo, req *tfprotov6.ReadResourceRequest, handler func(context.Context, *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error)) (*tfprotov6.ReadResourceResponse, error) {
+		resp, err := handler(ctx, req)
+
+		if resp != nil {
+			interceptedDiagnostics = resp.Diagnostics
+		}
+
+		return resp, err
+	})
+
+	// The panic recovery option is before the interceptor, but panics are
+	// always recovered before interceptors receive the response.
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithPanicRecovery(),
+		tf6muxserver.WithInterceptors(interceptor),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedDiagnostics := []*tfprotov6.Diagnostic{
+		{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  "Provider Server Panic",
+			Detail: `An underlying provider server panicked while handling the ReadResource RPC for "test_resource". ` +
+				"The provider logs contain the stack trace of the panic. " +
+				"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+				"Underlying server: *tf6testserver.TestServer\n" +
+				"Panic: test read panic",
+		},
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+
+	if diff := cmp.Diff(interceptedDiagnostics, expectedDiagnostics); diff != "" {
+		t.Errorf("unexpected intercepted diagnostics difference: %s", diff)
+	}
+}
+
+func TestWithPanicRecovery_ListResource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov6.ProviderServerWithListResource).ListResource(ctx, &tfprotov6.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_list_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var results []tfprotov6.ListResourceResult
+
+	for result := range resp.Results {
+		results = append(results, result)
+	}
+
+	expectedResults := []tfprotov6.ListResourceResult{
+		{
+			DisplayName: "one",
+		},
+		{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Provider Server Panic",
+					Detail: `An underlying provider server panicked while handling the ListResource RPC for "test_list_resource". ` +
+						"The provider logs contain the stack trace of the panic. " +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf6testserver.TestServer\n" +
+						"Panic: test list panic",
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(results, expectedResults); diff != "" {
+		t.Errorf("unexpected results difference: %s", diff)
+	}
+}
+
+func TestWithPanicRecovery_ListResourceConsumerPanic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov6.ProviderServerWithListResource).ListResource(ctx, &tfprotov6.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_list_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer func() {
+		if r := recover(); r != "test consumer panic" {
+			t.Errorf("expected consumer panic to not be recovered, got: %v", r)
+		}
+	}()
+
+	for range resp.Results {
+		panic("test consumer panic")
+	}
+}
+
+func TestWithPanicRecovery_ReadStateBytes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithPanicRecovery(),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().(tfprotov6.ProviderServerWithStateStores).ReadStateBytes(ctx, &tfprotov6.ReadStateBytesRequest{
+		TypeName: "test_statestore",
+		StateID:  "default",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var chunks []tfprotov6.ReadStateByteChunk
+
+	for chunk := range resp.Chunks {
+		chunks = append(chunks, chunk)
+	}
+
+	expectedChunks := []tfprotov6.ReadStateByteChunk{
+		{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Provider Server Panic",
+					Detail: `An underlying provider server panicked while handling the ReadStateBytes RPC for "test_statestore". ` +
+						"The provider logs contain the stack trace of the panic. " +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf6testserver.TestServer\n" +
+						"Panic: test read state bytes panic",
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(chunks, expectedChunks); diff != "" {
+		t.Errorf("unexpected chunks difference: %s", diff)
+	}
+}
+
+func TestWithPanicRecovery_NotEnabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newPanickingServer()
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, testServer.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	defer func() {
+		if r := recover(); r != "test read panic" {
+			t.Errorf("expected panic to not be recovered, got: %v", r)
+		}
+	}()
+
+	_, _ = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6toproto"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tftypesjson"
+)
+
+// logPayloads returns the Interceptor added when the TF_LOG_SDK_MUX_PAYLOADS
+// environment variable is enabled, which logs the request and response
+// payloads of each call to an underlying server at TRACE level, and each
+// element of request and response streams as it is consumed.
+//
+// Payloads are logged as JSON, with DynamicValues decoded with the schema of
+// the underlying server. Values of attributes which are sensitive or
+// write-only in the schema are redacted, as are private state, raw states,
+// state store bytes, and DynamicValues which cannot be decoded with a schema.
+func logPayloads(schemas *schemaCache) Interceptor {
+	return func(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+		l := &payloadLogger{
+			cache: schemas,
+			ctx:   ctx,
+			info:  info,
+		}
+
+		if payload := l.payload(recordingMessage(req)); payload != "" {
+			logging.MuxTrace(ctx, "downstream server request payload", map[string]interface{}{logging.KeyTfMuxRequestPayload: payload})
+		}
+
+		// The WriteStateBytes request is a stream of chunks from Terraform,
+		// which are logged as they are consumed by the underlying server.
+		if typedReq, ok := req.(*tfprotov6.WriteStateBytesStream); ok && typedReq != nil && typedReq.Chunks != nil {
+			loggedReq := *typedReq
+			loggedReq.Chunks = func(yield func(*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic) bool) {
+				for chunk, diags := range typedReq.Chunks {
+					if payload := l.payload(tfprotov6toproto.WriteStateBytesChunk(chunk)); payload != "" {
+						logging.MuxTrace(ctx, "downstream server request stream payload", map[string]interface{}{logging.KeyTfMuxRequestPayload: payload})
+					}
+
+					if !yield(chunk, diags) {
+						return
+					}
+				}
+			}
+			req = &loggedReq
+		}
+
+		resp, err := handler(ctx, req)
+
+		if err != nil {
+			logging.MuxTrace(ctx, "downstream server response error", map[string]interface{}{logging.KeyError: err.Error()})
+
+			return resp, err
+		}
+
+		switch typedResp := resp.(type) {
+		case *tfprotov6.InvokeActionServerStream:
+			if typedResp != nil && typedResp.Events != nil {
+				typedResp.Events = observeStream(typedResp.Events, func(event tfprotov6.InvokeActionEvent) {
+					l.logResponseStream(tfprotov6toproto.InvokeActionEvent(&event))
+				}, func() {})
+
+				return resp, err
+			}
+		case *tfprotov6.ListResourceServerStream:
+			if typedResp != nil && typedResp.Results != nil {
+				typedResp.Results = observeStream(typedResp.Results, func(result tfprotov6.ListResourceResult) {
+					l.logResponseStream(tfprotov6toproto.ListResourceResult(&result))
+				}, func() {})
+
+				return resp, err
+			}
+		case *tfprotov6.ReadStateBytesStream:
+			if typedResp != nil && typedResp.Chunks != nil {
+				typedResp.Chunks = observeStream(typedResp.Chunks, func(chunk tfprotov6.ReadStateByteChunk) {
+					l.logResponseStream(tfprotov6toproto.ReadStateBytesChunk(&chunk))
+				}, func() {})
+
+				return resp, err
+			}
+		}
+
+		if payload := l.payload(recordingMessage(resp)); payload != "" {
+			logging.MuxTrace(ctx, "downstream server response payload", map[string]interface{}{logging.KeyTfMuxResponsePayload: payload})
+		}
+
+		return resp, err
+	}
+}
+
+// payloadLogger encodes the payloads of a call, fetching the schemas of the
+// underlying server on first use.
+type payloadLogger struct {
+	cache   *schemaCache
+	ctx     context.Context
+	info    *CallInfo
+	schemas *serverSchemas
+	fetched bool
+}
+
+// logResponseStream logs the payload of an element of a response stream.
+func (l *payloadLogger) logResponseStream(message proto.Message) {
+	if payload := l.payload(message); payload != "" {
+		logging.MuxTrace(l.ctx, "downstream server response stream payload", map[string]interface{}{logging.KeyTfMuxResponsePayload: payload})
+	}
+}
+
+// payload returns the redacted JSON encoding of the message, or an empty
+// string if there is no message. Encoding errors are logged, as they should
+// not affect the call.
+func (l *payloadLogger) payload(message proto.Message) string {
+	if message == nil || !message.ProtoReflect().IsValid() {
+		return ""
+	}
+
+	object, err := messageObject(message)
+
+	if err != nil {
+		logging.MuxError(l.ctx, "error encoding payload", map[string]interface{}{logging.KeyError: err.Error()})
+
+		return ""
+	}
+
+	schemas := l.serverSchemas()
+
+	object = redactPayload(object, "", func(field string, value map[string]any) any {
+		typ := recordingValueType(schemas, l.info.RPC, l.info.TypeName, field)
+
+		if typ == nil {
+			return tftypesjson.Redacted
+		}
+
+		decoded, err := decodeDynamicValue(value, typ)
+
+		if err != nil {
+			return tftypesjson.Redacted
+		}
+
+		schema := recordingSchema(schemas, l.info.RPC, l.info.TypeName, field)
+
+		if schema == nil {
+			return tftypesjson.Value(decoded)
+		}
+
+		return redactBlock(schema.Block, tftypesjson.Value(decoded))
+	})
+
+	var payload strings.Builder
+
+	// HTML characters are not escaped, so redacted and unknown values are
+	// readable in logs.
+	encoder := json.NewEncoder(&payload)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(object); err != nil {
+		logging.MuxError(l.ctx, "error encoding payload", map[string]interface{}{logging.KeyError: err.Error()})
+
+		return ""
+	}
+
+	return strings.TrimSuffix(payload.String(), "\n")
+}
+
+// serverSchemas returns the schemas of the underlying server. Errors fetching
+// the schemas are logged, as they should not affect the call.
+func (l *payloadLogger) serverSchemas() *serverSchemas {
+	if l.fetched {
+		return l.schemas
+	}
+
+	l.fetched = true
+
+	schemas, err := l.cache.get(l.ctx, l.info)
+
+	if err != nil {
+		logging.MuxError(l.ctx, "error fetching schemas for payload logging", map[string]interface{}{logging.KeyError: err.Error()})
+	}
+
+	l.schemas = schemas
+
+	return schemas
+}
+
+// redactPayload returns the JSON encoding of a message with the values of
+// fields containing private state, raw states, or state bytes redacted, and each
+// DynamicValue replaced by the value returned by decode for the name of the
+// field containing it.
+func redactPayload(object any, field string, decode func(field string, value map[string]any) any) any {
+	switch field {
+	case "bytes", "raw_identity", "raw_state", "source_identity", "source_state":
+		return tftypesjson.Redacted
+	}
+
+	if field == "private" || strings.HasSuffix(field, "_private") {
+		return tftypesjson.Redacted
+	}
+
+	switch object := object.(type) {
+	case map[string]any:
+		if isDynamicValue(object) {
+			return decode(field, object)
+		}
+
+		for name, value := range object {
+			object[name] = redactPayload(value, name, decode)
+		}
+	case []any:
+		for index, element := range object {
+			object[index] = redactPayload(element, field, decode)
+		}
+	}
+
+	return object
+}
+
+// redactBlock returns the JSON value of an object of the block with the
+// values of sensitive and write-only attributes redacted, including those of
+// nested attributes and nested blocks.
+func redactBlock(block *tfprotov6.SchemaBlock, value any) any {
+	object, ok := value.(map[string]any)
+
+	if !ok || block == nil {
+		return value
+	}
+
+	redactAttributes(block.Attributes, object)
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock == nil {
+			continue
+		}
+
+		switch nestedValue := object[nestedBlock.TypeName].(type) {
+		case []any:
+			for index, element := range nestedValue {
+				nestedValue[index] = redactBlock(nestedBlock.Block, element)
+			}
+		case map[string]any:
+			if nestedBlock.Nesting == tfprotov6.SchemaNestedBlockNestingModeMap {
+				for key, element := range nestedValue {
+					nestedValue[key] = redactBlock(nestedBlock.Block, element)
+				}
+
+				continue
+			}
+
+			object[nestedBlock.TypeName] = redactBlock(nestedBlock.Block, nestedValue)
+		}
+	}
+
+	return object
+}
+
+// redactAttributes redacts the values of sensitive and write-only attributes
+// in the JSON value of an object, including those of nested attributes.
+func redactAttributes(attributes []*tfprotov6.SchemaAttribute, object map[string]any) {
+	for _, attribute := range attributes {
+		if attribute == nil || object[attribute.Name] == nil {
+			continue
+		}
+
+		if attribute.Sensitive || attribute.WriteOnly {
+			object[attribute.Name] = tftypesjson.Redacted
+
+			continue
+		}
+
+		if attribute.NestedType == nil {
+			continue
+		}
+
+		switch nestedValue := object[attribute.Name].(type) {
+		case []any:
+			for _, element := range nestedValue {
+				if elementObject, ok := element.(map[string]any); ok {
+					redactAttributes(attribute.NestedType.Attributes, elementObject)
+				}
+			}
+		case map[string]any:
+			if attribute.NestedType.Nesting != tfprotov6.SchemaObjectNestingModeMap {
+				redactAttributes(attribute.NestedType.Attributes, nestedValue)
+
+				continue
+			}
+
+			for _, element := range nestedValue {
+				if elementObject, ok := element.(map[string]any); ok {
+					redactAttributes(attribute.NestedType.Attributes, elementObject)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklogtest"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6dynamicvalue"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+)
+
+func TestPayloadLogging(t *testing.T) {
+	t.Setenv("TF_LOG_SDK_MUX_PAYLOADS", "1")
+
+	var output bytes.Buffer
+
+	ctx := tfsdklogtest.RootLogger(context.Background(), &output)
+	settingsType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"token": tftypes.String,
+		},
+	}
+	schemaType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":       tftypes.String,
+			"password": tftypes.String,
+			"settings": settingsType,
+		},
+	}
+	state := tf6dynamicvalue.Must(schemaType, tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, "test-id"),
+		"password": tftypes.NewValue(tftypes.String, "test-password"),
+		"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "test-token"),
+		}),
+	}))
+	testServer := newValidationTestServer(
+		&tfprotov6.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource": {
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "id",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:      "password",
+								Type:      tftypes.String,
+								Optional:  true,
+								Sensitive: true,
+							},
+							{
+								Name: "settings",
+								NestedType: &tfprotov6.SchemaObject{
+									Attributes: []*tfprotov6.SchemaAttribute{
+										{
+											Name:      "token",
+											Type:      tftypes.String,
+											Optional:  true,
+											Sensitive: true,
+										},
+									},
+									Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+								},
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		state,
+	)
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, testServer.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "test_resource",
+		CurrentState: state,
+		Private:      []byte("test-private"),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Contains(output.String(), "test-password") || strings.Contains(output.String(), "test-private") || strings.Contains(output.String(), "test-token") {
+		t.Errorf("expected sensitive values to be redacted, got: %s", output.String())
+	}
+
+	entries, err := tfsdklogtest.MultilineJSONDecode(&output)
+
+	if err != nil {
+		t.Fatalf("unable to read log entries: %s", err)
+	}
+
+	var got []map[string]interface{}
+
+	for _, entry := range entries {
+		// Payloads of the discovery of the server are not compared.
+		if entry["tf_rpc"] != "ReadResource" {
+			continue
+		}
+
+		for _, key := range []string{"tf_mux_request_payload", "tf_mux_response_payload"} {
+			if payload, ok := entry[key]; ok {
+				got = append(got, map[string]interface{}{
+					"@message": entry["@message"],
+					key:        payload,
+				})
+			}
+		}
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"@message":               "downstream server request payload",
+			"tf_mux_request_payload": `{"current_state":{"id":"test-id","password":"<redacted>","settings":{"token":"<redacted>"}},"private":"<redacted>","type_name":"test_resource"}`,
+		},
+		{
+			"@message":                "downstream server response payload",
+			"tf_mux_response_payload": `{"new_state":{"id":"test-id","password":"<redacted>","settings":{"token":"<redacted>"}}}`,
+		},
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected payload log entries difference: %s", diff)
+	}
+}
@@ -0,0 +1,519 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6toproto"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tftypesjson"
+)
+
+// WithRecording is a NewMuxServerWithOptions option which writes each call to
+// an underlying server, including those made to discover the types each
+// server implements, as a line of JSON to w. NewReplayServers serves the
+// responses of a recording again, such as to reproduce an issue in a unit
+// test without the credentials of the provider.
+//
+// Each line contains the RPC name, the Go type and index of the underlying
+// server, the type name of the request, and the request and response as the
+// JSON encoding of the protocol's Protocol Buffers messages, or the gRPC
+// error. Streamed ListResource results, InvokeAction events, and state byte
+// chunks are recorded once the stream has ended. DynamicValues additionally contain the value
+// decoded with the schemas from the underlying server's recorded
+// GetProviderSchema and GetResourceIdentitySchemas responses, if any.
+//
+// Recordings contain every value sent to and returned by the underlying
+// servers, including sensitive values such as credentials in the provider
+// configuration, and should be handled accordingly.
+//
+// Calls are recorded after any interceptors from WithInterceptors, so calls
+// which are handled by an interceptor without calling the underlying server
+// are not recorded.
+func WithRecording(w io.Writer) MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.recording = w
+	}
+}
+
+// recordingEntry is a line of a recording.
+type recordingEntry struct {
+	RPC            string            `json:"rpc"`
+	Server         string            `json:"server"`
+	ServerIndex    int               `json:"server_index"`
+	TypeName       string            `json:"type_name,omitempty"`
+	Request        json.RawMessage   `json:"request,omitempty"`
+	RequestStream  []json.RawMessage `json:"request_stream,omitempty"`
+	Response       json.RawMessage   `json:"response,omitempty"`
+	ResponseStream []json.RawMessage `json:"response_stream,omitempty"`
+	Error          string            `json:"error,omitempty"`
+	ErrorCode      codes.Code        `json:"error_code,omitempty"`
+}
+
+// err returns the recorded gRPC error, if any.
+func (e recordingEntry) err() error {
+	if e.ErrorCode == codes.OK {
+		return nil
+	}
+
+	return status.Error(e.ErrorCode, e.Error)
+}
+
+// recorder writes the recording of WithRecording.
+type recorder struct {
+	// schemas contains the schemas which DynamicValues are decoded with.
+	// Underlying servers are not called for missing schemas, so the
+	// recording only contains calls of the mux server.
+	schemas *schemaCache
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newRecorder(w io.Writer, schemas *schemaCache) *recorder {
+	return &recorder{
+		schemas: schemas,
+		w:       w,
+	}
+}
+
+// intercept is the Interceptor added by WithRecording.
+func (r *recorder) intercept(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+	entry := recordingEntry{
+		RPC:         info.RPC,
+		Server:      fmt.Sprintf("%T", info.Server),
+		ServerIndex: info.serverIndex,
+		TypeName:    info.TypeName,
+	}
+
+	// The request is encoded before calling the underlying server, which
+	// could modify it.
+	entry.Request = r.encode(ctx, info, recordingMessage(req))
+
+	// The WriteStateBytes request is a stream of chunks from Terraform,
+	// which are recorded as they are consumed by the underlying server.
+	if typedReq, ok := req.(*tfprotov6.WriteStateBytesStream); ok && typedReq != nil && typedReq.Chunks != nil {
+		recordedReq := *typedReq
+		recordedReq.Chunks = func(yield func(*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic) bool) {
+			for chunk, diags := range typedReq.Chunks {
+				entry.RequestStream = append(entry.RequestStream, r.encode(ctx, info, tfprotov6toproto.WriteStateBytesChunk(chunk)))
+
+				if !yield(chunk, diags) {
+					return
+				}
+			}
+		}
+		req = &recordedReq
+	}
+
+	resp, err := handler(ctx, req)
+
+	// Errors are recorded as gRPC errors, as they would be returned over
+	// gRPC to Terraform.
+	if err != nil {
+		grpcStatus := status.Convert(err)
+		entry.Error = grpcStatus.Message()
+		entry.ErrorCode = grpcStatus.Code()
+	}
+
+	switch typedResp := resp.(type) {
+	case *tfprotov6.GetProviderSchemaResponse, *tfprotov6.GetResourceIdentitySchemasResponse:
+		r.schemas.observe(info.serverIndex, typedResp)
+	case *tfprotov6.InvokeActionServerStream:
+		if typedResp != nil && typedResp.Events != nil {
+			typedResp.Events = observeStream(typedResp.Events, func(event tfprotov6.InvokeActionEvent) {
+				entry.ResponseStream = append(entry.ResponseStream, r.encode(ctx, info, tfprotov6toproto.InvokeActionEvent(&event)))
+			}, func() {
+				r.write(ctx, entry)
+			})
+
+			return resp, err
+		}
+	case *tfprotov6.ListResourceServerStream:
+		if typedResp != nil && typedResp.Results != nil {
+			typedResp.Results = observeStream(typedResp.Results, func(result tfprotov6.ListResourceResult) {
+				entry.ResponseStream = append(entry.ResponseStream, r.encode(ctx, info, tfprotov6toproto.ListResourceResult(&result)))
+			}, func() {
+				r.write(ctx, entry)
+			})
+
+			return resp, err
+		}
+	case *tfprotov6.ReadStateBytesStream:
+		if typedResp != nil && typedResp.Chunks != nil {
+			typedResp.Chunks = observeStream(typedResp.Chunks, func(chunk tfprotov6.ReadStateByteChunk) {
+				entry.ResponseStream = append(entry.ResponseStream, r.encode(ctx, info, tfprotov6toproto.ReadStateBytesChunk(&chunk)))
+			}, func() {
+				r.write(ctx, entry)
+			})
+
+			return resp, err
+		}
+	}
+
+	entry.Response = r.encode(ctx, info, recordingMessage(resp))
+	r.write(ctx, entry)
+
+	return resp, err
+}
+
+// encode returns the JSON encoding of the message, with the decoded value
+// added to each DynamicValue. Encoding errors are logged, as they should not
+// affect the call.
+func (r *recorder) encode(ctx context.Context, info *CallInfo, message proto.Message) json.RawMessage {
+	if message == nil || !message.ProtoReflect().IsValid() {
+		return nil
+	}
+
+	object, err := messageObject(message)
+
+	if err != nil {
+		logging.MuxError(ctx, "error encoding recording message", map[string]interface{}{logging.KeyError: err.Error()})
+
+		return nil
+	}
+
+	schemas := r.schemas.lookup(info.serverIndex)
+
+	decodeDynamicValues(object, "", func(field string) tftypes.Type {
+		return recordingValueType(schemas, info.RPC, info.TypeName, field)
+	})
+
+	data, err := json.Marshal(object)
+
+	if err != nil {
+		logging.MuxError(ctx, "error encoding recording message", map[string]interface{}{logging.KeyError: err.Error()})
+
+		return nil
+	}
+
+	return data
+}
+
+// write writes the entry as a line of JSON. Write errors are logged, as they
+// should not affect the call.
+func (r *recorder) write(ctx context.Context, entry recordingEntry) {
+	data, err := json.Marshal(entry)
+
+	if err != nil {
+		logging.MuxError(ctx, "error encoding recording entry", map[string]interface{}{logging.KeyError: err.Error()})
+
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.w.Write(append(data, '\n')); err != nil {
+		logging.MuxError(ctx, "error writing recording entry", map[string]interface{}{logging.KeyError: err.Error()})
+	}
+}
+
+// messageObject returns the JSON encoding of a message as a JSON value, with
+// numbers as json.Number.
+func messageObject(message proto.Message) (any, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var object any
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// recordingValueType returns the type which the DynamicValue of the field of
+// a request or response is decoded with, or nil if it is not decoded.
+func recordingValueType(schemas *serverSchemas, rpc string, typeName string, field string) tftypes.Type {
+	switch field {
+	case "identity_data":
+		return schemas.identityType(typeName)
+	case "include_resource_object":
+		return tftypes.Bool
+	case "limit":
+		return tftypes.Number
+	}
+
+	return schemaValueType(recordingSchema(schemas, rpc, typeName, field))
+}
+
+// recordingSchema returns the schema which the DynamicValue of the field of a
+// request or response is decoded with, or nil if it is not decoded with a
+// schema.
+func recordingSchema(schemas *serverSchemas, rpc string, typeName string, field string) *tfprotov6.Schema {
+	switch field {
+	case "identity_data", "include_resource_object", "limit", "raw_identity", "raw_state", "source_identity", "source_state":
+		return nil
+	case "provider_meta":
+		return schemas.providerMetaSchema()
+	}
+
+	switch rpc {
+	case "ConfigureProvider", "ValidateProviderConfig":
+		return schemas.providerConfigSchema()
+	case "ConfigureStateStore", "ValidateStateStoreConfig":
+		return schemas.stateStoreSchema(typeName)
+	case "InvokeAction", "PlanAction", "ValidateActionConfig":
+		return schemas.actionSchema(typeName)
+	case "ReadDataSource", "ValidateDataResourceConfig":
+		return schemas.dataSourceSchema(typeName)
+	case "CloseEphemeralResource", "OpenEphemeralResource", "RenewEphemeralResource", "ValidateEphemeralResourceConfig":
+		return schemas.ephemeralResourceSchema(typeName)
+	case "ListResource", "ValidateListResourceConfig":
+		if field == "resource_object" {
+			return schemas.resourceSchema(typeName)
+		}
+
+		return schemas.listResourceSchema(typeName)
+	case "ApplyResourceChange", "GenerateResourceConfig", "ImportResourceState", "MoveResourceState",
+		"PlanResourceChange", "ReadResource", "UpgradeResourceState", "ValidateResourceConfig":
+		return schemas.resourceSchema(typeName)
+	}
+
+	return nil
+}
+
+// decodeDynamicValues adds the decoded value to each DynamicValue in the JSON
+// encoding of a message, or the error decoding it. The type of each value is
+// returned by valueType for the name of the field containing it.
+func decodeDynamicValues(object any, field string, valueType func(field string) tftypes.Type) {
+	switch object := object.(type) {
+	case map[string]any:
+		if !isDynamicValue(object) {
+			for name, value := range object {
+				decodeDynamicValues(value, name, valueType)
+			}
+
+			return
+		}
+
+		typ := valueType(field)
+
+		if typ == nil {
+			return
+		}
+
+		value, err := decodeDynamicValue(object, typ)
+
+		if err != nil {
+			object["decode_error"] = err.Error()
+
+			return
+		}
+
+		object["decoded"] = tftypesjson.Value(value)
+	case []any:
+		for _, element := range object {
+			decodeDynamicValues(element, field, valueType)
+		}
+	}
+}
+
+// decodeDynamicValue decodes the JSON encoding of a DynamicValue.
+func decodeDynamicValue(object map[string]any, typ tftypes.Type) (tftypes.Value, error) {
+	dynamicValue := &tfprotov6.DynamicValue{}
+
+	for field, target := range map[string]*[]byte{"json": &dynamicValue.JSON, "msgpack": &dynamicValue.MsgPack} {
+		encoded, ok := object[field].(string)
+
+		if !ok {
+			continue
+		}
+
+		data, err := base64.StdEncoding.DecodeString(encoded)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		*target = data
+	}
+
+	return dynamicValue.Unmarshal(typ)
+}
+
+// isDynamicValue returns true if the JSON encoding of a message only
+// contains the fields of a DynamicValue. Fields with other messages
+// containing bytes, such as the RawState of UpgradeResourceState, have no
+// type and are not decoded.
+func isDynamicValue(object map[string]any) bool {
+	if len(object) == 0 {
+		return false
+	}
+
+	for field := range object {
+		if field != "json" && field != "msgpack" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// recordingMessage returns the Protocol Buffers message of a request or
+// response, or nil for other types such as streams.
+func recordingMessage(v any) proto.Message {
+	switch typed := v.(type) {
+	case *tfprotov6.ApplyResourceChangeRequest:
+		return tfprotov6toproto.ApplyResourceChangeRequest(typed)
+	case *tfprotov6.ApplyResourceChangeResponse:
+		return tfprotov6toproto.ApplyResourceChangeResponse(typed)
+	case *tfprotov6.CallFunctionRequest:
+		return tfprotov6toproto.CallFunctionRequest(typed)
+	case *tfprotov6.CallFunctionResponse:
+		return tfprotov6toproto.CallFunctionResponse(typed)
+	case *tfprotov6.CloseEphemeralResourceRequest:
+		return tfprotov6toproto.CloseEphemeralResourceRequest(typed)
+	case *tfprotov6.CloseEphemeralResourceResponse:
+		return tfprotov6toproto.CloseEphemeralResourceResponse(typed)
+	case *tfprotov6.ConfigureProviderRequest:
+		return tfprotov6toproto.ConfigureProviderRequest(typed)
+	case *tfprotov6.ConfigureProviderResponse:
+		return tfprotov6toproto.ConfigureProviderResponse(typed)
+	case *tfprotov6.ConfigureStateStoreRequest:
+		return tfprotov6toproto.ConfigureStateStoreRequest(typed)
+	case *tfprotov6.ConfigureStateStoreResponse:
+		return tfprotov6toproto.ConfigureStateStoreResponse(typed)
+	case *tfprotov6.DeleteStateRequest:
+		return tfprotov6toproto.DeleteStateRequest(typed)
+	case *tfprotov6.DeleteStateResponse:
+		return tfprotov6toproto.DeleteStateResponse(typed)
+	case *tfprotov6.GenerateResourceConfigRequest:
+		return tfprotov6toproto.GenerateResourceConfigRequest(typed)
+	case *tfprotov6.GenerateResourceConfigResponse:
+		return tfprotov6toproto.GenerateResourceConfigResponse(typed)
+	case *tfprotov6.GetFunctionsRequest:
+		return tfprotov6toproto.GetFunctionsRequest(typed)
+	case *tfprotov6.GetFunctionsResponse:
+		return tfprotov6toproto.GetFunctionsResponse(typed)
+	case *tfprotov6.GetMetadataRequest:
+		return tfprotov6toproto.GetMetadataRequest(typed)
+	case *tfprotov6.GetMetadataResponse:
+		return tfprotov6toproto.GetMetadataResponse(typed)
+	case *tfprotov6.GetProviderSchemaRequest:
+		return tfprotov6toproto.GetProviderSchemaRequest(typed)
+	case *tfprotov6.GetProviderSchemaResponse:
+		return tfprotov6toproto.GetProviderSchemaResponse(typed)
+	case *tfprotov6.GetResourceIdentitySchemasRequest:
+		return tfprotov6toproto.GetResourceIdentitySchemasRequest(typed)
+	case *tfprotov6.GetResourceIdentitySchemasResponse:
+		return tfprotov6toproto.GetResourceIdentitySchemasResponse(typed)
+	case *tfprotov6.GetStatesRequest:
+		return tfprotov6toproto.GetStatesRequest(typed)
+	case *tfprotov6.GetStatesResponse:
+		return tfprotov6toproto.GetStatesResponse(typed)
+	case *tfprotov6.ImportResourceStateRequest:
+		return tfprotov6toproto.ImportResourceStateRequest(typed)
+	case *tfprotov6.ImportResourceStateResponse:
+		return tfprotov6toproto.ImportResourceStateResponse(typed)
+	case *tfprotov6.InvokeActionRequest:
+		return tfprotov6toproto.InvokeActionRequest(typed)
+	case *tfprotov6.ListResourceRequest:
+		return tfprotov6toproto.ListResourceRequest(typed)
+	case *tfprotov6.LockStateRequest:
+		return tfprotov6toproto.LockStateRequest(typed)
+	case *tfprotov6.LockStateResponse:
+		return tfprotov6toproto.LockStateResponse(typed)
+	case *tfprotov6.MoveResourceStateRequest:
+		return tfprotov6toproto.MoveResourceStateRequest(typed)
+	case *tfprotov6.MoveResourceStateResponse:
+		return tfprotov6toproto.MoveResourceStateResponse(typed)
+	case *tfprotov6.OpenEphemeralResourceRequest:
+		return tfprotov6toproto.OpenEphemeralResourceRequest(typed)
+	case *tfprotov6.OpenEphemeralResourceResponse:
+		return tfprotov6toproto.OpenEphemeralResourceResponse(typed)
+	case *tfprotov6.PlanActionRequest:
+		return tfprotov6toproto.PlanActionRequest(typed)
+	case *tfprotov6.PlanActionResponse:
+		return tfprotov6toproto.PlanActionResponse(typed)
+	case *tfprotov6.PlanResourceChangeRequest:
+		return tfprotov6toproto.PlanResourceChangeRequest(typed)
+	case *tfprotov6.PlanResourceChangeResponse:
+		return tfprotov6toproto.PlanResourceChangeResponse(typed)
+	case *tfprotov6.ReadDataSourceRequest:
+		return tfprotov6toproto.ReadDataSourceRequest(typed)
+	case *tfprotov6.ReadDataSourceResponse:
+		return tfprotov6toproto.ReadDataSourceResponse(typed)
+	case *tfprotov6.ReadResourceRequest:
+		return tfprotov6toproto.ReadResourceRequest(typed)
+	case *tfprotov6.ReadResourceResponse:
+		return tfprotov6toproto.ReadResourceResponse(typed)
+	case *tfprotov6.ReadStateBytesRequest:
+		return tfprotov6toproto.ReadStateBytesRequest(typed)
+	case *tfprotov6.RenewEphemeralResourceRequest:
+		return tfprotov6toproto.RenewEphemeralResourceRequest(typed)
+	case *tfprotov6.RenewEphemeralResourceResponse:
+		return tfprotov6toproto.RenewEphemeralResourceResponse(typed)
+	case *tfprotov6.StopProviderRequest:
+		return tfprotov6toproto.StopProviderRequest(typed)
+	case *tfprotov6.StopProviderResponse:
+		return tfprotov6toproto.StopProviderResponse(typed)
+	case *tfprotov6.UnlockStateRequest:
+		return tfprotov6toproto.UnlockStateRequest(typed)
+	case *tfprotov6.UnlockStateResponse:
+		return tfprotov6toproto.UnlockStateResponse(typed)
+	case *tfprotov6.UpgradeResourceIdentityRequest:
+		return tfprotov6toproto.UpgradeResourceIdentityRequest(typed)
+	case *tfprotov6.UpgradeResourceIdentityResponse:
+		return tfprotov6toproto.UpgradeResourceIdentityResponse(typed)
+	case *tfprotov6.UpgradeResourceStateRequest:
+		return tfprotov6toproto.UpgradeResourceStateRequest(typed)
+	case *tfprotov6.UpgradeResourceStateResponse:
+		return tfprotov6toproto.UpgradeResourceStateResponse(typed)
+	case *tfprotov6.ValidateActionConfigRequest:
+		return tfprotov6toproto.ValidateActionConfigRequest(typed)
+	case *tfprotov6.ValidateActionConfigResponse:
+		return tfprotov6toproto.ValidateActionConfigResponse(typed)
+	case *tfprotov6.ValidateDataResourceConfigRequest:
+		return tfprotov6toproto.ValidateDataResourceConfigRequest(typed)
+	case *tfprotov6.ValidateDataResourceConfigResponse:
+		return tfprotov6toproto.ValidateDataResourceConfigResponse(typed)
+	case *tfprotov6.ValidateEphemeralResourceConfigRequest:
+		return tfprotov6toproto.ValidateEphemeralResourceConfigRequest(typed)
+	case *tfprotov6.ValidateEphemeralResourceConfigResponse:
+		return tfprotov6toproto.ValidateEphemeralResourceConfigResponse(typed)
+	case *tfprotov6.ValidateListResourceConfigRequest:
+		return tfprotov6toproto.ValidateListResourceConfigRequest(typed)
+	case *tfprotov6.ValidateListResourceConfigResponse:
+		return tfprotov6toproto.ValidateListResourceConfigResponse(typed)
+	case *tfprotov6.ValidateProviderConfigRequest:
+		return tfprotov6toproto.ValidateProviderConfigRequest(typed)
+	case *tfprotov6.ValidateProviderConfigResponse:
+		return tfprotov6toproto.ValidateProviderConfigResponse(typed)
+	case *tfprotov6.ValidateResourceConfigRequest:
+		return tfprotov6toproto.ValidateResourceConfigRequest(typed)
+	case *tfprotov6.ValidateResourceConfigResponse:
+		return tfprotov6toproto.ValidateResourceConfigResponse(typed)
+	case *tfprotov6.ValidateStateStoreConfigRequest:
+		return tfprotov6toproto.ValidateStateStoreConfigRequest(typed)
+	case *tfprotov6.ValidateStateStoreConfigResponse:
+		return tfprotov6toproto.ValidateStateStoreConfigResponse(typed)
+	case *tfprotov6.WriteStateBytesResponse:
+		return tfprotov6toproto.WriteStateBytesResponse(typed)
+	}
+
+	return nil
+}
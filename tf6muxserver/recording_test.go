@@ -0,0 +1,408 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6dynamicvalue"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+)
+
+// recordingTestType is the type of the test_resource schema of
+// newRecordingTestServer.
+var recordingTestType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"id":   tftypes.String,
+		"name": tftypes.String,
+	},
+}
+
+// newRecordingTestServer returns a test server which returns the values of
+// test_resource and the state of test_statestore, so they can be recorded and
+// replayed.
+func newRecordingTestServer() *tf6testserver.TestServer {
+	schema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:     "name",
+					Type:     tftypes.String,
+					Optional: true,
+				},
+			},
+		},
+	}
+
+	return &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			ListResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource": {
+					Block: &tfprotov6.SchemaBlock{},
+				},
+			},
+			ResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource": schema,
+			},
+			StateStoreSchemas: map[string]*tfprotov6.Schema{
+				"test_statestore": {
+					Block: &tfprotov6.SchemaBlock{},
+				},
+			},
+		},
+		ImportResourceStateFunc: func(_ context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+			return nil, status.Errorf(codes.NotFound, "test import error for %s", req.ID)
+		},
+		ListResourceFunc: func(_ context.Context, _ *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
+			return &tfprotov6.ListResourceServerStream{
+				Results: func(yield func(tfprotov6.ListResourceResult) bool) {
+					for _, id := range []string{"one", "two"} {
+						result := tfprotov6.ListResourceResult{
+							DisplayName: id,
+							Resource: tf6dynamicvalue.Must(recordingTestType, tftypes.NewValue(recordingTestType, map[string]tftypes.Value{
+								"id":   tftypes.NewValue(tftypes.String, id),
+								"name": tftypes.NewValue(tftypes.String, nil),
+							})),
+						}
+
+						if !yield(result) {
+							return
+						}
+					}
+				},
+			}, nil
+		},
+		ReadResourceFunc: func(_ context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+			return &tfprotov6.ReadResourceResponse{
+				NewState: tf6dynamicvalue.Must(recordingTestType, tftypes.NewValue(recordingTestType, map[string]tftypes.Value{
+					"id":   tftypes.NewValue(tftypes.String, "test-id"),
+					"name": tftypes.NewValue(tftypes.String, "read"),
+				})),
+				Private: req.Private,
+			}, nil
+		},
+		ReadStateBytesFunc: func(_ context.Context, _ *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+			return &tfprotov6.ReadStateBytesStream{
+				Chunks: func(yield func(tfprotov6.ReadStateByteChunk) bool) {
+					if !yield(tfprotov6.ReadStateByteChunk{
+						StateByteChunk: tfprotov6.StateByteChunk{
+							Bytes:       []byte(`{"version": 4}`),
+							TotalLength: 14,
+							Range: tfprotov6.StateByteRange{
+								Start: 0,
+								End:   13,
+							},
+						},
+					}) {
+						return
+					}
+
+					yield(tfprotov6.ReadStateByteChunk{
+						Diagnostics: []*tfprotov6.Diagnostic{
+							{
+								Severity: tfprotov6.DiagnosticSeverityWarning,
+								Summary:  "test read state bytes warning",
+							},
+						},
+					})
+				},
+			}, nil
+		},
+		WriteStateBytesFunc: func(_ context.Context, req *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
+			var length int
+
+			for chunk := range req.Chunks {
+				length += len(chunk.Bytes)
+			}
+
+			return &tfprotov6.WriteStateBytesResponse{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityWarning,
+						Summary:  fmt.Sprintf("test write state bytes warning for %d bytes", length),
+					},
+				},
+			}, nil
+		},
+	}
+}
+
+func TestWithRecording(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf6testserver.TestServer{}
+	testServer2 := newRecordingTestServer()
+
+	var recording bytes.Buffer
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer},
+		tf6muxserver.WithRecording(&recording),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+		CurrentState: tf6dynamicvalue.Must(recordingTestType, tftypes.NewValue(recordingTestType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "test-id"),
+			"name": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		})),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(recording.String()), "\n")
+
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 recorded calls, got: %s", recording.String())
+	}
+
+	var got map[string]any
+
+	if err := json.Unmarshal([]byte(lines[2]), &got); err != nil {
+		t.Fatalf("unexpected error decoding recording: %s", err)
+	}
+
+	expected := map[string]any{
+		"rpc":          "ReadResource",
+		"server":       "*tf6testserver.TestServer",
+		"server_index": float64(1),
+		"type_name":    "test_resource",
+		"request": map[string]any{
+			"type_name": "test_resource",
+			"current_state": map[string]any{
+				"msgpack": got["request"].(map[string]any)["current_state"].(map[string]any)["msgpack"], //nolint:forcetypeassert
+				"decoded": map[string]any{
+					"id":   "test-id",
+					"name": "<unknown>",
+				},
+			},
+		},
+		"response": map[string]any{
+			"new_state": map[string]any{
+				"msgpack": got["response"].(map[string]any)["new_state"].(map[string]any)["msgpack"], //nolint:forcetypeassert
+				"decoded": map[string]any{
+					"id":   "test-id",
+					"name": "read",
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected recording difference: %s", diff)
+	}
+}
+
+func TestNewReplayServers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer1 := &tf6testserver.TestServer{}
+	testServer2 := newRecordingTestServer()
+
+	var recording bytes.Buffer
+
+	recordedServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer1.ProviderServer, testServer2.ProviderServer},
+		tf6muxserver.WithRecording(&recording),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	expected := runReplayTestCalls(ctx, t, recordedServer.ProviderServer())
+
+	replayServers, err := tf6muxserver.NewReplayServers(&recording)
+
+	if err != nil {
+		t.Fatalf("unexpected error reading recording: %s", err)
+	}
+
+	if len(replayServers) != 2 {
+		t.Fatalf("expected 2 replay servers, got %d", len(replayServers))
+	}
+
+	replayedServer, err := tf6muxserver.NewMuxServer(ctx, replayServers...)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up replay muxer: %s", err)
+	}
+
+	got := runReplayTestCalls(ctx, t, replayedServer.ProviderServer())
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected replay difference: %s", diff)
+	}
+}
+
+// replayTestResults are the results of runReplayTestCalls.
+type replayTestResults struct {
+	ImportResourceStateError string
+	ListResourceResults      []tfprotov6.ListResourceResult
+	ReadResource             *tfprotov6.ReadResourceResponse
+	ReadStateBytesChunks     []tfprotov6.ReadStateByteChunk
+	WriteStateBytes          *tfprotov6.WriteStateBytesResponse
+}
+
+// runReplayTestCalls calls the server with the RPCs of TestNewReplayServers.
+func runReplayTestCalls(ctx context.Context, t *testing.T, server tfprotov6.ProviderServer) replayTestResults {
+	t.Helper()
+
+	var results replayTestResults
+
+	_, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected GetProviderSchema error: %s", err)
+	}
+
+	results.ReadResource, err = server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "test_resource",
+		Private:  []byte(`{"private":true}`),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected ReadResource error: %s", err)
+	}
+
+	listResp, err := server.(tfprotov6.ProviderServerWithListResource).ListResource(ctx, &tfprotov6.ListResourceRequest{ //nolint:staticcheck
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected ListResource error: %s", err)
+	}
+
+	for result := range listResp.Results {
+		results.ListResourceResults = append(results.ListResourceResults, result)
+	}
+
+	_, err = server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: "test_resource",
+		ID:       "missing",
+	})
+
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected ImportResourceState not found error, got: %s", err)
+	}
+
+	results.ImportResourceStateError = err.Error()
+
+	stateStoreServer, ok := server.(tfprotov6.StateStoreServer)
+
+	if !ok {
+		t.Fatal("expected server to implement StateStoreServer")
+	}
+
+	readStateBytesResp, err := stateStoreServer.ReadStateBytes(ctx, &tfprotov6.ReadStateBytesRequest{
+		TypeName: "test_statestore",
+		StateID:  "test_state_id",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected ReadStateBytes error: %s", err)
+	}
+
+	for chunk := range readStateBytesResp.Chunks {
+		results.ReadStateBytesChunks = append(results.ReadStateBytesChunks, chunk)
+	}
+
+	results.WriteStateBytes, err = stateStoreServer.WriteStateBytes(ctx, writeStateBytesStream("test_statestore"))
+
+	if err != nil {
+		t.Fatalf("unexpected WriteStateBytes error: %s", err)
+	}
+
+	return results
+}
+
+func TestWithRecording_WriteStateBytes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testServer := newRecordingTestServer()
+
+	var recording bytes.Buffer
+
+	muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+		ctx,
+		[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+		tf6muxserver.WithRecording(&recording),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error setting up muxer: %s", err)
+	}
+
+	_, err = muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	stateStoreServer, ok := muxServer.ProviderServer().(tfprotov6.StateStoreServer)
+
+	if !ok {
+		t.Fatal("expected mux server to implement StateStoreServer")
+	}
+
+	_, err = stateStoreServer.WriteStateBytes(ctx, writeStateBytesStream("test_statestore"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(recording.String()), "\n")
+
+	var got struct {
+		RPC           string            `json:"rpc"`
+		TypeName      string            `json:"type_name"`
+		RequestStream []json.RawMessage `json:"request_stream"`
+	}
+
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &got); err != nil {
+		t.Fatalf("unexpected error decoding recording: %s", err)
+	}
+
+	if got.RPC != "WriteStateBytes" || got.TypeName != "test_statestore" {
+		t.Fatalf("expected WriteStateBytes call for test_statestore, got: %s", lines[len(lines)-1])
+	}
+
+	if len(got.RequestStream) != 2 {
+		t.Errorf("expected 2 recorded request chunks, got: %d", len(got.RequestStream))
+	}
+}
@@ -0,0 +1,428 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin6"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6fromproto"
+)
+
+var _ tfprotov6.ProviderServer = &replayServer{}
+
+// NewReplayServers returns servers which serve the responses of a recording
+// written by WithRecording, such as to reproduce an issue in a unit test
+// without the credentials of the provider. One server is returned for each
+// underlying server of the recording, in the same order, so combining them
+// with NewMuxServer routes requests as they were recorded.
+//
+// Each call is served the next response recorded for the RPC, underlying
+// server, and type name of the request, regardless of the other request
+// fields. Once all of those responses have been served, the last one is
+// served again. RPCs without recorded responses return the gRPC
+// unimplemented error.
+func NewReplayServers(r io.Reader) ([]func() tfprotov6.ProviderServer, error) {
+	var servers []*replayServer
+
+	decoder := json.NewDecoder(r)
+
+	for {
+		var entry recordingEntry
+
+		err := decoder.Decode(&entry)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading recording: %w", err)
+		}
+
+		if entry.ServerIndex < 0 {
+			return nil, fmt.Errorf("error reading recording: %s call has invalid server index %d", entry.RPC, entry.ServerIndex)
+		}
+
+		for len(servers) <= entry.ServerIndex {
+			servers = append(servers, &replayServer{
+				entries: make(map[replayKey][]recordingEntry),
+				served:  make(map[replayKey]int),
+			})
+		}
+
+		key := replayKey{rpc: entry.RPC, typeName: entry.TypeName}
+		servers[entry.ServerIndex].entries[key] = append(servers[entry.ServerIndex].entries[key], entry)
+	}
+
+	result := make([]func() tfprotov6.ProviderServer, 0, len(servers))
+
+	for _, server := range servers {
+		result = append(result, server.ProviderServer)
+	}
+
+	return result, nil
+}
+
+// replayKey is the RPC and type name which recorded responses are served
+// for.
+type replayKey struct {
+	rpc      string
+	typeName string
+}
+
+// replayServer is a tfprotov6.ProviderServer which serves the responses of a
+// recording. It should always be instantiated by calling NewReplayServers().
+type replayServer struct {
+	mu      sync.Mutex
+	entries map[replayKey][]recordingEntry
+	served  map[replayKey]int
+}
+
+// ProviderServer is a function compatible with tf6server.Serve.
+func (s *replayServer) ProviderServer() tfprotov6.ProviderServer {
+	return s
+}
+
+// next returns the next recorded call of the RPC and type name.
+func (s *replayServer) next(rpc string, typeName string) (recordingEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := replayKey{rpc: rpc, typeName: typeName}
+	entries := s.entries[key]
+
+	if len(entries) == 0 {
+		return recordingEntry{}, status.Errorf(codes.Unimplemented, "no recorded %s response for %q", rpc, typeName)
+	}
+
+	index := min(s.served[key], len(entries)-1)
+	s.served[key]++
+
+	return entries[index], nil
+}
+
+// replayResponse returns the next recorded response of the RPC and type name
+// and the recorded error, if any. The response is nil if the recorded
+// response was nil.
+func replayResponse[M proto.Message](s *replayServer, rpc string, typeName string, message M) (M, error) {
+	var none M
+
+	entry, err := s.next(rpc, typeName)
+
+	if err != nil {
+		return none, err
+	}
+
+	if entry.Response == nil {
+		return none, entry.err()
+	}
+
+	// The decoded values of DynamicValues are discarded, as only the
+	// encoded values are part of the message.
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(entry.Response, message); err != nil {
+		return none, fmt.Errorf("error replaying %s response: %w", rpc, err)
+	}
+
+	return message, entry.err()
+}
+
+// replayStream returns the next recorded stream of the RPC and type name, or
+// the recorded error.
+func replayStream[M proto.Message, T any](s *replayServer, rpc string, typeName string, newMessage func() M, convert func(M) T) (iter.Seq[T], error) {
+	entry, err := s.next(rpc, typeName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := entry.err(); err != nil {
+		return nil, err
+	}
+
+	elements := make([]T, 0, len(entry.ResponseStream))
+
+	for _, data := range entry.ResponseStream {
+		message := newMessage()
+
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, message); err != nil {
+			return nil, fmt.Errorf("error replaying %s response: %w", rpc, err)
+		}
+
+		elements = append(elements, convert(message))
+	}
+
+	return slices.Values(elements), nil
+}
+
+func (s *replayServer) ApplyResourceChange(_ context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	resp, err := replayResponse(s, "ApplyResourceChange", req.TypeName, &tfplugin6.ApplyResourceChange_Response{})
+
+	return tfprotov6fromproto.ApplyResourceChangeResponse(resp), err
+}
+
+func (s *replayServer) CallFunction(_ context.Context, req *tfprotov6.CallFunctionRequest) (*tfprotov6.CallFunctionResponse, error) {
+	resp, err := replayResponse(s, "CallFunction", req.Name, &tfplugin6.CallFunction_Response{})
+
+	return tfprotov6fromproto.CallFunctionResponse(resp), err
+}
+
+func (s *replayServer) CloseEphemeralResource(_ context.Context, req *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
+	resp, err := replayResponse(s, "CloseEphemeralResource", req.TypeName, &tfplugin6.CloseEphemeralResource_Response{})
+
+	return tfprotov6fromproto.CloseEphemeralResourceResponse(resp), err
+}
+
+func (s *replayServer) ConfigureProvider(_ context.Context, _ *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	resp, err := replayResponse(s, "ConfigureProvider", "", &tfplugin6.ConfigureProvider_Response{})
+
+	return tfprotov6fromproto.ConfigureProviderResponse(resp), err
+}
+
+func (s *replayServer) ConfigureStateStore(_ context.Context, req *tfprotov6.ConfigureStateStoreRequest) (*tfprotov6.ConfigureStateStoreResponse, error) {
+	resp, err := replayResponse(s, "ConfigureStateStore", req.TypeName, &tfplugin6.ConfigureStateStore_Response{})
+
+	return tfprotov6fromproto.ConfigureStateStoreResponse(resp), err
+}
+
+func (s *replayServer) DeleteState(_ context.Context, req *tfprotov6.DeleteStateRequest) (*tfprotov6.DeleteStateResponse, error) {
+	resp, err := replayResponse(s, "DeleteState", req.TypeName, &tfplugin6.DeleteState_Response{})
+
+	return tfprotov6fromproto.DeleteStateResponse(resp), err
+}
+
+func (s *replayServer) GenerateResourceConfig(_ context.Context, req *tfprotov6.GenerateResourceConfigRequest) (*tfprotov6.GenerateResourceConfigResponse, error) {
+	resp, err := replayResponse(s, "GenerateResourceConfig", req.TypeName, &tfplugin6.GenerateResourceConfig_Response{})
+
+	return tfprotov6fromproto.GenerateResourceConfigResponse(resp), err
+}
+
+func (s *replayServer) GetFunctions(_ context.Context, _ *tfprotov6.GetFunctionsRequest) (*tfprotov6.GetFunctionsResponse, error) {
+	resp, err := replayResponse(s, "GetFunctions", "", &tfplugin6.GetFunctions_Response{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.GetFunctionsResponse(resp)
+}
+
+func (s *replayServer) GetMetadata(_ context.Context, _ *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
+	resp, err := replayResponse(s, "GetMetadata", "", &tfplugin6.GetMetadata_Response{})
+
+	return tfprotov6fromproto.GetMetadataResponse(resp), err
+}
+
+func (s *replayServer) GetProviderSchema(_ context.Context, _ *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	resp, err := replayResponse(s, "GetProviderSchema", "", &tfplugin6.GetProviderSchema_Response{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.GetProviderSchemaResponse(resp)
+}
+
+func (s *replayServer) GetResourceIdentitySchemas(_ context.Context, _ *tfprotov6.GetResourceIdentitySchemasRequest) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
+	resp, err := replayResponse(s, "GetResourceIdentitySchemas", "", &tfplugin6.GetResourceIdentitySchemas_Response{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.GetResourceIdentitySchemasResponse(resp)
+}
+
+func (s *replayServer) GetStates(_ context.Context, req *tfprotov6.GetStatesRequest) (*tfprotov6.GetStatesResponse, error) {
+	resp, err := replayResponse(s, "GetStates", req.TypeName, &tfplugin6.GetStates_Response{})
+
+	return tfprotov6fromproto.GetStatesResponse(resp), err
+}
+
+func (s *replayServer) ImportResourceState(_ context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	resp, err := replayResponse(s, "ImportResourceState", req.TypeName, &tfplugin6.ImportResourceState_Response{})
+
+	return tfprotov6fromproto.ImportResourceStateResponse(resp), err
+}
+
+func (s *replayServer) InvokeAction(_ context.Context, req *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
+	events, err := replayStream(s, "InvokeAction", req.ActionType, func() *tfplugin6.InvokeAction_Event { return &tfplugin6.InvokeAction_Event{} }, tfprotov6fromproto.InvokeActionEvent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.InvokeActionServerStream{
+		Events: events,
+	}, nil
+}
+
+func (s *replayServer) ListResource(_ context.Context, req *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
+	results, err := replayStream(s, "ListResource", req.TypeName, func() *tfplugin6.ListResource_Event { return &tfplugin6.ListResource_Event{} }, tfprotov6fromproto.ListResourceResult)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.ListResourceServerStream{
+		Results: results,
+	}, nil
+}
+
+func (s *replayServer) LockState(_ context.Context, req *tfprotov6.LockStateRequest) (*tfprotov6.LockStateResponse, error) {
+	resp, err := replayResponse(s, "LockState", req.TypeName, &tfplugin6.LockState_Response{})
+
+	return tfprotov6fromproto.LockStateResponse(resp), err
+}
+
+func (s *replayServer) MoveResourceState(_ context.Context, req *tfprotov6.MoveResourceStateRequest) (*tfprotov6.MoveResourceStateResponse, error) {
+	resp, err := replayResponse(s, "MoveResourceState", req.TargetTypeName, &tfplugin6.MoveResourceState_Response{})
+
+	return tfprotov6fromproto.MoveResourceStateResponse(resp), err
+}
+
+func (s *replayServer) OpenEphemeralResource(_ context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	resp, err := replayResponse(s, "OpenEphemeralResource", req.TypeName, &tfplugin6.OpenEphemeralResource_Response{})
+
+	return tfprotov6fromproto.OpenEphemeralResourceResponse(resp), err
+}
+
+func (s *replayServer) PlanAction(_ context.Context, req *tfprotov6.PlanActionRequest) (*tfprotov6.PlanActionResponse, error) {
+	resp, err := replayResponse(s, "PlanAction", req.ActionType, &tfplugin6.PlanAction_Response{})
+
+	return tfprotov6fromproto.PlanActionResponse(resp), err
+}
+
+func (s *replayServer) PlanResourceChange(_ context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	resp, err := replayResponse(s, "PlanResourceChange", req.TypeName, &tfplugin6.PlanResourceChange_Response{})
+
+	return tfprotov6fromproto.PlanResourceChangeResponse(resp), err
+}
+
+func (s *replayServer) ReadDataSource(_ context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	resp, err := replayResponse(s, "ReadDataSource", req.TypeName, &tfplugin6.ReadDataSource_Response{})
+
+	return tfprotov6fromproto.ReadDataSourceResponse(resp), err
+}
+
+func (s *replayServer) ReadResource(_ context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	resp, err := replayResponse(s, "ReadResource", req.TypeName, &tfplugin6.ReadResource_Response{})
+
+	return tfprotov6fromproto.ReadResourceResponse(resp), err
+}
+
+func (s *replayServer) ReadStateBytes(_ context.Context, req *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+	chunks, err := replayStream(s, "ReadStateBytes", req.TypeName, func() *tfplugin6.ReadStateBytes_ResponseChunk { return &tfplugin6.ReadStateBytes_ResponseChunk{} }, tfprotov6fromproto.ReadStateBytesChunk)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.ReadStateBytesStream{
+		Chunks: chunks,
+	}, nil
+}
+
+func (s *replayServer) RenewEphemeralResource(_ context.Context, req *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+	resp, err := replayResponse(s, "RenewEphemeralResource", req.TypeName, &tfplugin6.RenewEphemeralResource_Response{})
+
+	return tfprotov6fromproto.RenewEphemeralResourceResponse(resp), err
+}
+
+func (s *replayServer) StopProvider(_ context.Context, _ *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error) {
+	resp, err := replayResponse(s, "StopProvider", "", &tfplugin6.StopProvider_Response{})
+
+	return tfprotov6fromproto.StopProviderResponse(resp), err
+}
+
+func (s *replayServer) UnlockState(_ context.Context, req *tfprotov6.UnlockStateRequest) (*tfprotov6.UnlockStateResponse, error) {
+	resp, err := replayResponse(s, "UnlockState", req.TypeName, &tfplugin6.UnlockState_Response{})
+
+	return tfprotov6fromproto.UnlockStateResponse(resp), err
+}
+
+func (s *replayServer) UpgradeResourceIdentity(_ context.Context, req *tfprotov6.UpgradeResourceIdentityRequest) (*tfprotov6.UpgradeResourceIdentityResponse, error) {
+	resp, err := replayResponse(s, "UpgradeResourceIdentity", req.TypeName, &tfplugin6.UpgradeResourceIdentity_Response{})
+
+	return tfprotov6fromproto.UpgradeResourceIdentityResponse(resp), err
+}
+
+func (s *replayServer) UpgradeResourceState(_ context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
+	resp, err := replayResponse(s, "UpgradeResourceState", req.TypeName, &tfplugin6.UpgradeResourceState_Response{})
+
+	return tfprotov6fromproto.UpgradeResourceStateResponse(resp), err
+}
+
+func (s *replayServer) ValidateActionConfig(_ context.Context, req *tfprotov6.ValidateActionConfigRequest) (*tfprotov6.ValidateActionConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateActionConfig", req.ActionType, &tfplugin6.ValidateActionConfig_Response{})
+
+	return tfprotov6fromproto.ValidateActionConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateDataResourceConfig(_ context.Context, req *tfprotov6.ValidateDataResourceConfigRequest) (*tfprotov6.ValidateDataResourceConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateDataResourceConfig", req.TypeName, &tfplugin6.ValidateDataResourceConfig_Response{})
+
+	return tfprotov6fromproto.ValidateDataResourceConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateEphemeralResourceConfig(_ context.Context, req *tfprotov6.ValidateEphemeralResourceConfigRequest) (*tfprotov6.ValidateEphemeralResourceConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateEphemeralResourceConfig", req.TypeName, &tfplugin6.ValidateEphemeralResourceConfig_Response{})
+
+	return tfprotov6fromproto.ValidateEphemeralResourceConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateListResourceConfig(_ context.Context, req *tfprotov6.ValidateListResourceConfigRequest) (*tfprotov6.ValidateListResourceConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateListResourceConfig", req.TypeName, &tfplugin6.ValidateListResourceConfig_Response{})
+
+	return tfprotov6fromproto.ValidateListResourceConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateProviderConfig(_ context.Context, _ *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateProviderConfig", "", &tfplugin6.ValidateProviderConfig_Response{})
+
+	return tfprotov6fromproto.ValidateProviderConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateResourceConfig(_ context.Context, req *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateResourceConfig", req.TypeName, &tfplugin6.ValidateResourceConfig_Response{})
+
+	return tfprotov6fromproto.ValidateResourceConfigResponse(resp), err
+}
+
+func (s *replayServer) ValidateStateStoreConfig(_ context.Context, req *tfprotov6.ValidateStateStoreConfigRequest) (*tfprotov6.ValidateStateStoreConfigResponse, error) {
+	resp, err := replayResponse(s, "ValidateStateStoreConfig", req.TypeName, &tfplugin6.ValidateStateStoreConfig_Response{})
+
+	return tfprotov6fromproto.ValidateStateStoreConfigResponse(resp), err
+}
+
+func (s *replayServer) WriteStateBytes(_ context.Context, req *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
+	var typeName string
+
+	// The request stream is consumed as it would be by an underlying server,
+	// with the type name from the metadata of the first chunk.
+	if req != nil && req.Chunks != nil {
+		for chunk := range req.Chunks {
+			if chunk != nil && chunk.Meta != nil && typeName == "" {
+				typeName = chunk.Meta.TypeName
+			}
+		}
+	}
+
+	resp, err := replayResponse(s, "WriteStateBytes", typeName, &tfplugin6.WriteStateBytes_Response{})
+
+	return tfprotov6fromproto.WriteStateBytesResponse(resp), err
+}
@@ -0,0 +1,176 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6dynamicvalue"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+)
+
+// newValidationTestServer returns a test server with the schemas, which
+// returns the new state of test_resource in ApplyResourceChange,
+// PlanResourceChange and ReadResource responses.
+func newValidationTestServer(getProviderSchemaResponse *tfprotov6.GetProviderSchemaResponse, newState *tfprotov6.DynamicValue) *tf6testserver.TestServer {
+	return &tf6testserver.TestServer{
+		GetProviderSchemaResponse: getProviderSchemaResponse,
+		ApplyResourceChangeFunc: func(_ context.Context, _ *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+			return &tfprotov6.ApplyResourceChangeResponse{
+				NewState: newState,
+			}, nil
+		},
+		PlanResourceChangeFunc: func(_ context.Context, _ *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+			return &tfprotov6.PlanResourceChangeResponse{
+				PlannedState: newState,
+			}, nil
+		},
+		ReadResourceFunc: func(_ context.Context, _ *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+			return &tfprotov6.ReadResourceResponse{
+				NewState: newState,
+			}, nil
+		},
+	}
+}
+
+func TestWithResponseValidation(t *testing.T) {
+	t.Parallel()
+
+	schemaType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":   tftypes.String,
+			"name": tftypes.String,
+		},
+	}
+	missingAttributeType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id": tftypes.String,
+		},
+	}
+	unknownState := tf6dynamicvalue.Must(schemaType, tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "test-id"),
+		"name": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	}))
+
+	testCases := map[string]struct {
+		newState *tfprotov6.DynamicValue
+		call     func(context.Context, tfprotov6.ProviderServer) ([]*tfprotov6.Diagnostic, error)
+		expected []*tfprotov6.Diagnostic
+	}{
+		"ApplyResourceChange-valid": {
+			newState: tf6dynamicvalue.Must(schemaType, tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":   tftypes.NewValue(tftypes.String, "test-id"),
+				"name": tftypes.NewValue(tftypes.String, nil),
+			})),
+			call: func(ctx context.Context, server tfprotov6.ProviderServer) ([]*tfprotov6.Diagnostic, error) {
+				resp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{TypeName: "test_resource"})
+
+				return resp.Diagnostics, err
+			},
+		},
+		"ApplyResourceChange-unknown": {
+			newState: unknownState,
+			call: func(ctx context.Context, server tfprotov6.ProviderServer) ([]*tfprotov6.Diagnostic, error) {
+				resp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{TypeName: "test_resource"})
+
+				return resp.Diagnostics, err
+			},
+			expected: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Invalid Provider Server Response",
+					Detail: `An underlying provider server returned an invalid NewState value for "test_resource" in the ApplyResourceChange RPC response. ` +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf6testserver.TestServer\n" +
+						`Error: unknown value at AttributeName("name"), which must be known in this response`,
+				},
+			},
+		},
+		"PlanResourceChange-unknown": {
+			newState: unknownState,
+			call: func(ctx context.Context, server tfprotov6.ProviderServer) ([]*tfprotov6.Diagnostic, error) {
+				resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{TypeName: "test_resource"})
+
+				return resp.Diagnostics, err
+			},
+		},
+		"ReadResource-missing-attribute": {
+			newState: tf6dynamicvalue.Must(missingAttributeType, tftypes.NewValue(missingAttributeType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "test-id"),
+			})),
+			call: func(ctx context.Context, server tfprotov6.ProviderServer) ([]*tfprotov6.Diagnostic, error) {
+				resp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{TypeName: "test_resource"})
+
+				return resp.Diagnostics, err
+			},
+			expected: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Invalid Provider Server Response",
+					Detail: `An underlying provider server returned an invalid NewState value for "test_resource" in the ReadResource RPC response. ` +
+						"This is always an issue in the provider implementation and should be reported to the provider developers.\n\n" +
+						"Underlying server: *tf6testserver.TestServer\n" +
+						"Error: error decoding object; expected 2 attributes, got 1",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			testServer := newValidationTestServer(
+				&tfprotov6.GetProviderSchemaResponse{
+					ResourceSchemas: map[string]*tfprotov6.Schema{
+						"test_resource": {
+							Block: &tfprotov6.SchemaBlock{
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "id",
+										Type:     tftypes.String,
+										Computed: true,
+									},
+									{
+										Name:     "name",
+										Type:     tftypes.String,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+				testCase.newState,
+			)
+
+			muxServer, err := tf6muxserver.NewMuxServerWithOptions(
+				ctx,
+				[]func() tfprotov6.ProviderServer{testServer.ProviderServer},
+				tf6muxserver.WithResponseValidation(),
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error setting up muxer: %s", err)
+			}
+
+			got, err := testCase.call(ctx, muxServer.ProviderServer())
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
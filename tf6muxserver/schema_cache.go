@@ -0,0 +1,215 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// schemaCache contains the schemas of each underlying server, for options
+// which decode the DynamicValues of requests and responses.
+type schemaCache struct {
+	mu sync.Mutex
+
+	// schemas are stored by the index of the underlying server in the
+	// servers of the mux server.
+	schemas map[int]*serverSchemas
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{
+		schemas: make(map[int]*serverSchemas),
+	}
+}
+
+// get returns the schemas of the underlying server of the call, calling its
+// GetProviderSchema and GetResourceIdentitySchemas RPCs without interceptors
+// for any which are not yet known. The known schemas are returned with any
+// error.
+func (c *schemaCache) get(ctx context.Context, info *CallInfo) (*serverSchemas, error) {
+	server := info.Server
+	schemas := c.lookup(info.serverIndex)
+
+	if schemas == nil || schemas.providerSchema == nil {
+		resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+		if err != nil {
+			return schemas, fmt.Errorf("error calling GetProviderSchema for %T: %w", server, err)
+		}
+
+		c.observe(info.serverIndex, resp)
+	}
+
+	schemas = c.lookup(info.serverIndex)
+
+	if schemas == nil || schemas.identitySchemas == nil {
+		resp, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+
+		if err != nil {
+			return schemas, fmt.Errorf("error calling GetResourceIdentitySchemas for %T: %w", server, err)
+		}
+
+		c.observe(info.serverIndex, resp)
+	}
+
+	return c.lookup(info.serverIndex), nil
+}
+
+// lookup returns the known schemas of the underlying server, without calling
+// it, or nil if there are none.
+func (c *schemaCache) lookup(serverIndex int) *serverSchemas {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.schemas[serverIndex]
+}
+
+// observe stores the schemas of a GetProviderSchema or
+// GetResourceIdentitySchemas response of the underlying server. Other
+// responses are ignored.
+func (c *schemaCache) observe(serverIndex int, resp any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The schemas are replaced rather than modified, as they are read
+	// without holding the lock.
+	schemas := &serverSchemas{}
+
+	if existing, ok := c.schemas[serverIndex]; ok {
+		*schemas = *existing
+	}
+
+	switch typedResp := resp.(type) {
+	case *tfprotov6.GetProviderSchemaResponse:
+		if typedResp == nil {
+			return
+		}
+
+		schemas.providerSchema = typedResp
+	case *tfprotov6.GetResourceIdentitySchemasResponse:
+		if typedResp == nil {
+			return
+		}
+
+		schemas.identitySchemas = typedResp
+	default:
+		return
+	}
+
+	c.schemas[serverIndex] = schemas
+}
+
+// serverSchemas are the schemas of an underlying server. The schema and value
+// type methods return nil for unknown types.
+type serverSchemas struct {
+	identitySchemas *tfprotov6.GetResourceIdentitySchemasResponse
+	providerSchema  *tfprotov6.GetProviderSchemaResponse
+}
+
+func (s *serverSchemas) actionSchema(actionType string) *tfprotov6.Schema {
+	if s == nil || s.providerSchema == nil || s.providerSchema.ActionSchemas[actionType] == nil {
+		return nil
+	}
+
+	return s.providerSchema.ActionSchemas[actionType].Schema
+}
+
+func (s *serverSchemas) dataSourceSchema(typeName string) *tfprotov6.Schema {
+	if s == nil || s.providerSchema == nil {
+		return nil
+	}
+
+	return s.providerSchema.DataSourceSchemas[typeName]
+}
+
+func (s *serverSchemas) dataSourceType(typeName string) tftypes.Type {
+	return schemaValueType(s.dataSourceSchema(typeName))
+}
+
+func (s *serverSchemas) ephemeralResourceSchema(typeName string) *tfprotov6.Schema {
+	if s == nil || s.providerSchema == nil {
+		return nil
+	}
+
+	return s.providerSchema.EphemeralResourceSchemas[typeName]
+}
+
+func (s *serverSchemas) ephemeralResourceType(typeName string) tftypes.Type {
+	return schemaValueType(s.ephemeralResourceSchema(typeName))
+}
+
+func (s *serverSchemas) functionReturnType(name string) tftypes.Type {
+	if s == nil || s.providerSchema == nil || s.providerSchema.Functions[name] == nil || s.providerSchema.Functions[name].Return == nil {
+		return nil
+	}
+
+	return s.providerSchema.Functions[name].Return.Type
+}
+
+func (s *serverSchemas) identityType(typeName string) tftypes.Type {
+	if s == nil || s.identitySchemas == nil || s.identitySchemas.IdentitySchemas[typeName] == nil {
+		return nil
+	}
+
+	return s.identitySchemas.IdentitySchemas[typeName].ValueType()
+}
+
+func (s *serverSchemas) listResourceSchema(typeName string) *tfprotov6.Schema {
+	if s == nil || s.providerSchema == nil {
+		return nil
+	}
+
+	return s.providerSchema.ListResourceSchemas[typeName]
+}
+
+func (s *serverSchemas) providerConfigSchema() *tfprotov6.Schema {
+	if s == nil || s.providerSchema == nil {
+		return nil
+	}
+
+	return s.providerSchema.Provider
+}
+
+func (s *serverSchemas) providerMetaSchema() *tfprotov6.Schema {
+	if s == nil || s.providerSchema == nil {
+		return nil
+	}
+
+	return s.providerSchema.ProviderMeta
+}
+
+func (s *serverSchemas) resourceSchema(typeName string) *tfprotov6.Schema {
+	if s == nil || s.providerSchema == nil {
+		return nil
+	}
+
+	return s.providerSchema.ResourceSchemas[typeName]
+}
+
+func (s *serverSchemas) resourceType(typeName string) tftypes.Type {
+	return schemaValueType(s.resourceSchema(typeName))
+}
+
+func (s *serverSchemas) stateStoreSchema(typeName string) *tfprotov6.Schema {
+	if s == nil || s.providerSchema == nil {
+		return nil
+	}
+
+	return s.providerSchema.StateStoreSchemas[typeName]
+}
+
+// schemaValueType returns the value type of the schema, or nil if the schema
+// is nil.
+func schemaValueType(schema *tfprotov6.Schema) tftypes.Type {
+	if schema == nil {
+		return nil
+	}
+
+	return schema.ValueType()
+}
@@ -0,0 +1,181 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6muxserver
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/logging"
+	"github.com/hashicorp/terraform-plugin-mux/muxtrace"
+)
+
+// WithTracer is a NewMuxServerWithOptions option which creates spans with the
+// tracer for each RPC, the discovery of each underlying server, each call to
+// an underlying server, and each element of streams. Refer to the muxtrace
+// package documentation for the spans and their attributes.
+//
+// Calls are traced after any interceptors from WithInterceptors, so calls
+// which are handled by an interceptor without calling the underlying server
+// are only part of the RPC span.
+func WithTracer(tracer muxtrace.Tracer) MuxServerOption {
+	return func(o *muxServerOptions) {
+		o.tracer = tracer
+	}
+}
+
+// startDiscoverySpan starts the span of the discovery of an underlying server.
+// The span does nothing if tracing is not enabled.
+func (s *muxServer) startDiscoverySpan(ctx context.Context, server tfprotov6.ProviderServer) (context.Context, muxtrace.Span) {
+	return muxtrace.Start(ctx, s.tracer, "mux.Discover", muxtrace.String(logging.KeyTfMuxProvider, fmt.Sprintf("%T", server)))
+}
+
+// startRPCSpan starts the span of a combined server RPC. The span does nothing
+// if tracing is not enabled.
+func (s *muxServer) startRPCSpan(ctx context.Context, rpc string) (context.Context, muxtrace.Span) {
+	return muxtrace.Start(ctx, s.tracer, "mux."+rpc, muxtrace.String(logging.KeyTfRpc, rpc))
+}
+
+// traceCalls returns the Interceptor added by WithTracer.
+func traceCalls(tracer muxtrace.Tracer) Interceptor {
+	return func(ctx context.Context, info *CallInfo, req any, handler Handler) (any, error) {
+		attributes := []muxtrace.Attribute{
+			muxtrace.String(logging.KeyTfRpc, info.RPC),
+			muxtrace.String(logging.KeyTfMuxProvider, fmt.Sprintf("%T", info.Server)),
+		}
+
+		if info.TypeName != "" {
+			attributes = append(attributes, muxtrace.String(muxtrace.AttributeKeyTypeName, info.TypeName))
+		}
+
+		ctx, span := muxtrace.Start(ctx, tracer, "downstream."+info.RPC, attributes...)
+
+		// The WriteStateBytes request is a stream of chunks from Terraform,
+		// which are traced as they are consumed by the underlying server.
+		if typedReq, ok := req.(*tfprotov6.WriteStateBytesStream); ok && typedReq != nil && typedReq.Chunks != nil {
+			tracedReq := *typedReq
+			tracedReq.Chunks = traceWriteStateBytesChunks(ctx, tracer, info, typedReq.Chunks)
+			req = &tracedReq
+		}
+
+		resp, err := handler(ctx, req)
+
+		if err != nil {
+			span.SetAttributes(muxtrace.String(muxtrace.AttributeKeyError, err.Error()))
+		}
+
+		switch typedResp := resp.(type) {
+		case *tfprotov6.InvokeActionServerStream:
+			if typedResp != nil && typedResp.Events != nil {
+				typedResp.Events = traceStream(ctx, tracer, info, span, typedResp.Events, invokeActionEventDiagnostics)
+
+				return resp, err
+			}
+		case *tfprotov6.ListResourceServerStream:
+			if typedResp != nil && typedResp.Results != nil {
+				typedResp.Results = traceStream(ctx, tracer, info, span, typedResp.Results, func(result tfprotov6.ListResourceResult) []*tfprotov6.Diagnostic {
+					return result.Diagnostics
+				})
+
+				return resp, err
+			}
+		case *tfprotov6.ReadStateBytesStream:
+			if typedResp != nil && typedResp.Chunks != nil {
+				typedResp.Chunks = traceStream(ctx, tracer, info, span, typedResp.Chunks, func(chunk tfprotov6.ReadStateByteChunk) []*tfprotov6.Diagnostic {
+					return chunk.Diagnostics
+				})
+
+				return resp, err
+			}
+		}
+
+		span.SetAttributes(diagnosticAttributes(responseDiagnostics(resp))...)
+		span.End()
+
+		return resp, err
+	}
+}
+
+// traceStream returns an iterator which creates a span for each element of
+// the stream and ends the call span once the stream has ended.
+func traceStream[T any](ctx context.Context, tracer muxtrace.Tracer, info *CallInfo, callSpan muxtrace.Span, stream iter.Seq[T], diagnostics func(T) []*tfprotov6.Diagnostic) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var streamDiagnostics []*tfprotov6.Diagnostic
+
+		defer func() {
+			callSpan.SetAttributes(diagnosticAttributes(streamDiagnostics)...)
+			callSpan.End()
+		}()
+
+		var chunk int
+
+		for element := range stream {
+			elementDiagnostics := diagnostics(element)
+			streamDiagnostics = append(streamDiagnostics, elementDiagnostics...)
+
+			_, chunkSpan := muxtrace.Start(ctx, tracer, "downstream."+info.RPC+".chunk", muxtrace.Int(muxtrace.AttributeKeyStreamChunk, chunk))
+			chunkSpan.SetAttributes(diagnosticAttributes(elementDiagnostics)...)
+
+			ok := yield(element)
+
+			chunkSpan.End()
+
+			if !ok {
+				return
+			}
+
+			chunk++
+		}
+	}
+}
+
+// traceWriteStateBytesChunks returns an iterator which creates a span for each
+// chunk of the WriteStateBytes request stream.
+func traceWriteStateBytesChunks(ctx context.Context, tracer muxtrace.Tracer, info *CallInfo, chunks iter.Seq2[*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic]) iter.Seq2[*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic] {
+	return func(yield func(*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic) bool) {
+		var chunk int
+
+		for element, diags := range chunks {
+			_, chunkSpan := muxtrace.Start(ctx, tracer, "downstream."+info.RPC+".chunk", muxtrace.Int(muxtrace.AttributeKeyStreamChunk, chunk))
+			chunkSpan.SetAttributes(diagnosticAttributes(diags)...)
+
+			ok := yield(element, diags)
+
+			chunkSpan.End()
+
+			if !ok {
+				return
+			}
+
+			chunk++
+		}
+	}
+}
+
+// diagnosticAttributes returns the span attributes of the diagnostic severity
+// counts.
+func diagnosticAttributes(diagnostics []*tfprotov6.Diagnostic) []muxtrace.Attribute {
+	var errorCount, warningCount int
+
+	for _, diagnostic := range diagnostics {
+		if diagnostic == nil {
+			continue
+		}
+
+		switch diagnostic.Severity {
+		case tfprotov6.DiagnosticSeverityError:
+			errorCount++
+		case tfprotov6.DiagnosticSeverityWarning:
+			warningCount++
+		}
+	}
+
+	return []muxtrace.Attribute{
+		muxtrace.Int(muxtrace.AttributeKeyDiagnosticErrorCount, errorCount),
+		muxtrace.Int(muxtrace.AttributeKeyDiagnosticWarningCount, warningCount),
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6pluginclient
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin6"
+	"github.com/hashicorp/terraform-plugin-mux/muxconformance"
+)
+
+// TestProviderServer_Conformance sends every RPC through the copies of the
+// tfplugin6 Protocol Buffers types and their conversions in this module to a
+// terraform-plugin-go server, which fails if the copies are missing a field
+// that is implemented by terraform-plugin-go.
+func TestProviderServer_Conformance(t *testing.T) {
+	t.Parallel()
+
+	muxconformance.RunV6(
+		t,
+		func(server func() tfprotov6.ProviderServer) (tfprotov6.ProviderServer, error) {
+			conn, _ := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+				providerPlugin := &tf6server.GRPCProviderPlugin{
+					GRPCProvider: server,
+					Name:         "test",
+					Opts:         []tf6server.ServeOpt{tf6server.WithLoggingSink(t)},
+				}
+
+				// GRPCServer never returns an error.
+				_ = providerPlugin.GRPCServer(nil, s)
+			})
+
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+
+			return &providerServer{
+				client: tfplugin6.NewProviderClient(conn),
+			}, nil
+		},
+		// terraform-plugin-go servers do not convert the MoveResourceState
+		// field from Protocol Buffers, and the ValidateProviderConfig field
+		// is not part of protocol version 6.
+		muxconformance.WithIgnoredFields(
+			"MoveResourceState request.SourceIdentitySchemaVersion",
+			"ValidateProviderConfig response.PreparedConfig",
+		),
+		// The generated chunks contain diagnostics, with which the client
+		// abandons the write without calling the provider.
+		muxconformance.WithSkippedRPCs("WriteStateBytes"),
+	)
+}
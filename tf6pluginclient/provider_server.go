@@ -0,0 +1,549 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6pluginclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfplugin6"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6fromproto"
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6toproto"
+)
+
+var (
+	_ tfprotov6.ProviderServer                 = &providerServer{}
+	_ tfprotov6.ProviderServerWithActions      = &providerServer{} //nolint:staticcheck
+	_ tfprotov6.ProviderServerWithListResource = &providerServer{} //nolint:staticcheck
+	_ tfprotov6.ProviderServerWithStateStores  = &providerServer{} //nolint:staticcheck
+)
+
+// providerServer implements tfprotov6.ProviderServer by proxying each RPC to
+// a provider binary over gRPC.
+type providerServer struct {
+	client tfplugin6.ProviderClient
+}
+
+func (s *providerServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	protoResp, err := s.client.ApplyResourceChange(ctx, tfprotov6toproto.ApplyResourceChangeRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ApplyResourceChangeResponse(protoResp), nil
+}
+
+func (s *providerServer) CallFunction(ctx context.Context, req *tfprotov6.CallFunctionRequest) (*tfprotov6.CallFunctionResponse, error) {
+	protoResp, err := s.client.CallFunction(ctx, tfprotov6toproto.CallFunctionRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.CallFunctionResponse(protoResp), nil
+}
+
+func (s *providerServer) CloseEphemeralResource(ctx context.Context, req *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
+	protoResp, err := s.client.CloseEphemeralResource(ctx, tfprotov6toproto.CloseEphemeralResourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.CloseEphemeralResourceResponse(protoResp), nil
+}
+
+func (s *providerServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	protoResp, err := s.client.ConfigureProvider(ctx, tfprotov6toproto.ConfigureProviderRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ConfigureProviderResponse(protoResp), nil
+}
+
+func (s *providerServer) ConfigureStateStore(ctx context.Context, req *tfprotov6.ConfigureStateStoreRequest) (*tfprotov6.ConfigureStateStoreResponse, error) {
+	protoResp, err := s.client.ConfigureStateStore(ctx, tfprotov6toproto.ConfigureStateStoreRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ConfigureStateStoreResponse(protoResp), nil
+}
+
+func (s *providerServer) DeleteState(ctx context.Context, req *tfprotov6.DeleteStateRequest) (*tfprotov6.DeleteStateResponse, error) {
+	protoResp, err := s.client.DeleteState(ctx, tfprotov6toproto.DeleteStateRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.DeleteStateResponse(protoResp), nil
+}
+
+func (s *providerServer) GenerateResourceConfig(ctx context.Context, req *tfprotov6.GenerateResourceConfigRequest) (*tfprotov6.GenerateResourceConfigResponse, error) {
+	protoResp, err := s.client.GenerateResourceConfig(ctx, tfprotov6toproto.GenerateResourceConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.GenerateResourceConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) GetFunctions(ctx context.Context, req *tfprotov6.GetFunctionsRequest) (*tfprotov6.GetFunctionsResponse, error) {
+	protoResp, err := s.client.GetFunctions(ctx, tfprotov6toproto.GetFunctionsRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tfprotov6fromproto.GetFunctionsResponse(protoResp)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert GetFunctions response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) GetMetadata(ctx context.Context, req *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
+	protoResp, err := s.client.GetMetadata(ctx, tfprotov6toproto.GetMetadataRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.GetMetadataResponse(protoResp), nil
+}
+
+func (s *providerServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	protoResp, err := s.client.GetProviderSchema(ctx, tfprotov6toproto.GetProviderSchemaRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tfprotov6fromproto.GetProviderSchemaResponse(protoResp)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert GetProviderSchema response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) GetResourceIdentitySchemas(ctx context.Context, req *tfprotov6.GetResourceIdentitySchemasRequest) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
+	protoResp, err := s.client.GetResourceIdentitySchemas(ctx, tfprotov6toproto.GetResourceIdentitySchemasRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tfprotov6fromproto.GetResourceIdentitySchemasResponse(protoResp)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert GetResourceIdentitySchemas response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) GetStates(ctx context.Context, req *tfprotov6.GetStatesRequest) (*tfprotov6.GetStatesResponse, error) {
+	protoResp, err := s.client.GetStates(ctx, tfprotov6toproto.GetStatesRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.GetStatesResponse(protoResp), nil
+}
+
+func (s *providerServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	protoResp, err := s.client.ImportResourceState(ctx, tfprotov6toproto.ImportResourceStateRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ImportResourceStateResponse(protoResp), nil
+}
+
+func (s *providerServer) InvokeAction(ctx context.Context, req *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	protoStream, err := s.client.InvokeAction(ctx, tfprotov6toproto.InvokeActionRequest(req))
+
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	return &tfprotov6.InvokeActionServerStream{
+		Events: func(yield func(tfprotov6.InvokeActionEvent) bool) {
+			// Stop receiving if the iteration ends early.
+			defer cancel()
+
+			for {
+				protoEvent, err := protoStream.Recv()
+
+				if errors.Is(err, io.EOF) {
+					return
+				}
+
+				if err != nil {
+					yield(tfprotov6.InvokeActionEvent{
+						Type: tfprotov6.CompletedInvokeActionEventType{
+							Diagnostics: []*tfprotov6.Diagnostic{
+								streamError("InvokeAction", err),
+							},
+						},
+					})
+
+					return
+				}
+
+				if !yield(tfprotov6fromproto.InvokeActionEvent(protoEvent)) {
+					return
+				}
+			}
+		},
+	}, nil
+}
+
+func (s *providerServer) ListResource(ctx context.Context, req *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	protoStream, err := s.client.ListResource(ctx, tfprotov6toproto.ListResourceRequest(req))
+
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	return &tfprotov6.ListResourceServerStream{
+		Results: func(yield func(tfprotov6.ListResourceResult) bool) {
+			// Stop receiving if the iteration ends early.
+			defer cancel()
+
+			for {
+				protoEvent, err := protoStream.Recv()
+
+				if errors.Is(err, io.EOF) {
+					return
+				}
+
+				if err != nil {
+					yield(tfprotov6.ListResourceResult{
+						Diagnostics: []*tfprotov6.Diagnostic{
+							streamError("ListResource", err),
+						},
+					})
+
+					return
+				}
+
+				if !yield(tfprotov6fromproto.ListResourceResult(protoEvent)) {
+					return
+				}
+			}
+		},
+	}, nil
+}
+
+func (s *providerServer) LockState(ctx context.Context, req *tfprotov6.LockStateRequest) (*tfprotov6.LockStateResponse, error) {
+	protoResp, err := s.client.LockState(ctx, tfprotov6toproto.LockStateRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.LockStateResponse(protoResp), nil
+}
+
+func (s *providerServer) MoveResourceState(ctx context.Context, req *tfprotov6.MoveResourceStateRequest) (*tfprotov6.MoveResourceStateResponse, error) {
+	protoResp, err := s.client.MoveResourceState(ctx, tfprotov6toproto.MoveResourceStateRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.MoveResourceStateResponse(protoResp), nil
+}
+
+func (s *providerServer) OpenEphemeralResource(ctx context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	protoResp, err := s.client.OpenEphemeralResource(ctx, tfprotov6toproto.OpenEphemeralResourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.OpenEphemeralResourceResponse(protoResp), nil
+}
+
+func (s *providerServer) PlanAction(ctx context.Context, req *tfprotov6.PlanActionRequest) (*tfprotov6.PlanActionResponse, error) {
+	protoResp, err := s.client.PlanAction(ctx, tfprotov6toproto.PlanActionRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.PlanActionResponse(protoResp), nil
+}
+
+func (s *providerServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	protoResp, err := s.client.PlanResourceChange(ctx, tfprotov6toproto.PlanResourceChangeRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.PlanResourceChangeResponse(protoResp), nil
+}
+
+func (s *providerServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	protoResp, err := s.client.ReadDataSource(ctx, tfprotov6toproto.ReadDataSourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ReadDataSourceResponse(protoResp), nil
+}
+
+func (s *providerServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	protoResp, err := s.client.ReadResource(ctx, tfprotov6toproto.ReadResourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ReadResourceResponse(protoResp), nil
+}
+
+func (s *providerServer) ReadStateBytes(ctx context.Context, req *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	protoStream, err := s.client.ReadStateBytes(ctx, tfprotov6toproto.ReadStateBytesRequest(req))
+
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	return &tfprotov6.ReadStateBytesStream{
+		Chunks: func(yield func(tfprotov6.ReadStateByteChunk) bool) {
+			// Stop receiving if the iteration ends early.
+			defer cancel()
+
+			for {
+				protoChunk, err := protoStream.Recv()
+
+				if errors.Is(err, io.EOF) {
+					return
+				}
+
+				if err != nil {
+					yield(tfprotov6.ReadStateByteChunk{
+						Diagnostics: []*tfprotov6.Diagnostic{
+							streamError("ReadStateBytes", err),
+						},
+					})
+
+					return
+				}
+
+				if !yield(tfprotov6fromproto.ReadStateBytesChunk(protoChunk)) {
+					return
+				}
+			}
+		},
+	}, nil
+}
+
+func (s *providerServer) RenewEphemeralResource(ctx context.Context, req *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+	protoResp, err := s.client.RenewEphemeralResource(ctx, tfprotov6toproto.RenewEphemeralResourceRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.RenewEphemeralResourceResponse(protoResp), nil
+}
+
+func (s *providerServer) StopProvider(ctx context.Context, req *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error) {
+	protoResp, err := s.client.StopProvider(ctx, tfprotov6toproto.StopProviderRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.StopProviderResponse(protoResp), nil
+}
+
+func (s *providerServer) UnlockState(ctx context.Context, req *tfprotov6.UnlockStateRequest) (*tfprotov6.UnlockStateResponse, error) {
+	protoResp, err := s.client.UnlockState(ctx, tfprotov6toproto.UnlockStateRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.UnlockStateResponse(protoResp), nil
+}
+
+func (s *providerServer) UpgradeResourceIdentity(ctx context.Context, req *tfprotov6.UpgradeResourceIdentityRequest) (*tfprotov6.UpgradeResourceIdentityResponse, error) {
+	protoResp, err := s.client.UpgradeResourceIdentity(ctx, tfprotov6toproto.UpgradeResourceIdentityRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.UpgradeResourceIdentityResponse(protoResp), nil
+}
+
+func (s *providerServer) UpgradeResourceState(ctx context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
+	protoResp, err := s.client.UpgradeResourceState(ctx, tfprotov6toproto.UpgradeResourceStateRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.UpgradeResourceStateResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateActionConfig(ctx context.Context, req *tfprotov6.ValidateActionConfigRequest) (*tfprotov6.ValidateActionConfigResponse, error) {
+	protoResp, err := s.client.ValidateActionConfig(ctx, tfprotov6toproto.ValidateActionConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ValidateActionConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateDataResourceConfig(ctx context.Context, req *tfprotov6.ValidateDataResourceConfigRequest) (*tfprotov6.ValidateDataResourceConfigResponse, error) {
+	protoResp, err := s.client.ValidateDataResourceConfig(ctx, tfprotov6toproto.ValidateDataResourceConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ValidateDataResourceConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateEphemeralResourceConfig(ctx context.Context, req *tfprotov6.ValidateEphemeralResourceConfigRequest) (*tfprotov6.ValidateEphemeralResourceConfigResponse, error) {
+	protoResp, err := s.client.ValidateEphemeralResourceConfig(ctx, tfprotov6toproto.ValidateEphemeralResourceConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ValidateEphemeralResourceConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateListResourceConfig(ctx context.Context, req *tfprotov6.ValidateListResourceConfigRequest) (*tfprotov6.ValidateListResourceConfigResponse, error) {
+	protoResp, err := s.client.ValidateListResourceConfig(ctx, tfprotov6toproto.ValidateListResourceConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ValidateListResourceConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateProviderConfig(ctx context.Context, req *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	protoResp, err := s.client.ValidateProviderConfig(ctx, tfprotov6toproto.ValidateProviderConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ValidateProviderConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) ValidateResourceConfig(ctx context.Context, req *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
+	protoResp, err := s.client.ValidateResourceConfig(ctx, tfprotov6toproto.ValidateResourceConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ValidateResourceConfigResponse(protoResp), nil
+}
+
+// streamError returns an error diagnostic for an error received from a
+// streaming RPC after the stream was opened, since the stream types cannot
+// return errors.
+
+func (s *providerServer) ValidateStateStoreConfig(ctx context.Context, req *tfprotov6.ValidateStateStoreConfigRequest) (*tfprotov6.ValidateStateStoreConfigResponse, error) {
+	protoResp, err := s.client.ValidateStateStoreConfig(ctx, tfprotov6toproto.ValidateStateStoreConfigRequest(req))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.ValidateStateStoreConfigResponse(protoResp), nil
+}
+
+func (s *providerServer) WriteStateBytes(ctx context.Context, req *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	protoStream, err := s.client.WriteStateBytes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for chunk, diags := range req.Chunks {
+		// The chunks could not be received from Terraform, so the write is
+		// abandoned by cancelling the stream rather than closing it, which
+		// would leave the provider with partial state.
+		if len(diags) > 0 {
+			return &tfprotov6.WriteStateBytesResponse{
+				Diagnostics: diags,
+			}, nil
+		}
+
+		err := protoStream.Send(tfprotov6toproto.WriteStateBytesChunk(chunk))
+
+		// The provider closed the stream early, the reason is returned by
+		// CloseAndRecv below.
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	protoResp, err := protoStream.CloseAndRecv()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tfprotov6fromproto.WriteStateBytesResponse(protoResp), nil
+}
+
+func streamError(rpc string, err error) *tfprotov6.Diagnostic {
+	return &tfprotov6.Diagnostic{
+		Severity: tfprotov6.DiagnosticSeverityError,
+		Summary:  "Error Receiving " + rpc + " Stream",
+		Detail: "The provider returned an error while streaming the " + rpc + " response. " +
+			"This may be an issue with the provider binary or its connection.\n\n" +
+			"Error: " + err.Error(),
+	}
+}
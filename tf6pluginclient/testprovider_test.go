@@ -0,0 +1,692 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6pluginclient_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/tf6pluginclient"
+)
+
+// envTestProvider is the environment variable which makes the test binary
+// run as the test provider binary, rather than running the tests. A value of
+// "serve" serves testProviderServer and "exit" exits without a handshake.
+const envTestProvider = "TF6PLUGINCLIENT_TEST_PROVIDER"
+
+// TestMain enables the test binary to also be the test provider binary, so
+// the tests can launch it with testProviderCommand.
+func TestMain(m *testing.M) {
+	switch os.Getenv(envTestProvider) {
+	case "serve":
+		err := tf6server.Serve("registry.terraform.io/hashicorp/test", func() tfprotov6.ProviderServer {
+			return testProviderServer{}
+		})
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	case "exit":
+		os.Exit(1)
+	}
+
+	os.Exit(m.Run())
+}
+
+// testProviderCommand returns a command which runs the test binary as the
+// test provider binary in the given mode.
+func testProviderCommand(mode string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0]) //nolint:gosec
+	cmd.Env = append(os.Environ(), envTestProvider+"="+mode)
+
+	return cmd
+}
+
+// newTestClient launches the test provider binary and kills it when the test
+// completes.
+func newTestClient(t *testing.T) *tf6pluginclient.Client {
+	t.Helper()
+
+	client, err := tf6pluginclient.New(context.Background(), testProviderCommand("serve"), tf6pluginclient.WithLogger(hclog.NewNullLogger()))
+
+	if err != nil {
+		t.Fatalf("unexpected error launching test provider: %s", err)
+	}
+
+	t.Cleanup(client.Kill)
+
+	return client
+}
+
+var (
+	testIdentityType = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id": tftypes.String,
+		},
+	}
+
+	testRenewAt = time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	testResourceType = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id": tftypes.String,
+			"tags": tftypes.Map{
+				ElementType: tftypes.String,
+			},
+		},
+	}
+)
+
+// testChunkSize is deliberately small so state is always sent in multiple
+// chunks.
+const testChunkSize = 4
+
+func testState(stateID string) string {
+	return `{"version":4,"serial":1,"lineage":"` + stateID + `"}`
+}
+
+func testDynamicValue(value string) *tfprotov6.DynamicValue {
+	dynamicValue, err := tfprotov6.NewDynamicValue(testResourceType, tftypes.NewValue(testResourceType, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, value),
+		"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+	}))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return &dynamicValue
+}
+
+func testIdentity(value string) *tfprotov6.ResourceIdentityData {
+	dynamicValue, err := tfprotov6.NewDynamicValue(testIdentityType, tftypes.NewValue(testIdentityType, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, value),
+	}))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return &tfprotov6.ResourceIdentityData{
+		IdentityData: &dynamicValue,
+	}
+}
+
+func testProviderSchemaResponse() *tfprotov6.GetProviderSchemaResponse {
+	return &tfprotov6.GetProviderSchemaResponse{
+		ActionSchemas: map[string]*tfprotov6.ActionSchema{
+			"test_action": {
+				Schema: &tfprotov6.Schema{
+					Block: &tfprotov6.SchemaBlock{},
+				},
+			},
+		},
+		DataSourceSchemas:        map[string]*tfprotov6.Schema{},
+		EphemeralResourceSchemas: map[string]*tfprotov6.Schema{},
+		Functions: map[string]*tfprotov6.Function{
+			"test_function": {
+				Parameters: []*tfprotov6.FunctionParameter{
+					{
+						AllowNullValue: true,
+						Name:           "input",
+						Type:           tftypes.String,
+					},
+				},
+				Return: &tfprotov6.FunctionReturn{
+					Type: tftypes.String,
+				},
+				Summary: "Test function",
+			},
+		},
+		ListResourceSchemas: map[string]*tfprotov6.Schema{
+			"test_resource": {
+				Block: &tfprotov6.SchemaBlock{},
+			},
+		},
+		Provider: &tfprotov6.Schema{
+			Block: &tfprotov6.SchemaBlock{
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{
+						Name:      "token",
+						Optional:  true,
+						Sensitive: true,
+						Type:      tftypes.String,
+					},
+				},
+				BlockTypes:      []*tfprotov6.SchemaNestedBlock{},
+				Description:     "Test provider",
+				DescriptionKind: tfprotov6.StringKindMarkdown,
+			},
+		},
+		ResourceSchemas: map[string]*tfprotov6.Schema{
+			"test_resource": {
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Computed: true,
+							Name:     "id",
+							Type:     tftypes.String,
+						},
+						{
+							Name:     "tags",
+							Optional: true,
+							Type: tftypes.Map{
+								ElementType: tftypes.String,
+							},
+						},
+					},
+					BlockTypes: []*tfprotov6.SchemaNestedBlock{
+						{
+							Block: &tfprotov6.SchemaBlock{
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:      "value",
+										Required:  true,
+										Type:      tftypes.Number,
+										WriteOnly: true,
+									},
+								},
+								BlockTypes: []*tfprotov6.SchemaNestedBlock{},
+							},
+							MaxItems: 2,
+							Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+							TypeName: "nested",
+						},
+					},
+				},
+				Version: 1,
+			},
+		},
+		ServerCapabilities: &tfprotov6.ServerCapabilities{
+			GetProviderSchemaOptional: true,
+			PlanDestroy:               true,
+		},
+		StateStoreSchemas: map[string]*tfprotov6.Schema{
+			"test_store": {
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name: "backend",
+							NestedType: &tfprotov6.SchemaObject{
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "path",
+										Required: true,
+										Type:     tftypes.String,
+									},
+								},
+								Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+							},
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// testProviderServer is the provider served by the test provider binary.
+// Responses are derived from requests so tests can verify both directions of
+// each RPC.
+type testProviderServer struct{}
+
+func (s testProviderServer) ApplyResourceChange(_ context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	return &tfprotov6.ApplyResourceChangeResponse{
+		NewIdentity: req.PlannedIdentity,
+		NewState:    req.PlannedState,
+		Private:     req.PlannedPrivate,
+	}, nil
+}
+
+func (s testProviderServer) CallFunction(_ context.Context, req *tfprotov6.CallFunctionRequest) (*tfprotov6.CallFunctionResponse, error) {
+	if len(req.Arguments) == 0 {
+		argument := int64(0)
+
+		return &tfprotov6.CallFunctionResponse{
+			Error: &tfprotov6.FunctionError{
+				FunctionArgument: &argument,
+				Text:             "missing argument",
+			},
+		}, nil
+	}
+
+	return &tfprotov6.CallFunctionResponse{
+		Result: req.Arguments[0],
+	}, nil
+}
+
+func (s testProviderServer) CloseEphemeralResource(_ context.Context, _ *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
+	return &tfprotov6.CloseEphemeralResourceResponse{}, nil
+}
+
+func (s testProviderServer) ConfigureProvider(_ context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	return &tfprotov6.ConfigureProviderResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityWarning,
+				Summary:  "Configured",
+				Detail:   "Terraform " + req.TerraformVersion,
+			},
+		},
+	}, nil
+}
+
+func (s testProviderServer) ConfigureStateStore(_ context.Context, req *tfprotov6.ConfigureStateStoreRequest) (*tfprotov6.ConfigureStateStoreResponse, error) {
+	resp := &tfprotov6.ConfigureStateStoreResponse{
+		Capabilities: &tfprotov6.StateStoreServerCapabilities{
+			ChunkSize: testChunkSize,
+		},
+	}
+
+	if req.Capabilities != nil {
+		resp.Diagnostics = []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityWarning,
+				Summary:  "Requested chunk size",
+				Detail:   fmt.Sprintf("%d", req.Capabilities.ChunkSize),
+			},
+		}
+	}
+
+	return resp, nil
+}
+
+func (s testProviderServer) DeleteState(_ context.Context, req *tfprotov6.DeleteStateRequest) (*tfprotov6.DeleteStateResponse, error) {
+	if req.StateID == "default" {
+		return &tfprotov6.DeleteStateResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Cannot delete default state",
+				},
+			},
+		}, nil
+	}
+
+	return &tfprotov6.DeleteStateResponse{}, nil
+}
+
+func (s testProviderServer) GenerateResourceConfig(_ context.Context, req *tfprotov6.GenerateResourceConfigRequest) (*tfprotov6.GenerateResourceConfigResponse, error) {
+	return &tfprotov6.GenerateResourceConfigResponse{
+		Config: req.State,
+	}, nil
+}
+
+func (s testProviderServer) GetFunctions(_ context.Context, _ *tfprotov6.GetFunctionsRequest) (*tfprotov6.GetFunctionsResponse, error) {
+	return &tfprotov6.GetFunctionsResponse{
+		Functions: testProviderSchemaResponse().Functions,
+	}, nil
+}
+
+func (s testProviderServer) GetMetadata(_ context.Context, _ *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
+	return &tfprotov6.GetMetadataResponse{
+		Actions: []tfprotov6.ActionMetadata{
+			{
+				TypeName: "test_action",
+			},
+		},
+		Functions: []tfprotov6.FunctionMetadata{
+			{
+				Name: "test_function",
+			},
+		},
+		ListResources: []tfprotov6.ListResourceMetadata{
+			{
+				TypeName: "test_resource",
+			},
+		},
+		Resources: []tfprotov6.ResourceMetadata{
+			{
+				TypeName: "test_resource",
+			},
+		},
+		ServerCapabilities: testProviderSchemaResponse().ServerCapabilities,
+		StateStores: []tfprotov6.StateStoreMetadata{
+			{
+				TypeName: "test_store",
+			},
+		},
+	}, nil
+}
+
+func (s testProviderServer) GetProviderSchema(_ context.Context, _ *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return testProviderSchemaResponse(), nil
+}
+
+func (s testProviderServer) GetResourceIdentitySchemas(_ context.Context, _ *tfprotov6.GetResourceIdentitySchemasRequest) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
+	return &tfprotov6.GetResourceIdentitySchemasResponse{
+		IdentitySchemas: map[string]*tfprotov6.ResourceIdentitySchema{
+			"test_resource": {
+				IdentityAttributes: []*tfprotov6.ResourceIdentitySchemaAttribute{
+					{
+						Name:              "id",
+						RequiredForImport: true,
+						Type:              tftypes.String,
+					},
+				},
+				Version: 1,
+			},
+		},
+	}, nil
+}
+
+func (s testProviderServer) GetStates(_ context.Context, _ *tfprotov6.GetStatesRequest) (*tfprotov6.GetStatesResponse, error) {
+	return &tfprotov6.GetStatesResponse{
+		StateIDs: []string{"default", "test"},
+	}, nil
+}
+
+func (s testProviderServer) ImportResourceState(_ context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	resp := &tfprotov6.ImportResourceStateResponse{
+		ImportedResources: []*tfprotov6.ImportedResource{
+			{
+				Identity: req.Identity,
+				State:    testDynamicValue(req.ID),
+				TypeName: req.TypeName,
+			},
+		},
+	}
+
+	if req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &tfprotov6.Deferred{
+			Reason: tfprotov6.DeferredReasonAbsentPrereq,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s testProviderServer) InvokeAction(_ context.Context, _ *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
+	return &tfprotov6.InvokeActionServerStream{
+		Events: func(yield func(tfprotov6.InvokeActionEvent) bool) {
+			for _, message := range []string{"starting", "finishing"} {
+				event := tfprotov6.InvokeActionEvent{
+					Type: tfprotov6.ProgressInvokeActionEventType{
+						Message: message,
+					},
+				}
+
+				if !yield(event) {
+					return
+				}
+			}
+
+			yield(tfprotov6.InvokeActionEvent{
+				Type: tfprotov6.CompletedInvokeActionEventType{
+					Diagnostics: []*tfprotov6.Diagnostic{
+						{
+							Severity: tfprotov6.DiagnosticSeverityWarning,
+							Summary:  "Action completed",
+						},
+					},
+				},
+			})
+		},
+	}, nil
+}
+
+func (s testProviderServer) ListResource(_ context.Context, req *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
+	return &tfprotov6.ListResourceServerStream{
+		Results: func(yield func(tfprotov6.ListResourceResult) bool) {
+			for i := range req.Limit {
+				result := tfprotov6.ListResourceResult{
+					DisplayName: fmt.Sprintf("result-%d", i),
+					Identity:    testIdentity(fmt.Sprintf("id-%d", i)),
+				}
+
+				if req.IncludeResource {
+					result.Resource = testDynamicValue(fmt.Sprintf("id-%d", i))
+				}
+
+				if !yield(result) {
+					return
+				}
+			}
+		},
+	}, nil
+}
+
+func (s testProviderServer) LockState(_ context.Context, req *tfprotov6.LockStateRequest) (*tfprotov6.LockStateResponse, error) {
+	return &tfprotov6.LockStateResponse{
+		LockID: req.StateID + "-" + req.Operation,
+	}, nil
+}
+
+func (s testProviderServer) MoveResourceState(_ context.Context, req *tfprotov6.MoveResourceStateRequest) (*tfprotov6.MoveResourceStateResponse, error) {
+	return &tfprotov6.MoveResourceStateResponse{
+		TargetPrivate: req.SourcePrivate,
+		TargetState: &tfprotov6.DynamicValue{
+			JSON: req.SourceState.JSON,
+		},
+	}, nil
+}
+
+func (s testProviderServer) OpenEphemeralResource(_ context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	return &tfprotov6.OpenEphemeralResourceResponse{
+		Private: []byte(req.TypeName),
+		RenewAt: testRenewAt,
+		Result:  req.Config,
+	}, nil
+}
+
+func (s testProviderServer) PlanAction(_ context.Context, req *tfprotov6.PlanActionRequest) (*tfprotov6.PlanActionResponse, error) {
+	resp := &tfprotov6.PlanActionResponse{}
+
+	if req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &tfprotov6.Deferred{
+			Reason: tfprotov6.DeferredReasonProviderConfigUnknown,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s testProviderServer) PlanResourceChange(_ context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	resp := &tfprotov6.PlanResourceChangeResponse{
+		PlannedIdentity: req.PriorIdentity,
+		PlannedPrivate:  req.PriorPrivate,
+		PlannedState:    req.ProposedNewState,
+		RequiresReplace: []*tftypes.AttributePath{
+			tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("name"),
+		},
+	}
+
+	if req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &tfprotov6.Deferred{
+			Reason: tfprotov6.DeferredReasonResourceConfigUnknown,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s testProviderServer) ReadDataSource(_ context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	return &tfprotov6.ReadDataSourceResponse{
+		State: req.Config,
+	}, nil
+}
+
+func (s testProviderServer) ReadStateBytes(_ context.Context, req *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+	state := []byte(testState(req.StateID))
+
+	return &tfprotov6.ReadStateBytesStream{
+		Chunks: func(yield func(tfprotov6.ReadStateByteChunk) bool) {
+			for start := int64(0); start < int64(len(state)); start += testChunkSize {
+				end := min(start+testChunkSize, int64(len(state)))
+
+				chunk := tfprotov6.ReadStateByteChunk{
+					StateByteChunk: tfprotov6.StateByteChunk{
+						Bytes:       state[start:end],
+						TotalLength: int64(len(state)),
+						Range: tfprotov6.StateByteRange{
+							Start: start,
+							End:   end,
+						},
+					},
+				}
+
+				if !yield(chunk) {
+					return
+				}
+			}
+		},
+	}, nil
+}
+
+func (s testProviderServer) ReadResource(_ context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	return &tfprotov6.ReadResourceResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Attribute: tftypes.NewAttributePath().WithAttributeName("nested").WithElementKeyInt(1).WithAttributeName("value"),
+				Detail:    "Read " + req.TypeName,
+				Severity:  tfprotov6.DiagnosticSeverityWarning,
+				Summary:   "Test warning",
+			},
+		},
+		NewIdentity: req.CurrentIdentity,
+		NewState:    req.CurrentState,
+		Private:     req.Private,
+	}, nil
+}
+
+func (s testProviderServer) RenewEphemeralResource(_ context.Context, req *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+	return &tfprotov6.RenewEphemeralResourceResponse{
+		Private: req.Private,
+		RenewAt: testRenewAt.Add(time.Hour),
+	}, nil
+}
+
+func (s testProviderServer) StopProvider(_ context.Context, _ *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error) {
+	return &tfprotov6.StopProviderResponse{
+		Error: "test stop error",
+	}, nil
+}
+
+func (s testProviderServer) UnlockState(_ context.Context, req *tfprotov6.UnlockStateRequest) (*tfprotov6.UnlockStateResponse, error) {
+	if req.LockID != req.StateID+"-apply" {
+		return &tfprotov6.UnlockStateResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Unexpected lock ID",
+					Detail:   req.LockID,
+				},
+			},
+		}, nil
+	}
+
+	return &tfprotov6.UnlockStateResponse{}, nil
+}
+
+func (s testProviderServer) UpgradeResourceIdentity(_ context.Context, req *tfprotov6.UpgradeResourceIdentityRequest) (*tfprotov6.UpgradeResourceIdentityResponse, error) {
+	return &tfprotov6.UpgradeResourceIdentityResponse{
+		UpgradedIdentity: &tfprotov6.ResourceIdentityData{
+			IdentityData: &tfprotov6.DynamicValue{
+				JSON: req.RawIdentity.JSON,
+			},
+		},
+	}, nil
+}
+
+func (s testProviderServer) UpgradeResourceState(_ context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
+	return &tfprotov6.UpgradeResourceStateResponse{
+		UpgradedState: &tfprotov6.DynamicValue{
+			JSON: req.RawState.JSON,
+		},
+	}, nil
+}
+
+func (s testProviderServer) ValidateActionConfig(_ context.Context, _ *tfprotov6.ValidateActionConfigRequest) (*tfprotov6.ValidateActionConfigResponse, error) {
+	return &tfprotov6.ValidateActionConfigResponse{}, nil
+}
+
+func (s testProviderServer) ValidateDataResourceConfig(_ context.Context, _ *tfprotov6.ValidateDataResourceConfigRequest) (*tfprotov6.ValidateDataResourceConfigResponse, error) {
+	return &tfprotov6.ValidateDataResourceConfigResponse{}, nil
+}
+
+func (s testProviderServer) ValidateEphemeralResourceConfig(_ context.Context, _ *tfprotov6.ValidateEphemeralResourceConfigRequest) (*tfprotov6.ValidateEphemeralResourceConfigResponse, error) {
+	return &tfprotov6.ValidateEphemeralResourceConfigResponse{}, nil
+}
+
+func (s testProviderServer) ValidateListResourceConfig(_ context.Context, _ *tfprotov6.ValidateListResourceConfigRequest) (*tfprotov6.ValidateListResourceConfigResponse, error) {
+	return &tfprotov6.ValidateListResourceConfigResponse{}, nil
+}
+
+func (s testProviderServer) ValidateProviderConfig(_ context.Context, _ *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	return &tfprotov6.ValidateProviderConfigResponse{}, nil
+}
+
+func (s testProviderServer) ValidateResourceConfig(_ context.Context, req *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
+	resp := &tfprotov6.ValidateResourceConfigResponse{}
+
+	if req.ClientCapabilities == nil || !req.ClientCapabilities.WriteOnlyAttributesAllowed {
+		resp.Diagnostics = []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityError,
+				Summary:  "Write-only attributes not allowed",
+			},
+		}
+	}
+
+	return resp, nil
+}
+
+func (s testProviderServer) ValidateStateStoreConfig(_ context.Context, _ *tfprotov6.ValidateStateStoreConfigRequest) (*tfprotov6.ValidateStateStoreConfigResponse, error) {
+	return &tfprotov6.ValidateStateStoreConfigResponse{}, nil
+}
+
+// WriteStateBytes responds with the received state as a warning diagnostic,
+// so tests can verify the chunks were reassembled.
+func (s testProviderServer) WriteStateBytes(_ context.Context, req *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
+	var meta *tfprotov6.WriteStateChunkMeta
+	var state []byte
+
+	for chunk, diags := range req.Chunks {
+		if len(diags) > 0 {
+			return &tfprotov6.WriteStateBytesResponse{
+				Diagnostics: diags,
+			}, nil
+		}
+
+		if chunk.Meta != nil {
+			meta = chunk.Meta
+		}
+
+		state = append(state, chunk.Bytes...)
+	}
+
+	if meta == nil {
+		return &tfprotov6.WriteStateBytesResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Missing chunk metadata",
+				},
+			},
+		}, nil
+	}
+
+	return &tfprotov6.WriteStateBytesResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityWarning,
+				Summary:  "Wrote " + meta.TypeName + " state " + meta.StateID,
+				Detail:   string(state),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,720 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6pluginclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6pluginclient"
+)
+
+var testCmpOptions = []cmp.Option{
+	cmpopts.EquateEmpty(),
+	cmp.Comparer(func(x, y tftypes.Type) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+
+		return x.Equal(y)
+	}),
+	cmp.Comparer(func(x, y *tftypes.AttributePath) bool {
+		return x.Equal(y)
+	}),
+}
+
+func TestNew_Error(t *testing.T) {
+	t.Parallel()
+
+	_, err := tf6pluginclient.New(context.Background(), testProviderCommand("exit"), tf6pluginclient.WithLogger(hclog.NewNullLogger()))
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestClient_GetProviderSchema(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(resp, testProviderSchemaResponse(), testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_GetResourceIdentitySchemas(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.GetResourceIdentitySchemasResponse{
+		IdentitySchemas: map[string]*tfprotov6.ResourceIdentitySchema{
+			"test_resource": {
+				IdentityAttributes: []*tfprotov6.ResourceIdentitySchemaAttribute{
+					{
+						Name:              "id",
+						RequiredForImport: true,
+						Type:              tftypes.String,
+					},
+				},
+				Version: 1,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_CallFunction(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.CallFunction(context.Background(), &tfprotov6.CallFunctionRequest{
+		Name: "test_function",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	argument := int64(0)
+	expected := &tfprotov6.CallFunctionResponse{
+		Error: &tfprotov6.FunctionError{
+			FunctionArgument: &argument,
+			Text:             "missing argument",
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_ConfigureProvider(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.14.0",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.ConfigureProviderResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityWarning,
+				Summary:  "Configured",
+				Detail:   "Terraform 1.14.0",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_ImportResourceState(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+		ClientCapabilities: &tfprotov6.ImportResourceStateClientCapabilities{
+			DeferralAllowed: true,
+		},
+		ID:       "test-id",
+		Identity: testIdentity("test-id"),
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.ImportResourceStateResponse{
+		Deferred: &tfprotov6.Deferred{
+			Reason: tfprotov6.DeferredReasonAbsentPrereq,
+		},
+		ImportedResources: []*tfprotov6.ImportedResource{
+			{
+				Identity: testIdentity("test-id"),
+				State:    testDynamicValue("test-id"),
+				TypeName: "test_resource",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_OpenEphemeralResource(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.OpenEphemeralResource(context.Background(), &tfprotov6.OpenEphemeralResourceRequest{
+		Config:   testDynamicValue("test-id"),
+		TypeName: "test_ephemeral",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.OpenEphemeralResourceResponse{
+		Private: []byte("test_ephemeral"),
+		RenewAt: testRenewAt,
+		Result:  testDynamicValue("test-id"),
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_PlanResourceChange(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		ClientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{
+			DeferralAllowed: true,
+		},
+		PriorIdentity:    testIdentity("test-id"),
+		PriorPrivate:     []byte("private"),
+		ProposedNewState: testDynamicValue("test-id"),
+		TypeName:         "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.PlanResourceChangeResponse{
+		Deferred: &tfprotov6.Deferred{
+			Reason: tfprotov6.DeferredReasonResourceConfigUnknown,
+		},
+		PlannedIdentity: testIdentity("test-id"),
+		PlannedPrivate:  []byte("private"),
+		PlannedState:    testDynamicValue("test-id"),
+		RequiresReplace: []*tftypes.AttributePath{
+			tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("name"),
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_ReadResource(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		CurrentIdentity: testIdentity("test-id"),
+		CurrentState:    testDynamicValue("test-id"),
+		Private:         []byte("private"),
+		TypeName:        "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.ReadResourceResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Attribute: tftypes.NewAttributePath().WithAttributeName("nested").WithElementKeyInt(1).WithAttributeName("value"),
+				Detail:    "Read test_resource",
+				Severity:  tfprotov6.DiagnosticSeverityWarning,
+				Summary:   "Test warning",
+			},
+		},
+		NewIdentity: testIdentity("test-id"),
+		NewState:    testDynamicValue("test-id"),
+		Private:     []byte("private"),
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_StopProvider(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.StopProvider(context.Background(), &tfprotov6.StopProviderRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.Error != "test stop error" {
+		t.Errorf("expected error %q, got %q", "test stop error", resp.Error)
+	}
+}
+
+func TestClient_ValidateResourceConfig(t *testing.T) {
+	t.Parallel()
+
+	server := newTestClient(t).ProviderServer()
+
+	resp, err := server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+		ClientCapabilities: &tfprotov6.ValidateResourceConfigClientCapabilities{
+			WriteOnlyAttributesAllowed: true,
+		},
+		Config:   testDynamicValue("test-id"),
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
+func TestClient_InvokeAction(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov6.ProviderServerWithActions) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement actions")
+	}
+
+	resp, err := server.InvokeAction(context.Background(), &tfprotov6.InvokeActionRequest{
+		ActionType: "test_action",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var events []tfprotov6.InvokeActionEvent
+
+	for event := range resp.Events {
+		events = append(events, event)
+	}
+
+	expected := []tfprotov6.InvokeActionEvent{
+		{
+			Type: tfprotov6.ProgressInvokeActionEventType{
+				Message: "starting",
+			},
+		},
+		{
+			Type: tfprotov6.ProgressInvokeActionEventType{
+				Message: "finishing",
+			},
+		},
+		{
+			Type: tfprotov6.CompletedInvokeActionEventType{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityWarning,
+						Summary:  "Action completed",
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(events, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected events difference: %s", diff)
+	}
+}
+
+func TestClient_ListResource(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov6.ProviderServerWithListResource) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement list resources")
+	}
+
+	resp, err := server.ListResource(context.Background(), &tfprotov6.ListResourceRequest{
+		IncludeResource: true,
+		Limit:           3,
+		TypeName:        "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var results []tfprotov6.ListResourceResult
+
+	for result := range resp.Results {
+		results = append(results, result)
+	}
+
+	expected := []tfprotov6.ListResourceResult{
+		{
+			DisplayName: "result-0",
+			Identity:    testIdentity("id-0"),
+			Resource:    testDynamicValue("id-0"),
+		},
+		{
+			DisplayName: "result-1",
+			Identity:    testIdentity("id-1"),
+			Resource:    testDynamicValue("id-1"),
+		},
+		{
+			DisplayName: "result-2",
+			Identity:    testIdentity("id-2"),
+			Resource:    testDynamicValue("id-2"),
+		},
+	}
+
+	if diff := cmp.Diff(results, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected results difference: %s", diff)
+	}
+}
+
+func TestClient_ListResource_EarlyStop(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov6.ProviderServerWithListResource) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement list resources")
+	}
+
+	resp, err := server.ListResource(context.Background(), &tfprotov6.ListResourceRequest{
+		Limit:    10,
+		TypeName: "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var count int
+
+	for range resp.Results {
+		count++
+
+		if count == 2 {
+			break
+		}
+	}
+
+	if count != 2 {
+		t.Errorf("expected 2 results, got %d", count)
+	}
+}
+
+func TestClient_MuxServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, client.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error creating mux server: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		CurrentState: testDynamicValue("test-id"),
+		TypeName:     "test_resource",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(resp.NewState, testDynamicValue("test-id")); diff != "" {
+		t.Errorf("unexpected state difference: %s", diff)
+	}
+}
+
+func TestClient_ConfigureStateStore(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov6.ProviderServerWithStateStores) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement state stores")
+	}
+
+	resp, err := server.ConfigureStateStore(context.Background(), &tfprotov6.ConfigureStateStoreRequest{
+		Capabilities: &tfprotov6.ConfigureStateStoreClientCapabilities{
+			ChunkSize: 8 << 20,
+		},
+		TypeName: "test_store",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.ConfigureStateStoreResponse{
+		Capabilities: &tfprotov6.StateStoreServerCapabilities{
+			ChunkSize: 4,
+		},
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityWarning,
+				Summary:  "Requested chunk size",
+				Detail:   "8388608",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_GetStates(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov6.ProviderServerWithStateStores) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement state stores")
+	}
+
+	resp, err := server.GetStates(context.Background(), &tfprotov6.GetStatesRequest{
+		TypeName: "test_store",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(resp.StateIDs, []string{"default", "test"}); diff != "" {
+		t.Errorf("unexpected state IDs difference: %s", diff)
+	}
+
+	deleteResp, err := server.DeleteState(context.Background(), &tfprotov6.DeleteStateRequest{
+		StateID:  "default",
+		TypeName: "test_store",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(deleteResp.Diagnostics) != 1 {
+		t.Errorf("expected 1 diagnostic, got %d", len(deleteResp.Diagnostics))
+	}
+}
+
+func TestClient_LockState(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov6.ProviderServerWithStateStores) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement state stores")
+	}
+
+	lockResp, err := server.LockState(context.Background(), &tfprotov6.LockStateRequest{
+		Operation: "apply",
+		StateID:   "test",
+		TypeName:  "test_store",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if lockResp.LockID != "test-apply" {
+		t.Fatalf("expected lock ID %q, got %q", "test-apply", lockResp.LockID)
+	}
+
+	unlockResp, err := server.UnlockState(context.Background(), &tfprotov6.UnlockStateRequest{
+		LockID:   lockResp.LockID,
+		StateID:  "test",
+		TypeName: "test_store",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(unlockResp.Diagnostics) != 0 {
+		t.Errorf("unexpected diagnostics: %v", unlockResp.Diagnostics)
+	}
+}
+
+func TestClient_ReadStateBytes(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov6.ProviderServerWithStateStores) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement state stores")
+	}
+
+	resp, err := server.ReadStateBytes(context.Background(), &tfprotov6.ReadStateBytesRequest{
+		StateID:  "test",
+		TypeName: "test_store",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var chunks int
+	var state []byte
+
+	for chunk := range resp.Chunks {
+		if len(chunk.Diagnostics) > 0 {
+			t.Fatalf("unexpected diagnostics: %v", chunk.Diagnostics)
+		}
+
+		if chunk.Range.Start != int64(len(state)) {
+			t.Fatalf("expected chunk to start at %d, got %d", len(state), chunk.Range.Start)
+		}
+
+		if chunk.TotalLength != int64(len(testState("test"))) {
+			t.Fatalf("expected total length %d, got %d", len(testState("test")), chunk.TotalLength)
+		}
+
+		chunks++
+		state = append(state, chunk.Bytes...)
+	}
+
+	if chunks < 2 {
+		t.Errorf("expected multiple chunks, got %d", chunks)
+	}
+
+	if string(state) != testState("test") {
+		t.Errorf("expected state %q, got %q", testState("test"), string(state))
+	}
+}
+
+func TestClient_WriteStateBytes(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov6.ProviderServerWithStateStores) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement state stores")
+	}
+
+	state := []byte(testState("written"))
+
+	resp, err := server.WriteStateBytes(context.Background(), &tfprotov6.WriteStateBytesStream{
+		Chunks: func(yield func(*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic) bool) {
+			for start := int64(0); start < int64(len(state)); start += 8 {
+				end := min(start+8, int64(len(state)))
+
+				chunk := &tfprotov6.WriteStateBytesChunk{
+					StateByteChunk: tfprotov6.StateByteChunk{
+						Bytes:       state[start:end],
+						TotalLength: int64(len(state)),
+						Range: tfprotov6.StateByteRange{
+							Start: start,
+							End:   end,
+						},
+					},
+				}
+
+				if start == 0 {
+					chunk.Meta = &tfprotov6.WriteStateChunkMeta{
+						StateID:  "written",
+						TypeName: "test_store",
+					}
+				}
+
+				if !yield(chunk, nil) {
+					return
+				}
+			}
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.WriteStateBytesResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityWarning,
+				Summary:  "Wrote test_store state written",
+				Detail:   string(state),
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected, testCmpOptions...); diff != "" {
+		t.Errorf("unexpected response difference: %s", diff)
+	}
+}
+
+func TestClient_WriteStateBytes_Diagnostics(t *testing.T) {
+	t.Parallel()
+
+	server, ok := newTestClient(t).ProviderServer().(tfprotov6.ProviderServerWithStateStores) //nolint:staticcheck
+
+	if !ok {
+		t.Fatal("expected server to implement state stores")
+	}
+
+	diags := []*tfprotov6.Diagnostic{
+		{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  "Unexpected GRPC error received in WriteStateBytes",
+		},
+	}
+
+	resp, err := server.WriteStateBytes(context.Background(), &tfprotov6.WriteStateBytesStream{
+		Chunks: func(yield func(*tfprotov6.WriteStateBytesChunk, []*tfprotov6.Diagnostic) bool) {
+			yield(nil, diags)
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, diags); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6to5server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6tov5"
+)
+
+var (
+	// ErrSchemaAttributeNestedTypeNotImplemented is the error of
+	// incompatibilities of nested attributes (SchemaAttribute.NestedType),
+	// which are not implemented in protocol version 5 unless the
+	// WithNestedAttributeLowering option is used.
+	ErrSchemaAttributeNestedTypeNotImplemented = tfprotov6tov5.ErrSchemaAttributeNestedTypeNotImplemented
+
+	// ErrStateStoreNotImplemented is the error of incompatibilities of state
+	// stores, which are not implemented in protocol version 5 and are
+	// dropped by downgraded servers.
+	ErrStateStoreNotImplemented = tfprotov6tov5.ErrStateStoreNotImplemented
+
+	// ErrSchemaDiagnostic is the error of incompatibilities of error
+	// diagnostics returned with the schemas, as the schemas may be
+	// incomplete.
+	ErrSchemaDiagnostic = errors.New("GetProviderSchema returned an error diagnostic")
+)
+
+// CompatibilityReport contains every incompatibility of a protocol version 6
+// server with protocol version 5, which is returned by CheckCompatibility.
+type CompatibilityReport struct {
+	// Incompatibilities are ordered by the error diagnostics returned with
+	// the schemas, the provider schema, the provider meta schema, then by
+	// schema kind and type name, then by the order of attributes and blocks
+	// within each schema.
+	Incompatibilities []Incompatibility
+}
+
+// Compatible returns true if the report contains no incompatibilities.
+func (r *CompatibilityReport) Compatible() bool {
+	return r == nil || len(r.Incompatibilities) == 0
+}
+
+// Incompatibility is a part of a protocol version 6 server which is not
+// compatible with protocol version 5.
+type Incompatibility struct {
+	// SchemaKind is the kind of schema containing the incompatibility, which
+	// is one of "action", "data source", "ephemeral resource", "list resource",
+	// "provider", "provider meta", "resource", or "state store". Error
+	// diagnostics returned with the schemas are of the "provider" kind.
+	SchemaKind string
+
+	// TypeName is the type name of the schema, or empty for the provider and
+	// provider meta schemas.
+	TypeName string
+
+	// Path is the path of the incompatible attribute within the schema,
+	// including the names of any blocks containing it, or nil if the whole
+	// schema is incompatible, such as for state stores.
+	Path *tftypes.AttributePath
+
+	// Err is the reason for the incompatibility, which wraps one of
+	// ErrSchemaAttributeNestedTypeNotImplemented, ErrSchemaDiagnostic, or
+	// ErrStateStoreNotImplemented.
+	Err error
+}
+
+// String returns a description of the incompatibility, such as:
+//
+//	resource "examplecloud_thing" attribute "settings.tags": SchemaAttribute NestedType is not implemented in protocol version 5
+func (i Incompatibility) String() string {
+	var description strings.Builder
+
+	description.WriteString(i.SchemaKind)
+
+	if i.TypeName != "" {
+		fmt.Fprintf(&description, " %q", i.TypeName)
+	}
+
+	if i.Path != nil && len(i.Path.Steps()) > 0 {
+		names := make([]string, 0, len(i.Path.Steps()))
+
+		for _, step := range i.Path.Steps() {
+			if name, ok := step.(tftypes.AttributeName); ok {
+				names = append(names, string(name))
+			}
+		}
+
+		fmt.Fprintf(&description, " attribute %q", strings.Join(names, "."))
+	}
+
+	fmt.Fprintf(&description, ": %s", i.Err)
+
+	return description.String()
+}
+
+// CheckCompatibility calls the GetProviderSchema RPC of the protocol version 6
+// server and returns every incompatibility of its schemas with protocol
+// version 5, rather than only the first, as returned by DowngradeServer. The
+// provider, provider meta, action, data source, ephemeral resource, list
+// resource, and resource schemas are checked for nested attributes, and state
+// stores, which are dropped by downgraded servers, are reported. Functions are
+// the same in both protocol versions, so are not checked. Error diagnostics
+// returned with the schemas are reported before the incompatibilities of the
+// schemas, as the schemas may be incomplete.
+//
+// The DowngradeServer options which affect compatibility are applied, so
+// nested attributes are not reported with the WithNestedAttributeLowering
+// option.
+func CheckCompatibility(ctx context.Context, v6server func() tfprotov6.ProviderServer, opts ...DowngradeServerOption) (*CompatibilityReport, error) {
+	var options downgradeServerOptions
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	resp, err := v6server().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CompatibilityReport{}
+
+	if resp == nil {
+		return report, nil
+	}
+
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic == nil || diagnostic.Severity != tfprotov6.DiagnosticSeverityError {
+			continue
+		}
+
+		report.Incompatibilities = append(report.Incompatibilities, Incompatibility{
+			SchemaKind: schemaKindProvider,
+			Err:        fmt.Errorf("%w: %s: %s", ErrSchemaDiagnostic, diagnostic.Summary, diagnostic.Detail),
+		})
+	}
+
+	if options.nestedAttributeLowering {
+		resp = lowerProviderSchema(resp)
+	}
+
+	report.checkSchema(schemaKindProvider, "", resp.Provider)
+	report.checkSchema(schemaKindProviderMeta, "", resp.ProviderMeta)
+
+	for _, typeName := range slices.Sorted(maps.Keys(resp.ActionSchemas)) {
+		if actionSchema := resp.ActionSchemas[typeName]; actionSchema != nil {
+			report.checkSchema(schemaKindAction, typeName, actionSchema.Schema)
+		}
+	}
+
+	report.checkSchemas(schemaKindDataSource, resp.DataSourceSchemas)
+	report.checkSchemas(schemaKindEphemeralResource, resp.EphemeralResourceSchemas)
+
+	report.checkSchemas(schemaKindListResource, resp.ListResourceSchemas)
+	report.checkSchemas(schemaKindResource, resp.ResourceSchemas)
+
+	for _, typeName := range slices.Sorted(maps.Keys(resp.StateStoreSchemas)) {
+		report.Incompatibilities = append(report.Incompatibilities, Incompatibility{
+			SchemaKind: schemaKindStateStore,
+			TypeName:   typeName,
+			Err:        ErrStateStoreNotImplemented,
+		})
+	}
+
+	return report, nil
+}
+
+func (r *CompatibilityReport) checkSchemas(kind string, schemas map[string]*tfprotov6.Schema) {
+	for _, typeName := range slices.Sorted(maps.Keys(schemas)) {
+		r.checkSchema(kind, typeName, schemas[typeName])
+	}
+}
+
+func (r *CompatibilityReport) checkSchema(kind string, typeName string, schema *tfprotov6.Schema) {
+	if schema == nil {
+		return
+	}
+
+	r.checkBlock(kind, typeName, tftypes.NewAttributePath(), schema.Block)
+}
+
+// checkBlock reports the nested attributes of the block and its nested
+// blocks. Nested attributes within other nested attributes are not reported
+// separately.
+func (r *CompatibilityReport) checkBlock(kind string, typeName string, path *tftypes.AttributePath, block *tfprotov6.SchemaBlock) {
+	if block == nil {
+		return
+	}
+
+	for _, attribute := range block.Attributes {
+		if attribute == nil || attribute.NestedType == nil {
+			continue
+		}
+
+		r.Incompatibilities = append(r.Incompatibilities, Incompatibility{
+			SchemaKind: kind,
+			TypeName:   typeName,
+			Path:       path.WithAttributeName(attribute.Name),
+			Err:        ErrSchemaAttributeNestedTypeNotImplemented,
+		})
+	}
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock == nil {
+			continue
+		}
+
+		r.checkBlock(kind, typeName, path.WithAttributeName(nestedBlock.TypeName), nestedBlock.Block)
+	}
+}
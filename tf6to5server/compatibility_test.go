@@ -0,0 +1,209 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6to5server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Parallel()
+
+	nestedAttribute := func(name string) *tfprotov6.SchemaAttribute {
+		return &tfprotov6.SchemaAttribute{
+			Name: name,
+			NestedType: &tfprotov6.SchemaObject{
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{
+						Name:     "inner",
+						Type:     tftypes.String,
+						Optional: true,
+					},
+				},
+				Nesting: tfprotov6.SchemaObjectNestingModeList,
+			},
+			Optional: true,
+		}
+	}
+	nestedAttributeSchema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				nestedAttribute("nested_attribute"),
+			},
+		},
+	}
+	v6server := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			ActionSchemas: map[string]*tfprotov6.ActionSchema{
+				"test_action": {
+					Schema: nestedAttributeSchema,
+				},
+			},
+			DataSourceSchemas: map[string]*tfprotov6.Schema{
+				"test_data_source": nestedAttributeSchema,
+			},
+			EphemeralResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_ephemeral_resource": nestedAttributeSchema,
+			},
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "test summary",
+					Detail:   "test detail",
+				},
+				{
+					Severity: tfprotov6.DiagnosticSeverityWarning,
+					Summary:  "test warning summary",
+					Detail:   "test warning detail",
+				},
+			},
+			Functions: map[string]*tfprotov6.Function{
+				"test_function": {
+					Parameters: []*tfprotov6.FunctionParameter{
+						{
+							Name: "parameter",
+							Type: tftypes.List{ElementType: tftypes.String},
+						},
+					},
+					Return: &tfprotov6.FunctionReturn{
+						Type: tftypes.Object{
+							AttributeTypes: map[string]tftypes.Type{
+								"attribute": tftypes.DynamicPseudoType,
+							},
+						},
+					},
+				},
+			},
+			ListResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_list_resource": nestedAttributeSchema,
+			},
+			Provider: nestedAttributeSchema,
+			ResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource1": {
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "id",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							nestedAttribute("nested_attribute1"),
+							nestedAttribute("nested_attribute2"),
+						},
+						BlockTypes: []*tfprotov6.SchemaNestedBlock{
+							{
+								TypeName: "test_block",
+								Block: &tfprotov6.SchemaBlock{
+									Attributes: []*tfprotov6.SchemaAttribute{
+										nestedAttribute("nested_attribute"),
+									},
+								},
+								Nesting: tfprotov6.SchemaNestedBlockNestingModeList,
+							},
+						},
+					},
+				},
+				"test_resource2": {},
+			},
+			StateStoreSchemas: map[string]*tfprotov6.Schema{
+				"test_state_store": {},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		opts     []tf6to5server.DowngradeServerOption
+		expected []string
+	}{
+		"default": {
+			expected: []string{
+				`provider: GetProviderSchema returned an error diagnostic: test summary: test detail`,
+				`provider attribute "nested_attribute": SchemaAttribute NestedType is not implemented in protocol version 5`,
+				`action "test_action" attribute "nested_attribute": SchemaAttribute NestedType is not implemented in protocol version 5`,
+				`data source "test_data_source" attribute "nested_attribute": SchemaAttribute NestedType is not implemented in protocol version 5`,
+				`ephemeral resource "test_ephemeral_resource" attribute "nested_attribute": SchemaAttribute NestedType is not implemented in protocol version 5`,
+				`list resource "test_list_resource" attribute "nested_attribute": SchemaAttribute NestedType is not implemented in protocol version 5`,
+				`resource "test_resource1" attribute "nested_attribute1": SchemaAttribute NestedType is not implemented in protocol version 5`,
+				`resource "test_resource1" attribute "nested_attribute2": SchemaAttribute NestedType is not implemented in protocol version 5`,
+				`resource "test_resource1" attribute "test_block.nested_attribute": SchemaAttribute NestedType is not implemented in protocol version 5`,
+				`state store "test_state_store": state stores are not implemented in protocol version 5`,
+			},
+		},
+		"WithNestedAttributeLowering": {
+			opts: []tf6to5server.DowngradeServerOption{
+				tf6to5server.WithNestedAttributeLowering(),
+			},
+			expected: []string{
+				`provider: GetProviderSchema returned an error diagnostic: test summary: test detail`,
+				`state store "test_state_store": state stores are not implemented in protocol version 5`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := tf6to5server.CheckCompatibility(context.Background(), v6server.ProviderServer, testCase.opts...)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if report.Compatible() {
+				t.Errorf("expected report to not be compatible")
+			}
+
+			var got []string
+
+			for _, incompatibility := range report.Incompatibilities {
+				got = append(got, incompatibility.String())
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected incompatibilities difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckCompatibility_Compatible(t *testing.T) {
+	t.Parallel()
+
+	v6server := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource": {
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "id",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	report, err := tf6to5server.CheckCompatibility(context.Background(), v6server.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !report.Compatible() {
+		t.Errorf("unexpected incompatibilities: %v", report.Incompatibilities)
+	}
+}
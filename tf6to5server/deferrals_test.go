@@ -0,0 +1,143 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6to5server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
+)
+
+// deferralTestServer is a test server which records the client capabilities
+// of PlanResourceChange requests and always defers.
+type deferralTestServer struct {
+	tf6testserver.TestServer
+
+	clientCapabilities *tfprotov6.PlanResourceChangeClientCapabilities
+}
+
+func (s *deferralTestServer) ProviderServer() tfprotov6.ProviderServer {
+	return s
+}
+
+func (s *deferralTestServer) PlanResourceChange(_ context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	s.clientCapabilities = req.ClientCapabilities
+
+	return &tfprotov6.PlanResourceChangeResponse{
+		Deferred: &tfprotov6.Deferred{
+			Reason: tfprotov6.DeferredReasonProviderConfigUnknown,
+		},
+	}, nil
+}
+
+func TestDowngradeServer_WithDeferralNegotiation(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		opts                       []tf6to5server.DowngradeServerOption
+		clientCapabilities         *tfprotov5.PlanResourceChangeClientCapabilities
+		expectedClientCapabilities *tfprotov6.PlanResourceChangeClientCapabilities
+		expectedResponse           *tfprotov5.PlanResourceChangeResponse
+	}{
+		"no-negotiation": {
+			expectedResponse: &tfprotov5.PlanResourceChangeResponse{
+				Deferred: &tfprotov5.Deferred{
+					Reason: tfprotov5.DeferredReasonProviderConfigUnknown,
+				},
+			},
+		},
+		"deferral-allowed": {
+			opts: []tf6to5server.DowngradeServerOption{
+				tf6to5server.WithDeferralNegotiation(true),
+			},
+			clientCapabilities: &tfprotov5.PlanResourceChangeClientCapabilities{
+				DeferralAllowed: true,
+			},
+			expectedClientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{
+				DeferralAllowed: true,
+			},
+			expectedResponse: &tfprotov5.PlanResourceChangeResponse{
+				Deferred: &tfprotov5.Deferred{
+					Reason: tfprotov5.DeferredReasonProviderConfigUnknown,
+				},
+			},
+		},
+		"deferral-not-allowed": {
+			opts: []tf6to5server.DowngradeServerOption{
+				tf6to5server.WithDeferralNegotiation(true),
+			},
+			expectedResponse: &tfprotov5.PlanResourceChangeResponse{
+				Diagnostics: []*tfprotov5.Diagnostic{
+					{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "Deferral Not Allowed",
+						Detail: "The provider deferred the PlanResourceChange call with reason PROVIDER_CONFIG_UNKNOWN, however Terraform did not allow deferrals for the call. " +
+							"This is always an issue with the provider and should be reported to the provider developers.",
+					},
+				},
+			},
+		},
+		"server-not-supporting-deferrals": {
+			opts: []tf6to5server.DowngradeServerOption{
+				tf6to5server.WithDeferralNegotiation(false),
+			},
+			clientCapabilities: &tfprotov5.PlanResourceChangeClientCapabilities{
+				DeferralAllowed: true,
+			},
+			expectedClientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{},
+			// The client allowed the deferral, so it is kept.
+			expectedResponse: &tfprotov5.PlanResourceChangeResponse{
+				Deferred: &tfprotov5.Deferred{
+					Reason: tfprotov5.DeferredReasonProviderConfigUnknown,
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			v6server := &deferralTestServer{}
+
+			v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer, testCase.opts...)
+
+			if err != nil {
+				t.Fatalf("unexpected error downgrading server: %s", err)
+			}
+
+			req := &tfprotov5.PlanResourceChangeRequest{
+				ClientCapabilities: testCase.clientCapabilities,
+				TypeName:           "test_resource",
+			}
+			deferralAllowed := req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed
+
+			resp, err := v5server.PlanResourceChange(ctx, req)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(v6server.clientCapabilities, testCase.expectedClientCapabilities); diff != "" {
+				t.Errorf("unexpected client capabilities difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(resp, testCase.expectedResponse); diff != "" {
+				t.Errorf("unexpected response difference: %s", diff)
+			}
+
+			// The client capabilities of the request are not modified.
+			if req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed != deferralAllowed {
+				t.Errorf("unexpected request client capabilities modification")
+			}
+		})
+	}
+}
@@ -0,0 +1,690 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6to5server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// WithNestedAttributeLowering is a DowngradeServer option which lowers nested
+// attributes (SchemaAttribute.NestedType), which are not supported by
+// protocol version 5, rather than returning an error for schemas which
+// contain them:
+//
+//   - Required nested attributes, and optional nested attributes which are
+//     not computed, are lowered to nested blocks with the same nesting mode.
+//     Required single nested attributes are lowered to blocks with MinItems
+//     and MaxItems of 1, and required list and set nested attributes to
+//     blocks with MinItems of 1. The sensitivity and write-only flags of
+//     nested attributes are applied to each attribute of the block.
+//   - Computed nested attributes, including those which are also optional,
+//     are lowered to attributes of the equivalent object, list, set, or map
+//     type with the same flags, as blocks cannot be computed.
+//
+// Protocol version 5 has no null blocks, so null list, set, and map nested
+// attributes in the values returned by the underlying server, such as
+// states, are translated to empty blocks, and empty blocks in the values
+// sent to the underlying server, such as configurations, are translated to
+// null. The underlying server cannot distinguish between empty and null
+// nested attributes which are lowered to blocks. Resource identities are not
+// translated, as identity schemas cannot contain nested attributes.
+//
+// Values are translated with the schemas returned by the GetProviderSchema
+// RPC of the underlying server, which is called on first use, unless it was
+// already called to validate the schemas or through the returned server.
+func WithNestedAttributeLowering() DowngradeServerOption {
+	return func(o *downgradeServerOptions) {
+		o.nestedAttributeLowering = true
+	}
+}
+
+// Schema kinds, which select the schema used to translate a value, and are
+// the SchemaKind of incompatibilities.
+const (
+	schemaKindAction            = "action"
+	schemaKindDataSource        = "data source"
+	schemaKindEphemeralResource = "ephemeral resource"
+	schemaKindListResource      = "list resource"
+	schemaKindProvider          = "provider"
+	schemaKindProviderMeta      = "provider meta"
+	schemaKindResource          = "resource"
+	schemaKindStateStore        = "state store"
+)
+
+// nestedAttributeLowering translates the values of requests and responses
+// between the schemas of the underlying server and the lowered schemas. Its
+// methods do nothing if it is nil, which is when lowering is not enabled.
+type nestedAttributeLowering struct {
+	server tfprotov6.ProviderServer
+
+	mu      sync.Mutex
+	schemas *tfprotov6.GetProviderSchemaResponse
+}
+
+// observe caches a GetProviderSchema response of the underlying server.
+func (l *nestedAttributeLowering) observe(resp *tfprotov6.GetProviderSchemaResponse) {
+	if l == nil || resp == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.schemas = resp
+}
+
+// schema returns the schema of the kind and type name, calling the
+// GetProviderSchema RPC of the underlying server if the schemas are not yet
+// known. Unknown types return a nil schema, so their values are not
+// translated.
+func (l *nestedAttributeLowering) schema(ctx context.Context, kind string, typeName string) (*tfprotov6.Schema, error) {
+	l.mu.Lock()
+	schemas := l.schemas
+	l.mu.Unlock()
+
+	if schemas == nil {
+		resp, err := l.server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch schemas for nested attribute lowering: %w", err)
+		}
+
+		l.observe(resp)
+
+		schemas = resp
+	}
+
+	if schemas == nil {
+		return nil, nil
+	}
+
+	switch kind {
+	case schemaKindAction:
+		if actionSchema := schemas.ActionSchemas[typeName]; actionSchema != nil {
+			return actionSchema.Schema, nil
+		}
+
+		return nil, nil
+	case schemaKindDataSource:
+		return schemas.DataSourceSchemas[typeName], nil
+	case schemaKindEphemeralResource:
+		return schemas.EphemeralResourceSchemas[typeName], nil
+	case schemaKindListResource:
+		return schemas.ListResourceSchemas[typeName], nil
+	case schemaKindProvider:
+		return schemas.Provider, nil
+	case schemaKindProviderMeta:
+		return schemas.ProviderMeta, nil
+	default:
+		return schemas.ResourceSchemas[typeName], nil
+	}
+}
+
+// raiseValue translates a value sent to the underlying server from the
+// lowered schema to the schema of the underlying server.
+func (l *nestedAttributeLowering) raiseValue(ctx context.Context, kind string, typeName string, value **tfprotov6.DynamicValue) error {
+	return l.translateValue(ctx, kind, typeName, value, false)
+}
+
+// lowerValue translates a value returned by the underlying server from its
+// schema to the lowered schema.
+func (l *nestedAttributeLowering) lowerValue(ctx context.Context, kind string, typeName string, value **tfprotov6.DynamicValue) error {
+	return l.translateValue(ctx, kind, typeName, value, true)
+}
+
+// translateValue replaces the value with its translation.
+func (l *nestedAttributeLowering) translateValue(ctx context.Context, kind string, typeName string, value **tfprotov6.DynamicValue, lower bool) error {
+	if *value == nil {
+		return nil
+	}
+
+	schema, err := l.schema(ctx, kind, typeName)
+
+	if err != nil {
+		return err
+	}
+
+	if schema == nil || !hasLoweredNestedAttributes(schema.Block) {
+		return nil
+	}
+
+	typ := schema.ValueType()
+	decoded, err := (*value).Unmarshal(typ)
+
+	if err != nil {
+		return fmt.Errorf("unable to translate nested attributes of %s %q value: %w", kind, typeName, err)
+	}
+
+	translated, err := translateObjectValue(schema.Block.Attributes, schema.Block.BlockTypes, decoded, lower)
+
+	if err != nil {
+		return fmt.Errorf("unable to translate nested attributes of %s %q value: %w", kind, typeName, err)
+	}
+
+	encoded, err := tfprotov6.NewDynamicValue(typ, translated)
+
+	if err != nil {
+		return fmt.Errorf("unable to translate nested attributes of %s %q value: %w", kind, typeName, err)
+	}
+
+	*value = &encoded
+
+	return nil
+}
+
+// raiseRequest translates the values of a request to the underlying server.
+func (l *nestedAttributeLowering) raiseRequest(ctx context.Context, req any) error {
+	if l == nil {
+		return nil
+	}
+
+	switch r := req.(type) {
+	case *tfprotov6.ApplyResourceChangeRequest:
+		return errors.Join(
+			l.raiseValue(ctx, schemaKindResource, r.TypeName, &r.Config),
+			l.raiseValue(ctx, schemaKindResource, r.TypeName, &r.PriorState),
+			l.raiseValue(ctx, schemaKindResource, r.TypeName, &r.PlannedState),
+			l.raiseValue(ctx, schemaKindProviderMeta, "", &r.ProviderMeta),
+		)
+	case *tfprotov6.ConfigureProviderRequest:
+		return l.raiseValue(ctx, schemaKindProvider, "", &r.Config)
+	case *tfprotov6.GenerateResourceConfigRequest:
+		return l.raiseValue(ctx, schemaKindResource, r.TypeName, &r.State)
+	case *tfprotov6.InvokeActionRequest:
+		return l.raiseValue(ctx, schemaKindAction, r.ActionType, &r.Config)
+	case *tfprotov6.ListResourceRequest:
+		return l.raiseValue(ctx, schemaKindListResource, r.TypeName, &r.Config)
+	case *tfprotov6.OpenEphemeralResourceRequest:
+		return l.raiseValue(ctx, schemaKindEphemeralResource, r.TypeName, &r.Config)
+	case *tfprotov6.PlanActionRequest:
+		return l.raiseValue(ctx, schemaKindAction, r.ActionType, &r.Config)
+	case *tfprotov6.PlanResourceChangeRequest:
+		return errors.Join(
+			l.raiseValue(ctx, schemaKindResource, r.TypeName, &r.Config),
+			l.raiseValue(ctx, schemaKindResource, r.TypeName, &r.PriorState),
+			l.raiseValue(ctx, schemaKindResource, r.TypeName, &r.ProposedNewState),
+			l.raiseValue(ctx, schemaKindProviderMeta, "", &r.ProviderMeta),
+		)
+	case *tfprotov6.ReadDataSourceRequest:
+		return errors.Join(
+			l.raiseValue(ctx, schemaKindDataSource, r.TypeName, &r.Config),
+			l.raiseValue(ctx, schemaKindProviderMeta, "", &r.ProviderMeta),
+		)
+	case *tfprotov6.ReadResourceRequest:
+		return errors.Join(
+			l.raiseValue(ctx, schemaKindResource, r.TypeName, &r.CurrentState),
+			l.raiseValue(ctx, schemaKindProviderMeta, "", &r.ProviderMeta),
+		)
+	case *tfprotov6.ValidateActionConfigRequest:
+		return l.raiseValue(ctx, schemaKindAction, r.ActionType, &r.Config)
+	case *tfprotov6.ValidateDataResourceConfigRequest:
+		return l.raiseValue(ctx, schemaKindDataSource, r.TypeName, &r.Config)
+	case *tfprotov6.ValidateEphemeralResourceConfigRequest:
+		return l.raiseValue(ctx, schemaKindEphemeralResource, r.TypeName, &r.Config)
+	case *tfprotov6.ValidateListResourceConfigRequest:
+		return l.raiseValue(ctx, schemaKindListResource, r.TypeName, &r.Config)
+	case *tfprotov6.ValidateProviderConfigRequest:
+		return l.raiseValue(ctx, schemaKindProvider, "", &r.Config)
+	case *tfprotov6.ValidateResourceConfigRequest:
+		return l.raiseValue(ctx, schemaKindResource, r.TypeName, &r.Config)
+	}
+
+	return nil
+}
+
+// lowerResponse translates the values of a response from the underlying
+// server, for the request type name.
+func (l *nestedAttributeLowering) lowerResponse(ctx context.Context, typeName string, resp any) error {
+	if l == nil {
+		return nil
+	}
+
+	switch r := resp.(type) {
+	case *tfprotov6.ApplyResourceChangeResponse:
+		if r != nil {
+			return l.lowerValue(ctx, schemaKindResource, typeName, &r.NewState)
+		}
+	case *tfprotov6.GenerateResourceConfigResponse:
+		if r != nil {
+			return l.lowerValue(ctx, schemaKindResource, typeName, &r.Config)
+		}
+	case *tfprotov6.ImportResourceStateResponse:
+		if r != nil {
+			for _, importedResource := range r.ImportedResources {
+				if importedResource == nil {
+					continue
+				}
+
+				if err := l.lowerValue(ctx, schemaKindResource, importedResource.TypeName, &importedResource.State); err != nil {
+					return err
+				}
+			}
+		}
+	case *tfprotov6.MoveResourceStateResponse:
+		if r != nil {
+			return l.lowerValue(ctx, schemaKindResource, typeName, &r.TargetState)
+		}
+	case *tfprotov6.OpenEphemeralResourceResponse:
+		if r != nil {
+			return l.lowerValue(ctx, schemaKindEphemeralResource, typeName, &r.Result)
+		}
+	case *tfprotov6.PlanResourceChangeResponse:
+		if r != nil {
+			return l.lowerValue(ctx, schemaKindResource, typeName, &r.PlannedState)
+		}
+	case *tfprotov6.ReadDataSourceResponse:
+		if r != nil {
+			return l.lowerValue(ctx, schemaKindDataSource, typeName, &r.State)
+		}
+	case *tfprotov6.ReadResourceResponse:
+		if r != nil {
+			return l.lowerValue(ctx, schemaKindResource, typeName, &r.NewState)
+		}
+	case *tfprotov6.UpgradeResourceStateResponse:
+		if r != nil {
+			return l.lowerValue(ctx, schemaKindResource, typeName, &r.UpgradedState)
+		}
+	case *tfprotov6.ValidateProviderConfigResponse:
+		if r != nil {
+			return l.lowerValue(ctx, schemaKindProvider, "", &r.PreparedConfig)
+		}
+	}
+
+	return nil
+}
+
+// lowerListResults returns the results with the resource objects translated,
+// replacing results which cannot be translated with an error diagnostic.
+func (l *nestedAttributeLowering) lowerListResults(ctx context.Context, typeName string, results iter.Seq[tfprotov6.ListResourceResult]) iter.Seq[tfprotov6.ListResourceResult] {
+	if l == nil || results == nil {
+		return results
+	}
+
+	return func(yield func(tfprotov6.ListResourceResult) bool) {
+		for result := range results {
+			if err := l.lowerValue(ctx, schemaKindResource, typeName, &result.Resource); err != nil {
+				result = tfprotov6.ListResourceResult{
+					Diagnostics: []*tfprotov6.Diagnostic{
+						{
+							Severity: tfprotov6.DiagnosticSeverityError,
+							Summary:  "Unable to Translate List Resource Result",
+							Detail:   "The resource object of a list resource result could not be translated to protocol version 5: " + err.Error(),
+						},
+					},
+				}
+			}
+
+			if !yield(result) {
+				return
+			}
+		}
+	}
+}
+
+// lowersToBlock returns true if the attribute is a nested attribute which is
+// lowered to a nested block, rather than an attribute. Computed nested
+// attributes are never lowered to blocks, as the underlying server may set
+// their values when they are not configured.
+func lowersToBlock(attribute *tfprotov6.SchemaAttribute) bool {
+	return attribute != nil && attribute.NestedType != nil && (attribute.Required || (attribute.Optional && !attribute.Computed))
+}
+
+// hasLoweredNestedAttributes returns true if the block contains nested
+// attributes which are lowered to nested blocks, so its values need to be
+// translated.
+func hasLoweredNestedAttributes(block *tfprotov6.SchemaBlock) bool {
+	if block == nil {
+		return false
+	}
+
+	for _, attribute := range block.Attributes {
+		if lowersToBlock(attribute) {
+			return true
+		}
+	}
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock != nil && hasLoweredNestedAttributes(nestedBlock.Block) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lowerProviderSchema returns a copy of the GetProviderSchema response with
+// the nested attributes of each schema lowered.
+func lowerProviderSchema(resp *tfprotov6.GetProviderSchemaResponse) *tfprotov6.GetProviderSchemaResponse {
+	if resp == nil {
+		return nil
+	}
+
+	lowered := *resp
+
+	lowered.Provider = lowerSchema(resp.Provider)
+	lowered.ProviderMeta = lowerSchema(resp.ProviderMeta)
+	lowered.DataSourceSchemas = lowerSchemas(resp.DataSourceSchemas)
+	lowered.EphemeralResourceSchemas = lowerSchemas(resp.EphemeralResourceSchemas)
+	lowered.ListResourceSchemas = lowerSchemas(resp.ListResourceSchemas)
+	lowered.ResourceSchemas = lowerSchemas(resp.ResourceSchemas)
+
+	if resp.ActionSchemas != nil {
+		lowered.ActionSchemas = make(map[string]*tfprotov6.ActionSchema, len(resp.ActionSchemas))
+
+		for typeName, actionSchema := range resp.ActionSchemas {
+			if actionSchema == nil {
+				lowered.ActionSchemas[typeName] = nil
+
+				continue
+			}
+
+			lowered.ActionSchemas[typeName] = &tfprotov6.ActionSchema{
+				Schema: lowerSchema(actionSchema.Schema),
+			}
+		}
+	}
+
+	return &lowered
+}
+
+func lowerSchemas(schemas map[string]*tfprotov6.Schema) map[string]*tfprotov6.Schema {
+	if schemas == nil {
+		return nil
+	}
+
+	lowered := make(map[string]*tfprotov6.Schema, len(schemas))
+
+	for typeName, schema := range schemas {
+		lowered[typeName] = lowerSchema(schema)
+	}
+
+	return lowered
+}
+
+func lowerSchema(schema *tfprotov6.Schema) *tfprotov6.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	return &tfprotov6.Schema{
+		Block:   lowerBlock(schema.Block),
+		Version: schema.Version,
+	}
+}
+
+func lowerBlock(block *tfprotov6.SchemaBlock) *tfprotov6.SchemaBlock {
+	if block == nil {
+		return nil
+	}
+
+	lowered := *block
+
+	attributes, loweredBlocks := lowerAttributes(block.Attributes, false, false)
+
+	lowered.Attributes = attributes
+	lowered.BlockTypes = nil
+
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock == nil {
+			continue
+		}
+
+		loweredNestedBlock := *nestedBlock
+		loweredNestedBlock.Block = lowerBlock(nestedBlock.Block)
+
+		lowered.BlockTypes = append(lowered.BlockTypes, &loweredNestedBlock)
+	}
+
+	lowered.BlockTypes = append(lowered.BlockTypes, loweredBlocks...)
+
+	return &lowered
+}
+
+// lowerAttributes returns the lowered attributes, and the nested blocks which
+// nested attributes are lowered to. The attributes are marked sensitive or
+// write-only if the nested attribute containing them is.
+func lowerAttributes(attributes []*tfprotov6.SchemaAttribute, sensitive bool, writeOnly bool) ([]*tfprotov6.SchemaAttribute, []*tfprotov6.SchemaNestedBlock) {
+	var loweredAttributes []*tfprotov6.SchemaAttribute
+	var loweredBlocks []*tfprotov6.SchemaNestedBlock
+
+	for _, attribute := range attributes {
+		if attribute == nil {
+			continue
+		}
+
+		lowered := *attribute
+
+		lowered.Sensitive = attribute.Sensitive || sensitive
+		lowered.WriteOnly = attribute.WriteOnly || writeOnly
+
+		if attribute.NestedType == nil {
+			loweredAttributes = append(loweredAttributes, &lowered)
+
+			continue
+		}
+
+		if !lowersToBlock(attribute) {
+			lowered.NestedType = nil
+			lowered.Type = attribute.ValueType()
+
+			loweredAttributes = append(loweredAttributes, &lowered)
+
+			continue
+		}
+
+		loweredBlocks = append(loweredBlocks, lowerNestedAttribute(&lowered))
+	}
+
+	return loweredAttributes, loweredBlocks
+}
+
+// lowerNestedAttribute returns the nested block which a required, or optional
+// and not computed, nested attribute is lowered to.
+func lowerNestedAttribute(attribute *tfprotov6.SchemaAttribute) *tfprotov6.SchemaNestedBlock {
+	attributes, blocks := lowerAttributes(attribute.NestedType.Attributes, attribute.Sensitive, attribute.WriteOnly)
+
+	nestedBlock := &tfprotov6.SchemaNestedBlock{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes:         attributes,
+			BlockTypes:         blocks,
+			Deprecated:         attribute.Deprecated,
+			DeprecationMessage: attribute.DeprecationMessage,
+			Description:        attribute.Description,
+			DescriptionKind:    attribute.DescriptionKind,
+		},
+		TypeName: attribute.Name,
+	}
+
+	switch attribute.NestedType.Nesting {
+	case tfprotov6.SchemaObjectNestingModeSingle:
+		nestedBlock.Nesting = tfprotov6.SchemaNestedBlockNestingModeSingle
+
+		if attribute.Required {
+			nestedBlock.MinItems = 1
+			nestedBlock.MaxItems = 1
+		}
+	case tfprotov6.SchemaObjectNestingModeList:
+		nestedBlock.Nesting = tfprotov6.SchemaNestedBlockNestingModeList
+
+		if attribute.Required {
+			nestedBlock.MinItems = 1
+		}
+	case tfprotov6.SchemaObjectNestingModeSet:
+		nestedBlock.Nesting = tfprotov6.SchemaNestedBlockNestingModeSet
+
+		if attribute.Required {
+			nestedBlock.MinItems = 1
+		}
+	case tfprotov6.SchemaObjectNestingModeMap:
+		nestedBlock.Nesting = tfprotov6.SchemaNestedBlockNestingModeMap
+	}
+
+	return nestedBlock
+}
+
+// translateObjectValue translates an object of the attributes and nested
+// blocks, replacing null collections of nested attributes which are lowered
+// to blocks with empty collections if lower is true, and empty collections
+// with null if lower is false.
+func translateObjectValue(attributes []*tfprotov6.SchemaAttribute, blocks []*tfprotov6.SchemaNestedBlock, value tftypes.Value, lower bool) (tftypes.Value, error) {
+	if !value.IsKnown() || value.IsNull() {
+		return value, nil
+	}
+
+	var object map[string]tftypes.Value
+
+	if err := value.As(&object); err != nil {
+		return value, err
+	}
+
+	for _, attribute := range attributes {
+		if !lowersToBlock(attribute) {
+			continue
+		}
+
+		nestedType := attribute.NestedType
+
+		translated, err := translateNestedValue(object[attribute.Name], nestedType.Nesting == tfprotov6.SchemaObjectNestingModeSingle, lower, func(element tftypes.Value) (tftypes.Value, error) {
+			return translateObjectValue(nestedType.Attributes, nil, element, lower)
+		})
+
+		if err != nil {
+			return value, fmt.Errorf("attribute %q: %w", attribute.Name, err)
+		}
+
+		object[attribute.Name] = translated
+	}
+
+	for _, block := range blocks {
+		if block == nil || !hasLoweredNestedAttributes(block.Block) {
+			continue
+		}
+
+		single := block.Nesting == tfprotov6.SchemaNestedBlockNestingModeSingle || block.Nesting == tfprotov6.SchemaNestedBlockNestingModeGroup
+
+		translated, err := translateElements(object[block.TypeName], single, func(element tftypes.Value) (tftypes.Value, error) {
+			return translateObjectValue(block.Block.Attributes, block.Block.BlockTypes, element, lower)
+		})
+
+		if err != nil {
+			return value, fmt.Errorf("block %q: %w", block.TypeName, err)
+		}
+
+		object[block.TypeName] = translated
+	}
+
+	return tftypes.NewValue(value.Type(), object), nil
+}
+
+// translateNestedValue translates the value of a nested attribute which is
+// lowered to a block.
+func translateNestedValue(value tftypes.Value, single bool, lower bool, translate func(tftypes.Value) (tftypes.Value, error)) (tftypes.Value, error) {
+	if single || !value.IsKnown() {
+		return translateElements(value, single, translate)
+	}
+
+	if value.IsNull() {
+		if !lower {
+			return value, nil
+		}
+
+		switch value.Type().(type) {
+		case tftypes.Map:
+			return tftypes.NewValue(value.Type(), map[string]tftypes.Value{}), nil
+		default:
+			return tftypes.NewValue(value.Type(), []tftypes.Value{}), nil
+		}
+	}
+
+	translated, err := translateElements(value, single, translate)
+
+	if err != nil || lower {
+		return translated, err
+	}
+
+	if translated.IsKnown() && !translated.IsNull() && collectionLength(translated) == 0 {
+		return tftypes.NewValue(value.Type(), nil), nil
+	}
+
+	return translated, nil
+}
+
+// translateElements translates the object, or each object of the list, set,
+// or map value.
+func translateElements(value tftypes.Value, single bool, translate func(tftypes.Value) (tftypes.Value, error)) (tftypes.Value, error) {
+	if single {
+		return translate(value)
+	}
+
+	if !value.IsKnown() || value.IsNull() {
+		return value, nil
+	}
+
+	switch value.Type().(type) {
+	case tftypes.List, tftypes.Set:
+		var elements []tftypes.Value
+
+		if err := value.As(&elements); err != nil {
+			return value, err
+		}
+
+		for index, element := range elements {
+			translated, err := translate(element)
+
+			if err != nil {
+				return value, err
+			}
+
+			elements[index] = translated
+		}
+
+		return tftypes.NewValue(value.Type(), elements), nil
+	case tftypes.Map:
+		var elements map[string]tftypes.Value
+
+		if err := value.As(&elements); err != nil {
+			return value, err
+		}
+
+		for key, element := range elements {
+			translated, err := translate(element)
+
+			if err != nil {
+				return value, err
+			}
+
+			elements[key] = translated
+		}
+
+		return tftypes.NewValue(value.Type(), elements), nil
+	}
+
+	return value, nil
+}
+
+// collectionLength returns the number of elements of a known list, set, or
+// map value.
+func collectionLength(value tftypes.Value) int {
+	switch value.Type().(type) {
+	case tftypes.Map:
+		var elements map[string]tftypes.Value
+
+		_ = value.As(&elements)
+
+		return len(elements)
+	default:
+		var elements []tftypes.Value
+
+		_ = value.As(&elements)
+
+		return len(elements)
+	}
+}
@@ -0,0 +1,480 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6to5server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tf6testserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
+)
+
+// nestedAttributesTestSchema is a resource schema with each nesting mode of
+// nested attributes, including a nested attribute within another.
+var nestedAttributesTestSchema = &tfprotov6.Schema{
+	Block: &tfprotov6.SchemaBlock{
+		Attributes: []*tfprotov6.SchemaAttribute{
+			{
+				Name:     "id",
+				Type:     tftypes.String,
+				Computed: true,
+			},
+			{
+				Name: "computed_nested",
+				NestedType: &tfprotov6.SchemaObject{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "value",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+					},
+					Nesting: tfprotov6.SchemaObjectNestingModeList,
+				},
+				Computed: true,
+			},
+			{
+				Name: "list_nested",
+				NestedType: &tfprotov6.SchemaObject{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "name",
+							Type:     tftypes.String,
+							Required: true,
+						},
+					},
+					Nesting: tfprotov6.SchemaObjectNestingModeList,
+				},
+				Optional:    true,
+				Description: "list_nested description",
+			},
+			{
+				Name: "map_nested",
+				NestedType: &tfprotov6.SchemaObject{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "value",
+							Type:     tftypes.String,
+							Optional: true,
+						},
+						{
+							Name: "inner",
+							NestedType: &tfprotov6.SchemaObject{
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "name",
+										Type:     tftypes.String,
+										Optional: true,
+									},
+								},
+								Nesting: tfprotov6.SchemaObjectNestingModeList,
+							},
+							Optional: true,
+						},
+					},
+					Nesting: tfprotov6.SchemaObjectNestingModeMap,
+				},
+				Optional: true,
+			},
+			{
+				Name: "set_nested",
+				NestedType: &tfprotov6.SchemaObject{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "value",
+							Type:     tftypes.String,
+							Optional: true,
+						},
+					},
+					Nesting: tfprotov6.SchemaObjectNestingModeSet,
+				},
+				Optional:  true,
+				Sensitive: true,
+			},
+			{
+				Name: "single_nested",
+				NestedType: &tfprotov6.SchemaObject{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "value",
+							Type:     tftypes.String,
+							Optional: true,
+						},
+					},
+					Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+				},
+				Required: true,
+			},
+		},
+	},
+}
+
+// nestedAttributesTestServer is a test server which records the current state
+// of ReadResource requests and returns it as the new state.
+type nestedAttributesTestServer struct {
+	*tf6testserver.TestServer
+
+	currentState *tfprotov6.DynamicValue
+}
+
+func (s *nestedAttributesTestServer) ProviderServer() tfprotov6.ProviderServer {
+	return s
+}
+
+func (s *nestedAttributesTestServer) ReadResource(_ context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	s.currentState = req.CurrentState
+
+	return &tfprotov6.ReadResourceResponse{
+		NewState: req.CurrentState,
+	}, nil
+}
+
+func TestDowngradeServer_WithNestedAttributeLowering(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v6server := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource": nestedAttributesTestSchema,
+			},
+		},
+	}
+
+	v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer, tf6to5server.WithNestedAttributeLowering())
+
+	if err != nil {
+		t.Fatalf("unexpected error downgrading server: %s", err)
+	}
+
+	resp, err := v5server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov5.Schema{
+		Block: &tfprotov5.SchemaBlock{
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name: "computed_nested",
+					Type: tftypes.List{
+						ElementType: tftypes.Object{
+							AttributeTypes: map[string]tftypes.Type{
+								"value": tftypes.String,
+							},
+						},
+					},
+					Computed: true,
+				},
+			},
+			BlockTypes: []*tfprotov5.SchemaNestedBlock{
+				{
+					TypeName: "list_nested",
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:     "name",
+								Type:     tftypes.String,
+								Required: true,
+							},
+						},
+						Description: "list_nested description",
+					},
+					Nesting: tfprotov5.SchemaNestedBlockNestingModeList,
+				},
+				{
+					TypeName: "map_nested",
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:     "value",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+						BlockTypes: []*tfprotov5.SchemaNestedBlock{
+							{
+								TypeName: "inner",
+								Block: &tfprotov5.SchemaBlock{
+									Attributes: []*tfprotov5.SchemaAttribute{
+										{
+											Name:     "name",
+											Type:     tftypes.String,
+											Optional: true,
+										},
+									},
+								},
+								Nesting: tfprotov5.SchemaNestedBlockNestingModeList,
+							},
+						},
+					},
+					Nesting: tfprotov5.SchemaNestedBlockNestingModeMap,
+				},
+				{
+					TypeName: "set_nested",
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:      "value",
+								Type:      tftypes.String,
+								Optional:  true,
+								Sensitive: true,
+							},
+						},
+					},
+					Nesting: tfprotov5.SchemaNestedBlockNestingModeSet,
+				},
+				{
+					TypeName: "single_nested",
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:     "value",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+					MaxItems: 1,
+					MinItems: 1,
+					Nesting:  tfprotov5.SchemaNestedBlockNestingModeSingle,
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(resp.ResourceSchemas["test_resource"], expected); diff != "" {
+		t.Errorf("unexpected schema difference: %s", diff)
+	}
+
+	// The lowered schema has the same value type, so values only differ in
+	// null and empty nested attribute collections.
+	if !resp.ResourceSchemas["test_resource"].ValueType().Equal(nestedAttributesTestSchema.ValueType()) {
+		t.Errorf("expected lowered schema to have the value type of the schema")
+	}
+}
+
+func TestDowngradeServer_WithNestedAttributeLowering_Values(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaType := nestedAttributesTestSchema.ValueType()
+	objectType := func(attributeName string) tftypes.Type {
+		return schemaType.(tftypes.Object).AttributeTypes[attributeName] //nolint:forcetypeassert
+	}
+	mapElementType := objectType("map_nested").(tftypes.Map).ElementType //nolint:forcetypeassert
+	innerType := mapElementType.(tftypes.Object).AttributeTypes["inner"] //nolint:forcetypeassert
+
+	v5Value := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "test-id"),
+		"computed_nested": tftypes.NewValue(objectType("computed_nested"), nil),
+		"list_nested":     tftypes.NewValue(objectType("list_nested"), []tftypes.Value{}),
+		"map_nested": tftypes.NewValue(objectType("map_nested"), map[string]tftypes.Value{
+			"test-key": tftypes.NewValue(mapElementType, map[string]tftypes.Value{
+				"inner": tftypes.NewValue(innerType, []tftypes.Value{}),
+				"value": tftypes.NewValue(tftypes.String, "test-value"),
+			}),
+		}),
+		"set_nested": tftypes.NewValue(objectType("set_nested"), []tftypes.Value{}),
+		"single_nested": tftypes.NewValue(objectType("single_nested"), map[string]tftypes.Value{
+			"value": tftypes.NewValue(tftypes.String, "test-value"),
+		}),
+	})
+	v6Value := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "test-id"),
+		"computed_nested": tftypes.NewValue(objectType("computed_nested"), nil),
+		"list_nested":     tftypes.NewValue(objectType("list_nested"), nil),
+		"map_nested": tftypes.NewValue(objectType("map_nested"), map[string]tftypes.Value{
+			"test-key": tftypes.NewValue(mapElementType, map[string]tftypes.Value{
+				"inner": tftypes.NewValue(innerType, nil),
+				"value": tftypes.NewValue(tftypes.String, "test-value"),
+			}),
+		}),
+		"set_nested": tftypes.NewValue(objectType("set_nested"), nil),
+		"single_nested": tftypes.NewValue(objectType("single_nested"), map[string]tftypes.Value{
+			"value": tftypes.NewValue(tftypes.String, "test-value"),
+		}),
+	})
+
+	v6server := &nestedAttributesTestServer{
+		TestServer: &tf6testserver.TestServer{
+			GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"test_resource": nestedAttributesTestSchema,
+				},
+			},
+		},
+	}
+
+	v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer, tf6to5server.WithNestedAttributeLowering())
+
+	if err != nil {
+		t.Fatalf("unexpected error downgrading server: %s", err)
+	}
+
+	currentState, err := tfprotov5.NewDynamicValue(schemaType, v5Value)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	resp, err := v5server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:     "test_resource",
+		CurrentState: &currentState,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Empty blocks are sent to the underlying server as null nested
+	// attributes.
+	gotV6Value, err := v6server.currentState.Unmarshal(schemaType)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(gotV6Value, v6Value); diff != "" {
+		t.Errorf("unexpected underlying server value difference: %s", diff)
+	}
+
+	// Null nested attributes are returned as empty blocks, so the value
+	// round-trips.
+	gotV5Value, err := resp.NewState.Unmarshal(schemaType)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(gotV5Value, v5Value); diff != "" {
+		t.Errorf("unexpected value difference: %s", diff)
+	}
+}
+
+func TestDowngradeServer_WithNestedAttributeLowering_OptionalComputed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name: "optional_computed_nested",
+					NestedType: &tfprotov6.SchemaObject{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "value",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+						Nesting: tfprotov6.SchemaObjectNestingModeList,
+					},
+					Optional: true,
+					Computed: true,
+				},
+			},
+		},
+	}
+	schemaType := schema.ValueType()
+	v6server := &nestedAttributesTestServer{
+		TestServer: &tf6testserver.TestServer{
+			GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"test_resource": schema,
+				},
+			},
+		},
+	}
+
+	v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer, tf6to5server.WithNestedAttributeLowering())
+
+	if err != nil {
+		t.Fatalf("unexpected error downgrading server: %s", err)
+	}
+
+	schemaResp, err := v5server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Optional and computed nested attributes are lowered to attributes, as
+	// blocks cannot be computed.
+	expected := &tfprotov5.Schema{
+		Block: &tfprotov5.SchemaBlock{
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name: "optional_computed_nested",
+					Type: tftypes.List{
+						ElementType: tftypes.Object{
+							AttributeTypes: map[string]tftypes.Type{
+								"value": tftypes.String,
+							},
+						},
+					},
+					Optional: true,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(schemaResp.ResourceSchemas["test_resource"], expected); diff != "" {
+		t.Errorf("unexpected schema difference: %s", diff)
+	}
+
+	// Null values are not translated to empty blocks, so the underlying
+	// server can set computed values when the attribute is not configured.
+	value := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"optional_computed_nested": tftypes.NewValue(schemaType.(tftypes.Object).AttributeTypes["optional_computed_nested"], nil), //nolint:forcetypeassert
+	})
+	currentState, err := tfprotov5.NewDynamicValue(schemaType, value)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	resp, err := v5server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:     "test_resource",
+		CurrentState: &currentState,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	gotV6Value, err := v6server.currentState.Unmarshal(schemaType)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(gotV6Value, value); diff != "" {
+		t.Errorf("unexpected underlying server value difference: %s", diff)
+	}
+
+	gotV5Value, err := resp.NewState.Unmarshal(schemaType)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(gotV5Value, value); diff != "" {
+		t.Errorf("unexpected value difference: %s", diff)
+	}
+}
@@ -6,12 +6,14 @@ package tf6to5server
 import (
 	"context"
 	"slices"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 
 	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov5tov6"
 	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6tov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server/translate"
 )
 
 // DowngradeServer wraps a protocol version 6 ProviderServer in a protocol
@@ -21,39 +23,173 @@ import (
 //   - GetProviderSchema is called to ensure SchemaAttribute.NestedType
 //     (nested attributes) are not implemented.
 //
+// The validation can be deferred to the GetProviderSchema RPC of the returned
+// server with the WithDeferredSchemaValidation option. Nested attributes can
+// instead be lowered to protocol version 5 nested blocks with the
+// WithNestedAttributeLowering option. Use the CheckCompatibility function to
+// report every incompatibility, rather than only the first. Deferrals can be
+// negotiated with the WithDeferralNegotiation option.
+//
+// The v6server function is called once, and the translated first
+// GetProviderSchema response without error diagnostics, including that of
+// the validation, is cached and a copy of it is returned by later
+// GetProviderSchema calls without calling the underlying server.
+//
+// Fields of responses which cannot be represented in protocol version 5, such
+// as state stores, are dropped and reported as a warning diagnostic, once per
+// server.
+//
 // Protocol version 5 servers require Terraform CLI 0.12 or later.
-func DowngradeServer(ctx context.Context, v6server func() tfprotov6.ProviderServer) (tfprotov5.ProviderServer, error) {
-	v6Resp, err := v6server().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+func DowngradeServer(ctx context.Context, v6server func() tfprotov6.ProviderServer, opts ...DowngradeServerOption) (tfprotov5.ProviderServer, error) {
+	var options downgradeServerOptions
 
-	if err != nil {
-		return nil, err
+	for _, opt := range opts {
+		opt(&options)
 	}
 
-	_, err = tfprotov6tov5.GetProviderSchemaResponse(v6Resp)
+	server := v6tov5Server{
+		losses:   &lossReporter{},
+		schemas:  &providerSchemaCache{},
+		v6Server: v6server(),
+	}
 
-	if err != nil {
-		return nil, err
+	if options.deferralNegotiation {
+		server.deferrals = &deferralNegotiation{
+			serverSupportsDeferrals: options.serverSupportsDeferrals,
+		}
 	}
 
-	return v6tov5Server{
-		v6Server: v6server(),
-	}, nil
+	if options.nestedAttributeLowering {
+		server.lowering = &nestedAttributeLowering{
+			server: server.v6Server,
+		}
+	}
+
+	// The validated schemas are cached, so the GetProviderSchema RPC of the
+	// returned server does not call the underlying server again.
+	if !options.deferSchemaValidation {
+		v6Resp, err := server.v6Server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = server.translateProviderSchema(v6Resp)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return server, nil
+}
+
+// DowngradeServerOption is an option for the DowngradeServer function.
+type DowngradeServerOption func(*downgradeServerOptions)
+
+// downgradeServerOptions contains the configuration of DowngradeServer.
+type downgradeServerOptions struct {
+	// deferSchemaValidation skips calling GetProviderSchema when the server
+	// is created.
+	deferSchemaValidation bool
+
+	// nestedAttributeLowering enables lowering nested attributes to nested
+	// blocks.
+	nestedAttributeLowering bool
+
+	// deferralNegotiation enables negotiating deferrals.
+	deferralNegotiation bool
+
+	// serverSupportsDeferrals keeps the DeferralAllowed client capabilities
+	// of requests when deferrals are negotiated.
+	serverSupportsDeferrals bool
+}
+
+// WithDeferredSchemaValidation is a DowngradeServer option which skips
+// calling GetProviderSchema to validate the schemas when the server is
+// created. Schemas which are not compatible with protocol version 5 are
+// instead returned as an error by the GetProviderSchema RPC of the server.
+// This is intended for servers which are validated later, such as by
+// tf5muxserver.
+func WithDeferredSchemaValidation() DowngradeServerOption {
+	return func(o *downgradeServerOptions) {
+		o.deferSchemaValidation = true
+	}
 }
 
 var _ tfprotov5.ProviderServer = v6tov5Server{}
 
 type v6tov5Server struct {
+	// deferrals is nil unless deferrals are negotiated.
+	deferrals *deferralNegotiation
+
+	// lowering is nil unless nested attribute lowering is enabled.
+	lowering *nestedAttributeLowering
+	losses   *lossReporter
+	schemas  *providerSchemaCache
 	v6Server tfprotov6.ProviderServer
 }
 
+// providerSchemaCache contains the translated GetProviderSchema response of
+// the underlying server, once a response without error diagnostics has been
+// translated, and the losses of its translation.
+type providerSchemaCache struct {
+	mu     sync.Mutex
+	resp   *tfprotov5.GetProviderSchemaResponse
+	losses []translate.Loss
+}
+
+// get returns a copy of the cached response with its own Diagnostics, so
+// callers can append diagnostics to it, and the losses of its translation,
+// or nil if no response is cached.
+func (c *providerSchemaCache) get() (*tfprotov5.GetProviderSchemaResponse, []translate.Loss) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return copyProviderSchemaResponse(c.resp), c.losses
+}
+
+// set caches a copy of the response, so later changes to it by the caller
+// are not cached, and the losses of its translation.
+func (c *providerSchemaCache) set(resp *tfprotov5.GetProviderSchemaResponse, losses []translate.Loss) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resp = copyProviderSchemaResponse(resp)
+	c.losses = losses
+}
+
+// copyProviderSchemaResponse returns a shallow copy of the response with its
+// own Diagnostics. The schemas are shared, as they are not changed after
+// translation.
+func copyProviderSchemaResponse(resp *tfprotov5.GetProviderSchemaResponse) *tfprotov5.GetProviderSchemaResponse {
+	if resp == nil {
+		return nil
+	}
+
+	respCopy := *resp
+	respCopy.Diagnostics = slices.Clone(resp.Diagnostics)
+
+	return &respCopy
+}
+
 func (s v6tov5Server) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
 	v6Req := tfprotov5tov6.ApplyResourceChangeRequest(req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.ApplyResourceChange(ctx, v6Req)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.lowering.lowerResponse(ctx, req.TypeName, v6Resp); err != nil {
+		return nil, err
+	}
+
 	return tfprotov6tov5.ApplyResourceChangeResponse(v6Resp), nil
 }
 
@@ -81,6 +217,13 @@ func (s v6tov5Server) CloseEphemeralResource(ctx context.Context, req *tfprotov5
 
 func (s v6tov5Server) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
 	v6Req := tfprotov5tov6.ConfigureProviderRequest(req)
+
+	s.deferrals.negotiateRequest(v6Req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.ConfigureProvider(ctx, v6Req)
 
 	if err != nil {
@@ -109,18 +252,72 @@ func (s v6tov5Server) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadat
 		return nil, err
 	}
 
-	return tfprotov6tov5.GetMetadataResponse(v6Resp), nil
+	v5Resp := tfprotov6tov5.GetMetadataResponse(v6Resp)
+
+	if v5Resp != nil {
+		v5Resp.Diagnostics = append(v5Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v6Resp))...)
+	}
+
+	return v5Resp, nil
 }
 
 func (s v6tov5Server) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
-	v6Req := tfprotov5tov6.GetProviderSchemaRequest(req)
-	v6Resp, err := s.v6Server.GetProviderSchema(ctx, v6Req)
+	v5Resp, losses := s.schemas.get()
+
+	if v5Resp == nil {
+		v6Req := tfprotov5tov6.GetProviderSchemaRequest(req)
+		v6Resp, err := s.v6Server.GetProviderSchema(ctx, v6Req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		v5Resp, err = s.translateProviderSchema(v6Resp)
+
+		if err != nil || v5Resp == nil {
+			return nil, err
+		}
+
+		losses = translate.Losses(v6Resp)
+	}
+
+	// The losses are reported here rather than cached with the response, so
+	// they are only reported once.
+	v5Resp.Diagnostics = append(v5Resp.Diagnostics, s.losses.diagnostics(losses)...)
+
+	return v5Resp, nil
+}
+
+// translateProviderSchema translates a GetProviderSchema response of the
+// underlying server, caching it unless it has error diagnostics.
+func (s v6tov5Server) translateProviderSchema(v6Resp *tfprotov6.GetProviderSchemaResponse) (*tfprotov5.GetProviderSchemaResponse, error) {
+	losses := translate.Losses(v6Resp)
+
+	if s.lowering != nil {
+		s.lowering.observe(v6Resp)
+
+		v6Resp = lowerProviderSchema(v6Resp)
+	}
+
+	v5Resp, err := tfprotov6tov5.GetProviderSchemaResponse(v6Resp)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov6tov5.GetProviderSchemaResponse(v6Resp)
+	if v5Resp == nil {
+		return nil, nil
+	}
+
+	if !slices.ContainsFunc(v5Resp.Diagnostics, isErrorDiagnostic) {
+		s.schemas.set(v5Resp, losses)
+	}
+
+	return v5Resp, nil
+}
+
+func isErrorDiagnostic(diagnostic *tfprotov5.Diagnostic) bool {
+	return diagnostic != nil && diagnostic.Severity == tfprotov5.DiagnosticSeverityError
 }
 
 func (s v6tov5Server) GetResourceIdentitySchemas(ctx context.Context, req *tfprotov5.GetResourceIdentitySchemasRequest) (*tfprotov5.GetResourceIdentitySchemasResponse, error) {
@@ -136,13 +333,28 @@ func (s v6tov5Server) GetResourceIdentitySchemas(ctx context.Context, req *tfpro
 
 func (s v6tov5Server) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
 	v6Req := tfprotov5tov6.ImportResourceStateRequest(req)
+
+	s.deferrals.negotiateRequest(v6Req)
+
 	v6Resp, err := s.v6Server.ImportResourceState(ctx, v6Req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov6tov5.ImportResourceStateResponse(v6Resp), nil
+	if err := s.lowering.lowerResponse(ctx, req.TypeName, v6Resp); err != nil {
+		return nil, err
+	}
+
+	v5Resp := tfprotov6tov5.ImportResourceStateResponse(v6Resp)
+
+	if v5Resp != nil {
+		v5Resp.Diagnostics = append(v5Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v6Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v5Resp)
+
+	return v5Resp, nil
 }
 
 func (s v6tov5Server) MoveResourceState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
@@ -153,39 +365,89 @@ func (s v6tov5Server) MoveResourceState(ctx context.Context, req *tfprotov5.Move
 		return nil, err
 	}
 
+	if err := s.lowering.lowerResponse(ctx, req.TargetTypeName, v6Resp); err != nil {
+		return nil, err
+	}
+
 	return tfprotov6tov5.MoveResourceStateResponse(v6Resp), nil
 }
 
 func (s v6tov5Server) OpenEphemeralResource(ctx context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
 	v6Req := tfprotov5tov6.OpenEphemeralResourceRequest(req)
 
+	s.deferrals.negotiateRequest(v6Req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.OpenEphemeralResource(ctx, v6Req)
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov6tov5.OpenEphemeralResourceResponse(v6Resp), nil
+	if err := s.lowering.lowerResponse(ctx, req.TypeName, v6Resp); err != nil {
+		return nil, err
+	}
+
+	v5Resp := tfprotov6tov5.OpenEphemeralResourceResponse(v6Resp)
+
+	if v5Resp != nil {
+		v5Resp.Diagnostics = append(v5Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v6Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v5Resp)
+
+	return v5Resp, nil
 }
 
 func (s v6tov5Server) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
 	v6Req := tfprotov5tov6.PlanResourceChangeRequest(req)
+
+	s.deferrals.negotiateRequest(v6Req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.PlanResourceChange(ctx, v6Req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov6tov5.PlanResourceChangeResponse(v6Resp), nil
+	if err := s.lowering.lowerResponse(ctx, req.TypeName, v6Resp); err != nil {
+		return nil, err
+	}
+
+	v5Resp := tfprotov6tov5.PlanResourceChangeResponse(v6Resp)
+
+	if v5Resp != nil {
+		v5Resp.Diagnostics = append(v5Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v6Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v5Resp)
+
+	return v5Resp, nil
 }
 
 func (s v6tov5Server) PrepareProviderConfig(ctx context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
 	v6Req := tfprotov5tov6.ValidateProviderConfigRequest(req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.ValidateProviderConfig(ctx, v6Req)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.lowering.lowerResponse(ctx, "", v6Resp); err != nil {
+		return nil, err
+	}
+
 	return tfprotov6tov5.PrepareProviderConfigResponse(v6Resp), nil
 }
 
@@ -196,24 +458,62 @@ func (s v6tov5Server) ProviderServer() tfprotov5.ProviderServer {
 
 func (s v6tov5Server) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
 	v6Req := tfprotov5tov6.ReadDataSourceRequest(req)
+
+	s.deferrals.negotiateRequest(v6Req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.ReadDataSource(ctx, v6Req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov6tov5.ReadDataSourceResponse(v6Resp), nil
+	if err := s.lowering.lowerResponse(ctx, req.TypeName, v6Resp); err != nil {
+		return nil, err
+	}
+
+	v5Resp := tfprotov6tov5.ReadDataSourceResponse(v6Resp)
+
+	if v5Resp != nil {
+		v5Resp.Diagnostics = append(v5Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v6Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v5Resp)
+
+	return v5Resp, nil
 }
 
 func (s v6tov5Server) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
 	v6Req := tfprotov5tov6.ReadResourceRequest(req)
+
+	s.deferrals.negotiateRequest(v6Req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.ReadResource(ctx, v6Req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov6tov5.ReadResourceResponse(v6Resp), nil
+	if err := s.lowering.lowerResponse(ctx, req.TypeName, v6Resp); err != nil {
+		return nil, err
+	}
+
+	v5Resp := tfprotov6tov5.ReadResourceResponse(v6Resp)
+
+	if v5Resp != nil {
+		v5Resp.Diagnostics = append(v5Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v6Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v5Resp)
+
+	return v5Resp, nil
 }
 
 func (s v6tov5Server) RenewEphemeralResource(ctx context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
@@ -246,6 +546,10 @@ func (s v6tov5Server) UpgradeResourceState(ctx context.Context, req *tfprotov5.U
 		return nil, err
 	}
 
+	if err := s.lowering.lowerResponse(ctx, req.TypeName, v6Resp); err != nil {
+		return nil, err
+	}
+
 	return tfprotov6tov5.UpgradeResourceStateResponse(v6Resp), nil
 }
 
@@ -262,6 +566,11 @@ func (s v6tov5Server) UpgradeResourceIdentity(ctx context.Context, req *tfprotov
 
 func (s v6tov5Server) ValidateDataSourceConfig(ctx context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
 	v6Req := tfprotov5tov6.ValidateDataResourceConfigRequest(req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.ValidateDataResourceConfig(ctx, v6Req)
 
 	if err != nil {
@@ -274,6 +583,10 @@ func (s v6tov5Server) ValidateDataSourceConfig(ctx context.Context, req *tfproto
 func (s v6tov5Server) ValidateEphemeralResourceConfig(ctx context.Context, req *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
 	v6Req := tfprotov5tov6.ValidateEphemeralResourceConfigRequest(req)
 
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.ValidateEphemeralResourceConfig(ctx, v6Req)
 	if err != nil {
 		return nil, err
@@ -284,6 +597,11 @@ func (s v6tov5Server) ValidateEphemeralResourceConfig(ctx context.Context, req *
 
 func (s v6tov5Server) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
 	v6Req := tfprotov5tov6.ValidateResourceConfigRequest(req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.ValidateResourceConfig(ctx, v6Req)
 
 	if err != nil {
@@ -313,6 +631,10 @@ func (s v6tov5Server) ValidateListResourceConfig(ctx context.Context, req *tfpro
 
 	v6Req := tfprotov5tov6.ValidateListResourceConfigRequest(req)
 
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := listResourceServer.ValidateListResourceConfig(ctx, v6Req)
 	if err != nil {
 		return nil, err
@@ -345,11 +667,19 @@ func (s v6tov5Server) ListResource(ctx context.Context, req *tfprotov5.ListResou
 
 	v6Req := tfprotov5tov6.ListResourceRequest(req)
 
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := listResourceServer.ListResource(ctx, v6Req)
 	if err != nil {
 		return nil, err
 	}
 
+	if v6Resp != nil {
+		v6Resp.Results = s.lowering.lowerListResults(ctx, req.TypeName, v6Resp.Results)
+	}
+
 	return tfprotov6tov5.ListResourceServerStream(v6Resp), nil
 }
 
@@ -373,6 +703,10 @@ func (s v6tov5Server) ValidateActionConfig(ctx context.Context, req *tfprotov5.V
 
 	v6Req := tfprotov5tov6.ValidateActionConfigRequest(req)
 
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	// v6Resp, err := s.v6Server.ValidateActionConfig(ctx, v6Req)
 	v6Resp, err := actionServer.ValidateActionConfig(ctx, v6Req)
 	if err != nil {
@@ -402,13 +736,27 @@ func (s v6tov5Server) PlanAction(ctx context.Context, req *tfprotov5.PlanActionR
 
 	v6Req := tfprotov5tov6.PlanActionRequest(req)
 
+	s.deferrals.negotiateRequest(v6Req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	// v6Resp, err := s.v6Server.PlanAction(ctx, v6Req)
 	v6Resp, err := actionServer.PlanAction(ctx, v6Req)
 	if err != nil {
 		return nil, err
 	}
 
-	return tfprotov6tov5.PlanActionResponse(v6Resp), nil
+	v5Resp := tfprotov6tov5.PlanActionResponse(v6Resp)
+
+	if v5Resp != nil {
+		v5Resp.Diagnostics = append(v5Resp.Diagnostics, s.losses.diagnostics(translate.Losses(v6Resp))...)
+	}
+
+	s.deferrals.negotiateResponse(req, v5Resp)
+
+	return v5Resp, nil
 }
 
 func (s v6tov5Server) InvokeAction(ctx context.Context, req *tfprotov5.InvokeActionRequest) (*tfprotov5.InvokeActionServerStream, error) {
@@ -437,6 +785,10 @@ func (s v6tov5Server) InvokeAction(ctx context.Context, req *tfprotov5.InvokeAct
 
 	v6Req := tfprotov5tov6.InvokeActionRequest(req)
 
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	// v6Resp, err := s.v6Server.InvokeAction(ctx, v6Req)
 	v6Resp, err := actionServer.InvokeAction(ctx, v6Req)
 	if err != nil {
@@ -448,11 +800,20 @@ func (s v6tov5Server) InvokeAction(ctx context.Context, req *tfprotov5.InvokeAct
 
 func (s v6tov5Server) GenerateResourceConfig(ctx context.Context, req *tfprotov5.GenerateResourceConfigRequest) (*tfprotov5.GenerateResourceConfigResponse, error) {
 	v6Req := tfprotov5tov6.GenerateResourceConfigRequest(req)
+
+	if err := s.lowering.raiseRequest(ctx, v6Req); err != nil {
+		return nil, err
+	}
+
 	v6Resp, err := s.v6Server.GenerateResourceConfig(ctx, v6Req)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.lowering.lowerResponse(ctx, req.TypeName, v6Resp); err != nil {
+		return nil, err
+	}
+
 	return tfprotov6tov5.GenerateResourceConfigResponse(v6Resp), nil
 }
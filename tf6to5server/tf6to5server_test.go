@@ -5,10 +5,12 @@ package tf6to5server_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -235,6 +237,204 @@ func TestDowngradeServer(t *testing.T) {
 	}
 }
 
+func TestDowngradeServer_WithDeferredSchemaValidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v6server := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			ResourceSchemas: map[string]*tfprotov6.Schema{
+				"test_resource": {
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name: "test_attribute",
+								NestedType: &tfprotov6.SchemaObject{
+									Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+								},
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer, tf6to5server.WithDeferredSchemaValidation())
+
+	if err != nil {
+		t.Fatalf("unexpected error downgrading server: %s", err)
+	}
+
+	if v6server.GetProviderSchemaCalled {
+		t.Errorf("unexpected GetProviderSchema call when downgrading server")
+	}
+
+	_, err = v5server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if !errors.Is(err, tfprotov6tov5.ErrSchemaAttributeNestedTypeNotImplemented) {
+		t.Errorf("expected ErrSchemaAttributeNestedTypeNotImplemented error, got: %v", err)
+	}
+}
+
+// schemaCountingTestServer counts the calls of the server factory and of the
+// GetProviderSchema RPC.
+type schemaCountingTestServer struct {
+	tf6testserver.TestServer
+
+	factoryCalls           int
+	getProviderSchemaCalls int
+}
+
+func (s *schemaCountingTestServer) ProviderServer() tfprotov6.ProviderServer {
+	s.factoryCalls++
+
+	return s
+}
+
+func (s *schemaCountingTestServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	s.getProviderSchemaCalls++
+
+	return s.TestServer.GetProviderSchema(ctx, req)
+}
+
+func TestDowngradeServer_Calls(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		opts                           []tf6to5server.DowngradeServerOption
+		getProviderSchemaResponse      *tfprotov6.GetProviderSchemaResponse
+		expectedGetProviderSchemaCalls int
+	}{
+		"default": {
+			getProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"test_resource": {},
+				},
+			},
+			expectedGetProviderSchemaCalls: 1,
+		},
+		"diagnostics": {
+			getProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityError,
+						Summary:  "test error summary",
+					},
+				},
+			},
+			// Responses with error diagnostics are not cached.
+			expectedGetProviderSchemaCalls: 3,
+		},
+		"WithDeferredSchemaValidation": {
+			opts: []tf6to5server.DowngradeServerOption{
+				tf6to5server.WithDeferredSchemaValidation(),
+			},
+			getProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"test_resource": {},
+				},
+			},
+			expectedGetProviderSchemaCalls: 1,
+		},
+		"WithNestedAttributeLowering": {
+			opts: []tf6to5server.DowngradeServerOption{
+				tf6to5server.WithNestedAttributeLowering(),
+			},
+			getProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"test_resource": {},
+				},
+			},
+			expectedGetProviderSchemaCalls: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			v6server := &schemaCountingTestServer{
+				TestServer: tf6testserver.TestServer{
+					GetProviderSchemaResponse: testCase.getProviderSchemaResponse,
+				},
+			}
+
+			v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer, testCase.opts...)
+
+			if err != nil {
+				t.Fatalf("unexpected error downgrading server: %s", err)
+			}
+
+			for range 2 {
+				_, err = v5server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+				if err != nil {
+					t.Fatalf("unexpected error calling GetProviderSchema: %s", err)
+				}
+			}
+
+			if v6server.factoryCalls != 1 {
+				t.Errorf("expected 1 server factory call, got: %d", v6server.factoryCalls)
+			}
+
+			if v6server.getProviderSchemaCalls != testCase.expectedGetProviderSchemaCalls {
+				t.Errorf("expected %d GetProviderSchema calls, got: %d", testCase.expectedGetProviderSchemaCalls, v6server.getProviderSchemaCalls)
+			}
+		})
+	}
+}
+
+func TestDowngradeServer_GetProviderSchemaMutation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v6server := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityWarning,
+					Summary:  "test warning summary",
+				},
+			},
+		},
+	}
+
+	v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error downgrading server: %s", err)
+	}
+
+	// Callers such as mux servers append diagnostics to the response, which
+	// must not change the responses of later calls.
+	for range 2 {
+		resp, err := v5server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+		if err != nil {
+			t.Fatalf("unexpected error calling GetProviderSchema: %s", err)
+		}
+
+		expected := []*tfprotov5.Diagnostic{
+			{
+				Severity: tfprotov5.DiagnosticSeverityWarning,
+				Summary:  "test warning summary",
+			},
+		}
+
+		if diff := cmp.Diff(resp.Diagnostics, expected); diff != "" {
+			t.Errorf("unexpected diagnostics difference: %s", diff)
+		}
+
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "test caller summary",
+		})
+	}
+}
+
 func TestV6ToV5ServerApplyResourceChange(t *testing.T) {
 	t.Parallel()
 
@@ -417,6 +617,182 @@ func TestV6ToV5ServerGetMetadata(t *testing.T) {
 	}
 }
 
+func TestV6ToV5ServerGetMetadata_NoTranslationLoss(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v6server := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			Actions: []tfprotov6.ActionMetadata{
+				{
+					TypeName: "test_action",
+				},
+			},
+			DataSources: []tfprotov6.DataSourceMetadata{
+				{
+					TypeName: "test_data_source",
+				},
+			},
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityWarning,
+					Summary:  "test summary",
+				},
+			},
+			EphemeralResources: []tfprotov6.EphemeralResourceMetadata{
+				{
+					TypeName: "test_ephemeral_resource",
+				},
+			},
+			Functions: []tfprotov6.FunctionMetadata{
+				{
+					Name: "test_function",
+				},
+			},
+			ListResources: []tfprotov6.ListResourceMetadata{
+				{
+					TypeName: "test_list_resource",
+				},
+			},
+			Resources: []tfprotov6.ResourceMetadata{
+				{
+					TypeName: "test_resource",
+				},
+			},
+			ServerCapabilities: &tfprotov6.ServerCapabilities{
+				GetProviderSchemaOptional: true,
+				MoveResourceState:         true,
+			},
+		},
+	}
+
+	v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer, tf6to5server.WithDeferredSchemaValidation())
+
+	if err != nil {
+		t.Fatalf("unexpected error downgrading server: %s", err)
+	}
+
+	resp, err := v5server.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "test summary",
+		},
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expected); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+}
+
+func TestV6ToV5ServerGetMetadata_StateStores(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v6server := &tf6testserver.TestServer{
+		GetMetadataResponse: &tfprotov6.GetMetadataResponse{
+			StateStores: []tfprotov6.StateStoreMetadata{
+				{
+					TypeName: "test_state_store",
+				},
+			},
+		},
+	}
+
+	v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer, tf6to5server.WithDeferredSchemaValidation())
+
+	if err != nil {
+		t.Fatalf("unexpected error downgrading server: %s", err)
+	}
+
+	resp, err := v5server.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "Protocol Version Translation Loss",
+			Detail: "The GetMetadataResponse.StateStores field could not be translated between protocol versions 5 and 6, so it was dropped or changed: not implemented in protocol version 5. " +
+				"The behavior of the provider may differ from that of the underlying provider server.",
+		},
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expected); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+
+	// The loss is only reported once per server.
+	resp, err = v5server.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(resp.Diagnostics) > 0 {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
+func TestV6ToV5ServerGetProviderSchema_StateStores(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v6server := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: &tfprotov6.GetProviderSchemaResponse{
+			StateStoreSchemas: map[string]*tfprotov6.Schema{
+				"test_state_store": {},
+			},
+		},
+	}
+
+	// The schemas are validated and cached by DowngradeServer, which must
+	// not report the loss.
+	v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer)
+
+	if err != nil {
+		t.Fatalf("unexpected error downgrading server: %s", err)
+	}
+
+	resp, err := v5server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "Protocol Version Translation Loss",
+			Detail: "The GetProviderSchemaResponse.StateStoreSchemas field could not be translated between protocol versions 5 and 6, so it was dropped or changed: not implemented in protocol version 5. " +
+				"The behavior of the provider may differ from that of the underlying provider server.",
+		},
+	}
+
+	if diff := cmp.Diff(resp.Diagnostics, expected); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+
+	// The loss is only reported once per server, although the response is
+	// cached.
+	resp, err = v5server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(resp.Diagnostics) > 0 {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
 func TestV6ToV5ServerGetProviderSchema(t *testing.T) {
 	t.Parallel()
 
@@ -1116,3 +1492,46 @@ func TestV6ToV5ServerGenerateResourceConfig(t *testing.T) {
 		t.Errorf("expected test_resource GenerateResourceConfig to be called")
 	}
 }
+
+func BenchmarkDowngradeServer_GetProviderSchema(b *testing.B) {
+	ctx := context.Background()
+	resp := &tfprotov6.GetProviderSchemaResponse{
+		ResourceSchemas: make(map[string]*tfprotov6.Schema, 500),
+	}
+
+	for i := range 500 {
+		block := &tfprotov6.SchemaBlock{}
+
+		for j := range 20 {
+			block.Attributes = append(block.Attributes, &tfprotov6.SchemaAttribute{
+				Name:     fmt.Sprintf("attribute_%d", j),
+				Type:     tftypes.String,
+				Optional: true,
+			})
+		}
+
+		resp.ResourceSchemas[fmt.Sprintf("test_resource_%d", i)] = &tfprotov6.Schema{
+			Block: block,
+		}
+	}
+
+	v6server := &tf6testserver.TestServer{
+		GetProviderSchemaResponse: resp,
+	}
+
+	v5server, err := tf6to5server.DowngradeServer(ctx, v6server.ProviderServer)
+
+	if err != nil {
+		b.Fatalf("unexpected error: %s", err)
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, err := v5server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+		if err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package translate translates protocol version 6 requests, responses,
+// schemas, and other types into their protocol version 5 equivalents, as used
+// by the DowngradeServer function of the tf6to5server package. This allows
+// building custom adapters and test tooling across protocol versions.
+//
+// Translations which can lose information, because protocol version 5 does
+// not implement nested attributes or state stores, return an error wrapping
+// ErrSchemaAttributeNestedTypeNotImplemented or ErrStateStoreNotImplemented
+// instead. Unlike the DowngradeServer function, state stores are not silently
+// dropped. Refer to the tf5to6server/translate package for the reverse
+// translations.
+//
+// Translations return new values, but share byte slices and maps, such as
+// those of DynamicValue and RawState, rather than copying them, so the byte
+// slices and maps of neither value should be modified after translation.
+package translate
@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package translate
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// Loss is a field of a protocol version 6 value which cannot be represented
+// in protocol version 5, so is dropped or changed by its translation. Loss
+// implements error, so it can be returned as a structured error.
+type Loss struct {
+	// Field is the path of the field in the protocol version 6 value, such
+	// as "GetMetadataResponse.StateStores".
+	Field string
+
+	// Detail describes why the field cannot be represented.
+	Detail string
+}
+
+// Error returns the field and detail of the loss.
+func (l Loss) Error() string {
+	return fmt.Sprintf("%s: %s", l.Field, l.Detail)
+}
+
+// Losses returns the losses of translating in, a protocol version 6 response,
+// into protocol version 5, such as:
+//
+//	losses := translate.Losses(resp)
+//	out := translate.ReadResourceResponse(resp)
+//
+// The fields which can be lost are the StateStores of GetMetadataResponse,
+// the StateStoreSchemas of GetProviderSchemaResponse, and the deferred
+// reasons of responses which are not implemented in protocol version 5. No
+// losses are returned for other values.
+func Losses(in any) []Loss {
+	switch in := in.(type) {
+	case *tfprotov6.GetMetadataResponse:
+		if in != nil && len(in.StateStores) > 0 {
+			return []Loss{{Field: "GetMetadataResponse.StateStores", Detail: "not implemented in protocol version 5"}}
+		}
+	case *tfprotov6.GetProviderSchemaResponse:
+		if in != nil && len(in.StateStoreSchemas) > 0 {
+			return []Loss{{Field: "GetProviderSchemaResponse.StateStoreSchemas", Detail: "not implemented in protocol version 5"}}
+		}
+	case *tfprotov6.ImportResourceStateResponse:
+		if in != nil {
+			return deferredLosses("ImportResourceStateResponse", in.Deferred)
+		}
+	case *tfprotov6.OpenEphemeralResourceResponse:
+		if in != nil {
+			return deferredLosses("OpenEphemeralResourceResponse", in.Deferred)
+		}
+	case *tfprotov6.PlanActionResponse:
+		if in != nil {
+			return deferredLosses("PlanActionResponse", in.Deferred)
+		}
+	case *tfprotov6.PlanResourceChangeResponse:
+		if in != nil {
+			return deferredLosses("PlanResourceChangeResponse", in.Deferred)
+		}
+	case *tfprotov6.ReadDataSourceResponse:
+		if in != nil {
+			return deferredLosses("ReadDataSourceResponse", in.Deferred)
+		}
+	case *tfprotov6.ReadResourceResponse:
+		if in != nil {
+			return deferredLosses("ReadResourceResponse", in.Deferred)
+		}
+	}
+
+	return nil
+}
+
+// deferredLosses returns a loss for the deferred reason of the response if it
+// is not implemented in protocol version 5.
+func deferredLosses(response string, deferred *tfprotov6.Deferred) []Loss {
+	if deferred == nil || deferred.Reason == tfprotov6.DeferredReasonUnknown {
+		return nil
+	}
+
+	// Reasons which are not implemented have the same string as
+	// DeferredReasonUnknown.
+	if tfprotov5.DeferredReason(deferred.Reason).String() != "UNKNOWN" {
+		return nil
+	}
+
+	return []Loss{{
+		Field:  response + ".Deferred.Reason",
+		Detail: fmt.Sprintf("deferred reason %d is not implemented in protocol version 5", deferred.Reason),
+	}}
+}
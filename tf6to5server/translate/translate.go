@@ -0,0 +1,647 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package translate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-mux/internal/tfprotov6tov5"
+)
+
+var (
+	// ErrSchemaAttributeNestedTypeNotImplemented is wrapped by the errors of
+	// translations of schemas containing nested attributes
+	// (SchemaAttribute.NestedType), which are not implemented in protocol
+	// version 5.
+	ErrSchemaAttributeNestedTypeNotImplemented = tfprotov6tov5.ErrSchemaAttributeNestedTypeNotImplemented
+
+	// ErrStateStoreNotImplemented is wrapped by the errors of translations of
+	// responses containing state stores, which are not implemented in
+	// protocol version 5.
+	ErrStateStoreNotImplemented = tfprotov6tov5.ErrStateStoreNotImplemented
+)
+
+// ActionMetadata translates a protocol version 6 ActionMetadata into a protocol
+// version 5 ActionMetadata.
+func ActionMetadata(in tfprotov6.ActionMetadata) tfprotov5.ActionMetadata {
+	return tfprotov6tov5.ActionMetadata(in)
+}
+
+// ActionSchema translates a protocol version 6 ActionSchema into a protocol
+// version 5 ActionSchema. It returns an error wrapping
+// ErrSchemaAttributeNestedTypeNotImplemented if it contains nested attributes.
+func ActionSchema(in *tfprotov6.ActionSchema) (*tfprotov5.ActionSchema, error) {
+	return tfprotov6tov5.ActionSchema(in)
+}
+
+// ApplyResourceChangeRequest translates a protocol version 6
+// ApplyResourceChangeRequest into a protocol version 5
+// ApplyResourceChangeRequest.
+func ApplyResourceChangeRequest(in *tfprotov6.ApplyResourceChangeRequest) *tfprotov5.ApplyResourceChangeRequest {
+	return tfprotov6tov5.ApplyResourceChangeRequest(in)
+}
+
+// ApplyResourceChangeResponse translates a protocol version 6
+// ApplyResourceChangeResponse into a protocol version 5
+// ApplyResourceChangeResponse.
+func ApplyResourceChangeResponse(in *tfprotov6.ApplyResourceChangeResponse) *tfprotov5.ApplyResourceChangeResponse {
+	return tfprotov6tov5.ApplyResourceChangeResponse(in)
+}
+
+// CallFunctionRequest translates a protocol version 6 CallFunctionRequest into
+// a protocol version 5 CallFunctionRequest.
+func CallFunctionRequest(in *tfprotov6.CallFunctionRequest) *tfprotov5.CallFunctionRequest {
+	return tfprotov6tov5.CallFunctionRequest(in)
+}
+
+// CallFunctionResponse translates a protocol version 6 CallFunctionResponse
+// into a protocol version 5 CallFunctionResponse.
+func CallFunctionResponse(in *tfprotov6.CallFunctionResponse) *tfprotov5.CallFunctionResponse {
+	return tfprotov6tov5.CallFunctionResponse(in)
+}
+
+// CloseEphemeralResourceRequest translates a protocol version 6
+// CloseEphemeralResourceRequest into a protocol version 5
+// CloseEphemeralResourceRequest.
+func CloseEphemeralResourceRequest(in *tfprotov6.CloseEphemeralResourceRequest) *tfprotov5.CloseEphemeralResourceRequest {
+	return tfprotov6tov5.CloseEphemeralResourceRequest(in)
+}
+
+// CloseEphemeralResourceResponse translates a protocol version 6
+// CloseEphemeralResourceResponse into a protocol version 5
+// CloseEphemeralResourceResponse.
+func CloseEphemeralResourceResponse(in *tfprotov6.CloseEphemeralResourceResponse) *tfprotov5.CloseEphemeralResourceResponse {
+	return tfprotov6tov5.CloseEphemeralResourceResponse(in)
+}
+
+// ConfigureProviderClientCapabilities translates a protocol version 6
+// ConfigureProviderClientCapabilities into a protocol version 5
+// ConfigureProviderClientCapabilities.
+func ConfigureProviderClientCapabilities(in *tfprotov6.ConfigureProviderClientCapabilities) *tfprotov5.ConfigureProviderClientCapabilities {
+	return tfprotov6tov5.ConfigureProviderClientCapabilities(in)
+}
+
+// ConfigureProviderRequest translates a protocol version 6
+// ConfigureProviderRequest into a protocol version 5 ConfigureProviderRequest.
+func ConfigureProviderRequest(in *tfprotov6.ConfigureProviderRequest) *tfprotov5.ConfigureProviderRequest {
+	return tfprotov6tov5.ConfigureProviderRequest(in)
+}
+
+// ConfigureProviderResponse translates a protocol version 6
+// ConfigureProviderResponse into a protocol version 5
+// ConfigureProviderResponse.
+func ConfigureProviderResponse(in *tfprotov6.ConfigureProviderResponse) *tfprotov5.ConfigureProviderResponse {
+	return tfprotov6tov5.ConfigureProviderResponse(in)
+}
+
+// DataSourceMetadata translates a protocol version 6 DataSourceMetadata into a
+// protocol version 5 DataSourceMetadata.
+func DataSourceMetadata(in tfprotov6.DataSourceMetadata) tfprotov5.DataSourceMetadata {
+	return tfprotov6tov5.DataSourceMetadata(in)
+}
+
+// Deferred translates a protocol version 6 Deferred into a protocol version 5
+// Deferred.
+func Deferred(in *tfprotov6.Deferred) *tfprotov5.Deferred {
+	return tfprotov6tov5.Deferred(in)
+}
+
+// Diagnostics translates protocol version 6 Diagnostic values into protocol
+// version 5 Diagnostic values.
+func Diagnostics(in []*tfprotov6.Diagnostic) []*tfprotov5.Diagnostic {
+	return tfprotov6tov5.Diagnostics(in)
+}
+
+// DynamicValue translates a protocol version 6 DynamicValue into a protocol
+// version 5 DynamicValue.
+func DynamicValue(in *tfprotov6.DynamicValue) *tfprotov5.DynamicValue {
+	return tfprotov6tov5.DynamicValue(in)
+}
+
+// EphemeralResourceMetadata translates a protocol version 6
+// EphemeralResourceMetadata into a protocol version 5
+// EphemeralResourceMetadata.
+func EphemeralResourceMetadata(in tfprotov6.EphemeralResourceMetadata) tfprotov5.EphemeralResourceMetadata {
+	return tfprotov6tov5.EphemeralResourceMetadata(in)
+}
+
+// Function translates a protocol version 6 Function into a protocol version 5
+// Function.
+func Function(in *tfprotov6.Function) *tfprotov5.Function {
+	return tfprotov6tov5.Function(in)
+}
+
+// FunctionError translates a protocol version 6 FunctionError into a protocol
+// version 5 FunctionError.
+func FunctionError(in *tfprotov6.FunctionError) *tfprotov5.FunctionError {
+	return tfprotov6tov5.FunctionError(in)
+}
+
+// FunctionMetadata translates a protocol version 6 FunctionMetadata into a
+// protocol version 5 FunctionMetadata.
+func FunctionMetadata(in tfprotov6.FunctionMetadata) tfprotov5.FunctionMetadata {
+	return tfprotov6tov5.FunctionMetadata(in)
+}
+
+// FunctionParameter translates a protocol version 6 FunctionParameter into a
+// protocol version 5 FunctionParameter.
+func FunctionParameter(in *tfprotov6.FunctionParameter) *tfprotov5.FunctionParameter {
+	return tfprotov6tov5.FunctionParameter(in)
+}
+
+// FunctionReturn translates a protocol version 6 FunctionReturn into a protocol
+// version 5 FunctionReturn.
+func FunctionReturn(in *tfprotov6.FunctionReturn) *tfprotov5.FunctionReturn {
+	return tfprotov6tov5.FunctionReturn(in)
+}
+
+// GenerateResourceConfigRequest translates a protocol version 6
+// GenerateResourceConfigRequest into a protocol version 5
+// GenerateResourceConfigRequest.
+func GenerateResourceConfigRequest(in *tfprotov6.GenerateResourceConfigRequest) *tfprotov5.GenerateResourceConfigRequest {
+	return tfprotov6tov5.GenerateResourceConfigRequest(in)
+}
+
+// GenerateResourceConfigResponse translates a protocol version 6
+// GenerateResourceConfigResponse into a protocol version 5
+// GenerateResourceConfigResponse.
+func GenerateResourceConfigResponse(in *tfprotov6.GenerateResourceConfigResponse) *tfprotov5.GenerateResourceConfigResponse {
+	return tfprotov6tov5.GenerateResourceConfigResponse(in)
+}
+
+// GetFunctionsRequest translates a protocol version 6 GetFunctionsRequest into
+// a protocol version 5 GetFunctionsRequest.
+func GetFunctionsRequest(in *tfprotov6.GetFunctionsRequest) *tfprotov5.GetFunctionsRequest {
+	return tfprotov6tov5.GetFunctionsRequest(in)
+}
+
+// GetFunctionsResponse translates a protocol version 6 GetFunctionsResponse
+// into a protocol version 5 GetFunctionsResponse.
+func GetFunctionsResponse(in *tfprotov6.GetFunctionsResponse) *tfprotov5.GetFunctionsResponse {
+	return tfprotov6tov5.GetFunctionsResponse(in)
+}
+
+// GetMetadataRequest translates a protocol version 6 GetMetadataRequest into a
+// protocol version 5 GetMetadataRequest.
+func GetMetadataRequest(in *tfprotov6.GetMetadataRequest) *tfprotov5.GetMetadataRequest {
+	return tfprotov6tov5.GetMetadataRequest(in)
+}
+
+// GetMetadataResponse translates a protocol version 6 GetMetadataResponse into
+// a protocol version 5 GetMetadataResponse. It returns an error wrapping
+// ErrStateStoreNotImplemented if the response contains state stores.
+func GetMetadataResponse(in *tfprotov6.GetMetadataResponse) (*tfprotov5.GetMetadataResponse, error) {
+	if in != nil && len(in.StateStores) > 0 {
+		return nil, fmt.Errorf("unable to convert state store %q metadata: %w", in.StateStores[0].TypeName, ErrStateStoreNotImplemented)
+	}
+
+	return tfprotov6tov5.GetMetadataResponse(in), nil
+}
+
+// GetProviderSchemaRequest translates a protocol version 6
+// GetProviderSchemaRequest into a protocol version 5 GetProviderSchemaRequest.
+func GetProviderSchemaRequest(in *tfprotov6.GetProviderSchemaRequest) *tfprotov5.GetProviderSchemaRequest {
+	return tfprotov6tov5.GetProviderSchemaRequest(in)
+}
+
+// GetProviderSchemaResponse translates a protocol version 6
+// GetProviderSchemaResponse into a protocol version 5
+// GetProviderSchemaResponse. It returns an error wrapping
+// ErrSchemaAttributeNestedTypeNotImplemented if any schema contains nested
+// attributes, or ErrStateStoreNotImplemented if the response contains state
+// store schemas.
+func GetProviderSchemaResponse(in *tfprotov6.GetProviderSchemaResponse) (*tfprotov5.GetProviderSchemaResponse, error) {
+	if in != nil && len(in.StateStoreSchemas) > 0 {
+		typeName := slices.Min(slices.Collect(maps.Keys(in.StateStoreSchemas)))
+
+		return nil, fmt.Errorf("unable to convert state store %q schema: %w", typeName, ErrStateStoreNotImplemented)
+	}
+
+	return tfprotov6tov5.GetProviderSchemaResponse(in)
+}
+
+// GetResourceIdentitySchemasRequest translates a protocol version 6
+// GetResourceIdentitySchemasRequest into a protocol version 5
+// GetResourceIdentitySchemasRequest.
+func GetResourceIdentitySchemasRequest(in *tfprotov6.GetResourceIdentitySchemasRequest) *tfprotov5.GetResourceIdentitySchemasRequest {
+	return tfprotov6tov5.GetResourceIdentitySchemasRequest(in)
+}
+
+// GetResourceIdentitySchemasResponse translates a protocol version 6
+// GetResourceIdentitySchemasResponse into a protocol version 5
+// GetResourceIdentitySchemasResponse.
+func GetResourceIdentitySchemasResponse(in *tfprotov6.GetResourceIdentitySchemasResponse) *tfprotov5.GetResourceIdentitySchemasResponse {
+	return tfprotov6tov5.GetResourceIdentitySchemasResponse(in)
+}
+
+// ImportResourceStateClientCapabilities translates a protocol version 6
+// ImportResourceStateClientCapabilities into a protocol version 5
+// ImportResourceStateClientCapabilities.
+func ImportResourceStateClientCapabilities(in *tfprotov6.ImportResourceStateClientCapabilities) *tfprotov5.ImportResourceStateClientCapabilities {
+	return tfprotov6tov5.ImportResourceStateClientCapabilities(in)
+}
+
+// ImportResourceStateRequest translates a protocol version 6
+// ImportResourceStateRequest into a protocol version 5
+// ImportResourceStateRequest.
+func ImportResourceStateRequest(in *tfprotov6.ImportResourceStateRequest) *tfprotov5.ImportResourceStateRequest {
+	return tfprotov6tov5.ImportResourceStateRequest(in)
+}
+
+// ImportResourceStateResponse translates a protocol version 6
+// ImportResourceStateResponse into a protocol version 5
+// ImportResourceStateResponse.
+func ImportResourceStateResponse(in *tfprotov6.ImportResourceStateResponse) *tfprotov5.ImportResourceStateResponse {
+	return tfprotov6tov5.ImportResourceStateResponse(in)
+}
+
+// ImportedResources translates protocol version 6 ImportedResource values into
+// protocol version 5 ImportedResource values.
+func ImportedResources(in []*tfprotov6.ImportedResource) []*tfprotov5.ImportedResource {
+	return tfprotov6tov5.ImportedResources(in)
+}
+
+// InvokeActionClientCapabilities translates a protocol version 6
+// InvokeActionClientCapabilities into a protocol version 5
+// InvokeActionClientCapabilities.
+func InvokeActionClientCapabilities(in *tfprotov6.InvokeActionClientCapabilities) *tfprotov5.InvokeActionClientCapabilities {
+	return tfprotov6tov5.InvokeActionClientCapabilities(in)
+}
+
+// InvokeActionEvent translates a protocol version 6 InvokeActionEvent into a
+// protocol version 5 InvokeActionEvent.
+func InvokeActionEvent(in tfprotov6.InvokeActionEvent) tfprotov5.InvokeActionEvent {
+	return tfprotov6tov5.InvokeActionEvent(in)
+}
+
+// InvokeActionRequest translates a protocol version 6 InvokeActionRequest into
+// a protocol version 5 InvokeActionRequest.
+func InvokeActionRequest(in *tfprotov6.InvokeActionRequest) *tfprotov5.InvokeActionRequest {
+	return tfprotov6tov5.InvokeActionRequest(in)
+}
+
+// InvokeActionServerStream translates a protocol version 6
+// InvokeActionServerStream into a protocol version 5 InvokeActionServerStream.
+// The stream is translated as it is iterated.
+func InvokeActionServerStream(in *tfprotov6.InvokeActionServerStream) *tfprotov5.InvokeActionServerStream {
+	return tfprotov6tov5.InvokeActionServerStream(in)
+}
+
+// ListResourceMetadata translates a protocol version 6 ListResourceMetadata
+// into a protocol version 5 ListResourceMetadata.
+func ListResourceMetadata(in tfprotov6.ListResourceMetadata) tfprotov5.ListResourceMetadata {
+	return tfprotov6tov5.ListResourceMetadata(in)
+}
+
+// ListResourceRequest translates a protocol version 6 ListResourceRequest into
+// a protocol version 5 ListResourceRequest.
+func ListResourceRequest(in *tfprotov6.ListResourceRequest) *tfprotov5.ListResourceRequest {
+	return tfprotov6tov5.ListResourceRequest(in)
+}
+
+// ListResourceResult translates a protocol version 6 ListResourceResult into a
+// protocol version 5 ListResourceResult.
+func ListResourceResult(in tfprotov6.ListResourceResult) tfprotov5.ListResourceResult {
+	return tfprotov6tov5.ListResourceResult(in)
+}
+
+// ListResourceServerStream translates a protocol version 6
+// ListResourceServerStream into a protocol version 5 ListResourceServerStream.
+// The stream is translated as it is iterated.
+func ListResourceServerStream(in *tfprotov6.ListResourceServerStream) *tfprotov5.ListResourceServerStream {
+	return tfprotov6tov5.ListResourceServerStream(in)
+}
+
+// MoveResourceStateRequest translates a protocol version 6
+// MoveResourceStateRequest into a protocol version 5 MoveResourceStateRequest.
+func MoveResourceStateRequest(in *tfprotov6.MoveResourceStateRequest) *tfprotov5.MoveResourceStateRequest {
+	return tfprotov6tov5.MoveResourceStateRequest(in)
+}
+
+// MoveResourceStateResponse translates a protocol version 6
+// MoveResourceStateResponse into a protocol version 5
+// MoveResourceStateResponse.
+func MoveResourceStateResponse(in *tfprotov6.MoveResourceStateResponse) *tfprotov5.MoveResourceStateResponse {
+	return tfprotov6tov5.MoveResourceStateResponse(in)
+}
+
+// OpenEphemeralResourceClientCapabilities translates a protocol version 6
+// OpenEphemeralResourceClientCapabilities into a protocol version 5
+// OpenEphemeralResourceClientCapabilities.
+func OpenEphemeralResourceClientCapabilities(in *tfprotov6.OpenEphemeralResourceClientCapabilities) *tfprotov5.OpenEphemeralResourceClientCapabilities {
+	return tfprotov6tov5.OpenEphemeralResourceClientCapabilities(in)
+}
+
+// OpenEphemeralResourceRequest translates a protocol version 6
+// OpenEphemeralResourceRequest into a protocol version 5
+// OpenEphemeralResourceRequest.
+func OpenEphemeralResourceRequest(in *tfprotov6.OpenEphemeralResourceRequest) *tfprotov5.OpenEphemeralResourceRequest {
+	return tfprotov6tov5.OpenEphemeralResourceRequest(in)
+}
+
+// OpenEphemeralResourceResponse translates a protocol version 6
+// OpenEphemeralResourceResponse into a protocol version 5
+// OpenEphemeralResourceResponse.
+func OpenEphemeralResourceResponse(in *tfprotov6.OpenEphemeralResourceResponse) *tfprotov5.OpenEphemeralResourceResponse {
+	return tfprotov6tov5.OpenEphemeralResourceResponse(in)
+}
+
+// PlanActionClientCapabilities translates a protocol version 6
+// PlanActionClientCapabilities into a protocol version 5
+// PlanActionClientCapabilities.
+func PlanActionClientCapabilities(in *tfprotov6.PlanActionClientCapabilities) *tfprotov5.PlanActionClientCapabilities {
+	return tfprotov6tov5.PlanActionClientCapabilities(in)
+}
+
+// PlanActionRequest translates a protocol version 6 PlanActionRequest into a
+// protocol version 5 PlanActionRequest.
+func PlanActionRequest(in *tfprotov6.PlanActionRequest) *tfprotov5.PlanActionRequest {
+	return tfprotov6tov5.PlanActionRequest(in)
+}
+
+// PlanActionResponse translates a protocol version 6 PlanActionResponse into a
+// protocol version 5 PlanActionResponse.
+func PlanActionResponse(in *tfprotov6.PlanActionResponse) *tfprotov5.PlanActionResponse {
+	return tfprotov6tov5.PlanActionResponse(in)
+}
+
+// PlanResourceChangeClientCapabilities translates a protocol version 6
+// PlanResourceChangeClientCapabilities into a protocol version 5
+// PlanResourceChangeClientCapabilities.
+func PlanResourceChangeClientCapabilities(in *tfprotov6.PlanResourceChangeClientCapabilities) *tfprotov5.PlanResourceChangeClientCapabilities {
+	return tfprotov6tov5.PlanResourceChangeClientCapabilities(in)
+}
+
+// PlanResourceChangeRequest translates a protocol version 6
+// PlanResourceChangeRequest into a protocol version 5
+// PlanResourceChangeRequest.
+func PlanResourceChangeRequest(in *tfprotov6.PlanResourceChangeRequest) *tfprotov5.PlanResourceChangeRequest {
+	return tfprotov6tov5.PlanResourceChangeRequest(in)
+}
+
+// PlanResourceChangeResponse translates a protocol version 6
+// PlanResourceChangeResponse into a protocol version 5
+// PlanResourceChangeResponse.
+func PlanResourceChangeResponse(in *tfprotov6.PlanResourceChangeResponse) *tfprotov5.PlanResourceChangeResponse {
+	return tfprotov6tov5.PlanResourceChangeResponse(in)
+}
+
+// PrepareProviderConfigRequest translates a protocol version 6
+// ValidateProviderConfigRequest into a protocol version 5
+// PrepareProviderConfigRequest.
+func PrepareProviderConfigRequest(in *tfprotov6.ValidateProviderConfigRequest) *tfprotov5.PrepareProviderConfigRequest {
+	return tfprotov6tov5.PrepareProviderConfigRequest(in)
+}
+
+// PrepareProviderConfigResponse translates a protocol version 6
+// ValidateProviderConfigResponse into a protocol version 5
+// PrepareProviderConfigResponse.
+func PrepareProviderConfigResponse(in *tfprotov6.ValidateProviderConfigResponse) *tfprotov5.PrepareProviderConfigResponse {
+	return tfprotov6tov5.PrepareProviderConfigResponse(in)
+}
+
+// RawState translates a protocol version 6 RawState into a protocol version 5
+// RawState.
+func RawState(in *tfprotov6.RawState) *tfprotov5.RawState {
+	return tfprotov6tov5.RawState(in)
+}
+
+// ReadDataSourceClientCapabilities translates a protocol version 6
+// ReadDataSourceClientCapabilities into a protocol version 5
+// ReadDataSourceClientCapabilities.
+func ReadDataSourceClientCapabilities(in *tfprotov6.ReadDataSourceClientCapabilities) *tfprotov5.ReadDataSourceClientCapabilities {
+	return tfprotov6tov5.ReadDataSourceClientCapabilities(in)
+}
+
+// ReadDataSourceRequest translates a protocol version 6 ReadDataSourceRequest
+// into a protocol version 5 ReadDataSourceRequest.
+func ReadDataSourceRequest(in *tfprotov6.ReadDataSourceRequest) *tfprotov5.ReadDataSourceRequest {
+	return tfprotov6tov5.ReadDataSourceRequest(in)
+}
+
+// ReadDataSourceResponse translates a protocol version 6 ReadDataSourceResponse
+// into a protocol version 5 ReadDataSourceResponse.
+func ReadDataSourceResponse(in *tfprotov6.ReadDataSourceResponse) *tfprotov5.ReadDataSourceResponse {
+	return tfprotov6tov5.ReadDataSourceResponse(in)
+}
+
+// ReadResourceClientCapabilities translates a protocol version 6
+// ReadResourceClientCapabilities into a protocol version 5
+// ReadResourceClientCapabilities.
+func ReadResourceClientCapabilities(in *tfprotov6.ReadResourceClientCapabilities) *tfprotov5.ReadResourceClientCapabilities {
+	return tfprotov6tov5.ReadResourceClientCapabilities(in)
+}
+
+// ReadResourceRequest translates a protocol version 6 ReadResourceRequest into
+// a protocol version 5 ReadResourceRequest.
+func ReadResourceRequest(in *tfprotov6.ReadResourceRequest) *tfprotov5.ReadResourceRequest {
+	return tfprotov6tov5.ReadResourceRequest(in)
+}
+
+// ReadResourceResponse translates a protocol version 6 ReadResourceResponse
+// into a protocol version 5 ReadResourceResponse.
+func ReadResourceResponse(in *tfprotov6.ReadResourceResponse) *tfprotov5.ReadResourceResponse {
+	return tfprotov6tov5.ReadResourceResponse(in)
+}
+
+// RenewEphemeralResourceRequest translates a protocol version 6
+// RenewEphemeralResourceRequest into a protocol version 5
+// RenewEphemeralResourceRequest.
+func RenewEphemeralResourceRequest(in *tfprotov6.RenewEphemeralResourceRequest) *tfprotov5.RenewEphemeralResourceRequest {
+	return tfprotov6tov5.RenewEphemeralResourceRequest(in)
+}
+
+// RenewEphemeralResourceResponse translates a protocol version 6
+// RenewEphemeralResourceResponse into a protocol version 5
+// RenewEphemeralResourceResponse.
+func RenewEphemeralResourceResponse(in *tfprotov6.RenewEphemeralResourceResponse) *tfprotov5.RenewEphemeralResourceResponse {
+	return tfprotov6tov5.RenewEphemeralResourceResponse(in)
+}
+
+// ResourceIdentityData translates a protocol version 6 ResourceIdentityData
+// into a protocol version 5 ResourceIdentityData.
+func ResourceIdentityData(in *tfprotov6.ResourceIdentityData) *tfprotov5.ResourceIdentityData {
+	return tfprotov6tov5.ResourceIdentityData(in)
+}
+
+// ResourceIdentitySchema translates a protocol version 6 ResourceIdentitySchema
+// into a protocol version 5 ResourceIdentitySchema.
+func ResourceIdentitySchema(in *tfprotov6.ResourceIdentitySchema) *tfprotov5.ResourceIdentitySchema {
+	return tfprotov6tov5.ResourceIdentitySchema(in)
+}
+
+// ResourceIdentitySchemaAttribute translates a protocol version 6
+// ResourceIdentitySchemaAttribute into a protocol version 5
+// ResourceIdentitySchemaAttribute.
+func ResourceIdentitySchemaAttribute(in *tfprotov6.ResourceIdentitySchemaAttribute) *tfprotov5.ResourceIdentitySchemaAttribute {
+	return tfprotov6tov5.ResourceIdentitySchemaAttribute(in)
+}
+
+// ResourceMetadata translates a protocol version 6 ResourceMetadata into a
+// protocol version 5 ResourceMetadata.
+func ResourceMetadata(in tfprotov6.ResourceMetadata) tfprotov5.ResourceMetadata {
+	return tfprotov6tov5.ResourceMetadata(in)
+}
+
+// Schema translates a protocol version 6 Schema into a protocol version 5
+// Schema. It returns an error wrapping
+// ErrSchemaAttributeNestedTypeNotImplemented if it contains nested attributes.
+func Schema(in *tfprotov6.Schema) (*tfprotov5.Schema, error) {
+	return tfprotov6tov5.Schema(in)
+}
+
+// SchemaAttribute translates a protocol version 6 SchemaAttribute into a
+// protocol version 5 SchemaAttribute. It returns an error wrapping
+// ErrSchemaAttributeNestedTypeNotImplemented if it contains nested attributes.
+func SchemaAttribute(in *tfprotov6.SchemaAttribute) (*tfprotov5.SchemaAttribute, error) {
+	return tfprotov6tov5.SchemaAttribute(in)
+}
+
+// SchemaBlock translates a protocol version 6 SchemaBlock into a protocol
+// version 5 SchemaBlock. It returns an error wrapping
+// ErrSchemaAttributeNestedTypeNotImplemented if it contains nested attributes.
+func SchemaBlock(in *tfprotov6.SchemaBlock) (*tfprotov5.SchemaBlock, error) {
+	return tfprotov6tov5.SchemaBlock(in)
+}
+
+// SchemaNestedBlock translates a protocol version 6 SchemaNestedBlock into a
+// protocol version 5 SchemaNestedBlock. It returns an error wrapping
+// ErrSchemaAttributeNestedTypeNotImplemented if it contains nested attributes.
+func SchemaNestedBlock(in *tfprotov6.SchemaNestedBlock) (*tfprotov5.SchemaNestedBlock, error) {
+	return tfprotov6tov5.SchemaNestedBlock(in)
+}
+
+// ServerCapabilities translates a protocol version 6 ServerCapabilities into a
+// protocol version 5 ServerCapabilities.
+func ServerCapabilities(in *tfprotov6.ServerCapabilities) *tfprotov5.ServerCapabilities {
+	return tfprotov6tov5.ServerCapabilities(in)
+}
+
+// StopProviderRequest translates a protocol version 6 StopProviderRequest into
+// a protocol version 5 StopProviderRequest.
+func StopProviderRequest(in *tfprotov6.StopProviderRequest) *tfprotov5.StopProviderRequest {
+	return tfprotov6tov5.StopProviderRequest(in)
+}
+
+// StopProviderResponse translates a protocol version 6 StopProviderResponse
+// into a protocol version 5 StopProviderResponse.
+func StopProviderResponse(in *tfprotov6.StopProviderResponse) *tfprotov5.StopProviderResponse {
+	return tfprotov6tov5.StopProviderResponse(in)
+}
+
+// StringKind translates a protocol version 6 StringKind into a protocol version
+// 5 StringKind.
+func StringKind(in tfprotov6.StringKind) tfprotov5.StringKind {
+	return tfprotov6tov5.StringKind(in)
+}
+
+// UpgradeResourceIdentityRequest translates a protocol version 6
+// UpgradeResourceIdentityRequest into a protocol version 5
+// UpgradeResourceIdentityRequest.
+func UpgradeResourceIdentityRequest(in *tfprotov6.UpgradeResourceIdentityRequest) *tfprotov5.UpgradeResourceIdentityRequest {
+	return tfprotov6tov5.UpgradeResourceIdentityRequest(in)
+}
+
+// UpgradeResourceIdentityResponse translates a protocol version 6
+// UpgradeResourceIdentityResponse into a protocol version 5
+// UpgradeResourceIdentityResponse.
+func UpgradeResourceIdentityResponse(in *tfprotov6.UpgradeResourceIdentityResponse) *tfprotov5.UpgradeResourceIdentityResponse {
+	return tfprotov6tov5.UpgradeResourceIdentityResponse(in)
+}
+
+// UpgradeResourceStateRequest translates a protocol version 6
+// UpgradeResourceStateRequest into a protocol version 5
+// UpgradeResourceStateRequest.
+func UpgradeResourceStateRequest(in *tfprotov6.UpgradeResourceStateRequest) *tfprotov5.UpgradeResourceStateRequest {
+	return tfprotov6tov5.UpgradeResourceStateRequest(in)
+}
+
+// UpgradeResourceStateResponse translates a protocol version 6
+// UpgradeResourceStateResponse into a protocol version 5
+// UpgradeResourceStateResponse.
+func UpgradeResourceStateResponse(in *tfprotov6.UpgradeResourceStateResponse) *tfprotov5.UpgradeResourceStateResponse {
+	return tfprotov6tov5.UpgradeResourceStateResponse(in)
+}
+
+// ValidateActionConfigRequest translates a protocol version 6
+// ValidateActionConfigRequest into a protocol version 5
+// ValidateActionConfigRequest.
+func ValidateActionConfigRequest(in *tfprotov6.ValidateActionConfigRequest) *tfprotov5.ValidateActionConfigRequest {
+	return tfprotov6tov5.ValidateActionConfigRequest(in)
+}
+
+// ValidateActionConfigResponse translates a protocol version 6
+// ValidateActionConfigResponse into a protocol version 5
+// ValidateActionConfigResponse.
+func ValidateActionConfigResponse(in *tfprotov6.ValidateActionConfigResponse) *tfprotov5.ValidateActionConfigResponse {
+	return tfprotov6tov5.ValidateActionConfigResponse(in)
+}
+
+// ValidateDataSourceConfigRequest translates a protocol version 6
+// ValidateDataResourceConfigRequest into a protocol version 5
+// ValidateDataSourceConfigRequest.
+func ValidateDataSourceConfigRequest(in *tfprotov6.ValidateDataResourceConfigRequest) *tfprotov5.ValidateDataSourceConfigRequest {
+	return tfprotov6tov5.ValidateDataSourceConfigRequest(in)
+}
+
+// ValidateDataSourceConfigResponse translates a protocol version 6
+// ValidateDataResourceConfigResponse into a protocol version 5
+// ValidateDataSourceConfigResponse.
+func ValidateDataSourceConfigResponse(in *tfprotov6.ValidateDataResourceConfigResponse) *tfprotov5.ValidateDataSourceConfigResponse {
+	return tfprotov6tov5.ValidateDataSourceConfigResponse(in)
+}
+
+// ValidateEphemeralResourceConfigRequest translates a protocol version 6
+// ValidateEphemeralResourceConfigRequest into a protocol version 5
+// ValidateEphemeralResourceConfigRequest.
+func ValidateEphemeralResourceConfigRequest(in *tfprotov6.ValidateEphemeralResourceConfigRequest) *tfprotov5.ValidateEphemeralResourceConfigRequest {
+	return tfprotov6tov5.ValidateEphemeralResourceConfigRequest(in)
+}
+
+// ValidateEphemeralResourceConfigResponse translates a protocol version 6
+// ValidateEphemeralResourceConfigResponse into a protocol version 5
+// ValidateEphemeralResourceConfigResponse.
+func ValidateEphemeralResourceConfigResponse(in *tfprotov6.ValidateEphemeralResourceConfigResponse) *tfprotov5.ValidateEphemeralResourceConfigResponse {
+	return tfprotov6tov5.ValidateEphemeralResourceConfigResponse(in)
+}
+
+// ValidateListResourceConfigRequest translates a protocol version 6
+// ValidateListResourceConfigRequest into a protocol version 5
+// ValidateListResourceConfigRequest.
+func ValidateListResourceConfigRequest(in *tfprotov6.ValidateListResourceConfigRequest) *tfprotov5.ValidateListResourceConfigRequest {
+	return tfprotov6tov5.ValidateListResourceConfigRequest(in)
+}
+
+// ValidateListResourceConfigResponse translates a protocol version 6
+// ValidateListResourceConfigResponse into a protocol version 5
+// ValidateListResourceConfigResponse.
+func ValidateListResourceConfigResponse(in *tfprotov6.ValidateListResourceConfigResponse) *tfprotov5.ValidateListResourceConfigResponse {
+	return tfprotov6tov5.ValidateListResourceConfigResponse(in)
+}
+
+// ValidateResourceConfigClientCapabilities translates a protocol version 6
+// ValidateResourceConfigClientCapabilities into a protocol version 5
+// ValidateResourceTypeConfigClientCapabilities.
+func ValidateResourceConfigClientCapabilities(in *tfprotov6.ValidateResourceConfigClientCapabilities) *tfprotov5.ValidateResourceTypeConfigClientCapabilities {
+	return tfprotov6tov5.ValidateResourceConfigClientCapabilities(in)
+}
+
+// ValidateResourceTypeConfigRequest translates a protocol version 6
+// ValidateResourceConfigRequest into a protocol version 5
+// ValidateResourceTypeConfigRequest.
+func ValidateResourceTypeConfigRequest(in *tfprotov6.ValidateResourceConfigRequest) *tfprotov5.ValidateResourceTypeConfigRequest {
+	return tfprotov6tov5.ValidateResourceTypeConfigRequest(in)
+}
+
+// ValidateResourceTypeConfigResponse translates a protocol version 6
+// ValidateResourceConfigResponse into a protocol version 5
+// ValidateResourceTypeConfigResponse.
+func ValidateResourceTypeConfigResponse(in *tfprotov6.ValidateResourceConfigResponse) *tfprotov5.ValidateResourceTypeConfigResponse {
+	return tfprotov6tov5.ValidateResourceTypeConfigResponse(in)
+}
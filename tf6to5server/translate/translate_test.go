@@ -0,0 +1,285 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package translate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server/translate"
+)
+
+func TestGetMetadataResponse(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		in            *tfprotov6.GetMetadataResponse
+		expected      *tfprotov5.GetMetadataResponse
+		expectedError error
+	}{
+		"nil": {
+			in:       nil,
+			expected: nil,
+		},
+		"resources": {
+			in: &tfprotov6.GetMetadataResponse{
+				Resources: []tfprotov6.ResourceMetadata{
+					{
+						TypeName: "test_resource",
+					},
+				},
+			},
+			expected: &tfprotov5.GetMetadataResponse{
+				Actions:            []tfprotov5.ActionMetadata{},
+				DataSources:        []tfprotov5.DataSourceMetadata{},
+				EphemeralResources: []tfprotov5.EphemeralResourceMetadata{},
+				Functions:          []tfprotov5.FunctionMetadata{},
+				ListResources:      []tfprotov5.ListResourceMetadata{},
+				Resources: []tfprotov5.ResourceMetadata{
+					{
+						TypeName: "test_resource",
+					},
+				},
+			},
+		},
+		"state-stores": {
+			in: &tfprotov6.GetMetadataResponse{
+				StateStores: []tfprotov6.StateStoreMetadata{
+					{
+						TypeName: "test_state_store",
+					},
+				},
+			},
+			expectedError: translate.ErrStateStoreNotImplemented,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := translate.GetMetadataResponse(testCase.in)
+
+			if !errors.Is(err, testCase.expectedError) {
+				t.Fatalf("expected error %v, got: %v", testCase.expectedError, err)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGetProviderSchemaResponse(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		in            *tfprotov6.GetProviderSchemaResponse
+		expected      *tfprotov5.GetProviderSchemaResponse
+		expectedError error
+	}{
+		"nil": {
+			in:       nil,
+			expected: nil,
+		},
+		"resource-schemas": {
+			in: &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"test_resource": {},
+				},
+			},
+			expected: &tfprotov5.GetProviderSchemaResponse{
+				ActionSchemas:            map[string]*tfprotov5.ActionSchema{},
+				DataSourceSchemas:        map[string]*tfprotov5.Schema{},
+				EphemeralResourceSchemas: map[string]*tfprotov5.Schema{},
+				Functions:                map[string]*tfprotov5.Function{},
+				ListResourceSchemas:      map[string]*tfprotov5.Schema{},
+				ResourceSchemas: map[string]*tfprotov5.Schema{
+					"test_resource": {},
+				},
+			},
+		},
+		"nested-attributes": {
+			in: &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"test_resource": {
+						Block: &tfprotov6.SchemaBlock{
+							Attributes: []*tfprotov6.SchemaAttribute{
+								{
+									Name: "test_attribute",
+									NestedType: &tfprotov6.SchemaObject{
+										Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+									},
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError: translate.ErrSchemaAttributeNestedTypeNotImplemented,
+		},
+		"state-store-schemas": {
+			in: &tfprotov6.GetProviderSchemaResponse{
+				StateStoreSchemas: map[string]*tfprotov6.Schema{
+					"test_state_store": {},
+				},
+			},
+			expectedError: translate.ErrStateStoreNotImplemented,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := translate.GetProviderSchemaResponse(testCase.in)
+
+			if !errors.Is(err, testCase.expectedError) {
+				t.Fatalf("expected error %v, got: %v", testCase.expectedError, err)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLosses(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		in       any
+		expected []translate.Loss
+	}{
+		"nil": {
+			in:       (*tfprotov6.GetMetadataResponse)(nil),
+			expected: nil,
+		},
+		"deferred-reason-implemented": {
+			in: &tfprotov6.ReadResourceResponse{
+				Deferred: &tfprotov6.Deferred{
+					Reason: tfprotov6.DeferredReasonAbsentPrereq,
+				},
+			},
+			expected: nil,
+		},
+		"deferred-reason-not-implemented": {
+			in: &tfprotov6.PlanResourceChangeResponse{
+				Deferred: &tfprotov6.Deferred{
+					Reason: tfprotov6.DeferredReason(99),
+				},
+			},
+			expected: []translate.Loss{
+				{
+					Field:  "PlanResourceChangeResponse.Deferred.Reason",
+					Detail: "deferred reason 99 is not implemented in protocol version 5",
+				},
+			},
+		},
+		"state-store-metadata": {
+			in: &tfprotov6.GetMetadataResponse{
+				StateStores: []tfprotov6.StateStoreMetadata{
+					{
+						TypeName: "test_state_store",
+					},
+				},
+			},
+			expected: []translate.Loss{
+				{
+					Field:  "GetMetadataResponse.StateStores",
+					Detail: "not implemented in protocol version 5",
+				},
+			},
+		},
+		"state-store-schemas": {
+			in: &tfprotov6.GetProviderSchemaResponse{
+				StateStoreSchemas: map[string]*tfprotov6.Schema{
+					"test_state_store": {},
+				},
+			},
+			expected: []translate.Loss{
+				{
+					Field:  "GetProviderSchemaResponse.StateStoreSchemas",
+					Detail: "not implemented in protocol version 5",
+				},
+			},
+		},
+		"unsupported-type": {
+			in:       &tfprotov6.ConfigureProviderResponse{},
+			expected: nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := translate.Losses(testCase.in)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPlanResourceChangeRequest(t *testing.T) {
+	t.Parallel()
+
+	in := &tfprotov6.PlanResourceChangeRequest{
+		ClientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{
+			DeferralAllowed: true,
+		},
+		PriorPrivate: []byte("test"),
+		TypeName:     "test_resource",
+	}
+	expected := &tfprotov5.PlanResourceChangeRequest{
+		ClientCapabilities: &tfprotov5.PlanResourceChangeClientCapabilities{
+			DeferralAllowed: true,
+		},
+		PriorPrivate: []byte("test"),
+		TypeName:     "test_resource",
+	}
+
+	got := translate.PlanResourceChangeRequest(in)
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	_, err := translate.Schema(&tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "test_attribute",
+					Type:     tftypes.String,
+					Required: true,
+				},
+				{
+					Name: "test_nested_attribute",
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeList,
+					},
+					Optional: true,
+				},
+			},
+		},
+	})
+
+	if !errors.Is(err, translate.ErrSchemaAttributeNestedTypeNotImplemented) {
+		t.Errorf("expected ErrSchemaAttributeNestedTypeNotImplemented error, got: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf6to5server
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server/translate"
+)
+
+// lossReporter reports the fields of responses of the underlying server which
+// cannot be represented in protocol version 5, such as state stores or newer
+// deferred reasons, as warning diagnostics, so the differences in behavior
+// are visible rather than silent. Each field is only reported once per
+// server, rather than in every response.
+type lossReporter struct {
+	mu       sync.Mutex
+	reported map[string]bool
+}
+
+// diagnostics returns a warning diagnostic for each loss whose field has not
+// yet been reported.
+func (r *lossReporter) diagnostics(losses []translate.Loss) []*tfprotov5.Diagnostic {
+	if len(losses) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var diagnostics []*tfprotov5.Diagnostic
+
+	for _, loss := range losses {
+		if r.reported[loss.Field] {
+			continue
+		}
+
+		if r.reported == nil {
+			r.reported = make(map[string]bool)
+		}
+
+		r.reported[loss.Field] = true
+
+		diagnostics = append(diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "Protocol Version Translation Loss",
+			Detail: fmt.Sprintf("The %s field could not be translated between protocol versions 5 and 6, so it was dropped or changed: %s. "+
+				"The behavior of the provider may differ from that of the underlying provider server.", loss.Field, loss.Detail),
+		})
+	}
+
+	return diagnostics
+}